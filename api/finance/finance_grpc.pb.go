@@ -20,14 +20,147 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	FinanceService_AddIncome_FullMethodName = "/finance.FinanceService/AddIncome"
+	FinanceService_AddIncome_FullMethodName               = "/finance.FinanceService/AddIncome"
+	FinanceService_GetIncome_FullMethodName               = "/finance.FinanceService/GetIncome"
+	FinanceService_ListIncomes_FullMethodName             = "/finance.FinanceService/ListIncomes"
+	FinanceService_UpdateIncome_FullMethodName            = "/finance.FinanceService/UpdateIncome"
+	FinanceService_DeleteIncome_FullMethodName            = "/finance.FinanceService/DeleteIncome"
+	FinanceService_BatchAddIncomes_FullMethodName         = "/finance.FinanceService/BatchAddIncomes"
+	FinanceService_ImportIncomes_FullMethodName           = "/finance.FinanceService/ImportIncomes"
+	FinanceService_MergeTransactions_FullMethodName       = "/finance.FinanceService/MergeTransactions"
+	FinanceService_AddExpense_FullMethodName              = "/finance.FinanceService/AddExpense"
+	FinanceService_GetExpense_FullMethodName              = "/finance.FinanceService/GetExpense"
+	FinanceService_ListExpenses_FullMethodName            = "/finance.FinanceService/ListExpenses"
+	FinanceService_UpdateExpense_FullMethodName           = "/finance.FinanceService/UpdateExpense"
+	FinanceService_DeleteExpense_FullMethodName           = "/finance.FinanceService/DeleteExpense"
+	FinanceService_GetUserTimezone_FullMethodName         = "/finance.FinanceService/GetUserTimezone"
+	FinanceService_SetUserTimezone_FullMethodName         = "/finance.FinanceService/SetUserTimezone"
+	FinanceService_CreateCategory_FullMethodName          = "/finance.FinanceService/CreateCategory"
+	FinanceService_ListCategories_FullMethodName          = "/finance.FinanceService/ListCategories"
+	FinanceService_RenameCategory_FullMethodName          = "/finance.FinanceService/RenameCategory"
+	FinanceService_ArchiveCategory_FullMethodName         = "/finance.FinanceService/ArchiveCategory"
+	FinanceService_MergeCategories_FullMethodName         = "/finance.FinanceService/MergeCategories"
+	FinanceService_CreateAccount_FullMethodName           = "/finance.FinanceService/CreateAccount"
+	FinanceService_ListAccounts_FullMethodName            = "/finance.FinanceService/ListAccounts"
+	FinanceService_UpdateAccount_FullMethodName           = "/finance.FinanceService/UpdateAccount"
+	FinanceService_CloseAccount_FullMethodName            = "/finance.FinanceService/CloseAccount"
+	FinanceService_AddTransfer_FullMethodName             = "/finance.FinanceService/AddTransfer"
+	FinanceService_ListTransfers_FullMethodName           = "/finance.FinanceService/ListTransfers"
+	FinanceService_CreateBudget_FullMethodName            = "/finance.FinanceService/CreateBudget"
+	FinanceService_GetBudget_FullMethodName               = "/finance.FinanceService/GetBudget"
+	FinanceService_ListBudgets_FullMethodName             = "/finance.FinanceService/ListBudgets"
+	FinanceService_UpdateBudget_FullMethodName            = "/finance.FinanceService/UpdateBudget"
+	FinanceService_DeleteBudget_FullMethodName            = "/finance.FinanceService/DeleteBudget"
+	FinanceService_GetBudgetStatus_FullMethodName         = "/finance.FinanceService/GetBudgetStatus"
+	FinanceService_CreateRecurringRule_FullMethodName     = "/finance.FinanceService/CreateRecurringRule"
+	FinanceService_ListRecurringRules_FullMethodName      = "/finance.FinanceService/ListRecurringRules"
+	FinanceService_PauseRecurringRule_FullMethodName      = "/finance.FinanceService/PauseRecurringRule"
+	FinanceService_ResumeRecurringRule_FullMethodName     = "/finance.FinanceService/ResumeRecurringRule"
+	FinanceService_SkipRecurringOccurrence_FullMethodName = "/finance.FinanceService/SkipRecurringOccurrence"
+	FinanceService_ListUpcomingOccurrences_FullMethodName = "/finance.FinanceService/ListUpcomingOccurrences"
+	FinanceService_ImportStatement_FullMethodName         = "/finance.FinanceService/ImportStatement"
+	FinanceService_CreateRule_FullMethodName              = "/finance.FinanceService/CreateRule"
+	FinanceService_ListRules_FullMethodName               = "/finance.FinanceService/ListRules"
+	FinanceService_UpdateRule_FullMethodName              = "/finance.FinanceService/UpdateRule"
+	FinanceService_DeleteRule_FullMethodName              = "/finance.FinanceService/DeleteRule"
+	FinanceService_DryRunRule_FullMethodName              = "/finance.FinanceService/DryRunRule"
+	FinanceService_ApplyRule_FullMethodName               = "/finance.FinanceService/ApplyRule"
+	FinanceService_ListTags_FullMethodName                = "/finance.FinanceService/ListTags"
+	FinanceService_RenameTag_FullMethodName               = "/finance.FinanceService/RenameTag"
+	FinanceService_MergeTags_FullMethodName               = "/finance.FinanceService/MergeTags"
+	FinanceService_GetIncomeSummary_FullMethodName        = "/finance.FinanceService/GetIncomeSummary"
+	FinanceService_GetCashFlow_FullMethodName             = "/finance.FinanceService/GetCashFlow"
+	FinanceService_GetNetWorth_FullMethodName             = "/finance.FinanceService/GetNetWorth"
+	FinanceService_ExportTransactions_FullMethodName      = "/finance.FinanceService/ExportTransactions"
+	FinanceService_ExportUserData_FullMethodName          = "/finance.FinanceService/ExportUserData"
+	FinanceService_EraseUser_FullMethodName               = "/finance.FinanceService/EraseUser"
 )
 
 // FinanceServiceClient is the client API for FinanceService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type FinanceServiceClient interface {
-	AddIncome(ctx context.Context, in *AddIncomeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AddIncome(ctx context.Context, in *AddIncomeRequest, opts ...grpc.CallOption) (*Income, error)
+	GetIncome(ctx context.Context, in *GetIncomeRequest, opts ...grpc.CallOption) (*Income, error)
+	ListIncomes(ctx context.Context, in *ListIncomesRequest, opts ...grpc.CallOption) (*ListIncomesResponse, error)
+	UpdateIncome(ctx context.Context, in *UpdateIncomeRequest, opts ...grpc.CallOption) (*Income, error)
+	DeleteIncome(ctx context.Context, in *DeleteIncomeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Пакет доходов одной транзакцией: целиком или с результатом по каждому элементу
+	BatchAddIncomes(ctx context.Context, in *BatchAddIncomesRequest, opts ...grpc.CallOption) (*BatchAddIncomesResponse, error)
+	// Потоковый импорт доходов: записываются одной транзакцией после завершения потока.
+	// Ключ идемпотентности не поддерживается: запрос с метаданными idempotency-key отклоняется
+	ImportIncomes(ctx context.Context, opts ...grpc.CallOption) (FinanceService_ImportIncomesClient, error)
+	// Объединяет доход-дубликат с другим доходом: дубликат удаляется и сохраняется как источник оставшегося
+	MergeTransactions(ctx context.Context, in *MergeTransactionsRequest, opts ...grpc.CallOption) (*MergeTransactionsResponse, error)
+	AddExpense(ctx context.Context, in *AddExpenseRequest, opts ...grpc.CallOption) (*Expense, error)
+	GetExpense(ctx context.Context, in *GetExpenseRequest, opts ...grpc.CallOption) (*Expense, error)
+	ListExpenses(ctx context.Context, in *ListExpensesRequest, opts ...grpc.CallOption) (*ListExpensesResponse, error)
+	UpdateExpense(ctx context.Context, in *UpdateExpenseRequest, opts ...grpc.CallOption) (*Expense, error)
+	DeleteExpense(ctx context.Context, in *DeleteExpenseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetUserTimezone(ctx context.Context, in *GetUserTimezoneRequest, opts ...grpc.CallOption) (*UserTimezone, error)
+	SetUserTimezone(ctx context.Context, in *SetUserTimezoneRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*Category, error)
+	ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error)
+	RenameCategory(ctx context.Context, in *RenameCategoryRequest, opts ...grpc.CallOption) (*Category, error)
+	ArchiveCategory(ctx context.Context, in *ArchiveCategoryRequest, opts ...grpc.CallOption) (*Category, error)
+	// Переносит операции и подкатегории source в target, удаляет source и возвращает target
+	MergeCategories(ctx context.Context, in *MergeCategoriesRequest, opts ...grpc.CallOption) (*Category, error)
+	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	CloseAccount(ctx context.Context, in *CloseAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	// Перевод между счетами пользователя: не является ни доходом, ни расходом
+	AddTransfer(ctx context.Context, in *AddTransferRequest, opts ...grpc.CallOption) (*Transfer, error)
+	ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error)
+	CreateBudget(ctx context.Context, in *CreateBudgetRequest, opts ...grpc.CallOption) (*Budget, error)
+	GetBudget(ctx context.Context, in *GetBudgetRequest, opts ...grpc.CallOption) (*Budget, error)
+	ListBudgets(ctx context.Context, in *ListBudgetsRequest, opts ...grpc.CallOption) (*ListBudgetsResponse, error)
+	UpdateBudget(ctx context.Context, in *UpdateBudgetRequest, opts ...grpc.CallOption) (*Budget, error)
+	DeleteBudget(ctx context.Context, in *DeleteBudgetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Израсходовано, остаток и прогноз на конец периода бюджета
+	GetBudgetStatus(ctx context.Context, in *GetBudgetStatusRequest, opts ...grpc.CallOption) (*BudgetStatus, error)
+	// Повторяющиеся доходы: наступившие повторения проводятся сервисом как обычные доходы
+	CreateRecurringRule(ctx context.Context, in *CreateRecurringRuleRequest, opts ...grpc.CallOption) (*RecurringRule, error)
+	ListRecurringRules(ctx context.Context, in *ListRecurringRulesRequest, opts ...grpc.CallOption) (*ListRecurringRulesResponse, error)
+	PauseRecurringRule(ctx context.Context, in *PauseRecurringRuleRequest, opts ...grpc.CallOption) (*RecurringRule, error)
+	// Повторения, наступившие за время паузы, не проводятся
+	ResumeRecurringRule(ctx context.Context, in *ResumeRecurringRuleRequest, opts ...grpc.CallOption) (*RecurringRule, error)
+	SkipRecurringOccurrence(ctx context.Context, in *SkipRecurringOccurrenceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListUpcomingOccurrences(ctx context.Context, in *ListUpcomingOccurrencesRequest, opts ...grpc.CallOption) (*ListUpcomingOccurrencesResponse, error)
+	// Импорт банковской выписки: поступления становятся доходами, списания - расходами
+	ImportStatement(ctx context.Context, in *ImportStatementRequest, opts ...grpc.CallOption) (*ImportStatementResponse, error)
+	// Правила автоматической категоризации применяются в порядке приоритета при AddIncome и импорте выписок
+	CreateRule(ctx context.Context, in *CreateRuleRequest, opts ...grpc.CallOption) (*Rule, error)
+	ListRules(ctx context.Context, in *ListRulesRequest, opts ...grpc.CallOption) (*ListRulesResponse, error)
+	// Полностью заменяет условия и действия правила; уже сохраненные операции не меняются
+	UpdateRule(ctx context.Context, in *UpdateRuleRequest, opts ...grpc.CallOption) (*Rule, error)
+	DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Показывает, что правило, в том числе несохраненное, изменило бы в операциях за период; ничего не сохраняет
+	DryRunRule(ctx context.Context, in *DryRunRuleRequest, opts ...grpc.CallOption) (*RuleChangesResponse, error)
+	// Применяет сохраненное правило к операциям за период одной транзакцией
+	ApplyRule(ctx context.Context, in *ApplyRuleRequest, opts ...grpc.CallOption) (*RuleChangesResponse, error)
+	// Автодополнение тегов: теги пользователя по префиксу, сначала самые используемые
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	// Меняет название тега во всех операциях; название не должно совпадать с другим тегом пользователя
+	RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*Tag, error)
+	// Переносит операции тега source на target, удаляет source и возвращает target
+	MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*Tag, error)
+	// Доходы за период по календарным периодам, категориям и счетам в валюте отчета
+	GetIncomeSummary(ctx context.Context, in *GetIncomeSummaryRequest, opts ...grpc.CallOption) (*IncomeSummary, error)
+	// Доходы, расходы, переводы, чистый поток и остатки на конец периода по счетам и в целом
+	GetCashFlow(ctx context.Context, in *CashFlowReportRequest, opts ...grpc.CallOption) (*CashFlow, error)
+	// Капитал: остатки на конец периода по счетам и в целом
+	GetNetWorth(ctx context.Context, in *CashFlowReportRequest, opts ...grpc.CallOption) (*NetWorth, error)
+	// Выгрузка операций в CSV, JSON Lines или XLSX: файл передается потоком частей
+	ExportTransactions(ctx context.Context, in *ExportTransactionsRequest, opts ...grpc.CallOption) (FinanceService_ExportTransactionsClient, error)
+	// Архив всех данных пользователя в JSON по схеме api/finance/user_data.schema.json: файл передается потоком частей.
+	// Разделы читаются из одного снимка данных. Журнала аудита сервис не ведет, изменения операций
+	// отражает журнал проводок (разделы journal_entries и journal_postings)
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (FinanceService_ExportUserDataClient, error)
+	// Безвозвратно удаляет все данные пользователя одной транзакцией и возвращает отчет об удаленных строках.
+	// Сервис не сохраняет записи о стирании: отчет - единственное его подтверждение, хранить его должен клиент.
+	// Если запрос передан с ключом идемпотентности, отчет хранится при ключе до истечения его срока
+	EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*ErasureReport, error)
 }
 
 type financeServiceClient struct {
@@ -38,58 +171,1866 @@ func NewFinanceServiceClient(cc grpc.ClientConnInterface) FinanceServiceClient {
 	return &financeServiceClient{cc}
 }
 
-func (c *financeServiceClient) AddIncome(ctx context.Context, in *AddIncomeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *financeServiceClient) AddIncome(ctx context.Context, in *AddIncomeRequest, opts ...grpc.CallOption) (*Income, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
+	out := new(Income)
 	err := c.cc.Invoke(ctx, FinanceService_AddIncome_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *financeServiceClient) GetIncome(ctx context.Context, in *GetIncomeRequest, opts ...grpc.CallOption) (*Income, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Income)
+	err := c.cc.Invoke(ctx, FinanceService_GetIncome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ListIncomes(ctx context.Context, in *ListIncomesRequest, opts ...grpc.CallOption) (*ListIncomesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIncomesResponse)
+	err := c.cc.Invoke(ctx, FinanceService_ListIncomes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) UpdateIncome(ctx context.Context, in *UpdateIncomeRequest, opts ...grpc.CallOption) (*Income, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Income)
+	err := c.cc.Invoke(ctx, FinanceService_UpdateIncome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) DeleteIncome(ctx context.Context, in *DeleteIncomeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FinanceService_DeleteIncome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) BatchAddIncomes(ctx context.Context, in *BatchAddIncomesRequest, opts ...grpc.CallOption) (*BatchAddIncomesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchAddIncomesResponse)
+	err := c.cc.Invoke(ctx, FinanceService_BatchAddIncomes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ImportIncomes(ctx context.Context, opts ...grpc.CallOption) (FinanceService_ImportIncomesClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FinanceService_ServiceDesc.Streams[0], FinanceService_ImportIncomes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &financeServiceImportIncomesClient{ClientStream: stream}
+	return x, nil
+}
+
+type FinanceService_ImportIncomesClient interface {
+	Send(*ImportIncomesRequest) error
+	CloseAndRecv() (*BatchAddIncomesResponse, error)
+	grpc.ClientStream
+}
+
+type financeServiceImportIncomesClient struct {
+	grpc.ClientStream
+}
+
+func (x *financeServiceImportIncomesClient) Send(m *ImportIncomesRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *financeServiceImportIncomesClient) CloseAndRecv() (*BatchAddIncomesResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(BatchAddIncomesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *financeServiceClient) MergeTransactions(ctx context.Context, in *MergeTransactionsRequest, opts ...grpc.CallOption) (*MergeTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeTransactionsResponse)
+	err := c.cc.Invoke(ctx, FinanceService_MergeTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) AddExpense(ctx context.Context, in *AddExpenseRequest, opts ...grpc.CallOption) (*Expense, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Expense)
+	err := c.cc.Invoke(ctx, FinanceService_AddExpense_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) GetExpense(ctx context.Context, in *GetExpenseRequest, opts ...grpc.CallOption) (*Expense, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Expense)
+	err := c.cc.Invoke(ctx, FinanceService_GetExpense_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ListExpenses(ctx context.Context, in *ListExpensesRequest, opts ...grpc.CallOption) (*ListExpensesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExpensesResponse)
+	err := c.cc.Invoke(ctx, FinanceService_ListExpenses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) UpdateExpense(ctx context.Context, in *UpdateExpenseRequest, opts ...grpc.CallOption) (*Expense, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Expense)
+	err := c.cc.Invoke(ctx, FinanceService_UpdateExpense_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) DeleteExpense(ctx context.Context, in *DeleteExpenseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FinanceService_DeleteExpense_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) GetUserTimezone(ctx context.Context, in *GetUserTimezoneRequest, opts ...grpc.CallOption) (*UserTimezone, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserTimezone)
+	err := c.cc.Invoke(ctx, FinanceService_GetUserTimezone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) SetUserTimezone(ctx context.Context, in *SetUserTimezoneRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FinanceService_SetUserTimezone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*Category, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Category)
+	err := c.cc.Invoke(ctx, FinanceService_CreateCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCategoriesResponse)
+	err := c.cc.Invoke(ctx, FinanceService_ListCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) RenameCategory(ctx context.Context, in *RenameCategoryRequest, opts ...grpc.CallOption) (*Category, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Category)
+	err := c.cc.Invoke(ctx, FinanceService_RenameCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ArchiveCategory(ctx context.Context, in *ArchiveCategoryRequest, opts ...grpc.CallOption) (*Category, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Category)
+	err := c.cc.Invoke(ctx, FinanceService_ArchiveCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) MergeCategories(ctx context.Context, in *MergeCategoriesRequest, opts ...grpc.CallOption) (*Category, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Category)
+	err := c.cc.Invoke(ctx, FinanceService_MergeCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, FinanceService_CreateAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, FinanceService_ListAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, FinanceService_UpdateAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) CloseAccount(ctx context.Context, in *CloseAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, FinanceService_CloseAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) AddTransfer(ctx context.Context, in *AddTransferRequest, opts ...grpc.CallOption) (*Transfer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transfer)
+	err := c.cc.Invoke(ctx, FinanceService_AddTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTransfersResponse)
+	err := c.cc.Invoke(ctx, FinanceService_ListTransfers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) CreateBudget(ctx context.Context, in *CreateBudgetRequest, opts ...grpc.CallOption) (*Budget, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Budget)
+	err := c.cc.Invoke(ctx, FinanceService_CreateBudget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) GetBudget(ctx context.Context, in *GetBudgetRequest, opts ...grpc.CallOption) (*Budget, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Budget)
+	err := c.cc.Invoke(ctx, FinanceService_GetBudget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ListBudgets(ctx context.Context, in *ListBudgetsRequest, opts ...grpc.CallOption) (*ListBudgetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBudgetsResponse)
+	err := c.cc.Invoke(ctx, FinanceService_ListBudgets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) UpdateBudget(ctx context.Context, in *UpdateBudgetRequest, opts ...grpc.CallOption) (*Budget, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Budget)
+	err := c.cc.Invoke(ctx, FinanceService_UpdateBudget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) DeleteBudget(ctx context.Context, in *DeleteBudgetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FinanceService_DeleteBudget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) GetBudgetStatus(ctx context.Context, in *GetBudgetStatusRequest, opts ...grpc.CallOption) (*BudgetStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BudgetStatus)
+	err := c.cc.Invoke(ctx, FinanceService_GetBudgetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) CreateRecurringRule(ctx context.Context, in *CreateRecurringRuleRequest, opts ...grpc.CallOption) (*RecurringRule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecurringRule)
+	err := c.cc.Invoke(ctx, FinanceService_CreateRecurringRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ListRecurringRules(ctx context.Context, in *ListRecurringRulesRequest, opts ...grpc.CallOption) (*ListRecurringRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRecurringRulesResponse)
+	err := c.cc.Invoke(ctx, FinanceService_ListRecurringRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) PauseRecurringRule(ctx context.Context, in *PauseRecurringRuleRequest, opts ...grpc.CallOption) (*RecurringRule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecurringRule)
+	err := c.cc.Invoke(ctx, FinanceService_PauseRecurringRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ResumeRecurringRule(ctx context.Context, in *ResumeRecurringRuleRequest, opts ...grpc.CallOption) (*RecurringRule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecurringRule)
+	err := c.cc.Invoke(ctx, FinanceService_ResumeRecurringRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) SkipRecurringOccurrence(ctx context.Context, in *SkipRecurringOccurrenceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FinanceService_SkipRecurringOccurrence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ListUpcomingOccurrences(ctx context.Context, in *ListUpcomingOccurrencesRequest, opts ...grpc.CallOption) (*ListUpcomingOccurrencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUpcomingOccurrencesResponse)
+	err := c.cc.Invoke(ctx, FinanceService_ListUpcomingOccurrences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ImportStatement(ctx context.Context, in *ImportStatementRequest, opts ...grpc.CallOption) (*ImportStatementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportStatementResponse)
+	err := c.cc.Invoke(ctx, FinanceService_ImportStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) CreateRule(ctx context.Context, in *CreateRuleRequest, opts ...grpc.CallOption) (*Rule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rule)
+	err := c.cc.Invoke(ctx, FinanceService_CreateRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ListRules(ctx context.Context, in *ListRulesRequest, opts ...grpc.CallOption) (*ListRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRulesResponse)
+	err := c.cc.Invoke(ctx, FinanceService_ListRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) UpdateRule(ctx context.Context, in *UpdateRuleRequest, opts ...grpc.CallOption) (*Rule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rule)
+	err := c.cc.Invoke(ctx, FinanceService_UpdateRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FinanceService_DeleteRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) DryRunRule(ctx context.Context, in *DryRunRuleRequest, opts ...grpc.CallOption) (*RuleChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RuleChangesResponse)
+	err := c.cc.Invoke(ctx, FinanceService_DryRunRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ApplyRule(ctx context.Context, in *ApplyRuleRequest, opts ...grpc.CallOption) (*RuleChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RuleChangesResponse)
+	err := c.cc.Invoke(ctx, FinanceService_ApplyRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, FinanceService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*Tag, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tag)
+	err := c.cc.Invoke(ctx, FinanceService_RenameTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*Tag, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tag)
+	err := c.cc.Invoke(ctx, FinanceService_MergeTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) GetIncomeSummary(ctx context.Context, in *GetIncomeSummaryRequest, opts ...grpc.CallOption) (*IncomeSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncomeSummary)
+	err := c.cc.Invoke(ctx, FinanceService_GetIncomeSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) GetCashFlow(ctx context.Context, in *CashFlowReportRequest, opts ...grpc.CallOption) (*CashFlow, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CashFlow)
+	err := c.cc.Invoke(ctx, FinanceService_GetCashFlow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) GetNetWorth(ctx context.Context, in *CashFlowReportRequest, opts ...grpc.CallOption) (*NetWorth, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NetWorth)
+	err := c.cc.Invoke(ctx, FinanceService_GetNetWorth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financeServiceClient) ExportTransactions(ctx context.Context, in *ExportTransactionsRequest, opts ...grpc.CallOption) (FinanceService_ExportTransactionsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FinanceService_ServiceDesc.Streams[1], FinanceService_ExportTransactions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &financeServiceExportTransactionsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FinanceService_ExportTransactionsClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type financeServiceExportTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *financeServiceExportTransactionsClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *financeServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (FinanceService_ExportUserDataClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FinanceService_ServiceDesc.Streams[2], FinanceService_ExportUserData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &financeServiceExportUserDataClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FinanceService_ExportUserDataClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type financeServiceExportUserDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *financeServiceExportUserDataClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *financeServiceClient) EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*ErasureReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ErasureReport)
+	err := c.cc.Invoke(ctx, FinanceService_EraseUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FinanceServiceServer is the server API for FinanceService service.
+// All implementations must embed UnimplementedFinanceServiceServer
+// for forward compatibility
+type FinanceServiceServer interface {
+	AddIncome(context.Context, *AddIncomeRequest) (*Income, error)
+	GetIncome(context.Context, *GetIncomeRequest) (*Income, error)
+	ListIncomes(context.Context, *ListIncomesRequest) (*ListIncomesResponse, error)
+	UpdateIncome(context.Context, *UpdateIncomeRequest) (*Income, error)
+	DeleteIncome(context.Context, *DeleteIncomeRequest) (*emptypb.Empty, error)
+	// Пакет доходов одной транзакцией: целиком или с результатом по каждому элементу
+	BatchAddIncomes(context.Context, *BatchAddIncomesRequest) (*BatchAddIncomesResponse, error)
+	// Потоковый импорт доходов: записываются одной транзакцией после завершения потока.
+	// Ключ идемпотентности не поддерживается: запрос с метаданными idempotency-key отклоняется
+	ImportIncomes(FinanceService_ImportIncomesServer) error
+	// Объединяет доход-дубликат с другим доходом: дубликат удаляется и сохраняется как источник оставшегося
+	MergeTransactions(context.Context, *MergeTransactionsRequest) (*MergeTransactionsResponse, error)
+	AddExpense(context.Context, *AddExpenseRequest) (*Expense, error)
+	GetExpense(context.Context, *GetExpenseRequest) (*Expense, error)
+	ListExpenses(context.Context, *ListExpensesRequest) (*ListExpensesResponse, error)
+	UpdateExpense(context.Context, *UpdateExpenseRequest) (*Expense, error)
+	DeleteExpense(context.Context, *DeleteExpenseRequest) (*emptypb.Empty, error)
+	GetUserTimezone(context.Context, *GetUserTimezoneRequest) (*UserTimezone, error)
+	SetUserTimezone(context.Context, *SetUserTimezoneRequest) (*emptypb.Empty, error)
+	CreateCategory(context.Context, *CreateCategoryRequest) (*Category, error)
+	ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error)
+	RenameCategory(context.Context, *RenameCategoryRequest) (*Category, error)
+	ArchiveCategory(context.Context, *ArchiveCategoryRequest) (*Category, error)
+	// Переносит операции и подкатегории source в target, удаляет source и возвращает target
+	MergeCategories(context.Context, *MergeCategoriesRequest) (*Category, error)
+	CreateAccount(context.Context, *CreateAccountRequest) (*Account, error)
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	UpdateAccount(context.Context, *UpdateAccountRequest) (*Account, error)
+	CloseAccount(context.Context, *CloseAccountRequest) (*Account, error)
+	// Перевод между счетами пользователя: не является ни доходом, ни расходом
+	AddTransfer(context.Context, *AddTransferRequest) (*Transfer, error)
+	ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error)
+	CreateBudget(context.Context, *CreateBudgetRequest) (*Budget, error)
+	GetBudget(context.Context, *GetBudgetRequest) (*Budget, error)
+	ListBudgets(context.Context, *ListBudgetsRequest) (*ListBudgetsResponse, error)
+	UpdateBudget(context.Context, *UpdateBudgetRequest) (*Budget, error)
+	DeleteBudget(context.Context, *DeleteBudgetRequest) (*emptypb.Empty, error)
+	// Израсходовано, остаток и прогноз на конец периода бюджета
+	GetBudgetStatus(context.Context, *GetBudgetStatusRequest) (*BudgetStatus, error)
+	// Повторяющиеся доходы: наступившие повторения проводятся сервисом как обычные доходы
+	CreateRecurringRule(context.Context, *CreateRecurringRuleRequest) (*RecurringRule, error)
+	ListRecurringRules(context.Context, *ListRecurringRulesRequest) (*ListRecurringRulesResponse, error)
+	PauseRecurringRule(context.Context, *PauseRecurringRuleRequest) (*RecurringRule, error)
+	// Повторения, наступившие за время паузы, не проводятся
+	ResumeRecurringRule(context.Context, *ResumeRecurringRuleRequest) (*RecurringRule, error)
+	SkipRecurringOccurrence(context.Context, *SkipRecurringOccurrenceRequest) (*emptypb.Empty, error)
+	ListUpcomingOccurrences(context.Context, *ListUpcomingOccurrencesRequest) (*ListUpcomingOccurrencesResponse, error)
+	// Импорт банковской выписки: поступления становятся доходами, списания - расходами
+	ImportStatement(context.Context, *ImportStatementRequest) (*ImportStatementResponse, error)
+	// Правила автоматической категоризации применяются в порядке приоритета при AddIncome и импорте выписок
+	CreateRule(context.Context, *CreateRuleRequest) (*Rule, error)
+	ListRules(context.Context, *ListRulesRequest) (*ListRulesResponse, error)
+	// Полностью заменяет условия и действия правила; уже сохраненные операции не меняются
+	UpdateRule(context.Context, *UpdateRuleRequest) (*Rule, error)
+	DeleteRule(context.Context, *DeleteRuleRequest) (*emptypb.Empty, error)
+	// Показывает, что правило, в том числе несохраненное, изменило бы в операциях за период; ничего не сохраняет
+	DryRunRule(context.Context, *DryRunRuleRequest) (*RuleChangesResponse, error)
+	// Применяет сохраненное правило к операциям за период одной транзакцией
+	ApplyRule(context.Context, *ApplyRuleRequest) (*RuleChangesResponse, error)
+	// Автодополнение тегов: теги пользователя по префиксу, сначала самые используемые
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	// Меняет название тега во всех операциях; название не должно совпадать с другим тегом пользователя
+	RenameTag(context.Context, *RenameTagRequest) (*Tag, error)
+	// Переносит операции тега source на target, удаляет source и возвращает target
+	MergeTags(context.Context, *MergeTagsRequest) (*Tag, error)
+	// Доходы за период по календарным периодам, категориям и счетам в валюте отчета
+	GetIncomeSummary(context.Context, *GetIncomeSummaryRequest) (*IncomeSummary, error)
+	// Доходы, расходы, переводы, чистый поток и остатки на конец периода по счетам и в целом
+	GetCashFlow(context.Context, *CashFlowReportRequest) (*CashFlow, error)
+	// Капитал: остатки на конец периода по счетам и в целом
+	GetNetWorth(context.Context, *CashFlowReportRequest) (*NetWorth, error)
+	// Выгрузка операций в CSV, JSON Lines или XLSX: файл передается потоком частей
+	ExportTransactions(*ExportTransactionsRequest, FinanceService_ExportTransactionsServer) error
+	// Архив всех данных пользователя в JSON по схеме api/finance/user_data.schema.json: файл передается потоком частей.
+	// Разделы читаются из одного снимка данных. Журнала аудита сервис не ведет, изменения операций
+	// отражает журнал проводок (разделы journal_entries и journal_postings)
+	ExportUserData(*ExportUserDataRequest, FinanceService_ExportUserDataServer) error
+	// Безвозвратно удаляет все данные пользователя одной транзакцией и возвращает отчет об удаленных строках.
+	// Сервис не сохраняет записи о стирании: отчет - единственное его подтверждение, хранить его должен клиент.
+	// Если запрос передан с ключом идемпотентности, отчет хранится при ключе до истечения его срока
+	EraseUser(context.Context, *EraseUserRequest) (*ErasureReport, error)
+	mustEmbedUnimplementedFinanceServiceServer()
+}
+
+// UnimplementedFinanceServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFinanceServiceServer struct {
+}
+
+func (UnimplementedFinanceServiceServer) AddIncome(context.Context, *AddIncomeRequest) (*Income, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddIncome not implemented")
+}
+func (UnimplementedFinanceServiceServer) GetIncome(context.Context, *GetIncomeRequest) (*Income, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIncome not implemented")
+}
+func (UnimplementedFinanceServiceServer) ListIncomes(context.Context, *ListIncomesRequest) (*ListIncomesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIncomes not implemented")
+}
+func (UnimplementedFinanceServiceServer) UpdateIncome(context.Context, *UpdateIncomeRequest) (*Income, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateIncome not implemented")
+}
+func (UnimplementedFinanceServiceServer) DeleteIncome(context.Context, *DeleteIncomeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteIncome not implemented")
+}
+func (UnimplementedFinanceServiceServer) BatchAddIncomes(context.Context, *BatchAddIncomesRequest) (*BatchAddIncomesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchAddIncomes not implemented")
+}
+func (UnimplementedFinanceServiceServer) ImportIncomes(FinanceService_ImportIncomesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportIncomes not implemented")
+}
+func (UnimplementedFinanceServiceServer) MergeTransactions(context.Context, *MergeTransactionsRequest) (*MergeTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeTransactions not implemented")
+}
+func (UnimplementedFinanceServiceServer) AddExpense(context.Context, *AddExpenseRequest) (*Expense, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddExpense not implemented")
+}
+func (UnimplementedFinanceServiceServer) GetExpense(context.Context, *GetExpenseRequest) (*Expense, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExpense not implemented")
+}
+func (UnimplementedFinanceServiceServer) ListExpenses(context.Context, *ListExpensesRequest) (*ListExpensesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExpenses not implemented")
+}
+func (UnimplementedFinanceServiceServer) UpdateExpense(context.Context, *UpdateExpenseRequest) (*Expense, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateExpense not implemented")
+}
+func (UnimplementedFinanceServiceServer) DeleteExpense(context.Context, *DeleteExpenseRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteExpense not implemented")
+}
+func (UnimplementedFinanceServiceServer) GetUserTimezone(context.Context, *GetUserTimezoneRequest) (*UserTimezone, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserTimezone not implemented")
+}
+func (UnimplementedFinanceServiceServer) SetUserTimezone(context.Context, *SetUserTimezoneRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserTimezone not implemented")
+}
+func (UnimplementedFinanceServiceServer) CreateCategory(context.Context, *CreateCategoryRequest) (*Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCategory not implemented")
+}
+func (UnimplementedFinanceServiceServer) ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCategories not implemented")
+}
+func (UnimplementedFinanceServiceServer) RenameCategory(context.Context, *RenameCategoryRequest) (*Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameCategory not implemented")
+}
+func (UnimplementedFinanceServiceServer) ArchiveCategory(context.Context, *ArchiveCategoryRequest) (*Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveCategory not implemented")
+}
+func (UnimplementedFinanceServiceServer) MergeCategories(context.Context, *MergeCategoriesRequest) (*Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeCategories not implemented")
+}
+func (UnimplementedFinanceServiceServer) CreateAccount(context.Context, *CreateAccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccount not implemented")
+}
+func (UnimplementedFinanceServiceServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (UnimplementedFinanceServiceServer) UpdateAccount(context.Context, *UpdateAccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAccount not implemented")
+}
+func (UnimplementedFinanceServiceServer) CloseAccount(context.Context, *CloseAccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseAccount not implemented")
+}
+func (UnimplementedFinanceServiceServer) AddTransfer(context.Context, *AddTransferRequest) (*Transfer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTransfer not implemented")
+}
+func (UnimplementedFinanceServiceServer) ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransfers not implemented")
+}
+func (UnimplementedFinanceServiceServer) CreateBudget(context.Context, *CreateBudgetRequest) (*Budget, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBudget not implemented")
+}
+func (UnimplementedFinanceServiceServer) GetBudget(context.Context, *GetBudgetRequest) (*Budget, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBudget not implemented")
+}
+func (UnimplementedFinanceServiceServer) ListBudgets(context.Context, *ListBudgetsRequest) (*ListBudgetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBudgets not implemented")
+}
+func (UnimplementedFinanceServiceServer) UpdateBudget(context.Context, *UpdateBudgetRequest) (*Budget, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBudget not implemented")
+}
+func (UnimplementedFinanceServiceServer) DeleteBudget(context.Context, *DeleteBudgetRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBudget not implemented")
+}
+func (UnimplementedFinanceServiceServer) GetBudgetStatus(context.Context, *GetBudgetStatusRequest) (*BudgetStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBudgetStatus not implemented")
+}
+func (UnimplementedFinanceServiceServer) CreateRecurringRule(context.Context, *CreateRecurringRuleRequest) (*RecurringRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRecurringRule not implemented")
+}
+func (UnimplementedFinanceServiceServer) ListRecurringRules(context.Context, *ListRecurringRulesRequest) (*ListRecurringRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecurringRules not implemented")
+}
+func (UnimplementedFinanceServiceServer) PauseRecurringRule(context.Context, *PauseRecurringRuleRequest) (*RecurringRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseRecurringRule not implemented")
+}
+func (UnimplementedFinanceServiceServer) ResumeRecurringRule(context.Context, *ResumeRecurringRuleRequest) (*RecurringRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeRecurringRule not implemented")
+}
+func (UnimplementedFinanceServiceServer) SkipRecurringOccurrence(context.Context, *SkipRecurringOccurrenceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SkipRecurringOccurrence not implemented")
+}
+func (UnimplementedFinanceServiceServer) ListUpcomingOccurrences(context.Context, *ListUpcomingOccurrencesRequest) (*ListUpcomingOccurrencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUpcomingOccurrences not implemented")
+}
+func (UnimplementedFinanceServiceServer) ImportStatement(context.Context, *ImportStatementRequest) (*ImportStatementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportStatement not implemented")
+}
+func (UnimplementedFinanceServiceServer) CreateRule(context.Context, *CreateRuleRequest) (*Rule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRule not implemented")
+}
+func (UnimplementedFinanceServiceServer) ListRules(context.Context, *ListRulesRequest) (*ListRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRules not implemented")
+}
+func (UnimplementedFinanceServiceServer) UpdateRule(context.Context, *UpdateRuleRequest) (*Rule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRule not implemented")
+}
+func (UnimplementedFinanceServiceServer) DeleteRule(context.Context, *DeleteRuleRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRule not implemented")
+}
+func (UnimplementedFinanceServiceServer) DryRunRule(context.Context, *DryRunRuleRequest) (*RuleChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DryRunRule not implemented")
+}
+func (UnimplementedFinanceServiceServer) ApplyRule(context.Context, *ApplyRuleRequest) (*RuleChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyRule not implemented")
+}
+func (UnimplementedFinanceServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedFinanceServiceServer) RenameTag(context.Context, *RenameTagRequest) (*Tag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameTag not implemented")
+}
+func (UnimplementedFinanceServiceServer) MergeTags(context.Context, *MergeTagsRequest) (*Tag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeTags not implemented")
+}
+func (UnimplementedFinanceServiceServer) GetIncomeSummary(context.Context, *GetIncomeSummaryRequest) (*IncomeSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIncomeSummary not implemented")
+}
+func (UnimplementedFinanceServiceServer) GetCashFlow(context.Context, *CashFlowReportRequest) (*CashFlow, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCashFlow not implemented")
+}
+func (UnimplementedFinanceServiceServer) GetNetWorth(context.Context, *CashFlowReportRequest) (*NetWorth, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetWorth not implemented")
+}
+func (UnimplementedFinanceServiceServer) ExportTransactions(*ExportTransactionsRequest, FinanceService_ExportTransactionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportTransactions not implemented")
+}
+func (UnimplementedFinanceServiceServer) ExportUserData(*ExportUserDataRequest, FinanceService_ExportUserDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedFinanceServiceServer) EraseUser(context.Context, *EraseUserRequest) (*ErasureReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseUser not implemented")
+}
+func (UnimplementedFinanceServiceServer) mustEmbedUnimplementedFinanceServiceServer() {}
+
+// UnsafeFinanceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FinanceServiceServer will
+// result in compilation errors.
+type UnsafeFinanceServiceServer interface {
+	mustEmbedUnimplementedFinanceServiceServer()
+}
+
+func RegisterFinanceServiceServer(s grpc.ServiceRegistrar, srv FinanceServiceServer) {
+	s.RegisterService(&FinanceService_ServiceDesc, srv)
+}
+
+func _FinanceService_AddIncome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddIncomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).AddIncome(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_AddIncome_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).AddIncome(ctx, req.(*AddIncomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_GetIncome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIncomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).GetIncome(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_GetIncome_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).GetIncome(ctx, req.(*GetIncomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ListIncomes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIncomesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ListIncomes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_ListIncomes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ListIncomes(ctx, req.(*ListIncomesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_UpdateIncome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateIncomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).UpdateIncome(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_UpdateIncome_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).UpdateIncome(ctx, req.(*UpdateIncomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_DeleteIncome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteIncomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).DeleteIncome(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_DeleteIncome_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).DeleteIncome(ctx, req.(*DeleteIncomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_BatchAddIncomes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchAddIncomesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).BatchAddIncomes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_BatchAddIncomes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).BatchAddIncomes(ctx, req.(*BatchAddIncomesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ImportIncomes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FinanceServiceServer).ImportIncomes(&financeServiceImportIncomesServer{ServerStream: stream})
+}
+
+type FinanceService_ImportIncomesServer interface {
+	SendAndClose(*BatchAddIncomesResponse) error
+	Recv() (*ImportIncomesRequest, error)
+	grpc.ServerStream
+}
+
+type financeServiceImportIncomesServer struct {
+	grpc.ServerStream
+}
+
+func (x *financeServiceImportIncomesServer) SendAndClose(m *BatchAddIncomesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *financeServiceImportIncomesServer) Recv() (*ImportIncomesRequest, error) {
+	m := new(ImportIncomesRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _FinanceService_MergeTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).MergeTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_MergeTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).MergeTransactions(ctx, req.(*MergeTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_AddExpense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddExpenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).AddExpense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_AddExpense_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).AddExpense(ctx, req.(*AddExpenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_GetExpense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExpenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).GetExpense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_GetExpense_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).GetExpense(ctx, req.(*GetExpenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ListExpenses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExpensesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ListExpenses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_ListExpenses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ListExpenses(ctx, req.(*ListExpensesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_UpdateExpense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateExpenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).UpdateExpense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_UpdateExpense_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).UpdateExpense(ctx, req.(*UpdateExpenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_DeleteExpense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteExpenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).DeleteExpense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_DeleteExpense_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).DeleteExpense(ctx, req.(*DeleteExpenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_GetUserTimezone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserTimezoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).GetUserTimezone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_GetUserTimezone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).GetUserTimezone(ctx, req.(*GetUserTimezoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_SetUserTimezone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserTimezoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).SetUserTimezone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_SetUserTimezone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).SetUserTimezone(ctx, req.(*SetUserTimezoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_CreateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).CreateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_CreateCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).CreateCategory(ctx, req.(*CreateCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ListCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ListCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_ListCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ListCategories(ctx, req.(*ListCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_RenameCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).RenameCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_RenameCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).RenameCategory(ctx, req.(*RenameCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ArchiveCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ArchiveCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_ArchiveCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ArchiveCategory(ctx, req.(*ArchiveCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_MergeCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).MergeCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_MergeCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).MergeCategories(ctx, req.(*MergeCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_CreateAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_ListAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_UpdateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).UpdateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_UpdateAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).UpdateAccount(ctx, req.(*UpdateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_CloseAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).CloseAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_CloseAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).CloseAccount(ctx, req.(*CloseAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_AddTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).AddTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_AddTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).AddTransfer(ctx, req.(*AddTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ListTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ListTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_ListTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ListTransfers(ctx, req.(*ListTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_CreateBudget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBudgetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).CreateBudget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_CreateBudget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).CreateBudget(ctx, req.(*CreateBudgetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_GetBudget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBudgetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).GetBudget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_GetBudget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).GetBudget(ctx, req.(*GetBudgetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ListBudgets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBudgetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ListBudgets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_ListBudgets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ListBudgets(ctx, req.(*ListBudgetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_UpdateBudget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBudgetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).UpdateBudget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_UpdateBudget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).UpdateBudget(ctx, req.(*UpdateBudgetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_DeleteBudget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBudgetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).DeleteBudget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_DeleteBudget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).DeleteBudget(ctx, req.(*DeleteBudgetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_GetBudgetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBudgetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).GetBudgetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_GetBudgetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).GetBudgetStatus(ctx, req.(*GetBudgetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_CreateRecurringRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRecurringRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).CreateRecurringRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_CreateRecurringRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).CreateRecurringRule(ctx, req.(*CreateRecurringRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ListRecurringRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecurringRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ListRecurringRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_ListRecurringRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ListRecurringRules(ctx, req.(*ListRecurringRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_PauseRecurringRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRecurringRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).PauseRecurringRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_PauseRecurringRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).PauseRecurringRule(ctx, req.(*PauseRecurringRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ResumeRecurringRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRecurringRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ResumeRecurringRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_ResumeRecurringRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ResumeRecurringRule(ctx, req.(*ResumeRecurringRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_SkipRecurringOccurrence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SkipRecurringOccurrenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).SkipRecurringOccurrence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_SkipRecurringOccurrence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).SkipRecurringOccurrence(ctx, req.(*SkipRecurringOccurrenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ListUpcomingOccurrences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUpcomingOccurrencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ListUpcomingOccurrences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_ListUpcomingOccurrences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ListUpcomingOccurrences(ctx, req.(*ListUpcomingOccurrencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ImportStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportStatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ImportStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_ImportStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ImportStatement(ctx, req.(*ImportStatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_CreateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).CreateRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_CreateRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).CreateRule(ctx, req.(*CreateRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ListRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ListRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_ListRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ListRules(ctx, req.(*ListRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_UpdateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).UpdateRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_UpdateRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).UpdateRule(ctx, req.(*UpdateRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_DeleteRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).DeleteRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_DeleteRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).DeleteRule(ctx, req.(*DeleteRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// FinanceServiceServer is the server API for FinanceService service.
-// All implementations must embed UnimplementedFinanceServiceServer
-// for forward compatibility
-type FinanceServiceServer interface {
-	AddIncome(context.Context, *AddIncomeRequest) (*emptypb.Empty, error)
-	mustEmbedUnimplementedFinanceServiceServer()
+func _FinanceService_DryRunRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DryRunRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).DryRunRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_DryRunRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).DryRunRule(ctx, req.(*DryRunRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedFinanceServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedFinanceServiceServer struct {
+func _FinanceService_ApplyRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ApplyRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_ApplyRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ApplyRule(ctx, req.(*ApplyRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (UnimplementedFinanceServiceServer) AddIncome(context.Context, *AddIncomeRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddIncome not implemented")
+func _FinanceService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFinanceServiceServer) mustEmbedUnimplementedFinanceServiceServer() {}
 
-// UnsafeFinanceServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to FinanceServiceServer will
-// result in compilation errors.
-type UnsafeFinanceServiceServer interface {
-	mustEmbedUnimplementedFinanceServiceServer()
+func _FinanceService_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_RenameTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).RenameTag(ctx, req.(*RenameTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterFinanceServiceServer(s grpc.ServiceRegistrar, srv FinanceServiceServer) {
-	s.RegisterService(&FinanceService_ServiceDesc, srv)
+func _FinanceService_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).MergeTags(ctx, req.(*MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _FinanceService_AddIncome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddIncomeRequest)
+func _FinanceService_GetIncomeSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIncomeSummaryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FinanceServiceServer).AddIncome(ctx, in)
+		return srv.(FinanceServiceServer).GetIncomeSummary(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FinanceService_AddIncome_FullMethodName,
+		FullMethod: FinanceService_GetIncomeSummary_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FinanceServiceServer).AddIncome(ctx, req.(*AddIncomeRequest))
+		return srv.(FinanceServiceServer).GetIncomeSummary(ctx, req.(*GetIncomeSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_GetCashFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CashFlowReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).GetCashFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_GetCashFlow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).GetCashFlow(ctx, req.(*CashFlowReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_GetNetWorth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CashFlowReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).GetNetWorth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_GetNetWorth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).GetNetWorth(ctx, req.(*CashFlowReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinanceService_ExportTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FinanceServiceServer).ExportTransactions(m, &financeServiceExportTransactionsServer{ServerStream: stream})
+}
+
+type FinanceService_ExportTransactionsServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type financeServiceExportTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *financeServiceExportTransactionsServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FinanceService_ExportUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUserDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FinanceServiceServer).ExportUserData(m, &financeServiceExportUserDataServer{ServerStream: stream})
+}
+
+type FinanceService_ExportUserDataServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type financeServiceExportUserDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *financeServiceExportUserDataServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FinanceService_EraseUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinanceServiceServer).EraseUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinanceService_EraseUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinanceServiceServer).EraseUser(ctx, req.(*EraseUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -105,7 +2046,223 @@ var FinanceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddIncome",
 			Handler:    _FinanceService_AddIncome_Handler,
 		},
+		{
+			MethodName: "GetIncome",
+			Handler:    _FinanceService_GetIncome_Handler,
+		},
+		{
+			MethodName: "ListIncomes",
+			Handler:    _FinanceService_ListIncomes_Handler,
+		},
+		{
+			MethodName: "UpdateIncome",
+			Handler:    _FinanceService_UpdateIncome_Handler,
+		},
+		{
+			MethodName: "DeleteIncome",
+			Handler:    _FinanceService_DeleteIncome_Handler,
+		},
+		{
+			MethodName: "BatchAddIncomes",
+			Handler:    _FinanceService_BatchAddIncomes_Handler,
+		},
+		{
+			MethodName: "MergeTransactions",
+			Handler:    _FinanceService_MergeTransactions_Handler,
+		},
+		{
+			MethodName: "AddExpense",
+			Handler:    _FinanceService_AddExpense_Handler,
+		},
+		{
+			MethodName: "GetExpense",
+			Handler:    _FinanceService_GetExpense_Handler,
+		},
+		{
+			MethodName: "ListExpenses",
+			Handler:    _FinanceService_ListExpenses_Handler,
+		},
+		{
+			MethodName: "UpdateExpense",
+			Handler:    _FinanceService_UpdateExpense_Handler,
+		},
+		{
+			MethodName: "DeleteExpense",
+			Handler:    _FinanceService_DeleteExpense_Handler,
+		},
+		{
+			MethodName: "GetUserTimezone",
+			Handler:    _FinanceService_GetUserTimezone_Handler,
+		},
+		{
+			MethodName: "SetUserTimezone",
+			Handler:    _FinanceService_SetUserTimezone_Handler,
+		},
+		{
+			MethodName: "CreateCategory",
+			Handler:    _FinanceService_CreateCategory_Handler,
+		},
+		{
+			MethodName: "ListCategories",
+			Handler:    _FinanceService_ListCategories_Handler,
+		},
+		{
+			MethodName: "RenameCategory",
+			Handler:    _FinanceService_RenameCategory_Handler,
+		},
+		{
+			MethodName: "ArchiveCategory",
+			Handler:    _FinanceService_ArchiveCategory_Handler,
+		},
+		{
+			MethodName: "MergeCategories",
+			Handler:    _FinanceService_MergeCategories_Handler,
+		},
+		{
+			MethodName: "CreateAccount",
+			Handler:    _FinanceService_CreateAccount_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _FinanceService_ListAccounts_Handler,
+		},
+		{
+			MethodName: "UpdateAccount",
+			Handler:    _FinanceService_UpdateAccount_Handler,
+		},
+		{
+			MethodName: "CloseAccount",
+			Handler:    _FinanceService_CloseAccount_Handler,
+		},
+		{
+			MethodName: "AddTransfer",
+			Handler:    _FinanceService_AddTransfer_Handler,
+		},
+		{
+			MethodName: "ListTransfers",
+			Handler:    _FinanceService_ListTransfers_Handler,
+		},
+		{
+			MethodName: "CreateBudget",
+			Handler:    _FinanceService_CreateBudget_Handler,
+		},
+		{
+			MethodName: "GetBudget",
+			Handler:    _FinanceService_GetBudget_Handler,
+		},
+		{
+			MethodName: "ListBudgets",
+			Handler:    _FinanceService_ListBudgets_Handler,
+		},
+		{
+			MethodName: "UpdateBudget",
+			Handler:    _FinanceService_UpdateBudget_Handler,
+		},
+		{
+			MethodName: "DeleteBudget",
+			Handler:    _FinanceService_DeleteBudget_Handler,
+		},
+		{
+			MethodName: "GetBudgetStatus",
+			Handler:    _FinanceService_GetBudgetStatus_Handler,
+		},
+		{
+			MethodName: "CreateRecurringRule",
+			Handler:    _FinanceService_CreateRecurringRule_Handler,
+		},
+		{
+			MethodName: "ListRecurringRules",
+			Handler:    _FinanceService_ListRecurringRules_Handler,
+		},
+		{
+			MethodName: "PauseRecurringRule",
+			Handler:    _FinanceService_PauseRecurringRule_Handler,
+		},
+		{
+			MethodName: "ResumeRecurringRule",
+			Handler:    _FinanceService_ResumeRecurringRule_Handler,
+		},
+		{
+			MethodName: "SkipRecurringOccurrence",
+			Handler:    _FinanceService_SkipRecurringOccurrence_Handler,
+		},
+		{
+			MethodName: "ListUpcomingOccurrences",
+			Handler:    _FinanceService_ListUpcomingOccurrences_Handler,
+		},
+		{
+			MethodName: "ImportStatement",
+			Handler:    _FinanceService_ImportStatement_Handler,
+		},
+		{
+			MethodName: "CreateRule",
+			Handler:    _FinanceService_CreateRule_Handler,
+		},
+		{
+			MethodName: "ListRules",
+			Handler:    _FinanceService_ListRules_Handler,
+		},
+		{
+			MethodName: "UpdateRule",
+			Handler:    _FinanceService_UpdateRule_Handler,
+		},
+		{
+			MethodName: "DeleteRule",
+			Handler:    _FinanceService_DeleteRule_Handler,
+		},
+		{
+			MethodName: "DryRunRule",
+			Handler:    _FinanceService_DryRunRule_Handler,
+		},
+		{
+			MethodName: "ApplyRule",
+			Handler:    _FinanceService_ApplyRule_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _FinanceService_ListTags_Handler,
+		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _FinanceService_RenameTag_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _FinanceService_MergeTags_Handler,
+		},
+		{
+			MethodName: "GetIncomeSummary",
+			Handler:    _FinanceService_GetIncomeSummary_Handler,
+		},
+		{
+			MethodName: "GetCashFlow",
+			Handler:    _FinanceService_GetCashFlow_Handler,
+		},
+		{
+			MethodName: "GetNetWorth",
+			Handler:    _FinanceService_GetNetWorth_Handler,
+		},
+		{
+			MethodName: "EraseUser",
+			Handler:    _FinanceService_EraseUser_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportIncomes",
+			Handler:       _FinanceService_ImportIncomes_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportTransactions",
+			Handler:       _FinanceService_ExportTransactions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportUserData",
+			Handler:       _FinanceService_ExportUserData_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "finance/finance.proto",
 }
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/migrations"
+	"fincraft-finance/internal/rates"
+)
+
+// runCommand выполняет подкоманду сервиса вместо запуска gRPC сервера
+func runCommand(ctx context.Context, db *sql.DB, args []string) (string, error) {
+	switch args[0] {
+	case "migrate":
+		return migrate(ctx, db, args[1:])
+	case "import-rates":
+		return importRates(ctx, db, args[1:])
+	default:
+		return "", fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// migrate применяет или откатывает миграции схемы: migrate [up | down [N] | version]
+func migrate(ctx context.Context, db *sql.DB, args []string) (string, error) {
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		return "", err
+	}
+
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	switch action {
+	case "up":
+		n, err := migrator.Up(ctx)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("applied %d migrations", n), nil
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return "", fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		n, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("reverted %d migrations", n), nil
+	case "version":
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("schema version %d", version), nil
+	default:
+		return "", fmt.Errorf("usage: migrate [up | down [N] | version]")
+	}
+}
+
+// importRates загружает файлы курсов валют; формат определяется по расширению (.csv или .json)
+func importRates(ctx context.Context, db *sql.DB, files []string) (string, error) {
+	if len(files) == 0 {
+		return "", fmt.Errorf("usage: import-rates <file.csv|file.json>...")
+	}
+
+	importer := rates.NewImporter(infrastructure.NewRateRepository(db))
+
+	total := 0
+	for _, name := range files {
+		n, err := importRateFile(ctx, importer, name)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", name, err)
+		}
+		total += n
+	}
+
+	return fmt.Sprintf("imported %d rates from %d files", total, len(files)), nil
+}
+
+// importRateFile загружает один файл курсов
+func importRateFile(ctx context.Context, importer *rates.Importer, name string) (int, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return importer.ImportCSV(ctx, f)
+	case ".json":
+		return importer.ImportJSON(ctx, f)
+	default:
+		return 0, fmt.Errorf("unsupported rate file extension %q", filepath.Ext(name))
+	}
+}
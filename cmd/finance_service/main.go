@@ -1,15 +1,21 @@
 package main
 
 import (
+	"context"
 	"os"
+	"time"
+	_ "time/tzdata" // База часовых поясов для образов без системной tzdata
 
 	"github.com/KutsDenis/logzap"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"fincraft-finance/internal/config"
 	"fincraft-finance/internal/infrastructure"
 	"fincraft-finance/internal/interfaces"
 	"fincraft-finance/internal/metrics"
+	"fincraft-finance/internal/rates"
+	"fincraft-finance/internal/scheduler"
 	"fincraft-finance/internal/server"
 	"fincraft-finance/internal/usecases"
 )
@@ -29,9 +35,6 @@ func main() {
 	}
 	log.Info("Configuration loaded successfully")
 
-	// Метрики
-	go metrics.StartMetricsServer(cfg.MetricsPort)
-
 	// Подключение к базе данных
 	db, err := infrastructure.NewDBConnection(cfg.DBDSN)
 	if err != nil {
@@ -42,13 +45,132 @@ func main() {
 	defer db.Close()
 	log.Info("Database connection established")
 
+	// Подкоманды выполняются вместо запуска сервера
+	if len(os.Args) > 1 {
+		result, err := runCommand(context.Background(), db, os.Args[1:])
+		if err != nil {
+			log.Fatal("Command failed", zap.String("command", os.Args[1]), zap.Error(err))
+			os.Exit(1)
+		}
+		log.Info(result)
+		return
+	}
+
+	// Метрики
+	go metrics.StartMetricsServer(cfg.MetricsPort)
+
 	// Создание зависимостей
+	txManager := infrastructure.NewTxManager(db)
+	ledgerRepo := infrastructure.NewLedgerRepository(db)
+	userRepo := infrastructure.NewUserRepository(db)
+	userUsecase := usecases.NewUserUseCase(userRepo, ledgerRepo, txManager)
+	categoryRepo := infrastructure.NewCategoryRepository(db)
+	categoryUsecase := usecases.NewCategoryUseCase(categoryRepo)
+	accountRepo := infrastructure.NewAccountRepository(db)
+	accountUsecase := usecases.NewAccountUseCase(accountRepo, txManager)
+	ruleRepo := infrastructure.NewRuleRepository(db)
 	incomeRepo := infrastructure.NewIncomeRepository(db)
-	incomeUsecase := usecases.NewIncomeUseCase(incomeRepo)
-	financeHandler := interfaces.NewFinanceHandler(incomeUsecase)
+	incomeUsecase := usecases.NewIncomeUseCase(usecases.IncomeDeps{
+		Incomes:    incomeRepo,
+		Users:      userRepo,
+		Categories: categoryRepo,
+		Accounts:   accountRepo,
+		Ledger:     ledgerRepo,
+		Rules:      ruleRepo,
+		Tx:         txManager,
+	}, cfg.FutureDateTolerance)
+	expenseRepo := infrastructure.NewExpenseRepository(db)
+	expenseUsecase := usecases.NewExpenseUseCase(usecases.ExpenseDeps{
+		Expenses:   expenseRepo,
+		Categories: categoryRepo,
+		Accounts:   accountRepo,
+		Ledger:     ledgerRepo,
+		Tx:         txManager,
+	}, cfg.FutureDateTolerance)
+	transferRepo := infrastructure.NewTransferRepository(db)
+	transferUsecase := usecases.NewTransferUseCase(transferRepo, accountRepo, ledgerRepo, txManager, cfg.FutureDateTolerance)
+	converter := rates.NewConverter(infrastructure.NewRateRepository(db), cfg.RatesFallbackDays)
+	budgetRepo := infrastructure.NewBudgetRepository(db)
+	budgetUsecase := usecases.NewBudgetUseCase(usecases.BudgetDeps{
+		Budgets:    budgetRepo,
+		Categories: categoryRepo,
+		Users:      userRepo,
+		Converter:  converter,
+		Tx:         txManager,
+	})
+	recurringRepo := infrastructure.NewRecurringRuleRepository(db)
+	recurringUsecase := usecases.NewRecurringUseCase(usecases.RecurringDeps{
+		Rules:      recurringRepo,
+		Incomes:    incomeUsecase,
+		Users:      userRepo,
+		Categories: categoryRepo,
+		Accounts:   accountRepo,
+		Tx:         txManager,
+	})
+	statementUsecase := usecases.NewStatementUseCase(usecases.StatementDeps{
+		Statements: infrastructure.NewStatementRepository(db),
+		Incomes:    incomeUsecase,
+		Expenses:   expenseUsecase,
+		Transfers:  transferUsecase,
+		Users:      userRepo,
+		Categories: categoryRepo,
+		Accounts:   accountRepo,
+		Rules:      ruleRepo,
+		Tx:         txManager,
+	}, cfg.FutureDateTolerance)
+	ruleUsecase := usecases.NewRuleUseCase(usecases.RuleDeps{
+		Rules:          ruleRepo,
+		IncomeHistory:  incomeRepo,
+		ExpenseHistory: expenseRepo,
+		Incomes:        incomeUsecase,
+		Expenses:       expenseUsecase,
+		Users:          userRepo,
+		Categories:     categoryRepo,
+		Accounts:       accountRepo,
+		Tx:             txManager,
+	})
+	tagUsecase := usecases.NewTagUseCase(infrastructure.NewTagRepository(db), txManager)
+	reportUsecase := usecases.NewReportUseCase(usecases.ReportDeps{
+		Reports:   infrastructure.NewReportRepository(db),
+		Users:     userRepo,
+		Converter: converter,
+	})
+	exportUsecase := usecases.NewExportUseCase(usecases.ExportDeps{
+		Incomes:   incomeRepo,
+		Expenses:  expenseRepo,
+		Transfers: transferRepo,
+		Users:     userRepo,
+	})
+	userDataUsecase := usecases.NewUserDataUseCase(infrastructure.NewUserDataRepository(db), userRepo, txManager)
+	idempotencyRepo := infrastructure.NewIdempotencyRepository(db)
+	idempotencyUsecase := usecases.NewIdempotencyUseCase(idempotencyRepo, txManager, cfg.IdempotencyTTL,
+		cfg.IdempotencyPendingTimeout)
+	financeHandler := interfaces.NewFinanceHandler(interfaces.Services{
+		Incomes:    incomeUsecase,
+		Expenses:   expenseUsecase,
+		Users:      userUsecase,
+		Categories: categoryUsecase,
+		Accounts:   accountUsecase,
+		Transfers:  transferUsecase,
+		Budgets:    budgetUsecase,
+		Recurring:  recurringUsecase,
+		Statements: statementUsecase,
+		Rules:      ruleUsecase,
+		Tags:       tagUsecase,
+		Reports:    reportUsecase,
+		Exports:    exportUsecase,
+		UserData:   userDataUsecase,
+	})
+
+	// Фоновые задачи: проведение повторяющихся доходов и очистка истекших ключей идемпотентности
+	go scheduler.New("recurring incomes", cfg.RecurringInterval, recurringUsecase.PostDue, log).Run(context.Background())
+	go scheduler.New("expired idempotency keys", time.Hour, idempotencyUsecase.DeleteExpired, log).Run(context.Background())
 
 	// Запуск сервера
-	if err := server.RunGRPCServer(cfg.GRPCPort, financeHandler); err != nil {
+	if err := server.RunGRPCServer(cfg.GRPCPort, financeHandler,
+		grpc.ChainUnaryInterceptor(interfaces.IdempotencyInterceptor(idempotencyUsecase)),
+		grpc.ChainStreamInterceptor(interfaces.IdempotencyStreamInterceptor()),
+	); err != nil {
 		log.Fatal("Failed to start gRPC server", zap.Error(err))
 		os.Exit(1)
 	}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 	"github.com/joho/godotenv"
@@ -13,6 +14,22 @@ type Config struct {
 	DBDSN       string `env:"DB_DSN,required"`
 	GRPCPort    string `env:"GRPC_PORT" envDefault:"50051"`
 	MetricsPort string `env:"METRICS_PORT" envDefault:"9091"`
+
+	// RatesFallbackDays на сколько дней назад можно искать курс, если на дату операции его нет
+	RatesFallbackDays int `env:"RATES_FALLBACK_DAYS" envDefault:"7"`
+
+	// FutureDateTolerance насколько дата операции может опережать время сервера
+	FutureDateTolerance time.Duration `env:"FUTURE_DATE_TOLERANCE" envDefault:"24h"`
+
+	// RecurringInterval как часто планировщик проводит наступившие повторяющиеся доходы
+	RecurringInterval time.Duration `env:"RECURRING_INTERVAL" envDefault:"1m"`
+
+	// IdempotencyTTL сколько хранится результат запроса, выполненного с ключом идемпотентности
+	IdempotencyTTL time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h"`
+
+	// IdempotencyPendingTimeout через сколько незавершенный запрос с ключом идемпотентности считается брошенным;
+	// должен быть больше дедлайна запросов клиентов
+	IdempotencyPendingTimeout time.Duration `env:"IDEMPOTENCY_PENDING_TIMEOUT" envDefault:"5m"`
 }
 
 // LoadConfig загружает конфигурацию из переменных окружения или файла .env.
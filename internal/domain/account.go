@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxAccountNameLength максимальная длина названия счета в символах
+const MaxAccountNameLength = 64
+
+// AccountType вид счета, на котором хранятся деньги
+type AccountType int
+
+const (
+	// AccountTypeCash наличные
+	AccountTypeCash AccountType = iota + 1
+	// AccountTypeCard банковская карта
+	AccountTypeCard
+	// AccountTypeChecking текущий банковский счет
+	AccountTypeChecking
+	// AccountTypeSavings сберегательный счет или депозит
+	AccountTypeSavings
+	// AccountTypeBrokerage брокерский счет
+	AccountTypeBrokerage
+)
+
+// Valid сообщает, что вид счета известен
+func (t AccountType) Valid() bool {
+	return t >= AccountTypeCash && t <= AccountTypeBrokerage
+}
+
+// Account счет (кошелек) пользователя.
+// Валюта счета задается валютой начального остатка и не меняется.
+type Account struct {
+	ID     int64
+	UserID int64
+	Name   string
+	Type   AccountType
+	// OpeningBalance остаток на момент начала учета, может быть отрицательным (кредитная карта)
+	OpeningBalance Money
+	// Balance текущий остаток: начальный остаток плюс все операции по счету
+	Balance Money
+	// ClosedAt момент закрытия счета, нулевое значение - счет открыт
+	ClosedAt  time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// AccountFilter условия выборки счетов пользователя
+type AccountFilter struct {
+	UserID        int64
+	IncludeClosed bool
+}
+
+// AccountPatch частичное изменение счета, nil-поля не меняются
+type AccountPatch struct {
+	Name           *string
+	Type           *AccountType
+	OpeningBalance *Money
+}
+
+// Currency возвращает валюту счета
+func (a *Account) Currency() Currency {
+	return a.OpeningBalance.Currency()
+}
+
+// IsClosed сообщает, что счет закрыт
+func (a *Account) IsClosed() bool {
+	return !a.ClosedAt.IsZero()
+}
+
+// Validate проверяет бизнес-правила для счета
+func (a *Account) Validate() error {
+	if a.UserID <= 0 {
+		return errors.New("user ID must be valid")
+	}
+	if strings.TrimSpace(a.Name) == "" {
+		return errors.New("account name must not be empty")
+	}
+	if utf8.RuneCountInString(a.Name) > MaxAccountNameLength {
+		return fmt.Errorf("account name must not exceed %d characters", MaxAccountNameLength)
+	}
+	if !a.Type.Valid() {
+		return errors.New("account type must be valid")
+	}
+	if a.Currency().IsZero() {
+		return errors.New("currency must be valid")
+	}
+	return nil
+}
+
+// Apply применяет частичное изменение к счету.
+// Текущий остаток не пересчитывается: хранилище выводит его из начального остатка и журнала.
+func (a *Account) Apply(patch AccountPatch) {
+	if patch.Name != nil {
+		a.Name = *patch.Name
+	}
+	if patch.Type != nil {
+		a.Type = *patch.Type
+	}
+	if patch.OpeningBalance != nil {
+		a.OpeningBalance = *patch.OpeningBalance
+	}
+}
+
+// CheckCanPost проверяет, что на счет можно провести операцию на сумму amount
+func (a *Account) CheckCanPost(amount Money) error {
+	if a.IsClosed() {
+		return fmt.Errorf("account %d is closed", a.ID)
+	}
+	if a.Currency() != amount.Currency() {
+		return fmt.Errorf("%w: account %d is in %s, operation is in %s",
+			ErrCurrencyMismatch, a.ID, a.Currency(), amount.Currency())
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fincraft-finance/internal/domain"
+)
+
+func Test_Account_CheckCanPost_ReturnsError_WhenClosedOrCurrencyDiffers(t *testing.T) {
+	kzt := domain.MustCurrency("KZT")
+	account := domain.Account{ID: 3, Name: "Cash", Type: domain.AccountTypeCash, OpeningBalance: domain.NewMoney(0, kzt)}
+	assert.NoError(t, account.CheckCanPost(domain.NewMoney(100, kzt)))
+
+	err := account.CheckCanPost(domain.NewMoney(100, domain.MustCurrency("USD")))
+	assert.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+
+	account.ClosedAt = time.Now()
+	assert.EqualError(t, account.CheckCanPost(domain.NewMoney(100, kzt)), "account 3 is closed")
+}
+
+func Test_Account_Apply_KeepsBalance_WhenOpeningBalanceChanged(t *testing.T) {
+	kzt := domain.MustCurrency("KZT")
+	account := domain.Account{OpeningBalance: domain.NewMoney(100, kzt), Balance: domain.NewMoney(700, kzt)}
+	opening := domain.NewMoney(300, kzt)
+
+	account.Apply(domain.AccountPatch{OpeningBalance: &opening})
+
+	assert.Equal(t, opening, account.OpeningBalance)
+	assert.Equal(t, domain.NewMoney(700, kzt), account.Balance)
+}
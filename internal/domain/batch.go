@@ -0,0 +1,38 @@
+package domain
+
+import "fmt"
+
+// MaxBatchSize наибольшее число операций в одном пакете или потоке импорта
+const MaxBatchSize = 5000
+
+// BatchMode режим пакетной записи операций
+type BatchMode int
+
+const (
+	// BatchAtomic пакет записывается целиком либо не записывается совсем
+	BatchAtomic BatchMode = iota
+	// BatchBestEffort записываются корректные элементы, отклоненные возвращаются с ошибкой
+	BatchBestEffort
+)
+
+// BatchItemError ошибка элемента пакета с его номером (с нуля)
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+// Error возвращает описание ошибки с номером элемента
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+// Unwrap возвращает исходную ошибку элемента
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
+
+// IncomeBatchResult результат записи одного дохода из пакета: сохраненный доход или причина отказа
+type IncomeBatchResult struct {
+	Income *Income
+	Err    error
+}
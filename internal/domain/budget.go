@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// BudgetPeriod период, на который задается лимит бюджета
+type BudgetPeriod int
+
+const (
+	// BudgetPeriodMonthly календарный месяц
+	BudgetPeriodMonthly BudgetPeriod = iota + 1
+	// BudgetPeriodWeekly календарная неделя с понедельника
+	BudgetPeriodWeekly
+	// BudgetPeriodCustom один произвольный период от StartDate до EndDate
+	BudgetPeriodCustom
+)
+
+// Valid сообщает, что период бюджета известен
+func (p BudgetPeriod) Valid() bool {
+	return p >= BudgetPeriodMonthly && p <= BudgetPeriodCustom
+}
+
+// Budget план операций по категории на период.
+// Бюджет родительской категории учитывает и операции ее подкатегорий.
+type Budget struct {
+	ID         int64
+	UserID     int64
+	CategoryID int
+	Period     BudgetPeriod
+	Limit      Money
+	// Rollover переносит неизрасходованный остаток предыдущего периода в текущий
+	Rollover bool
+	// StartDate дата начала действия бюджета
+	StartDate Date
+	// EndDate последний день произвольного периода, для повторяющихся периодов не задается
+	EndDate   Date
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// BudgetPatch частичное изменение бюджета, nil-поля не меняются
+type BudgetPatch struct {
+	Limit     *Money
+	Rollover  *bool
+	StartDate *Date
+	EndDate   *Date
+}
+
+// Validate проверяет бизнес-правила для бюджета
+func (b *Budget) Validate() error {
+	if b.UserID <= 0 {
+		return errors.New("user ID must be valid")
+	}
+	if b.CategoryID <= 0 {
+		return errors.New("category ID must be valid")
+	}
+	if !b.Period.Valid() {
+		return errors.New("budget period must be valid")
+	}
+	if !b.Limit.IsPositive() {
+		return errors.New("limit must be greater than 0")
+	}
+	if b.Limit.Currency().IsZero() {
+		return errors.New("currency must be valid")
+	}
+	if b.StartDate.IsZero() {
+		return errors.New("start date must be set")
+	}
+
+	if b.Period != BudgetPeriodCustom {
+		if !b.EndDate.IsZero() {
+			return errors.New("end date is only allowed for a custom period")
+		}
+		return nil
+	}
+	if b.EndDate.IsZero() || b.EndDate.Before(b.StartDate) {
+		return errors.New("end date of a custom period must not be before start date")
+	}
+	if b.Rollover {
+		return errors.New("rollover requires a recurring period")
+	}
+	return nil
+}
+
+// Apply применяет частичное изменение к бюджету
+func (b *Budget) Apply(patch BudgetPatch) {
+	if patch.Limit != nil {
+		b.Limit = *patch.Limit
+	}
+	if patch.Rollover != nil {
+		b.Rollover = *patch.Rollover
+	}
+	if patch.StartDate != nil {
+		b.StartDate = *patch.StartDate
+	}
+	if patch.EndDate != nil {
+		b.EndDate = *patch.EndDate
+	}
+}
+
+// PeriodOn возвращает границы периода бюджета, в который попадает дата d: [from, to)
+func (b *Budget) PeriodOn(d Date) (from, to Date) {
+	switch b.Period {
+	case BudgetPeriodMonthly:
+		from = Date{Year: d.Year, Month: d.Month, Day: 1}
+		return from, DateOf(from.Start(time.UTC).AddDate(0, 1, 0))
+	case BudgetPeriodWeekly:
+		// Неделя начинается с понедельника
+		from = d.AddDays(-((int(d.Weekday()) + 6) % 7))
+		return from, from.AddDays(7)
+	default:
+		return b.StartDate, b.EndDate.AddDays(1)
+	}
+}
+
+// PreviousPeriod возвращает период перед периодом, начинающимся с from.
+// ok ложно, если бюджет в том периоде еще не действовал или период не повторяется.
+func (b *Budget) PreviousPeriod(from Date) (prevFrom, prevTo Date, ok bool) {
+	if b.Period == BudgetPeriodCustom || !b.StartDate.Before(from) {
+		return Date{}, Date{}, false
+	}
+	prevFrom, prevTo = b.PeriodOn(from.AddDays(-1))
+	return prevFrom, prevTo, true
+}
+
+// BudgetStatus положение дел по бюджету в одном периоде
+type BudgetStatus struct {
+	Budget Budget
+	// PeriodStart и PeriodEnd первый и последний день периода
+	PeriodStart Date
+	PeriodEnd   Date
+	// CarriedOver остаток, перенесенный из предыдущего периода
+	CarriedOver Money
+	// Limit лимит периода с учетом перенесенного остатка
+	Limit Money
+	Spent Money
+	// Remaining отрицателен при перерасходе
+	Remaining Money
+	// PercentUsed доля израсходованного лимита в процентах, может превышать 100
+	PercentUsed float64
+	// Projected ожидаемая сумма к концу периода при сохранении текущего темпа
+	Projected Money
+}
+
+// NewBudgetStatus рассчитывает положение по бюджету в периоде [from, to) на дату today.
+// spent и carried должны быть в валюте лимита бюджета.
+func NewBudgetStatus(budget Budget, from, to Date, carried, spent Money, today Date) (BudgetStatus, error) {
+	limit, err := budget.Limit.Add(carried)
+	if err != nil {
+		return BudgetStatus{}, err
+	}
+	remaining, err := limit.Sub(spent)
+	if err != nil {
+		return BudgetStatus{}, err
+	}
+
+	status := BudgetStatus{
+		Budget:      budget,
+		PeriodStart: from,
+		PeriodEnd:   to.AddDays(-1),
+		CarriedOver: carried,
+		Limit:       limit,
+		Spent:       spent,
+		Remaining:   remaining,
+		Projected:   spent,
+	}
+	if limit.IsPositive() {
+		status.PercentUsed, _ = new(big.Rat).Quo(spent.Rat(), limit.Rat()).Float64()
+		status.PercentUsed *= 100
+	}
+
+	// Прогноз линейный: средний расход за прошедшие дни, умноженный на длину периода
+	if !today.Before(from) && today.Before(to) {
+		elapsed := from.DaysUntil(today) + 1
+		total := from.DaysUntil(to)
+		projected := new(big.Rat).Mul(spent.Rat(), big.NewRat(int64(total), int64(elapsed)))
+		if status.Projected, err = NewMoneyFromRat(projected, spent.Currency(), RoundHalfEven); err != nil {
+			return BudgetStatus{}, fmt.Errorf("failed to project spending: %w", err)
+		}
+	}
+
+	return status, nil
+}
+
+// Overspent сообщает, что лимит периода уже превышен
+func (s *BudgetStatus) Overspent() bool {
+	return s.Remaining.IsNegative()
+}
+
+// ProjectedOverspend сообщает, что при текущем темпе лимит будет превышен к концу периода
+func (s *BudgetStatus) ProjectedOverspend() bool {
+	cmp, err := s.Projected.Cmp(s.Limit)
+	return err == nil && cmp > 0
+}
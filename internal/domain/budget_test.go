@@ -0,0 +1,93 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fincraft-finance/internal/domain"
+)
+
+func Test_Budget_Validate_ReturnsError_WhenPeriodDatesInvalid(t *testing.T) {
+	limit := domain.NewMoney(100000, domain.MustCurrency("KZT"))
+	start := domain.Date{Year: 2024, Month: time.March, Day: 10}
+	tests := []struct {
+		name   string
+		budget domain.Budget
+		errMsg string
+	}{
+		{"Unknown Period", domain.Budget{UserID: 1, CategoryID: 6, Limit: limit, StartDate: start},
+			"budget period must be valid"},
+		{"End Date On Monthly", domain.Budget{UserID: 1, CategoryID: 6, Period: domain.BudgetPeriodMonthly, Limit: limit,
+			StartDate: start, EndDate: start.AddDays(30)}, "end date is only allowed for a custom period"},
+		{"Custom Ends Before Start", domain.Budget{UserID: 1, CategoryID: 6, Period: domain.BudgetPeriodCustom, Limit: limit,
+			StartDate: start, EndDate: start.AddDays(-1)}, "end date of a custom period must not be before start date"},
+		{"Custom Rollover", domain.Budget{UserID: 1, CategoryID: 6, Period: domain.BudgetPeriodCustom, Limit: limit,
+			Rollover: true, StartDate: start, EndDate: start}, "rollover requires a recurring period"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.budget.Validate(), tt.errMsg)
+		})
+	}
+}
+
+func Test_Budget_PeriodOn_ReturnsCalendarPeriod_WhenRecurring(t *testing.T) {
+	monthly := domain.Budget{Period: domain.BudgetPeriodMonthly}
+	from, to := monthly.PeriodOn(domain.Date{Year: 2024, Month: time.December, Day: 15})
+	assert.Equal(t, domain.Date{Year: 2024, Month: time.December, Day: 1}, from)
+	assert.Equal(t, domain.Date{Year: 2025, Month: time.January, Day: 1}, to)
+
+	// 2024-03-10 - воскресенье, неделя начинается в понедельник 4 марта
+	weekly := domain.Budget{Period: domain.BudgetPeriodWeekly}
+	from, to = weekly.PeriodOn(domain.Date{Year: 2024, Month: time.March, Day: 10})
+	assert.Equal(t, domain.Date{Year: 2024, Month: time.March, Day: 4}, from)
+	assert.Equal(t, domain.Date{Year: 2024, Month: time.March, Day: 11}, to)
+}
+
+func Test_Budget_PreviousPeriod_ReturnsFalse_WhenBudgetStartedInPeriod(t *testing.T) {
+	budget := domain.Budget{Period: domain.BudgetPeriodMonthly, StartDate: domain.Date{Year: 2024, Month: time.March, Day: 5}}
+
+	_, _, ok := budget.PreviousPeriod(domain.Date{Year: 2024, Month: time.March, Day: 1})
+	assert.False(t, ok)
+
+	from, to, ok := budget.PreviousPeriod(domain.Date{Year: 2024, Month: time.April, Day: 1})
+	assert.True(t, ok)
+	assert.Equal(t, domain.Date{Year: 2024, Month: time.March, Day: 1}, from)
+	assert.Equal(t, domain.Date{Year: 2024, Month: time.April, Day: 1}, to)
+}
+
+func Test_NewBudgetStatus_ProjectsSpending_WhenPeriodInProgress(t *testing.T) {
+	kzt := domain.MustCurrency("KZT")
+	budget := domain.Budget{Period: domain.BudgetPeriodMonthly, Limit: domain.NewMoney(30000, kzt)}
+	from, to := budget.PeriodOn(domain.Date{Year: 2024, Month: time.April, Day: 10})
+
+	status, err := domain.NewBudgetStatus(budget, from, to, domain.NewMoney(5000, kzt), domain.NewMoney(14000, kzt),
+		domain.Date{Year: 2024, Month: time.April, Day: 10})
+	assert.NoError(t, err)
+
+	assert.Equal(t, domain.NewMoney(35000, kzt), status.Limit)
+	assert.Equal(t, domain.NewMoney(21000, kzt), status.Remaining)
+	assert.InDelta(t, 40.0, status.PercentUsed, 1e-9)
+	// 14000 за 10 дней из 30 - 42000 к концу апреля
+	assert.Equal(t, domain.NewMoney(42000, kzt), status.Projected)
+	assert.Equal(t, domain.Date{Year: 2024, Month: time.April, Day: 30}, status.PeriodEnd)
+	assert.False(t, status.Overspent())
+	assert.True(t, status.ProjectedOverspend())
+}
+
+func Test_NewBudgetStatus_KeepsSpent_WhenPeriodFinished(t *testing.T) {
+	kzt := domain.MustCurrency("KZT")
+	budget := domain.Budget{Period: domain.BudgetPeriodWeekly, Limit: domain.NewMoney(1000, kzt)}
+	from, to := budget.PeriodOn(domain.Date{Year: 2024, Month: time.March, Day: 6})
+
+	status, err := domain.NewBudgetStatus(budget, from, to, domain.NewMoney(0, kzt), domain.NewMoney(1200, kzt),
+		domain.Date{Year: 2024, Month: time.May, Day: 1})
+	assert.NoError(t, err)
+
+	assert.Equal(t, domain.NewMoney(1200, kzt), status.Projected)
+	assert.Equal(t, domain.NewMoney(-200, kzt), status.Remaining)
+	assert.True(t, status.Overspent())
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxCategoryNameLength максимальная длина названия категории в символах
+const MaxCategoryNameLength = 64
+
+// CategoryKind тип операций, к которым применяется категория
+type CategoryKind int
+
+const (
+	// CategoryKindIncome категория доходов
+	CategoryKindIncome CategoryKind = iota + 1
+	// CategoryKindExpense категория расходов
+	CategoryKindExpense
+)
+
+// Valid сообщает, что тип категории известен
+func (k CategoryKind) Valid() bool {
+	return k == CategoryKindIncome || k == CategoryKindExpense
+}
+
+// String возвращает название типа категории
+func (k CategoryKind) String() string {
+	switch k {
+	case CategoryKindIncome:
+		return "income"
+	case CategoryKindExpense:
+		return "expense"
+	default:
+		return fmt.Sprintf("CategoryKind(%d)", int(k))
+	}
+}
+
+// Category категория доходов или расходов.
+// Категории образуют двухуровневую иерархию: категория верхнего уровня и ее подкатегории.
+type Category struct {
+	ID int
+	// UserID владелец категории, 0 - системная категория, доступная всем пользователям
+	UserID int64
+	Name   string
+	Kind   CategoryKind
+	// Icon идентификатор иконки для клиентов
+	Icon string
+	// ParentID родительская категория, 0 - категория верхнего уровня
+	ParentID int
+	// Archived архивная категория остается у старых операций, но недоступна для новых
+	Archived  bool
+	CreatedAt time.Time
+}
+
+// CategoryFilter условия выборки категорий, доступных пользователю
+type CategoryFilter struct {
+	UserID int64
+	// Kind 0 - категории любого типа
+	Kind            CategoryKind
+	IncludeArchived bool
+}
+
+// IsSystem сообщает, что категория системная и не принадлежит пользователю
+func (c *Category) IsSystem() bool {
+	return c.UserID == 0
+}
+
+// Validate проверяет бизнес-правила для категории
+func (c *Category) Validate() error {
+	if err := ValidateCategoryName(c.Name); err != nil {
+		return err
+	}
+	if !c.Kind.Valid() {
+		return errors.New("category kind must be income or expense")
+	}
+	if c.ParentID < 0 {
+		return errors.New("parent category ID must be valid")
+	}
+	return nil
+}
+
+// CheckAssignable проверяет, что категорию можно указать у новой операции типа kind
+func (c *Category) CheckAssignable(kind CategoryKind) error {
+	if c.Kind != kind {
+		return fmt.Errorf("category %d is not an %s category", c.ID, kind)
+	}
+	if c.Archived {
+		return fmt.Errorf("category %d is archived", c.ID)
+	}
+	return nil
+}
+
+// ValidateCategoryName проверяет название категории
+func ValidateCategoryName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("category name must not be empty")
+	}
+	if utf8.RuneCountInString(name) > MaxCategoryNameLength {
+		return fmt.Errorf("category name must not exceed %d characters", MaxCategoryNameLength)
+	}
+	return nil
+}
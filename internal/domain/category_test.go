@@ -0,0 +1,38 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fincraft-finance/internal/domain"
+)
+
+func Test_Category_Validate_ReturnsError_WhenInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		category domain.Category
+		errMsg   string
+	}{
+		{"Empty Name", domain.Category{Kind: domain.CategoryKindIncome}, "category name must not be empty"},
+		{"Long Name", domain.Category{Name: strings.Repeat("я", domain.MaxCategoryNameLength+1), Kind: domain.CategoryKindIncome},
+			"category name must not exceed 64 characters"},
+		{"Unknown Kind", domain.Category{Name: "Salary", Kind: 3}, "category kind must be income or expense"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.category.Validate(), tt.errMsg)
+		})
+	}
+}
+
+func Test_Category_CheckAssignable_ReturnsError_WhenKindDiffersOrArchived(t *testing.T) {
+	income := domain.Category{ID: 1, Name: "Salary", Kind: domain.CategoryKindIncome}
+	assert.NoError(t, income.CheckAssignable(domain.CategoryKindIncome))
+	assert.EqualError(t, income.CheckAssignable(domain.CategoryKindExpense), "category 1 is not an expense category")
+
+	income.Archived = true
+	assert.EqualError(t, income.CheckAssignable(domain.CategoryKindIncome), "category 1 is archived")
+}
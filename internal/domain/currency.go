@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownCurrency возвращается для кода валюты, которого нет в справочнике
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// Currency описывает валюту по ISO 4217
+type Currency struct {
+	// Code буквенный код валюты (KZT, USD, ...)
+	Code string
+	// MinorUnits количество знаков после запятой в минимальных единицах
+	MinorUnits int
+}
+
+// currencies справочник поддерживаемых валют, код -> количество минимальных единиц.
+// Значения взяты из ISO 4217; BTC в стандарт не входит и добавлена для криптовалютных счетов.
+var currencies = map[string]int{
+	"AED": 2, "AMD": 2, "AUD": 2, "AZN": 2, "BHD": 3, "BRL": 2, "BYN": 2,
+	"CAD": 2, "CHF": 2, "CLF": 4, "CLP": 0, "CNY": 2, "CZK": 2, "DKK": 2,
+	"EUR": 2, "GBP": 2, "GEL": 2, "HKD": 2, "HUF": 2, "IDR": 2, "ILS": 2,
+	"INR": 2, "IQD": 3, "ISK": 0, "JOD": 3, "JPY": 0, "KGS": 2, "KRW": 0,
+	"KWD": 3, "KZT": 2, "LYD": 3, "MDL": 2, "MXN": 2, "NOK": 2, "NZD": 2,
+	"OMR": 3, "PLN": 2, "RON": 2, "RSD": 2, "RUB": 2, "SAR": 2, "SEK": 2,
+	"SGD": 2, "THB": 2, "TJS": 2, "TMT": 2, "TND": 3, "TRY": 2, "UAH": 2,
+	"USD": 2, "UZS": 2, "VND": 0,
+	"BTC": 8,
+}
+
+// CurrencyByCode возвращает валюту по буквенному коду без учета регистра
+func CurrencyByCode(code string) (Currency, error) {
+	code = strings.ToUpper(code)
+	minor, ok := currencies[code]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+
+	return Currency{Code: code, MinorUnits: minor}, nil
+}
+
+// MustCurrency возвращает валюту по коду и паникует, если код неизвестен.
+// Предназначена для констант и тестов.
+func MustCurrency(code string) Currency {
+	c, err := CurrencyByCode(code)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// IsZero сообщает, что валюта не задана
+func (c Currency) IsZero() bool {
+	return c.Code == ""
+}
+
+// String возвращает код валюты
+func (c Currency) String() string {
+	return c.Code
+}
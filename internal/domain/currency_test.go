@@ -0,0 +1,34 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+)
+
+func Test_CurrencyByCode_ReturnsCurrency_WhenCodeKnown(t *testing.T) {
+	tests := []struct {
+		code  string
+		minor int
+	}{
+		{"KZT", 2}, {"rub", 2}, {"USD", 2}, {"EUR", 2}, {"JPY", 0}, {"KWD", 3}, {"BTC", 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			c, err := domain.CurrencyByCode(tt.code)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.minor, c.MinorUnits)
+		})
+	}
+}
+
+func Test_CurrencyByCode_ReturnsError_WhenCodeUnknown(t *testing.T) {
+	_, err := domain.CurrencyByCode("XXX")
+
+	assert.ErrorIs(t, err, domain.ErrUnknownCurrency)
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date календарная дата без времени и часового пояса
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// ParseDate разбирает дату в формате YYYY-MM-DD
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+	}
+	return DateOf(t), nil
+}
+
+// DateOf возвращает календарную дату момента t в его часовом поясе
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// IsZero сообщает, что дата не задана
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// Start возвращает начало дня в указанном часовом поясе
+func (d Date) Start(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays возвращает дату, сдвинутую на n дней
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.Start(time.UTC).AddDate(0, 0, n))
+}
+
+// Before сообщает, что дата раньше other
+func (d Date) Before(other Date) bool {
+	return d.Start(time.UTC).Before(other.Start(time.UTC))
+}
+
+// Weekday возвращает день недели
+func (d Date) Weekday() time.Weekday {
+	return d.Start(time.UTC).Weekday()
+}
+
+// DaysUntil возвращает количество дней от d до other, отрицательное, если other раньше
+func (d Date) DaysUntil(other Date) int {
+	return int(other.Start(time.UTC).Sub(d.Start(time.UTC)).Hours() / 24)
+}
+
+// String возвращает дату в формате YYYY-MM-DD
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// ValidateOccurredAt проверяет, что момент операции не находится в будущем дальше допустимого отклонения
+func ValidateOccurredAt(occurredAt, now time.Time, tolerance time.Duration) error {
+	if occurredAt.After(now.Add(tolerance)) {
+		return fmt.Errorf("occurred at %s is in the future", occurredAt.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
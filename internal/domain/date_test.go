@@ -0,0 +1,41 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+)
+
+func Test_ParseDate_ReturnsDate_WhenValidFormat(t *testing.T) {
+	d, err := domain.ParseDate("2024-02-29")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.Date{Year: 2024, Month: time.February, Day: 29}, d)
+	assert.Equal(t, "2024-03-01", d.AddDays(1).String())
+}
+
+func Test_ParseDate_ReturnsError_WhenInvalidFormat(t *testing.T) {
+	_, err := domain.ParseDate("2023-02-29")
+
+	assert.Error(t, err)
+}
+
+func Test_Date_Start_ReturnsLocalMidnight_WhenLocationGiven(t *testing.T) {
+	almaty, err := time.LoadLocation("Asia/Almaty")
+	require.NoError(t, err)
+
+	start := domain.Date{Year: 2024, Month: time.March, Day: 1}.Start(almaty)
+
+	assert.True(t, start.Equal(time.Date(2024, time.February, 29, 19, 0, 0, 0, time.UTC)))
+}
+
+func Test_ValidateOccurredAt_ReturnsError_WhenBeyondTolerance(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, domain.ValidateOccurredAt(now.Add(time.Hour), now, time.Hour))
+	assert.Error(t, domain.ValidateOccurredAt(now.Add(time.Hour+time.Second), now, time.Hour))
+}
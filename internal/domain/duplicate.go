@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// DuplicateWindowDays наибольшая разница в днях между датами дохода и его возможного дубликата
+	DuplicateWindowDays = 3
+	// DuplicateThreshold оценка, начиная с которой доход считается вероятным дубликатом
+	DuplicateThreshold = 0.7
+)
+
+// DuplicateCandidate существующий доход, похожий на проверяемый, с оценкой сходства от 0 до 1
+type DuplicateCandidate struct {
+	Income Income
+	Score  float64
+}
+
+// IncomeSource доход, объединенный с другим доходом: сохраняется как источник оставшейся записи
+type IncomeSource struct {
+	// IncomeID доход, который остался после объединения
+	IncomeID int64
+	// Income объединенный доход в том виде, в котором он был до удаления
+	Income   Income
+	MergedAt time.Time
+}
+
+// IncomeDuplicateScore оценивает, насколько вероятно, что доходы a и b - одна и та же операция.
+// Дубликатом может быть только доход пользователя на ту же сумму в той же валюте, на тот же счет
+// (если счет указан у обоих) и не дальше DuplicateWindowDays дней. Оценка складывается из близости дат
+// (вес 0.6) и сходства описаний (вес 0.4); если одно из описаний пустое, сходство считается средним.
+func IncomeDuplicateScore(a, b *Income) float64 {
+	if a.UserID != b.UserID || a.Amount != b.Amount {
+		return 0
+	}
+	if a.AccountID != 0 && b.AccountID != 0 && a.AccountID != b.AccountID {
+		return 0
+	}
+
+	diff := a.OccurredAt.Sub(b.OccurredAt)
+	if diff < 0 {
+		diff = -diff
+	}
+	days := int((diff + 12*time.Hour) / (24 * time.Hour))
+	if days > DuplicateWindowDays {
+		return 0
+	}
+	dateScore := 1 - float64(days)/float64(DuplicateWindowDays+1)
+
+	return 0.6*dateScore + 0.4*descriptionSimilarity(a.Description, b.Description)
+}
+
+// descriptionSimilarity сравнивает описания по общим триграммам без учета регистра и знаков препинания.
+// Доля берется от меньшего набора, поэтому сокращенное описание ("Salary") похоже на полное
+// ("ACME LLP salary for March"). Пустое описание дает 0.5.
+func descriptionSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0.5
+	}
+	if len(ta) > len(tb) {
+		ta, tb = tb, ta
+	}
+
+	common := 0
+	for t := range ta {
+		if tb[t] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(ta))
+}
+
+// trigrams возвращает триграммы слов описания, дополненных пробелами по краям
+func trigrams(s string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	set := make(map[string]bool)
+	for _, word := range words {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			set[string(runes[i:i+3])] = true
+		}
+	}
+	return set
+}
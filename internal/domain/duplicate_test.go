@@ -0,0 +1,46 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fincraft-finance/internal/domain"
+)
+
+func Test_IncomeDuplicateScore_ReturnsScore_WhenIncomesCompared(t *testing.T) {
+	kzt := domain.MustCurrency("KZT")
+	at := time.Date(2024, time.March, 15, 9, 0, 0, 0, time.UTC)
+	manual := domain.Income{UserID: 1, Amount: domain.NewMoney(50_000_000, kzt), Description: "Salary", OccurredAt: at}
+	tests := []struct {
+		name     string
+		other    domain.Income
+		expected float64
+	}{
+		{"Same Day Bank Description", domain.Income{UserID: 1, AccountID: 3, Amount: manual.Amount,
+			Description: "ACME LLP: SALARY for March", OccurredAt: at.Add(-9 * time.Hour)}, 1},
+		{"Next Day Empty Description", domain.Income{UserID: 1, Amount: manual.Amount,
+			OccurredAt: at.Add(24 * time.Hour)}, 0.65},
+		{"Different Amount", domain.Income{UserID: 1, Amount: domain.NewMoney(50_000_100, kzt),
+			Description: "Salary", OccurredAt: at}, 0},
+		{"Outside Window", domain.Income{UserID: 1, Amount: manual.Amount, Description: "Salary",
+			OccurredAt: at.AddDate(0, 0, 4)}, 0},
+		{"Other User", domain.Income{UserID: 2, Amount: manual.Amount, Description: "Salary", OccurredAt: at}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, domain.IncomeDuplicateScore(&manual, &tt.other), 1e-9)
+		})
+	}
+}
+
+func Test_IncomeDuplicateScore_ReturnsZero_WhenAccountsDiffer(t *testing.T) {
+	kzt := domain.MustCurrency("KZT")
+	at := time.Now()
+	a := domain.Income{UserID: 1, AccountID: 3, Amount: domain.NewMoney(100, kzt), OccurredAt: at}
+	b := domain.Income{UserID: 1, AccountID: 4, Amount: domain.NewMoney(100, kzt), OccurredAt: at}
+
+	assert.Zero(t, domain.IncomeDuplicateScore(&a, &b))
+}
@@ -0,0 +1,6 @@
+package domain
+
+import "errors"
+
+// ErrNotFound возвращается, если запрошенный объект не существует или принадлежит другому пользователю
+var ErrNotFound = errors.New("not found")
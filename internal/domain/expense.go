@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxDescriptionLength максимальная длина описания операции в символах
+const MaxDescriptionLength = 255
+
+// Expense представляет бизнес-объект расхода
+type Expense struct {
+	ID         int64
+	UserID     int64
+	CategoryID int
+	// AccountID счет, с которого списаны деньги, 0 - без счета
+	AccountID   int64
+	Amount      Money
+	Description string
+	// OccurredAt момент совершения расхода, указанный пользователем
+	OccurredAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ExpenseFilter условия выборки расходов пользователя
+type ExpenseFilter struct {
+	UserID     int64
+	CategoryID int
+	// AccountID 0 - расходы с любого счета и без счета
+	AccountID int64
+	// From и To ограничивают дату расхода полуинтервалом [From, To), нулевые значения не ограничивают
+	From time.Time
+	To   time.Time
+	// AfterID курсор: возвращаются расходы с ID меньше указанного, 0 - с начала
+	AfterID  int64
+	PageSize int
+}
+
+// ExpensePage страница списка расходов
+type ExpensePage struct {
+	Items []Expense
+	// HasMore сообщает, что за последним элементом есть еще данные
+	HasMore bool
+}
+
+// Validate проверяет бизнес-правила для расхода
+func (e *Expense) Validate() error {
+	if !e.Amount.IsPositive() {
+		return errors.New("amount must be greater than 0")
+	}
+	if e.Amount.Currency().IsZero() {
+		return errors.New("currency must be valid")
+	}
+	if e.UserID <= 0 {
+		return errors.New("user ID must be valid")
+	}
+	if e.CategoryID <= 0 {
+		return errors.New("category ID must be valid")
+	}
+	if e.AccountID < 0 {
+		return errors.New("account ID must be valid")
+	}
+	if utf8.RuneCountInString(e.Description) > MaxDescriptionLength {
+		return fmt.Errorf("description must not exceed %d characters", MaxDescriptionLength)
+	}
+	if e.OccurredAt.IsZero() {
+		return errors.New("occurred at must be set")
+	}
+	return nil
+}
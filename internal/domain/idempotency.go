@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxIdempotencyKeyLength максимальная длина ключа идемпотентности в символах
+const MaxIdempotencyKeyLength = 128
+
+// IdempotencyKey ключ идемпотентности в пределах метода API и пользователя
+type IdempotencyKey struct {
+	// UserID 0 - запрос не относится к конкретному пользователю
+	UserID int64
+	Method string
+	Key    string
+}
+
+// Validate проверяет ключ идемпотентности
+func (k IdempotencyKey) Validate() error {
+	if k.Method == "" {
+		return errors.New("idempotency method must be set")
+	}
+	if k.Key == "" {
+		return errors.New("idempotency key must not be empty")
+	}
+	if utf8.RuneCountInString(k.Key) > MaxIdempotencyKeyLength {
+		return fmt.Errorf("idempotency key must not exceed %d characters", MaxIdempotencyKeyLength)
+	}
+	for _, r := range k.Key {
+		if !unicode.IsPrint(r) || unicode.IsSpace(r) {
+			return errors.New("idempotency key must consist of printable characters without spaces")
+		}
+	}
+	return nil
+}
+
+// IdempotentResponse сохраненный результат запроса: имя типа сообщения и его сериализованное содержимое
+type IdempotentResponse struct {
+	Type string
+	Body []byte
+}
+
+// IdempotencyRecord запрос, выполненный с ключом идемпотентности
+type IdempotencyRecord struct {
+	Key IdempotencyKey
+	// Fingerprint отпечаток содержимого запроса: повтор с тем же ключом должен совпадать с ним
+	Fingerprint []byte
+	Response    IdempotentResponse
+	// Completed ложно, пока исходный запрос выполняется
+	Completed bool
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
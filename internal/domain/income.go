@@ -1,25 +1,198 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
 
 // Income представляет бизнес-объект дохода
 type Income struct {
-	UserID      int64
-	CategoryID  int
+	ID         int64
+	UserID     int64
+	CategoryID int
+	// AccountID счет, на который поступили деньги, 0 - доход не привязан к счету
+	AccountID   int64
 	Amount      Money
 	Description string
+	// Tags теги дохода без повторов без учета регистра
+	Tags []string
+	// OccurredAt момент получения дохода, указанный пользователем
+	OccurredAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// IncomeSortField поле сортировки списка доходов
+type IncomeSortField int
+
+const (
+	// IncomeSortByDate сортировка по дате дохода
+	IncomeSortByDate IncomeSortField = iota
+	// IncomeSortByAmount сортировка по сумме
+	IncomeSortByAmount
+)
+
+// IncomeCursor позиция последнего элемента предыдущей страницы
+type IncomeCursor struct {
+	ID int64
+	// SortBy сортировка, при которой получена позиция
+	SortBy IncomeSortField
+	// Value значение поля сортировки последнего элемента
+	Value string
+}
+
+// Validate проверяет, что позиция получена при сортировке sortBy и ее значение разбирается как поле сортировки
+func (c *IncomeCursor) Validate(sortBy IncomeSortField) error {
+	if c.ID <= 0 {
+		return errors.New("cursor ID must be valid")
+	}
+	if c.SortBy != sortBy {
+		return errors.New("cursor was issued for another sort order")
+	}
+	if sortBy == IncomeSortByAmount {
+		intPart, fracPart, hasPoint := strings.Cut(strings.TrimPrefix(c.Value, "-"), ".")
+		if !isDigits(intPart) || (hasPoint && !isDigits(fracPart)) {
+			return fmt.Errorf("invalid cursor amount %q", c.Value)
+		}
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339Nano, c.Value); err != nil {
+		return fmt.Errorf("invalid cursor date %q", c.Value)
+	}
+	return nil
+}
+
+// IncomeFilter условия выборки доходов пользователя
+type IncomeFilter struct {
+	UserID     int64
+	CategoryID int
+	AccountID  int64
+	// From и To ограничивают дату дохода полуинтервалом [From, To), нулевые значения не ограничивают
+	From time.Time
+	To   time.Time
+	// FromDate и ToDate ограничивают дату включительно по календарю пользователя.
+	// Use-case переводит их в From и To с учетом часового пояса пользователя.
+	FromDate Date
+	ToDate   Date
+	// MinAmount и MaxAmount ограничивают сумму включительно, валюта границ фильтрует и валюту дохода
+	MinAmount *Money
+	MaxAmount *Money
+	// Tags оставляет доходы, у которых есть все перечисленные теги (без учета регистра)
+	Tags []string
+
+	SortBy   IncomeSortField
+	Asc      bool
+	After    *IncomeCursor
+	PageSize int
+}
+
+// IncomePage страница списка доходов
+type IncomePage struct {
+	Items []Income
+	// HasMore сообщает, что за последним элементом есть еще данные
+	HasMore bool
+}
+
+// IncomePatch частичное изменение дохода, nil-поля не меняются
+type IncomePatch struct {
+	CategoryID  *int
+	AccountID   *int64
+	Amount      *Money
+	Description *string
+	OccurredAt  *time.Time
+	// Tags заменяет все теги дохода, пустой срез снимает их
+	Tags *[]string
 }
 
 // Validate проверяет бизнес-правила для дохода
 func (i *Income) Validate() error {
-	if i.Amount <= 0 {
+	if !i.Amount.IsPositive() {
 		return errors.New("amount must be greater than 0")
 	}
+	if i.Amount.Currency().IsZero() {
+		return errors.New("currency must be valid")
+	}
 	if i.UserID <= 0 {
 		return errors.New("user ID must be valid")
 	}
 	if i.CategoryID <= 0 {
 		return errors.New("category ID must be valid")
 	}
+	if i.AccountID < 0 {
+		return errors.New("account ID must be valid")
+	}
+	if utf8.RuneCountInString(i.Description) > MaxDescriptionLength {
+		return fmt.Errorf("description must not exceed %d characters", MaxDescriptionLength)
+	}
+	if i.OccurredAt.IsZero() {
+		return errors.New("occurred at must be set")
+	}
+	return validateTags(i.Tags)
+}
+
+// Apply применяет частичное изменение к доходу
+func (i *Income) Apply(patch IncomePatch) {
+	if patch.CategoryID != nil {
+		i.CategoryID = *patch.CategoryID
+	}
+	if patch.AccountID != nil {
+		i.AccountID = *patch.AccountID
+	}
+	if patch.Amount != nil {
+		i.Amount = *patch.Amount
+	}
+	if patch.Description != nil {
+		i.Description = *patch.Description
+	}
+	if patch.OccurredAt != nil {
+		i.OccurredAt = *patch.OccurredAt
+	}
+	if patch.Tags != nil {
+		i.Tags = *patch.Tags
+	}
+}
+
+// Cursor возвращает позицию дохода для постраничной выборки с указанной сортировкой
+func (i *Income) Cursor(sortBy IncomeSortField) IncomeCursor {
+	if sortBy == IncomeSortByAmount {
+		return IncomeCursor{ID: i.ID, SortBy: sortBy, Value: i.Amount.Decimal()}
+	}
+	return IncomeCursor{ID: i.ID, SortBy: sortBy, Value: i.OccurredAt.UTC().Format(time.RFC3339Nano)}
+}
+
+// Validate проверяет согласованность фильтра
+func (f *IncomeFilter) Validate() error {
+	if f.UserID <= 0 {
+		return errors.New("user ID must be valid")
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return errors.New("date range start must be before its end")
+	}
+	if (!f.FromDate.IsZero() || !f.ToDate.IsZero()) && (!f.From.IsZero() || !f.To.IsZero()) {
+		return errors.New("calendar dates and timestamps cannot be combined in one range")
+	}
+	if !f.FromDate.IsZero() && !f.ToDate.IsZero() && f.ToDate.Before(f.FromDate) {
+		return errors.New("date range start must not be after its end")
+	}
+	if f.MinAmount != nil && f.MaxAmount != nil {
+		cmp, err := f.MinAmount.Cmp(*f.MaxAmount)
+		if err != nil {
+			return fmt.Errorf("amount range: %w", err)
+		}
+		if cmp > 0 {
+			return errors.New("minimum amount must not exceed maximum amount")
+		}
+	}
+	if len(f.Tags) > MaxOperationTags {
+		return fmt.Errorf("tag filter must not contain more than %d tags", MaxOperationTags)
+	}
+	if f.After != nil {
+		if err := f.After.Validate(f.SortBy); err != nil {
+			return fmt.Errorf("page token: %w", err)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnbalancedEntry проводка, у которой сумма дебета не равна сумме кредита
+var ErrUnbalancedEntry = errors.New("journal entry is unbalanced")
+
+// LedgerAccountKind вид счета главной книги
+type LedgerAccountKind int
+
+const (
+	// LedgerAccountAsset счет пользователя (domain.Account), ID - идентификатор счета
+	LedgerAccountAsset LedgerAccountKind = iota + 1
+	// LedgerAccountUnassigned деньги пользователя, не привязанные ни к одному счету
+	LedgerAccountUnassigned
+	// LedgerAccountIncome источник дохода, ID - идентификатор категории доходов
+	LedgerAccountIncome
+	// LedgerAccountExpense статья расходов, ID - идентификатор категории расходов
+	LedgerAccountExpense
+	// LedgerAccountExchange счет обмена валют, через который проходят переводы между валютами
+	LedgerAccountExchange
+)
+
+// Valid сообщает, что вид счета главной книги известен
+func (k LedgerAccountKind) Valid() bool {
+	return k >= LedgerAccountAsset && k <= LedgerAccountExchange
+}
+
+// hasID сообщает, что счета этого вида различаются идентификатором
+func (k LedgerAccountKind) hasID() bool {
+	return k == LedgerAccountAsset || k == LedgerAccountIncome || k == LedgerAccountExpense
+}
+
+// LedgerAccount счет главной книги, на который делаются проводки
+type LedgerAccount struct {
+	Kind LedgerAccountKind
+	ID   int64
+}
+
+// JournalSourceKind вид операции, породившей запись в журнале
+type JournalSourceKind int
+
+const (
+	// JournalSourceIncome запись по доходу
+	JournalSourceIncome JournalSourceKind = iota + 1
+	// JournalSourceExpense запись по расходу
+	JournalSourceExpense
+	// JournalSourceTransfer запись по переводу между счетами
+	JournalSourceTransfer
+)
+
+// Posting строка записи журнала: положительная сумма - дебет, отрицательная - кредит
+type Posting struct {
+	Account LedgerAccount
+	Amount  Money
+}
+
+// JournalEntry запись журнала двойной записи.
+// Записи не изменяются: исправление операции оформляется сторнирующей записью и новой записью.
+type JournalEntry struct {
+	ID         int64
+	UserID     int64
+	SourceKind JournalSourceKind
+	SourceID   int64
+	OccurredAt time.Time
+	Postings   []Posting
+	CreatedAt  time.Time
+}
+
+// Validate проверяет инварианты двойной записи: в каждой валюте дебет равен кредиту
+func (e *JournalEntry) Validate() error {
+	if e.UserID <= 0 {
+		return errors.New("user ID must be valid")
+	}
+	if e.SourceKind < JournalSourceIncome || e.SourceKind > JournalSourceTransfer || e.SourceID <= 0 {
+		return errors.New("journal entry source must be valid")
+	}
+	if e.OccurredAt.IsZero() {
+		return errors.New("occurred at must be set")
+	}
+	if len(e.Postings) < 2 {
+		return errors.New("journal entry must have at least two postings")
+	}
+
+	totals := make(map[Currency]Money)
+	for _, p := range e.Postings {
+		if !p.Account.Kind.Valid() || p.Account.Kind.hasID() != (p.Account.ID > 0) {
+			return fmt.Errorf("invalid ledger account %d/%d", p.Account.Kind, p.Account.ID)
+		}
+		if p.Amount.IsZero() || p.Amount.Currency().IsZero() {
+			return errors.New("posting amount must be non-zero and have a currency")
+		}
+
+		total, ok := totals[p.Amount.Currency()]
+		if !ok {
+			total = NewMoney(0, p.Amount.Currency())
+		}
+		sum, err := total.Add(p.Amount)
+		if err != nil {
+			return err
+		}
+		totals[p.Amount.Currency()] = sum
+	}
+	for currency, total := range totals {
+		if !total.IsZero() {
+			return fmt.Errorf("%w: %s postings sum to %s", ErrUnbalancedEntry, currency, total)
+		}
+	}
+
+	return nil
+}
+
+// Reverse возвращает сторнирующую запись: те же счета с противоположными суммами
+func (e *JournalEntry) Reverse() JournalEntry {
+	reversal := JournalEntry{
+		UserID:     e.UserID,
+		SourceKind: e.SourceKind,
+		SourceID:   e.SourceID,
+		OccurredAt: e.OccurredAt,
+		Postings:   make([]Posting, len(e.Postings)),
+	}
+	for i, p := range e.Postings {
+		reversal.Postings[i] = Posting{Account: p.Account, Amount: p.Amount.Neg()}
+	}
+
+	return reversal
+}
+
+// Equivalent сообщает, что записи проводят одни и те же суммы по тем же счетам на ту же дату
+func (e *JournalEntry) Equivalent(other *JournalEntry) bool {
+	if !e.OccurredAt.Equal(other.OccurredAt) || len(e.Postings) != len(other.Postings) {
+		return false
+	}
+	for i := range e.Postings {
+		if e.Postings[i] != other.Postings[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// IncomeEntry возвращает запись журнала по доходу: дебет счета (или нераспределенных денег), кредит источника дохода
+func IncomeEntry(i *Income) JournalEntry {
+	return JournalEntry{
+		UserID:     i.UserID,
+		SourceKind: JournalSourceIncome,
+		SourceID:   i.ID,
+		OccurredAt: i.OccurredAt,
+		Postings: []Posting{
+			{Account: assetAccount(i.AccountID), Amount: i.Amount},
+			{Account: LedgerAccount{Kind: LedgerAccountIncome, ID: int64(i.CategoryID)}, Amount: i.Amount.Neg()},
+		},
+	}
+}
+
+// ExpenseEntry возвращает запись журнала по расходу: дебет статьи расходов, кредит счета списания
+// или, если счет не указан, нераспределенных денег
+func ExpenseEntry(e *Expense) JournalEntry {
+	return JournalEntry{
+		UserID:     e.UserID,
+		SourceKind: JournalSourceExpense,
+		SourceID:   e.ID,
+		OccurredAt: e.OccurredAt,
+		Postings: []Posting{
+			{Account: LedgerAccount{Kind: LedgerAccountExpense, ID: int64(e.CategoryID)}, Amount: e.Amount},
+			{Account: assetAccount(e.AccountID), Amount: e.Amount.Neg()},
+		},
+	}
+}
+
+// TransferEntry возвращает запись журнала по переводу: дебет счета-получателя, кредит счета-источника.
+// Перевод между валютами проходит через счет обмена, чтобы каждая валюта оставалась сбалансированной.
+func TransferEntry(t *Transfer) JournalEntry {
+	entry := JournalEntry{
+		UserID:     t.UserID,
+		SourceKind: JournalSourceTransfer,
+		SourceID:   t.ID,
+		OccurredAt: t.OccurredAt,
+		Postings: []Posting{
+			{Account: assetAccount(t.ToAccountID), Amount: t.ToAmount},
+			{Account: assetAccount(t.FromAccountID), Amount: t.FromAmount.Neg()},
+		},
+	}
+	if t.IsCrossCurrency() {
+		exchange := LedgerAccount{Kind: LedgerAccountExchange}
+		entry.Postings = append(entry.Postings,
+			Posting{Account: exchange, Amount: t.ToAmount.Neg()},
+			Posting{Account: exchange, Amount: t.FromAmount},
+		)
+	}
+
+	return entry
+}
+
+// assetAccount возвращает счет главной книги для счета пользователя, 0 - нераспределенные деньги
+func assetAccount(accountID int64) LedgerAccount {
+	if accountID == 0 {
+		return LedgerAccount{Kind: LedgerAccountUnassigned}
+	}
+	return LedgerAccount{Kind: LedgerAccountAsset, ID: accountID}
+}
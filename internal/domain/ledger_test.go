@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+)
+
+func Test_JournalEntry_Validate_ReturnsUnbalanced_WhenDebitsDifferFromCredits(t *testing.T) {
+	kzt := domain.MustCurrency("KZT")
+	entry := domain.JournalEntry{UserID: 1, SourceKind: domain.JournalSourceIncome, SourceID: 7, OccurredAt: time.Now(),
+		Postings: []domain.Posting{
+			{Account: domain.LedgerAccount{Kind: domain.LedgerAccountAsset, ID: 3}, Amount: domain.NewMoney(100, kzt)},
+			{Account: domain.LedgerAccount{Kind: domain.LedgerAccountIncome, ID: 2}, Amount: domain.NewMoney(-90, kzt)},
+		}}
+
+	assert.ErrorIs(t, entry.Validate(), domain.ErrUnbalancedEntry)
+}
+
+func Test_JournalEntry_Validate_ReturnsError_WhenLedgerAccountInvalid(t *testing.T) {
+	kzt := domain.MustCurrency("KZT")
+	entry := domain.JournalEntry{UserID: 1, SourceKind: domain.JournalSourceIncome, SourceID: 7, OccurredAt: time.Now(),
+		Postings: []domain.Posting{
+			{Account: domain.LedgerAccount{Kind: domain.LedgerAccountAsset}, Amount: domain.NewMoney(100, kzt)},
+			{Account: domain.LedgerAccount{Kind: domain.LedgerAccountIncome, ID: 2}, Amount: domain.NewMoney(-100, kzt)},
+		}}
+
+	assert.EqualError(t, entry.Validate(), "invalid ledger account 1/0")
+}
+
+func Test_TransferEntry_BalancesEachCurrency_WhenCrossCurrency(t *testing.T) {
+	kzt, usd := domain.MustCurrency("KZT"), domain.MustCurrency("USD")
+	entry := domain.TransferEntry(&domain.Transfer{ID: 5, UserID: 1, FromAccountID: 3, ToAccountID: 4, OccurredAt: time.Now(),
+		FromAmount: domain.NewMoney(4_700_000, kzt), ToAmount: domain.NewMoney(10000, usd)})
+
+	require.NoError(t, entry.Validate())
+	assert.Len(t, entry.Postings, 4)
+	assert.Equal(t, domain.LedgerAccount{Kind: domain.LedgerAccountExchange}, entry.Postings[2].Account)
+}
+
+func Test_JournalEntry_Reverse_NegatesPostings_WhenCalled(t *testing.T) {
+	kzt := domain.MustCurrency("KZT")
+	entry := domain.IncomeEntry(&domain.Income{ID: 7, UserID: 1, CategoryID: 2, Amount: domain.NewMoney(100, kzt),
+		OccurredAt: time.Now()})
+
+	reversal := entry.Reverse()
+
+	require.NoError(t, reversal.Validate())
+	assert.Equal(t, domain.LedgerAccount{Kind: domain.LedgerAccountUnassigned}, reversal.Postings[0].Account)
+	assert.Equal(t, domain.NewMoney(-100, kzt), reversal.Postings[0].Amount)
+	assert.False(t, entry.Equivalent(&reversal))
+}
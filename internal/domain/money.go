@@ -1,21 +1,271 @@
 package domain
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
 
-// Money представляет денежную сумму в тиынах, центах и т.д.
-type Money int64
+// Money представляет денежную сумму в минимальных единицах валюты (тиынах, центах и т.д.)
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+// maxFractionDigits максимальное количество знаков после запятой во входных данных (точность nanos)
+const maxFractionDigits = 9
+
+// nanosPerUnit количество nanos в одной целой единице
+const nanosPerUnit = 1_000_000_000
+
+// RoundingMode определяет способ округления до минимальных единиц
+type RoundingMode int
+
+const (
+	// RoundHalfEven банковское округление: половина округляется к четному
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp половина округляется от нуля
+	RoundHalfUp
+	// RoundTruncate лишние знаки отбрасываются (округление к нулю)
+	RoundTruncate
+)
+
+// Ошибки создания денежных сумм
+var (
+	ErrInvalidMoneyFormat    = errors.New("invalid money format")
+	ErrMoneyOverflow         = errors.New("money amount overflows")
+	ErrTooManyFractionDigits = errors.New("too many fractional digits")
+	ErrInvalidNanos          = errors.New("invalid nanos")
+	ErrCurrencyMismatch      = errors.New("currency mismatch")
+)
+
+// NewMoney создает объект денег из суммы в минимальных единицах валюты
+func NewMoney(amount int64, currency Currency) Money {
+	return Money{amount: amount, currency: currency}
+}
+
+// ParseMoney создает объект денег из десятичной строки вида "-123.45".
+// Знаки сверх точности валюты округляются согласно mode.
+func ParseMoney(s string, currency Currency, mode RoundingMode) (Money, error) {
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg = true
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasPoint := strings.Cut(s, ".")
+	if !isDigits(intPart) || (hasPoint && !isDigits(fracPart)) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoneyFormat, s)
+	}
+	if len(fracPart) > maxFractionDigits {
+		return Money{}, fmt.Errorf("%w: %d, max %d", ErrTooManyFractionDigits, len(fracPart), maxFractionDigits)
+	}
+
+	v, _ := new(big.Int).SetString(intPart+fracPart, 10)
+	if neg {
+		v.Neg(v)
+	}
+
+	return moneyFromScaled(v, len(fracPart), currency, mode)
+}
+
+// NewMoneyFromUnitsNanos создает объект денег из пары units + nanos (формат google.type.Money).
+// units и nanos должны иметь одинаковый знак, |nanos| < 1e9.
+func NewMoneyFromUnitsNanos(units int64, nanos int32, currency Currency, mode RoundingMode) (Money, error) {
+	if nanos <= -nanosPerUnit || nanos >= nanosPerUnit {
+		return Money{}, fmt.Errorf("%w: %d is out of range", ErrInvalidNanos, nanos)
+	}
+	if (units > 0 && nanos < 0) || (units < 0 && nanos > 0) {
+		return Money{}, fmt.Errorf("%w: units and nanos must have the same sign", ErrInvalidNanos)
+	}
+
+	v := new(big.Int).Mul(big.NewInt(units), big.NewInt(nanosPerUnit))
+	v.Add(v, big.NewInt(int64(nanos)))
+
+	return moneyFromScaled(v, maxFractionDigits, currency, mode)
+}
+
+// NewMoneyFromRat создает объект денег из рационального числа целых единиц валюты.
+// Используется при конвертации и распределении сумм, где результат не выражается конечной дробью.
+func NewMoneyFromRat(r *big.Rat, currency Currency, mode RoundingMode) (Money, error) {
+	if currency.IsZero() {
+		return Money{}, ErrUnknownCurrency
+	}
+
+	num := new(big.Int).Mul(r.Num(), pow10(currency.MinorUnits))
+	v := roundQuo(num, r.Denom(), mode)
+	if !v.IsInt64() {
+		return Money{}, ErrMoneyOverflow
+	}
+
+	return Money{amount: v.Int64(), currency: currency}, nil
+}
+
+// moneyFromScaled переводит число v * 10^-scale в минимальные единицы валюты с округлением
+func moneyFromScaled(v *big.Int, scale int, currency Currency, mode RoundingMode) (Money, error) {
+	if currency.IsZero() {
+		return Money{}, ErrUnknownCurrency
+	}
+
+	minor := currency.MinorUnits
+	if scale <= minor {
+		v = new(big.Int).Mul(v, pow10(minor-scale))
+	} else {
+		v = roundQuo(v, pow10(scale-minor), mode)
+	}
+
+	if !v.IsInt64() {
+		return Money{}, ErrMoneyOverflow
+	}
+
+	return Money{amount: v.Int64(), currency: currency}, nil
+}
+
+// roundQuo делит v на d с округлением согласно mode
+func roundQuo(v, d *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(v, d, new(big.Int))
+	if r.Sign() == 0 || mode == RoundTruncate {
+		return q
+	}
+
+	// Сравниваем удвоенный остаток с делителем, чтобы понять, где находится половина
+	cmp := new(big.Int).Abs(r)
+	cmp.Lsh(cmp, 1)
+	half := cmp.Cmp(d)
+
+	roundAway := half > 0 ||
+		(half == 0 && mode == RoundHalfUp) ||
+		(half == 0 && mode == RoundHalfEven && q.Bit(0) == 1)
+	if roundAway {
+		q.Add(q, big.NewInt(int64(v.Sign())))
+	}
+
+	return q
+}
+
+// pow10 возвращает 10^n
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// isDigits проверяет, что строка непустая и состоит только из цифр
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Amount возвращает сумму в минимальных единицах валюты
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency возвращает валюту суммы
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// IsZero сообщает, что сумма равна нулю
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsPositive сообщает, что сумма больше нуля
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+// IsNegative сообщает, что сумма меньше нуля
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
 
-// NewMoneyFromFloat создает новый объект денег из float64
-func NewMoneyFromFloat(amount float64) Money {
-	return Money(amount * 100)
+// Neg возвращает сумму с противоположным знаком
+func (m Money) Neg() Money {
+	return Money{amount: -m.amount, currency: m.currency}
 }
 
-// ToFloat возвращает значение денег в float64
-func (m Money) ToFloat() float64 {
-	return float64(m) / 100
+// Add складывает суммы одной валюты
+func (m Money) Add(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+
+	sum := m.amount + other.amount
+	if (other.amount > 0 && sum < m.amount) || (other.amount < 0 && sum > m.amount) {
+		return Money{}, ErrMoneyOverflow
+	}
+
+	return Money{amount: sum, currency: m.currency}, nil
+}
+
+// Sub вычитает сумму той же валюты
+func (m Money) Sub(other Money) (Money, error) {
+	if other.amount == math.MinInt64 {
+		return Money{}, ErrMoneyOverflow
+	}
+	return m.Add(other.Neg())
+}
+
+// Cmp сравнивает суммы одной валюты: -1, если m < other, 0 при равенстве, 1, если m > other
+func (m Money) Cmp(other Money) (int, error) {
+	if m.currency != other.currency {
+		return 0, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// UnitsNanos возвращает сумму в формате units + nanos (формат google.type.Money)
+func (m Money) UnitsNanos() (int64, int32) {
+	scale := pow10(m.currency.MinorUnits).Int64()
+	units := m.amount / scale
+	nanos := (m.amount % scale) * (nanosPerUnit / scale)
+	return units, int32(nanos)
+}
+
+// Rat возвращает сумму в целых единицах валюты в виде рационального числа
+func (m Money) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(m.amount), pow10(m.currency.MinorUnits))
+}
+
+// Decimal возвращает сумму в виде десятичной строки без кода валюты и без потери точности
+func (m Money) Decimal() string {
+	sign := ""
+	abs := uint64(m.amount)
+	if m.amount < 0 {
+		sign = "-"
+		abs = -abs
+	}
+
+	minor := m.currency.MinorUnits
+	if minor == 0 {
+		return fmt.Sprintf("%s%d", sign, abs)
+	}
+
+	scale := pow10(minor).Uint64()
+	return fmt.Sprintf("%s%d.%0*d", sign, abs/scale, minor, abs%scale)
 }
 
-// String возвращает значение денег в виде строки
+// String возвращает сумму вместе с кодом валюты, например "100.50 KZT"
 func (m Money) String() string {
-	return fmt.Sprintf("%.2f", m.ToFloat())
+	return m.Decimal() + " " + m.currency.Code
 }
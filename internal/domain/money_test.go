@@ -0,0 +1,197 @@
+package domain_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+)
+
+var (
+	kzt = domain.MustCurrency("KZT")
+	jpy = domain.MustCurrency("JPY")
+	kwd = domain.MustCurrency("KWD")
+	usd = domain.MustCurrency("USD")
+)
+
+func Test_ParseMoney_ReturnsExactMinorUnits_WhenValidDecimal(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		mode  domain.RoundingMode
+		want  int64
+	}{
+		{"Float Trap", "0.29", domain.RoundHalfEven, 29},
+		{"Integer", "100", domain.RoundHalfEven, 10000},
+		{"One Fraction Digit", "1.5", domain.RoundHalfEven, 150},
+		{"Plus Sign", "+2.01", domain.RoundHalfEven, 201},
+		{"Negative", "-100.50", domain.RoundHalfEven, -10050},
+		{"Half Even Down", "0.125", domain.RoundHalfEven, 12},
+		{"Half Even Up", "0.135", domain.RoundHalfEven, 14},
+		{"Half Even Negative", "-0.125", domain.RoundHalfEven, -12},
+		{"Half Up", "0.125", domain.RoundHalfUp, 13},
+		{"Half Up Negative", "-0.125", domain.RoundHalfUp, -13},
+		{"Above Half", "0.1251", domain.RoundHalfEven, 13},
+		{"Truncate", "0.129999999", domain.RoundTruncate, 12},
+		{"Truncate Negative", "-0.129", domain.RoundTruncate, -12},
+		{"Max Int64", "92233720368547758.07", domain.RoundHalfEven, 9223372036854775807},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.ParseMoney(tt.input, kzt, tt.mode)
+
+			require.NoError(t, err)
+			assert.Equal(t, domain.NewMoney(tt.want, kzt), got)
+		})
+	}
+}
+
+func Test_ParseMoney_ReturnsError_WhenInvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{"Empty", "", domain.ErrInvalidMoneyFormat},
+		{"Letters", "12a.00", domain.ErrInvalidMoneyFormat},
+		{"No Integer Part", ".50", domain.ErrInvalidMoneyFormat},
+		{"Trailing Point", "1.", domain.ErrInvalidMoneyFormat},
+		{"Comma Separator", "1,50", domain.ErrInvalidMoneyFormat},
+		{"Double Sign", "--1", domain.ErrInvalidMoneyFormat},
+		{"Too Many Fraction Digits", "0.1234567891", domain.ErrTooManyFractionDigits},
+		{"Overflow", "92233720368547758.08", domain.ErrMoneyOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.ParseMoney(tt.input, kzt, domain.RoundHalfEven)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func Test_NewMoneyFromUnitsNanos_ReturnsExactMinorUnits_WhenValidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		units int64
+		nanos int32
+		mode  domain.RoundingMode
+		want  int64
+	}{
+		{"Whole", 100, 0, domain.RoundHalfEven, 10000},
+		{"Cents", 0, 290_000_000, domain.RoundHalfEven, 29},
+		{"Negative", -100, -500_000_000, domain.RoundHalfEven, -10050},
+		{"Half Even", 0, 5_000_000, domain.RoundHalfEven, 0},
+		{"Half Up", 0, 5_000_000, domain.RoundHalfUp, 1},
+		{"Truncate", 1, 999_999_999, domain.RoundTruncate, 199},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.NewMoneyFromUnitsNanos(tt.units, tt.nanos, kzt, tt.mode)
+
+			require.NoError(t, err)
+			assert.Equal(t, domain.NewMoney(tt.want, kzt), got)
+		})
+	}
+}
+
+func Test_NewMoneyFromUnitsNanos_ReturnsError_WhenInvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		units int64
+		nanos int32
+		err   error
+	}{
+		{"Nanos Out Of Range", 1, 1_000_000_000, domain.ErrInvalidNanos},
+		{"Mixed Signs", 1, -1, domain.ErrInvalidNanos},
+		{"Overflow", 92233720368547758, 80_000_000, domain.ErrMoneyOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.NewMoneyFromUnitsNanos(tt.units, tt.nanos, kzt, domain.RoundHalfEven)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func Test_ParseMoney_UsesCurrencyMinorUnits_WhenCurrencyNotTwoDigit(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		currency domain.Currency
+		want     int64
+		decimal  string
+	}{
+		{"JPY Integer", "1500", jpy, 1500, "1500"},
+		{"JPY Rounded", "1500.5", jpy, 1500, "1500"},
+		{"KWD Three Digits", "1.234", kwd, 1234, "1.234"},
+		{"BTC Satoshi", "0.00000001", domain.MustCurrency("BTC"), 1, "0.00000001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.ParseMoney(tt.input, tt.currency, domain.RoundHalfEven)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Amount())
+			assert.Equal(t, tt.decimal, got.Decimal())
+		})
+	}
+}
+
+func Test_ParseMoney_ReturnsError_WhenCurrencyMissing(t *testing.T) {
+	_, err := domain.ParseMoney("1.00", domain.Currency{}, domain.RoundHalfEven)
+
+	assert.ErrorIs(t, err, domain.ErrUnknownCurrency)
+}
+
+func Test_Money_Add_ReturnsSum_WhenSameCurrency(t *testing.T) {
+	sum, err := domain.NewMoney(150, kzt).Add(domain.NewMoney(-50, kzt))
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(100, kzt), sum)
+}
+
+func Test_Money_Add_ReturnsError_WhenCurrenciesDiffer(t *testing.T) {
+	_, err := domain.NewMoney(150, kzt).Add(domain.NewMoney(50, usd))
+
+	assert.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+}
+
+func Test_Money_Add_ReturnsError_WhenOverflow(t *testing.T) {
+	_, err := domain.NewMoney(math.MaxInt64, kzt).Add(domain.NewMoney(1, kzt))
+
+	assert.ErrorIs(t, err, domain.ErrMoneyOverflow)
+}
+
+func Test_Money_Sub_ReturnsError_WhenCurrenciesDiffer(t *testing.T) {
+	_, err := domain.NewMoney(150, kzt).Sub(domain.NewMoney(50, usd))
+
+	assert.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+}
+
+func Test_Money_Cmp_ReturnsOrder_WhenSameCurrency(t *testing.T) {
+	cmp, err := domain.NewMoney(100, kzt).Cmp(domain.NewMoney(200, kzt))
+
+	require.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+
+	_, err = domain.NewMoney(100, kzt).Cmp(domain.NewMoney(100, usd))
+	assert.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+}
+
+func Test_Money_String_ReturnsDecimalWithCode_WhenCalled(t *testing.T) {
+	assert.Equal(t, "0.29 KZT", domain.NewMoney(29, kzt).String())
+	assert.Equal(t, "-0.05 USD", domain.NewMoney(-5, usd).String())
+	assert.Equal(t, "100.50 KZT", domain.NewMoney(10050, kzt).String())
+	assert.Equal(t, "-1.005 KWD", domain.NewMoney(-1005, kwd).String())
+	assert.Equal(t, "-92233720368547758.08", domain.NewMoney(math.MinInt64, kzt).Decimal())
+}
@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// RecurrenceFrequency единица шага повторения
+type RecurrenceFrequency int
+
+const (
+	// RecurrenceDaily каждые Interval дней
+	RecurrenceDaily RecurrenceFrequency = iota + 1
+	// RecurrenceWeekly каждые Interval недель в день недели даты начала
+	RecurrenceWeekly
+	// RecurrenceMonthly каждые Interval месяцев в день DayOfMonth
+	RecurrenceMonthly
+)
+
+// Valid сообщает, что частота повторения известна
+func (f RecurrenceFrequency) Valid() bool {
+	return f >= RecurrenceDaily && f <= RecurrenceMonthly
+}
+
+// RecurringRule правило повторяющегося дохода (по образцу RRULE).
+// Наступившие повторения проводятся как обычные доходы планировщиком.
+type RecurringRule struct {
+	ID          int64
+	UserID      int64
+	CategoryID  int
+	AccountID   int64
+	Amount      Money
+	Description string
+
+	Frequency RecurrenceFrequency
+	// Interval шаг повторения в единицах Frequency, не меньше 1
+	Interval int
+	// DayOfMonth день месяца для ежемесячного правила, 0 - день даты начала.
+	// В коротких месяцах повторение переносится на последний день месяца.
+	DayOfMonth int
+	StartDate  Date
+	// EndDate последняя допустимая дата повторения, нулевое значение - без ограничения
+	EndDate Date
+	// Count максимальное число повторений, 0 - без ограничения
+	Count int
+
+	Paused bool
+	// Processed число уже обработанных (проведенных или пропущенных) повторений
+	Processed int
+	// NextDate дата следующего необработанного повторения, нулевая - правило завершено
+	NextDate  Date
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate проверяет бизнес-правила для правила повторения
+func (r *RecurringRule) Validate() error {
+	if r.UserID <= 0 {
+		return errors.New("user ID must be valid")
+	}
+	if r.CategoryID <= 0 {
+		return errors.New("category ID must be valid")
+	}
+	if r.AccountID < 0 {
+		return errors.New("account ID must be valid")
+	}
+	if !r.Amount.IsPositive() {
+		return errors.New("amount must be greater than 0")
+	}
+	if r.Amount.Currency().IsZero() {
+		return errors.New("currency must be valid")
+	}
+	if utf8.RuneCountInString(r.Description) > MaxDescriptionLength {
+		return fmt.Errorf("description must not exceed %d characters", MaxDescriptionLength)
+	}
+	if !r.Frequency.Valid() {
+		return errors.New("frequency must be valid")
+	}
+	if r.Interval < 1 {
+		return errors.New("interval must be at least 1")
+	}
+	if r.DayOfMonth < 0 || r.DayOfMonth > 31 {
+		return errors.New("day of month must be between 1 and 31, or 0 for the start date day")
+	}
+	if r.DayOfMonth != 0 && r.Frequency != RecurrenceMonthly {
+		return errors.New("day of month is only allowed for a monthly rule")
+	}
+	if r.StartDate.IsZero() {
+		return errors.New("start date must be set")
+	}
+	if !r.EndDate.IsZero() && r.EndDate.Before(r.StartDate) {
+		return errors.New("end date must not be before start date")
+	}
+	if r.Count < 0 {
+		return errors.New("count must not be negative")
+	}
+	return nil
+}
+
+// Occurrence возвращает дату повторения с номером n (с нуля) и false, если такого повторения нет
+func (r *RecurringRule) Occurrence(n int) (Date, bool) {
+	if n < 0 || (r.Count > 0 && n >= r.Count) {
+		return Date{}, false
+	}
+
+	var d Date
+	switch r.Frequency {
+	case RecurrenceDaily:
+		d = r.StartDate.AddDays(n * r.Interval)
+	case RecurrenceWeekly:
+		d = r.StartDate.AddDays(7 * n * r.Interval)
+	default:
+		// Если день месяца в первом месяце уже прошел, отсчет начинается со следующего месяца
+		shift := 0
+		if r.monthDay(r.StartDate.Year, r.StartDate.Month).Before(r.StartDate) {
+			shift = 1
+		}
+		months := int(r.StartDate.Month) - 1 + shift + n*r.Interval
+		d = r.monthDay(r.StartDate.Year+months/12, time.Month(months%12+1))
+	}
+
+	if !r.EndDate.IsZero() && r.EndDate.Before(d) {
+		return Date{}, false
+	}
+	return d, true
+}
+
+// monthDay возвращает день повторения в указанном месяце с переносом на последний день месяца
+func (r *RecurringRule) monthDay(year int, month time.Month) Date {
+	day := r.DayOfMonth
+	if day == 0 {
+		day = r.StartDate.Day
+	}
+	if last := DateOf(time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)).Day; day > last {
+		day = last
+	}
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// Reschedule пересчитывает NextDate по числу обработанных повторений
+func (r *RecurringRule) Reschedule() {
+	r.NextDate, _ = r.Occurrence(r.Processed)
+}
+
+// Advance отмечает следующее повторение обработанным
+func (r *RecurringRule) Advance() {
+	r.Processed++
+	r.Reschedule()
+}
+
+// IsFinished сообщает, что у правила не осталось повторений
+func (r *RecurringRule) IsFinished() bool {
+	return r.NextDate.IsZero()
+}
+
+// Upcoming возвращает не более limit необработанных повторений не позже until
+func (r *RecurringRule) Upcoming(until Date, limit int) []Date {
+	var dates []Date
+	for n := r.Processed; len(dates) < limit; n++ {
+		d, ok := r.Occurrence(n)
+		if !ok || until.Before(d) {
+			break
+		}
+		dates = append(dates, d)
+	}
+	return dates
+}
+
+// IsUpcoming сообщает, что d - дата одного из необработанных повторений
+func (r *RecurringRule) IsUpcoming(d Date) bool {
+	for n := r.Processed; ; n++ {
+		occurrence, ok := r.Occurrence(n)
+		if !ok || d.Before(occurrence) {
+			return false
+		}
+		if occurrence == d {
+			return true
+		}
+	}
+}
+
+// SkipBefore отмечает обработанными повторения раньше даты d, не проводя их
+func (r *RecurringRule) SkipBefore(d Date) {
+	for !r.IsFinished() && r.NextDate.Before(d) {
+		r.Advance()
+	}
+}
+
+// Income возвращает доход по повторению в дату d, началом дня в часовом поясе loc
+func (r *RecurringRule) Income(d Date, loc *time.Location) *Income {
+	return &Income{
+		UserID:      r.UserID,
+		CategoryID:  r.CategoryID,
+		AccountID:   r.AccountID,
+		Amount:      r.Amount,
+		Description: r.Description,
+		OccurredAt:  d.Start(loc),
+	}
+}
+
+// UpcomingOccurrence предстоящее повторение правила
+type UpcomingOccurrence struct {
+	Rule RecurringRule
+	Date Date
+	// Skipped повторение пропускается по просьбе пользователя и не будет проведено
+	Skipped bool
+}
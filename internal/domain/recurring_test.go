@@ -0,0 +1,92 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fincraft-finance/internal/domain"
+)
+
+func newMonthlyRule(start domain.Date, dayOfMonth int) domain.RecurringRule {
+	return domain.RecurringRule{UserID: 1, CategoryID: 1, Amount: domain.NewMoney(100000, domain.MustCurrency("KZT")),
+		Frequency: domain.RecurrenceMonthly, Interval: 1, DayOfMonth: dayOfMonth, StartDate: start}
+}
+
+func Test_RecurringRule_Occurrence_ClampsToMonthEnd_WhenDayMissingInMonth(t *testing.T) {
+	rule := newMonthlyRule(domain.Date{Year: 2024, Month: time.January, Day: 31}, 0)
+
+	var got []domain.Date
+	for n := 0; n < 4; n++ {
+		d, ok := rule.Occurrence(n)
+		assert.True(t, ok)
+		got = append(got, d)
+	}
+
+	assert.Equal(t, []domain.Date{
+		{Year: 2024, Month: time.January, Day: 31},
+		{Year: 2024, Month: time.February, Day: 29},
+		{Year: 2024, Month: time.March, Day: 31},
+		{Year: 2024, Month: time.April, Day: 30},
+	}, got)
+}
+
+func Test_RecurringRule_Occurrence_StartsNextMonth_WhenDayOfMonthAlreadyPassed(t *testing.T) {
+	rule := newMonthlyRule(domain.Date{Year: 2024, Month: time.November, Day: 20}, 5)
+	rule.Interval = 2
+
+	first, _ := rule.Occurrence(0)
+	second, _ := rule.Occurrence(1)
+
+	assert.Equal(t, domain.Date{Year: 2024, Month: time.December, Day: 5}, first)
+	assert.Equal(t, domain.Date{Year: 2025, Month: time.February, Day: 5}, second)
+}
+
+func Test_RecurringRule_Occurrence_ReturnsFalse_WhenCountOrEndDateReached(t *testing.T) {
+	start := domain.Date{Year: 2024, Month: time.March, Day: 4}
+	rule := domain.RecurringRule{Frequency: domain.RecurrenceWeekly, Interval: 2, StartDate: start, Count: 3}
+
+	_, ok := rule.Occurrence(2)
+	assert.True(t, ok)
+	_, ok = rule.Occurrence(3)
+	assert.False(t, ok)
+
+	rule.Count = 0
+	rule.EndDate = start.AddDays(20)
+	_, ok = rule.Occurrence(1)
+	assert.True(t, ok)
+	_, ok = rule.Occurrence(2)
+	assert.False(t, ok)
+}
+
+func Test_RecurringRule_SkipBefore_FinishesRule_WhenNoOccurrencesLeft(t *testing.T) {
+	start := domain.Date{Year: 2024, Month: time.March, Day: 1}
+	rule := domain.RecurringRule{Frequency: domain.RecurrenceDaily, Interval: 1, StartDate: start, Count: 5}
+	rule.Reschedule()
+
+	rule.SkipBefore(start.AddDays(3))
+	assert.Equal(t, 3, rule.Processed)
+	assert.Equal(t, start.AddDays(3), rule.NextDate)
+
+	rule.SkipBefore(start.AddDays(10))
+	assert.True(t, rule.IsFinished())
+}
+
+func Test_RecurringRule_IsUpcoming_ReturnsFalse_WhenDateNotOnSchedule(t *testing.T) {
+	start := domain.Date{Year: 2024, Month: time.March, Day: 1}
+	rule := domain.RecurringRule{Frequency: domain.RecurrenceWeekly, Interval: 1, StartDate: start, Processed: 1}
+
+	assert.True(t, rule.IsUpcoming(start.AddDays(14)))
+	assert.False(t, rule.IsUpcoming(start.AddDays(15)))
+	// Повторение 1 марта уже обработано
+	assert.False(t, rule.IsUpcoming(start))
+}
+
+func Test_RecurringRule_Validate_ReturnsError_WhenDayOfMonthOnWeeklyRule(t *testing.T) {
+	rule := newMonthlyRule(domain.Date{Year: 2024, Month: time.March, Day: 1}, 15)
+	assert.NoError(t, rule.Validate())
+
+	rule.Frequency = domain.RecurrenceWeekly
+	assert.EqualError(t, rule.Validate(), "day of month is only allowed for a monthly rule")
+}
@@ -0,0 +1,306 @@
+package domain
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxRuleNameLength максимальная длина названия правила в символах
+	MaxRuleNameLength = 100
+	// MaxRuleTags наибольшее число тегов, которые добавляет одно правило
+	MaxRuleTags = 20
+	// MaxRuleHistoryDays наибольший период в днях, к операциям которого правило применяется за один раз
+	MaxRuleHistoryDays = 366
+)
+
+// RuleDirection операции, к которым применяется правило
+type RuleDirection int
+
+const (
+	// RuleDirectionAny доходы и расходы
+	RuleDirectionAny RuleDirection = iota
+	// RuleDirectionIncome только доходы (поступления)
+	RuleDirectionIncome
+	// RuleDirectionExpense только расходы (списания)
+	RuleDirectionExpense
+)
+
+// Valid сообщает, что направление правила известно
+func (d RuleDirection) Valid() bool {
+	return d >= RuleDirectionAny && d <= RuleDirectionExpense
+}
+
+// RuleConditions условия правила; выполнены должны быть все заданные условия
+type RuleConditions struct {
+	// DescriptionRegex регулярное выражение RE2 для описания, без учета регистра - с флагом (?i)
+	DescriptionRegex string
+	// DescriptionContains подстрока описания без учета регистра
+	DescriptionContains string
+	// MinAmount и MaxAmount ограничивают сумму операции без знака включительно.
+	// Операция в другой валюте условию не соответствует.
+	MinAmount *Money
+	MaxAmount *Money
+	AccountID int64
+	// Counterparty подстрока имени контрагента без учета регистра или его IBAN целиком
+	Counterparty string
+}
+
+// IsZero сообщает, что ни одно условие не задано
+func (c *RuleConditions) IsZero() bool {
+	return c.DescriptionRegex == "" && c.DescriptionContains == "" && c.MinAmount == nil && c.MaxAmount == nil &&
+		c.AccountID == 0 && c.Counterparty == ""
+}
+
+// RuleActions действия правила, нулевые поля не применяются
+type RuleActions struct {
+	CategoryID int
+	Tags       []string
+	// Description новое описание операции
+	Description string
+	// TransferAccountID помечает операцию как перевод с этим счетом пользователя
+	TransferAccountID int64
+}
+
+// IsZero сообщает, что ни одно действие не задано
+func (a *RuleActions) IsZero() bool {
+	return a.CategoryID == 0 && len(a.Tags) == 0 && a.Description == "" && a.TransferAccountID == 0
+}
+
+// Rule пользовательское правило автоматической категоризации операций
+type Rule struct {
+	ID     int64
+	UserID int64
+	Name   string
+	// Priority порядок применения: правило с меньшим значением применяется раньше
+	Priority   int
+	Direction  RuleDirection
+	Conditions RuleConditions
+	Actions    RuleActions
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Validate проверяет бизнес-правила для правила категоризации
+func (r *Rule) Validate() error {
+	if r.UserID <= 0 {
+		return errors.New("user ID must be valid")
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("rule name must not be empty")
+	}
+	if utf8.RuneCountInString(r.Name) > MaxRuleNameLength {
+		return fmt.Errorf("rule name must not exceed %d characters", MaxRuleNameLength)
+	}
+	if !r.Direction.Valid() {
+		return errors.New("rule direction must be valid")
+	}
+	if err := r.Conditions.validate(); err != nil {
+		return err
+	}
+	return r.validateActions()
+}
+
+// validate проверяет условия правила
+func (c *RuleConditions) validate() error {
+	if c.IsZero() {
+		return errors.New("rule must have at least one condition")
+	}
+	if c.DescriptionRegex != "" {
+		if _, err := regexp.Compile(c.DescriptionRegex); err != nil {
+			return fmt.Errorf("description regex is invalid: %v", err)
+		}
+	}
+	for _, bound := range []*Money{c.MinAmount, c.MaxAmount} {
+		if bound != nil && (bound.IsNegative() || bound.Currency().IsZero()) {
+			return errors.New("amount bounds must be non-negative and have a valid currency")
+		}
+	}
+	if c.MinAmount != nil && c.MaxAmount != nil {
+		if order, err := c.MinAmount.Cmp(*c.MaxAmount); err != nil || order > 0 {
+			return errors.New("amount range must be in one currency and min must not exceed max")
+		}
+	}
+	if c.AccountID < 0 {
+		return errors.New("account ID must be valid")
+	}
+	return nil
+}
+
+// validateActions проверяет действия правила
+func (r *Rule) validateActions() error {
+	a := &r.Actions
+	if a.IsZero() {
+		return errors.New("rule must have at least one action")
+	}
+	if a.CategoryID < 0 || a.TransferAccountID < 0 {
+		return errors.New("category ID and transfer account ID must be valid")
+	}
+	if a.CategoryID > 0 && r.Direction == RuleDirectionAny {
+		return errors.New("rule that sets a category must apply to incomes or expenses")
+	}
+	if a.CategoryID > 0 && a.TransferAccountID > 0 {
+		return errors.New("rule cannot both set a category and mark a transfer")
+	}
+	if utf8.RuneCountInString(a.Description) > MaxDescriptionLength {
+		return fmt.Errorf("description must not exceed %d characters", MaxDescriptionLength)
+	}
+	if len(a.Tags) > MaxRuleTags {
+		return fmt.Errorf("rule must not add more than %d tags", MaxRuleTags)
+	}
+	for _, tag := range a.Tags {
+		if err := ValidateTagName(tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RuleSubject операция, к которой применяются правила
+type RuleSubject struct {
+	// Amount сумма со знаком: доход положителен, расход отрицателен
+	Amount           Money
+	Description      string
+	AccountID        int64
+	CounterpartyName string
+	CounterpartyIBAN string
+}
+
+// RuleOutcome итог применения правил к операции
+type RuleOutcome struct {
+	CategoryID int
+	Tags       []string
+	// Description новое описание, пустое - описание не меняется
+	Description       string
+	TransferAccountID int64
+	// RuleIDs правила, условия которых выполнены, в порядке применения
+	RuleIDs []int64
+}
+
+// Matched сообщает, что к операции применилось хотя бы одно правило
+func (o *RuleOutcome) Matched() bool {
+	return len(o.RuleIDs) > 0
+}
+
+// RuleSet правила пользователя в порядке применения с разобранными регулярными выражениями
+type RuleSet struct {
+	rules    []Rule
+	patterns []*regexp.Regexp
+}
+
+// NewRuleSet упорядочивает правила по приоритету (при равенстве - по ID) и разбирает их выражения
+func NewRuleSet(rules []Rule) (*RuleSet, error) {
+	sorted := slices.Clone(rules)
+	slices.SortStableFunc(sorted, func(a, b Rule) int {
+		return cmp.Or(cmp.Compare(a.Priority, b.Priority), cmp.Compare(a.ID, b.ID))
+	})
+
+	set := &RuleSet{rules: sorted, patterns: make([]*regexp.Regexp, len(sorted))}
+	for i := range sorted {
+		if sorted[i].Conditions.DescriptionRegex == "" {
+			continue
+		}
+		re, err := regexp.Compile(sorted[i].Conditions.DescriptionRegex)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: description regex is invalid: %w", sorted[i].ID, err)
+		}
+		set.patterns[i] = re
+	}
+	return set, nil
+}
+
+// Evaluate применяет к операции все правила, условия которых выполнены, в порядке приоритета.
+// Категорию, описание и перевод задает первое правило, в котором они указаны; теги всех правил объединяются.
+func (s *RuleSet) Evaluate(subject RuleSubject) RuleOutcome {
+	var outcome RuleOutcome
+	for i := range s.rules {
+		rule := &s.rules[i]
+		if !rule.matches(s.patterns[i], &subject) {
+			continue
+		}
+
+		outcome.RuleIDs = append(outcome.RuleIDs, rule.ID)
+		a := &rule.Actions
+		if outcome.CategoryID == 0 && outcome.TransferAccountID == 0 {
+			outcome.CategoryID, outcome.TransferAccountID = a.CategoryID, a.TransferAccountID
+		}
+		if outcome.Description == "" {
+			outcome.Description = a.Description
+		}
+		for _, tag := range a.Tags {
+			if !slices.ContainsFunc(outcome.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+				outcome.Tags = append(outcome.Tags, tag)
+			}
+		}
+	}
+	return outcome
+}
+
+// matches проверяет условия правила; re - разобранное DescriptionRegex или nil
+func (r *Rule) matches(re *regexp.Regexp, s *RuleSubject) bool {
+	switch r.Direction {
+	case RuleDirectionIncome:
+		if !s.Amount.IsPositive() {
+			return false
+		}
+	case RuleDirectionExpense:
+		if !s.Amount.IsNegative() {
+			return false
+		}
+	}
+
+	c := &r.Conditions
+	if re != nil && !re.MatchString(s.Description) {
+		return false
+	}
+	if c.DescriptionContains != "" &&
+		!strings.Contains(strings.ToLower(s.Description), strings.ToLower(c.DescriptionContains)) {
+		return false
+	}
+	if c.AccountID != 0 && c.AccountID != s.AccountID {
+		return false
+	}
+	if c.Counterparty != "" && !strings.EqualFold(c.Counterparty, s.CounterpartyIBAN) &&
+		!strings.Contains(strings.ToLower(s.CounterpartyName), strings.ToLower(c.Counterparty)) {
+		return false
+	}
+
+	amount := s.Amount
+	if amount.IsNegative() {
+		amount = amount.Neg()
+	}
+	if c.MinAmount != nil {
+		if order, err := amount.Cmp(*c.MinAmount); err != nil || order < 0 {
+			return false
+		}
+	}
+	if c.MaxAmount != nil {
+		if order, err := amount.Cmp(*c.MaxAmount); err != nil || order > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RuleChange изменение, которое правило вносит в сохраненную операцию
+type RuleChange struct {
+	// Kind вид операции: доход или расход
+	Kind        JournalSourceKind
+	OperationID int64
+	Amount      Money
+	OccurredAt  time.Time
+	// OldCategoryID и NewCategoryID категория до и после применения правила; совпадают, если категория не меняется
+	OldCategoryID int
+	NewCategoryID int
+	// OldDescription и NewDescription описание до и после применения правила
+	OldDescription string
+	NewDescription string
+	// AddedTags теги правила, которых не было у дохода; расходам теги не добавляются
+	AddedTags []string
+}
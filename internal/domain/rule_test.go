@@ -0,0 +1,84 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+)
+
+func Test_RuleSet_Evaluate_AppliesRulesInPriorityOrder_WhenSeveralMatch(t *testing.T) {
+	kzt := domain.MustCurrency("KZT")
+	limit := domain.NewMoney(1_000_000, kzt)
+	set, err := domain.NewRuleSet([]domain.Rule{
+		{ID: 1, Priority: 20, Direction: domain.RuleDirectionExpense,
+			Conditions: domain.RuleConditions{DescriptionContains: "coffee"},
+			Actions:    domain.RuleActions{CategoryID: 5, Tags: []string{"food"}, Description: "Coffee"}},
+		{ID: 2, Priority: 10, Direction: domain.RuleDirectionExpense,
+			Conditions: domain.RuleConditions{DescriptionRegex: `(?i)^starbucks`, MaxAmount: &limit},
+			Actions:    domain.RuleActions{CategoryID: 7, Tags: []string{"Food", "cafe"}}},
+		{ID: 3, Priority: 0, Direction: domain.RuleDirectionIncome,
+			Conditions: domain.RuleConditions{DescriptionContains: "coffee"},
+			Actions:    domain.RuleActions{CategoryID: 9}},
+	})
+	require.NoError(t, err)
+
+	outcome := set.Evaluate(domain.RuleSubject{Amount: domain.NewMoney(-150_000, kzt), Description: "STARBUCKS coffee #12"})
+
+	assert.Equal(t, domain.RuleOutcome{CategoryID: 7, Tags: []string{"Food", "cafe"}, Description: "Coffee",
+		RuleIDs: []int64{2, 1}}, outcome)
+}
+
+func Test_RuleSet_Evaluate_ReturnsNoMatch_WhenConditionFails(t *testing.T) {
+	kzt := domain.MustCurrency("KZT")
+	minimum := domain.NewMoney(100_000, kzt)
+	set, err := domain.NewRuleSet([]domain.Rule{
+		{ID: 1, Conditions: domain.RuleConditions{Counterparty: "acme", MinAmount: &minimum},
+			Actions: domain.RuleActions{TransferAccountID: 4}},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		subject domain.RuleSubject
+		matched bool
+	}{
+		{"Counterparty Name", domain.RuleSubject{Amount: domain.NewMoney(100_000, kzt), CounterpartyName: "ACME LLP"}, true},
+		{"Below Minimum", domain.RuleSubject{Amount: domain.NewMoney(-99_999, kzt), CounterpartyName: "ACME LLP"}, false},
+		{"Other Currency", domain.RuleSubject{Amount: domain.NewMoney(100_000, domain.MustCurrency("USD")),
+			CounterpartyName: "ACME LLP"}, false},
+		{"Other Counterparty", domain.RuleSubject{Amount: domain.NewMoney(100_000, kzt), CounterpartyName: "Globex"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome := set.Evaluate(tt.subject)
+			assert.Equal(t, tt.matched, outcome.Matched())
+		})
+	}
+}
+
+func Test_Rule_Validate_ReturnsError_WhenRuleInvalid(t *testing.T) {
+	conditions := domain.RuleConditions{DescriptionContains: "taxi"}
+	tests := []struct {
+		name   string
+		rule   domain.Rule
+		errMsg string
+	}{
+		{"No Conditions", domain.Rule{UserID: 1, Name: "Taxi", Actions: domain.RuleActions{Description: "Taxi"}},
+			"rule must have at least one condition"},
+		{"No Actions", domain.Rule{UserID: 1, Name: "Taxi", Conditions: conditions}, "rule must have at least one action"},
+		{"Bad Regex", domain.Rule{UserID: 1, Name: "Taxi", Conditions: domain.RuleConditions{DescriptionRegex: "("},
+			Actions: domain.RuleActions{Description: "Taxi"}}, "description regex is invalid: error parsing regexp: missing closing ): `(`"},
+		{"Category Without Direction", domain.Rule{UserID: 1, Name: "Taxi", Conditions: conditions,
+			Actions: domain.RuleActions{CategoryID: 5}}, "rule that sets a category must apply to incomes or expenses"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.rule.Validate(), tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package domain
+
+import "errors"
+
+// ErrAlreadyImported строка выписки уже была импортирована ранее
+var ErrAlreadyImported = errors.New("transaction was already imported")
+
+// StatementLine операция из банковской выписки
+type StatementLine struct {
+	// Number номер строки (записи) в файле выписки, с единицы
+	Number int
+	// Date дата проведения операции банком
+	Date Date
+	// ValueDate дата валютирования, нулевая - совпадает с Date или неизвестна
+	ValueDate Date
+	// Amount сумма со знаком: поступление положительно, списание отрицательно
+	Amount      Money
+	Description string
+	// CounterpartyName и CounterpartyIBAN плательщик поступления или получатель списания, если известны
+	CounterpartyName string
+	CounterpartyIBAN string
+	// ExternalID идентификатор операции в банке (FITID вместе со счетом), пустой - неизвестен.
+	// Операция с уже импортированным идентификатором повторно не создается.
+	ExternalID string
+	// TransferAccount название счета пользователя, с которым выполнен перевод; пустое - не перевод
+	TransferAccount string
+	// TransferAccountID счет пользователя, с которым выполнен перевод, по правилу категоризации; 0 - не задан
+	TransferAccountID int64
+	// CategoryID категория по правилу категоризации, 0 - категория импорта по умолчанию
+	CategoryID int
+	// Tags теги, которые добавили правила категоризации
+	Tags []string
+	// RuleIDs правила категоризации, примененные к строке, в порядке применения
+	RuleIDs []int64
+	// DuplicateOf ID сохраненного дохода, который вероятно является той же операцией, 0 - дубликат не найден;
+	// DuplicateScore оценка сходства с ним
+	DuplicateOf    int64
+	DuplicateScore float64
+	// Err причина, по которой строка не импортируется
+	Err error
+	// OperationID ID созданного дохода, расхода или перевода, 0 - операция не создана
+	OperationID int64
+}
+
+// IsTransfer сообщает, что строка выписки является переводом между счетами пользователя
+func (l *StatementLine) IsTransfer() bool {
+	return l.TransferAccount != "" || l.TransferAccountID != 0
+}
+
+// IsIncome сообщает, что строка выписки является поступлением, но не переводом
+func (l *StatementLine) IsIncome() bool {
+	return !l.IsTransfer() && l.Amount.IsPositive()
+}
+
+// IsExpense сообщает, что строка выписки является списанием, но не переводом
+func (l *StatementLine) IsExpense() bool {
+	return !l.IsTransfer() && l.Amount.IsNegative()
+}
+
+// StatementImport параметры импорта разобранной выписки
+type StatementImport struct {
+	UserID int64
+	// AccountID счет, к которому относится выписка, 0 - без счета. Обязателен для переводов.
+	AccountID int64
+	// IncomeCategoryID и ExpenseCategoryID категории, в которые попадают поступления и списания
+	IncomeCategoryID  int
+	ExpenseCategoryID int
+	Lines             []StatementLine
+	// DryRun только проверяет строки и ничего не сохраняет (предпросмотр)
+	DryRun bool
+}
+
+// StatementImportResult итог импорта выписки
+type StatementImportResult struct {
+	// Lines строки выписки с причиной отказа или ID созданной операции
+	Lines []StatementLine
+	// Incomes, Expenses и Transfers число созданных (при DryRun - готовых к созданию) операций
+	Incomes   int
+	Expenses  int
+	Transfers int
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxTagLength максимальная длина тега в символах
+	MaxTagLength = 50
+	// MaxOperationTags наибольшее число тегов у одной операции
+	MaxOperationTags = 20
+)
+
+// Tag произвольная метка пользователя, которая объединяет операции поверх иерархии категорий.
+// Названия тегов пользователя уникальны без учета регистра.
+type Tag struct {
+	ID     int64
+	UserID int64
+	Name   string
+	// UsageCount число операций с тегом
+	UsageCount int
+	CreatedAt  time.Time
+}
+
+// ValidateTagName проверяет название тега
+func ValidateTagName(name string) error {
+	if strings.TrimSpace(name) == "" || utf8.RuneCountInString(name) > MaxTagLength {
+		return fmt.Errorf("tags must be non-empty and not exceed %d characters", MaxTagLength)
+	}
+	return nil
+}
+
+// NormalizeTags обрезает пробелы по краям тегов и убирает пустые и повторяющиеся без учета регистра теги.
+// Из повторов остается первый; nil, если тегов не осталось.
+func NormalizeTags(tags []string) []string {
+	var result []string
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, tag)
+	}
+	return result
+}
+
+// validateTags проверяет теги операции
+func validateTags(tags []string) error {
+	if len(tags) > MaxOperationTags {
+		return fmt.Errorf("operation must not have more than %d tags", MaxOperationTags)
+	}
+	for _, tag := range tags {
+		if err := ValidateTagName(tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fincraft-finance/internal/domain"
+)
+
+func Test_NormalizeTags_TrimsAndDropsDuplicates(t *testing.T) {
+	assert.Equal(t, []string{"Travel", "reimbursable"},
+		domain.NormalizeTags([]string{" Travel ", "", "reimbursable", "travel", "  "}))
+	assert.Nil(t, domain.NormalizeTags([]string{" "}))
+}
+
+func Test_ValidateTagName_ReturnsError_WhenEmptyOrLong(t *testing.T) {
+	assert.NoError(t, domain.ValidateTagName("vacation-2026"))
+	assert.EqualError(t, domain.ValidateTagName(" "), "tags must be non-empty and not exceed 50 characters")
+	assert.EqualError(t, domain.ValidateTagName(strings.Repeat("я", domain.MaxTagLength+1)),
+		"tags must be non-empty and not exceed 50 characters")
+}
+
+func Test_Income_Validate_ReturnsError_WhenTooManyTags(t *testing.T) {
+	income := domain.Income{UserID: 1, CategoryID: 2, Amount: domain.NewMoney(1000, kzt), OccurredAt: time.Now()}
+	for i := 0; i <= domain.MaxOperationTags; i++ {
+		income.Tags = append(income.Tags, strings.Repeat("t", i+1))
+	}
+
+	assert.EqualError(t, income.Validate(), "operation must not have more than 20 tags")
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+	"unicode/utf8"
+)
+
+// Transfer перевод денег между счетами одного пользователя.
+// Перевод не является ни доходом, ни расходом и не попадает в отчеты по ним.
+type Transfer struct {
+	ID            int64
+	UserID        int64
+	FromAccountID int64
+	ToAccountID   int64
+	// FromAmount списанная сумма в валюте счета-источника
+	FromAmount Money
+	// ToAmount зачисленная сумма в валюте счета-получателя
+	ToAmount    Money
+	Description string
+	// OccurredAt момент совершения перевода, указанный пользователем
+	OccurredAt time.Time
+	CreatedAt  time.Time
+}
+
+// TransferFilter условия выборки переводов пользователя
+type TransferFilter struct {
+	UserID int64
+	// AccountID счет-источник или счет-получатель, 0 - все счета
+	AccountID int64
+	// From и To ограничивают дату перевода полуинтервалом [From, To), нулевые значения не ограничивают
+	From time.Time
+	To   time.Time
+	// AfterID курсор: возвращаются переводы с ID меньше указанного, 0 - с начала
+	AfterID  int64
+	PageSize int
+}
+
+// TransferPage страница списка переводов
+type TransferPage struct {
+	Items []Transfer
+	// HasMore сообщает, что за последним элементом есть еще данные
+	HasMore bool
+}
+
+// IsCrossCurrency сообщает, что счета перевода в разных валютах
+func (t *Transfer) IsCrossCurrency() bool {
+	return t.FromAmount.Currency() != t.ToAmount.Currency()
+}
+
+// Rate возвращает подразумеваемый курс: сколько единиц валюты получателя дали за единицу валюты источника
+func (t *Transfer) Rate() *big.Rat {
+	if t.FromAmount.IsZero() {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Quo(t.ToAmount.Rat(), t.FromAmount.Rat())
+}
+
+// Validate проверяет бизнес-правила для перевода
+func (t *Transfer) Validate() error {
+	if t.UserID <= 0 {
+		return errors.New("user ID must be valid")
+	}
+	if t.FromAccountID <= 0 || t.ToAccountID <= 0 {
+		return errors.New("account IDs must be valid")
+	}
+	if t.FromAccountID == t.ToAccountID {
+		return errors.New("source and destination accounts must differ")
+	}
+	if !t.FromAmount.IsPositive() || !t.ToAmount.IsPositive() {
+		return errors.New("amounts must be greater than 0")
+	}
+	if t.FromAmount.Currency().IsZero() || t.ToAmount.Currency().IsZero() {
+		return errors.New("currency must be valid")
+	}
+	if !t.IsCrossCurrency() && t.FromAmount != t.ToAmount {
+		return fmt.Errorf("amounts of a same-currency transfer must be equal: %s and %s", t.FromAmount, t.ToAmount)
+	}
+	if utf8.RuneCountInString(t.Description) > MaxDescriptionLength {
+		return fmt.Errorf("description must not exceed %d characters", MaxDescriptionLength)
+	}
+	if t.OccurredAt.IsZero() {
+		return errors.New("occurred at must be set")
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fincraft-finance/internal/domain"
+)
+
+func Test_Transfer_Validate_AllowsDifferentAmounts_WhenCrossCurrency(t *testing.T) {
+	transfer := domain.Transfer{UserID: 1, FromAccountID: 3, ToAccountID: 4, OccurredAt: time.Now(),
+		FromAmount: domain.NewMoney(4_700_000, domain.MustCurrency("KZT")),
+		ToAmount:   domain.NewMoney(10000, domain.MustCurrency("USD"))}
+
+	assert.NoError(t, transfer.Validate())
+	assert.True(t, transfer.IsCrossCurrency())
+	assert.Equal(t, "1/470", transfer.Rate().String())
+}
+
+func Test_Transfer_Validate_ReturnsError_WhenAccountsInvalid(t *testing.T) {
+	kzt := domain.MustCurrency("KZT")
+	tests := []struct {
+		name     string
+		transfer domain.Transfer
+		errMsg   string
+	}{
+		{"Missing Account", domain.Transfer{UserID: 1, FromAccountID: 3}, "account IDs must be valid"},
+		{"Same Account", domain.Transfer{UserID: 1, FromAccountID: 3, ToAccountID: 3}, "source and destination accounts must differ"},
+		{"Negative Amount", domain.Transfer{UserID: 1, FromAccountID: 3, ToAccountID: 4,
+			FromAmount: domain.NewMoney(-100, kzt), ToAmount: domain.NewMoney(-100, kzt)}, "amounts must be greater than 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.transfer.Validate(), tt.errMsg)
+		})
+	}
+}
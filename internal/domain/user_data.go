@@ -0,0 +1,97 @@
+package domain
+
+import "time"
+
+// UserDataSchemaVersion версия схемы архива данных пользователя.
+// Схема описана в api/finance/user_data.schema.json и меняется только с увеличением версии.
+const UserDataSchemaVersion = 1
+
+// UserDataSection раздел архива данных пользователя: строки одной таблицы хранилища
+type UserDataSection string
+
+// Разделы архива данных пользователя
+const (
+	// UserDataUser профиль пользователя: email, имя и часовой пояс
+	UserDataUser UserDataSection = "user"
+	// UserDataAccounts счета
+	UserDataAccounts UserDataSection = "accounts"
+	// UserDataCategories собственные категории пользователя; системные категории в архив не входят
+	UserDataCategories UserDataSection = "categories"
+	// UserDataTags теги доходов
+	UserDataTags UserDataSection = "tags"
+	// UserDataIncomes доходы
+	UserDataIncomes UserDataSection = "incomes"
+	// UserDataIncomeTags связи доходов с тегами
+	UserDataIncomeTags UserDataSection = "income_tags"
+	// UserDataIncomeSources исходные доходы, объединенные при слиянии дубликатов
+	UserDataIncomeSources UserDataSection = "income_sources"
+	// UserDataExpenses расходы
+	UserDataExpenses UserDataSection = "expenses"
+	// UserDataTransfers переводы между счетами
+	UserDataTransfers UserDataSection = "transfers"
+	// UserDataBudgets бюджеты
+	UserDataBudgets UserDataSection = "budgets"
+	// UserDataRecurringRules правила повторяющихся доходов
+	UserDataRecurringRules UserDataSection = "recurring_rules"
+	// UserDataRecurringOccurrences проведенные и пропущенные повторения правил
+	UserDataRecurringOccurrences UserDataSection = "recurring_occurrences"
+	// UserDataRules правила категоризации
+	UserDataRules UserDataSection = "rules"
+	// UserDataJournalEntries записи журнала проводок: отдельного журнала аудита нет, изменения операций
+	// видны по записям и их сторнированию
+	UserDataJournalEntries UserDataSection = "journal_entries"
+	// UserDataJournalPostings проводки записей журнала
+	UserDataJournalPostings UserDataSection = "journal_postings"
+	// UserDataDailyBalances дневные обороты счетов
+	UserDataDailyBalances UserDataSection = "daily_balances"
+	// UserDataImportedTransactions внешние идентификаторы импортированных из выписок операций
+	UserDataImportedTransactions UserDataSection = "imported_transactions"
+	// UserDataIdempotencyKeys ключи идемпотентности завершенных запросов пользователя
+	UserDataIdempotencyKeys UserDataSection = "idempotency_keys"
+)
+
+// UserDataSections все разделы архива в порядке выгрузки
+var UserDataSections = []UserDataSection{
+	UserDataUser,
+	UserDataAccounts,
+	UserDataCategories,
+	UserDataTags,
+	UserDataIncomes,
+	UserDataIncomeTags,
+	UserDataIncomeSources,
+	UserDataExpenses,
+	UserDataTransfers,
+	UserDataBudgets,
+	UserDataRecurringRules,
+	UserDataRecurringOccurrences,
+	UserDataRules,
+	UserDataJournalEntries,
+	UserDataJournalPostings,
+	UserDataDailyBalances,
+	UserDataImportedTransactions,
+	UserDataIdempotencyKeys,
+}
+
+// SectionErasure число строк раздела, удаленных при стирании данных пользователя
+type SectionErasure struct {
+	Section UserDataSection
+	Removed int64
+}
+
+// ErasureReport отчет о стирании данных пользователя.
+// Отчет выдается только после проверки в той же транзакции, что ни в одном разделе не осталось строк.
+type ErasureReport struct {
+	UserID   int64
+	ErasedAt time.Time
+	// Sections удаленные строки по разделам в порядке UserDataSections
+	Sections []SectionErasure
+}
+
+// TotalRemoved возвращает общее число удаленных строк
+func (r *ErasureReport) TotalRemoved() int64 {
+	var total int64
+	for _, s := range r.Sections {
+		total += s.Removed
+	}
+	return total
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"fincraft-finance/internal/domain"
+)
+
+// csvWriter пишет операции в CSV с заголовком на языке локали
+type csvWriter struct {
+	w       *csv.Writer
+	locale  locale
+	decimal string
+}
+
+// newCSVWriter создает писателя CSV и сразу пишет заголовок
+func newCSVWriter(w io.Writer, opts Options) (*csvWriter, error) {
+	delimiter, decimal := opts.separators()
+	cw := &csvWriter{w: csv.NewWriter(w), locale: opts.locale(), decimal: decimal}
+	cw.w.Comma = delimiter
+	if err := cw.w.Write(cw.locale.headers); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+// Write пишет строку операции
+func (c *csvWriter) Write(r *Record) error {
+	return c.w.Write([]string{
+		c.locale.kinds[r.Kind],
+		strconv.FormatInt(r.ID, 10),
+		r.OccurredAt.Format(c.locale.dateLayout),
+		c.amount(r.Amount),
+		currencyCode(r.Amount),
+		optionalID(int64(r.CategoryID)),
+		optionalID(r.AccountID),
+		optionalID(r.ToAccountID),
+		c.amount(r.ToAmount),
+		currencyCode(r.ToAmount),
+		r.Description,
+		strings.Join(r.Tags, ", "),
+	})
+}
+
+// Close сбрасывает буфер и возвращает первую ошибку записи
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// amount форматирует сумму с разделителем дробной части выгрузки, нулевое значение Money - пустая ячейка
+func (c *csvWriter) amount(m domain.Money) string {
+	if m.Currency().IsZero() {
+		return ""
+	}
+	return strings.Replace(m.Decimal(), ".", c.decimal, 1)
+}
+
+// currencyCode возвращает код валюты суммы, для нулевого значения Money - пустую строку
+func currencyCode(m domain.Money) string {
+	if m.Currency().IsZero() {
+		return ""
+	}
+	return m.Currency().Code
+}
+
+// optionalID возвращает идентификатор строкой, 0 - пустая строка
+func optionalID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
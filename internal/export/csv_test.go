@@ -0,0 +1,28 @@
+package export_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fincraft-finance/internal/export"
+)
+
+func Test_CSVWriter_Write_UsesLocaleDefaults_WhenSeparatorsNotSet(t *testing.T) {
+	got := writeAll(t, export.Options{Format: export.FormatCSV, Locale: "ru"}, testRecords(t))
+
+	assert.Equal(t, "Вид;ID;Дата;Сумма;Валюта;ID категории;ID счета;ID счета получателя;Сумма зачисления;"+
+		"Валюта зачисления;Описание;Теги\n"+
+		`Доход;7;05.03.2024 14:30:00;12345,50;KZT;2;3;;;;"Salary ""March""";work, bonus`+"\n"+
+		"Перевод;9;06.03.2024 09:00:00;-100,00;USD;;4;3;45000,00;KZT;;\n", string(got))
+}
+
+func Test_CSVWriter_Write_UsesConfiguredSeparators_WhenSet(t *testing.T) {
+	records := testRecords(t)[1:]
+
+	got := writeAll(t, export.Options{Format: export.FormatCSV, Delimiter: '\t', DecimalSeparator: ","}, records)
+
+	assert.Equal(t, "Type\tID\tDate\tAmount\tCurrency\tCategory ID\tAccount ID\tTo account ID\tTo amount\t"+
+		"To currency\tDescription\tTags\n"+
+		"Transfer\t9\t2024-03-06 09:00:00\t-100,00\tUSD\t\t4\t3\t45000,00\tKZT\t\t\n", string(got))
+}
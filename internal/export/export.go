@@ -0,0 +1,246 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+// Format формат файла выгрузки
+type Format int
+
+const (
+	// FormatCSV таблица с разделителями, настраиваемыми локалью и параметрами выгрузки
+	FormatCSV Format = iota
+	// FormatJSONL JSON Lines: один объект операции в строке
+	FormatJSONL
+	// FormatXLSX книга Office Open XML с одним листом
+	FormatXLSX
+)
+
+// ContentType возвращает MIME-тип файла выгрузки
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSONL:
+		return "application/jsonl"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv"
+	}
+}
+
+// Extension возвращает расширение файла выгрузки без точки
+func (f Format) Extension() string {
+	switch f {
+	case FormatJSONL:
+		return "jsonl"
+	case FormatXLSX:
+		return "xlsx"
+	default:
+		return "csv"
+	}
+}
+
+// Kind вид выгружаемой операции
+type Kind int
+
+const (
+	// KindIncome доход
+	KindIncome Kind = iota + 1
+	// KindExpense расход
+	KindExpense
+	// KindTransfer перевод между счетами
+	KindTransfer
+)
+
+// Valid сообщает, что вид операции известен
+func (k Kind) Valid() bool {
+	return k >= KindIncome && k <= KindTransfer
+}
+
+// String возвращает название вида операции в JSON Lines
+func (k Kind) String() string {
+	switch k {
+	case KindIncome:
+		return "income"
+	case KindExpense:
+		return "expense"
+	case KindTransfer:
+		return "transfer"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+// Record строка выгрузки: одна операция любого вида
+type Record struct {
+	Kind Kind
+	ID   int64
+	// OccurredAt момент операции в часовом поясе пользователя
+	OccurredAt time.Time
+	// Amount сумма операции, для перевода - списанная сумма
+	Amount domain.Money
+	// CategoryID 0 - у операции нет категории (перевод)
+	CategoryID int
+	// AccountID счет дохода или расхода или счет-источник перевода, 0 - без счета
+	AccountID int64
+	// ToAccountID и ToAmount заполнены только у переводов
+	ToAccountID int64
+	ToAmount    domain.Money
+	Description string
+	Tags        []string
+}
+
+// Options параметры файла выгрузки
+type Options struct {
+	Format Format
+	// Locale "en" (по умолчанию) или "ru": задает заголовки, формат дат и разделители CSV по умолчанию
+	Locale string
+	// Delimiter разделитель полей CSV, 0 - по локали
+	Delimiter rune
+	// DecimalSeparator разделитель дробной части сумм в CSV, пустой - по локали
+	DecimalSeparator string
+}
+
+// Validate проверяет параметры файла
+func (o *Options) Validate() error {
+	if o.Format < FormatCSV || o.Format > FormatXLSX {
+		return errors.New("export format must be valid")
+	}
+	if _, ok := locales[o.Locale]; !ok && o.Locale != "" {
+		return fmt.Errorf("unsupported locale %q", o.Locale)
+	}
+	if o.Delimiter == '"' || o.Delimiter == '\r' || o.Delimiter == '\n' {
+		return fmt.Errorf("delimiter %q is not allowed", o.Delimiter)
+	}
+	delimiter, decimal := o.separators()
+	if string(delimiter) == decimal {
+		return errors.New("delimiter and decimal separator must differ")
+	}
+	return nil
+}
+
+// locale возвращает настройки локали выгрузки
+func (o *Options) locale() locale {
+	if l, ok := locales[o.Locale]; ok {
+		return l
+	}
+	return locales["en"]
+}
+
+// separators возвращает разделители полей и дробной части с учетом локали
+func (o *Options) separators() (rune, string) {
+	l := o.locale()
+	delimiter, decimal := l.delimiter, l.decimalSeparator
+	if o.Delimiter != 0 {
+		delimiter = o.Delimiter
+	}
+	if o.DecimalSeparator != "" {
+		decimal = o.DecimalSeparator
+	}
+	return delimiter, decimal
+}
+
+// locale заголовки и форматы выгрузки для одного языка
+type locale struct {
+	delimiter        rune
+	decimalSeparator string
+	dateLayout       string
+	sheetName        string
+	headers          []string
+	kinds            map[Kind]string
+}
+
+// locales поддерживаемые локали выгрузки
+var locales = map[string]locale{
+	"en": {
+		delimiter:        ',',
+		decimalSeparator: ".",
+		dateLayout:       "2006-01-02 15:04:05",
+		sheetName:        "Transactions",
+		headers: []string{"Type", "ID", "Date", "Amount", "Currency", "Category ID", "Account ID",
+			"To account ID", "To amount", "To currency", "Description", "Tags"},
+		kinds: map[Kind]string{KindIncome: "Income", KindExpense: "Expense", KindTransfer: "Transfer"},
+	},
+	"ru": {
+		delimiter:        ';',
+		decimalSeparator: ",",
+		dateLayout:       "02.01.2006 15:04:05",
+		sheetName:        "Операции",
+		headers: []string{"Вид", "ID", "Дата", "Сумма", "Валюта", "ID категории", "ID счета",
+			"ID счета получателя", "Сумма зачисления", "Валюта зачисления", "Описание", "Теги"},
+		kinds: map[Kind]string{KindIncome: "Доход", KindExpense: "Расход", KindTransfer: "Перевод"},
+	},
+}
+
+// Query параметры выгрузки операций пользователя
+type Query struct {
+	UserID int64
+	// Kinds виды операций, пустой список - все виды
+	Kinds []Kind
+	// FromDate и ToDate ограничивают дату операции включительно по календарю пользователя,
+	// нулевые значения не ограничивают
+	FromDate domain.Date
+	ToDate   domain.Date
+	// CategoryID, AccountID и Tags оставляют только операции, у которых есть такие категория, счет и теги:
+	// например, фильтр по тегам исключает расходы и переводы
+	CategoryID int
+	AccountID  int64
+	Tags       []string
+	Options    Options
+}
+
+// Validate проверяет параметры выгрузки
+func (q *Query) Validate() error {
+	if q.UserID <= 0 {
+		return errors.New("user ID must be valid")
+	}
+	for _, k := range q.Kinds {
+		if !k.Valid() {
+			return fmt.Errorf("unknown transaction kind %d", k)
+		}
+	}
+	if !q.FromDate.IsZero() && !q.ToDate.IsZero() && q.ToDate.Before(q.FromDate) {
+		return errors.New("from date must not be after to date")
+	}
+	if q.CategoryID < 0 || q.AccountID < 0 {
+		return errors.New("category ID and account ID must be valid")
+	}
+	if len(q.Tags) > domain.MaxOperationTags {
+		return fmt.Errorf("tag filter must not contain more than %d tags", domain.MaxOperationTags)
+	}
+	return q.Options.Validate()
+}
+
+// Includes сообщает, что операции вида k нужно выгрузить
+func (q *Query) Includes(k Kind) bool {
+	return len(q.Kinds) == 0 || slices.Contains(q.Kinds, k)
+}
+
+// Writer пишет операции в файл выгрузки по одной, не накапливая их в памяти
+type Writer interface {
+	Write(r *Record) error
+	// Close дописывает окончание файла; без него выгрузка неполная
+	Close() error
+}
+
+// NewWriter создает писателя выгрузки в формате opts.Format поверх w
+func NewWriter(w io.Writer, opts Options) (Writer, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch opts.Format {
+	case FormatJSONL:
+		return newJSONLWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, opts.locale())
+	default:
+		return newCSVWriter(w, opts)
+	}
+}
@@ -0,0 +1,81 @@
+package export_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/export"
+)
+
+var (
+	kzt = domain.MustCurrency("KZT")
+	usd = domain.MustCurrency("USD")
+)
+
+// testRecords доход с тегами и перевод между валютами
+func testRecords(t *testing.T) []export.Record {
+	almaty, err := time.LoadLocation("Asia/Almaty")
+	require.NoError(t, err)
+	return []export.Record{
+		{Kind: export.KindIncome, ID: 7, OccurredAt: time.Date(2024, time.March, 5, 14, 30, 0, 0, almaty),
+			Amount: domain.NewMoney(1_234_550, kzt), CategoryID: 2, AccountID: 3, Description: `Salary "March"`,
+			Tags: []string{"work", "bonus"}},
+		{Kind: export.KindTransfer, ID: 9, OccurredAt: time.Date(2024, time.March, 6, 9, 0, 0, 0, almaty),
+			Amount: domain.NewMoney(-10_000, usd), AccountID: 4, ToAccountID: 3,
+			ToAmount: domain.NewMoney(4_500_000, kzt)},
+	}
+}
+
+// writeAll выгружает записи и возвращает содержимое файла
+func writeAll(t *testing.T, opts export.Options, records []export.Record) []byte {
+	var buf bytes.Buffer
+	w, err := export.NewWriter(&buf, opts)
+	require.NoError(t, err)
+	for i := range records {
+		require.NoError(t, w.Write(&records[i]))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func Test_Options_Validate_ReturnsError_WhenOptionsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts export.Options
+		want string
+	}{
+		{"UnknownFormat", export.Options{Format: 7}, "export format must be valid"},
+		{"UnknownLocale", export.Options{Locale: "fr"}, `unsupported locale "fr"`},
+		{"QuoteDelimiter", export.Options{Delimiter: '"'}, `delimiter '"' is not allowed`},
+		{"SameSeparators", export.Options{Locale: "ru", Delimiter: ','}, "delimiter and decimal separator must differ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.opts.Validate(), tt.want)
+		})
+	}
+}
+
+func Test_Query_Includes_ReturnsTrueForAllKinds_WhenKindsEmpty(t *testing.T) {
+	all := export.Query{}
+	incomes := export.Query{Kinds: []export.Kind{export.KindIncome}}
+
+	assert.True(t, all.Includes(export.KindTransfer))
+	assert.True(t, incomes.Includes(export.KindIncome))
+	assert.False(t, incomes.Includes(export.KindExpense))
+}
+
+func Test_JSONLWriter_Write_WritesOneObjectPerLine_WhenRowsGiven(t *testing.T) {
+	got := writeAll(t, export.Options{Format: export.FormatJSONL, Locale: "ru"}, testRecords(t))
+
+	assert.Equal(t, `{"kind":"income","id":7,"occurred_at":"2024-03-05T14:30:00+05:00","amount":"12345.50",`+
+		`"currency":"KZT","category_id":2,"account_id":3,"description":"Salary \"March\"","tags":["work","bonus"]}`+"\n"+
+		`{"kind":"transfer","id":9,"occurred_at":"2024-03-06T09:00:00+05:00","amount":"-100.00","currency":"USD",`+
+		`"account_id":4,"to_account_id":3,"to_amount":"45000.00","to_currency":"KZT"}`+"\n", string(got))
+}
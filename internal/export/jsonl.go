@@ -0,0 +1,58 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonRecord операция в JSON Lines; суммы строками, чтобы не терять точность
+type jsonRecord struct {
+	Kind        string   `json:"kind"`
+	ID          int64    `json:"id"`
+	OccurredAt  string   `json:"occurred_at"`
+	Amount      string   `json:"amount"`
+	Currency    string   `json:"currency"`
+	CategoryID  int      `json:"category_id,omitempty"`
+	AccountID   int64    `json:"account_id,omitempty"`
+	ToAccountID int64    `json:"to_account_id,omitempty"`
+	ToAmount    string   `json:"to_amount,omitempty"`
+	ToCurrency  string   `json:"to_currency,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// jsonlWriter пишет операции в JSON Lines; локаль и разделители CSV не используются
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+// newJSONLWriter создает писателя JSON Lines
+func newJSONLWriter(w io.Writer) *jsonlWriter {
+	return &jsonlWriter{enc: json.NewEncoder(w)}
+}
+
+// Write пишет операцию отдельной строкой
+func (j *jsonlWriter) Write(r *Record) error {
+	rec := jsonRecord{
+		Kind:        r.Kind.String(),
+		ID:          r.ID,
+		OccurredAt:  r.OccurredAt.Format(time.RFC3339),
+		Amount:      r.Amount.Decimal(),
+		Currency:    r.Amount.Currency().Code,
+		CategoryID:  r.CategoryID,
+		AccountID:   r.AccountID,
+		ToAccountID: r.ToAccountID,
+		Description: r.Description,
+		Tags:        r.Tags,
+	}
+	if !r.ToAmount.Currency().IsZero() {
+		rec.ToAmount, rec.ToCurrency = r.ToAmount.Decimal(), r.ToAmount.Currency().Code
+	}
+	return j.enc.Encode(rec)
+}
+
+// Close ничего не дописывает: каждая строка JSON Lines закончена сама по себе
+func (j *jsonlWriter) Close() error {
+	return nil
+}
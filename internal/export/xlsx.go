@@ -0,0 +1,163 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+// Статические части книги: один лист и стиль даты со встроенным форматом 22 ("m/d/yy h:mm")
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="22" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>` +
+		`</styleSheet>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// xlsxEpoch начало отсчета дат Excel
+var xlsxEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxWriter пишет книгу XLSX потоково: служебные части сразу, строки листа по мере поступления.
+// Строки хранятся как inline-строки, поэтому таблица общих строк не нужна и память не растет с числом строк.
+type xlsxWriter struct {
+	zip    *zip.Writer
+	sheet  *bufio.Writer
+	locale locale
+}
+
+// newXLSXWriter пишет служебные части книги, открывает лист и пишет строку заголовка
+func newXLSXWriter(w io.Writer, l locale) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+	var sheetName strings.Builder
+	if err := xml.EscapeText(&sheetName, []byte(l.sheetName)); err != nil {
+		return nil, err
+	}
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, sheetName.String())},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, p.content); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	x := &xlsxWriter{zip: zw, sheet: bufio.NewWriter(f), locale: l}
+	x.sheet.WriteString(xlsxSheetStart)
+	x.sheet.WriteString("<row>")
+	for _, h := range l.headers {
+		x.text(h)
+	}
+	x.sheet.WriteString("</row>")
+	return x, nil
+}
+
+// Write пишет строку операции: суммы и идентификаторы числами, дату - числом Excel с форматом даты
+func (x *xlsxWriter) Write(r *Record) error {
+	x.sheet.WriteString("<row>")
+	x.text(x.locale.kinds[r.Kind])
+	x.number(strconv.FormatInt(r.ID, 10))
+	x.date(r.OccurredAt)
+	x.money(r.Amount)
+	x.text(currencyCode(r.Amount))
+	x.number(optionalID(int64(r.CategoryID)))
+	x.number(optionalID(r.AccountID))
+	x.number(optionalID(r.ToAccountID))
+	x.money(r.ToAmount)
+	x.text(currencyCode(r.ToAmount))
+	x.text(r.Description)
+	x.text(strings.Join(r.Tags, ", "))
+	_, err := x.sheet.WriteString("</row>")
+	return err
+}
+
+// Close закрывает лист и архив книги
+func (x *xlsxWriter) Close() error {
+	x.sheet.WriteString(xlsxSheetEnd)
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
+
+// text пишет строковую ячейку, пустая строка - пустая ячейка
+func (x *xlsxWriter) text(s string) {
+	if s == "" {
+		x.sheet.WriteString("<c/>")
+		return
+	}
+	x.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+	_ = xml.EscapeText(x.sheet, []byte(s))
+	x.sheet.WriteString("</t></is></c>")
+}
+
+// number пишет числовую ячейку, пустая строка - пустая ячейка
+func (x *xlsxWriter) number(v string) {
+	if v == "" {
+		x.sheet.WriteString("<c/>")
+		return
+	}
+	x.sheet.WriteString("<c><v>" + v + "</v></c>")
+}
+
+// money пишет сумму числом, нулевое значение Money - пустая ячейка
+func (x *xlsxWriter) money(m domain.Money) {
+	if m.Currency().IsZero() {
+		x.number("")
+		return
+	}
+	x.number(m.Decimal())
+}
+
+// date пишет время по часам его часового пояса числом дней от начала отсчета Excel
+func (x *xlsxWriter) date(t time.Time) {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	days := wall.Sub(xlsxEpoch).Seconds() / (24 * 60 * 60)
+	x.sheet.WriteString(`<c s="1"><v>` + strconv.FormatFloat(days, 'f', -1, 64) + "</v></c>")
+}
@@ -0,0 +1,38 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/export"
+)
+
+func Test_XLSXWriter_Write_WritesWorkbookWithTypedCells_WhenRowsGiven(t *testing.T) {
+	got := writeAll(t, export.Options{Format: export.FormatXLSX}, testRecords(t)[:1])
+
+	archive, err := zip.NewReader(bytes.NewReader(got), int64(len(got)))
+	require.NoError(t, err)
+	parts := make(map[string]string)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		parts[f.Name] = string(content)
+	}
+
+	assert.Contains(t, parts, "[Content_Types].xml")
+	assert.Contains(t, parts, "xl/styles.xml")
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="Transactions"`)
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c t="inlineStr"><is><t xml:space="preserve">Type</t></is></c>`)
+	// 5 марта 2024 14:30 - день 45356 от начала отсчета Excel
+	assert.Contains(t, sheet, `<c s="1"><v>45356.604166666664</v></c><c><v>12345.50</v></c>`)
+	assert.Contains(t, sheet, `<t xml:space="preserve">Salary &#34;March&#34;</t>`)
+	assert.True(t, bytes.HasSuffix([]byte(sheet), []byte("</sheetData></worksheet>")))
+}
@@ -0,0 +1,153 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+// accountColumns колонки, из которых собирается domain.Account.
+// Текущий остаток не хранится, а выводится из начального остатка и проводок журнала по счету.
+const accountColumns = `id, user_id, name, type, currency, opening_balance,
+	opening_balance + COALESCE((
+		SELECT SUM(p.amount)
+		FROM journal_postings p
+		WHERE p.account_kind = 'asset' AND p.ref_id = accounts.id
+	), 0) AS balance,
+	closed_at, created_at, updated_at`
+
+// accountTypes соответствие видов счетов значениям колонки type
+var accountTypes = map[domain.AccountType]string{
+	domain.AccountTypeCash:      "cash",
+	domain.AccountTypeCard:      "card",
+	domain.AccountTypeChecking:  "checking",
+	domain.AccountTypeSavings:   "savings",
+	domain.AccountTypeBrokerage: "brokerage",
+}
+
+// AccountRepository реализует методы для работы со счетами
+type AccountRepository struct {
+	db *sql.DB
+}
+
+// NewAccountRepository создает новый экземпляр AccountRepository
+func NewAccountRepository(db *sql.DB) *AccountRepository {
+	return &AccountRepository{db: db}
+}
+
+// CreateAccount добавляет счет пользователя
+func (r *AccountRepository) CreateAccount(ctx context.Context, account *domain.Account) (*domain.Account, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO accounts (user_id, name, type, currency, opening_balance)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+accountColumns,
+		account.UserID, account.Name, accountTypes[account.Type], account.Currency().Code,
+		account.OpeningBalance.Decimal())
+
+	return scanAccount(row)
+}
+
+// GetAccount возвращает счет пользователя по ID
+func (r *AccountRepository) GetAccount(ctx context.Context, userID, id int64) (*domain.Account, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+accountColumns+`
+		FROM accounts
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+
+	account, err := scanAccount(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("account %d: %w", id, domain.ErrNotFound)
+	}
+
+	return account, err
+}
+
+// ListAccounts возвращает счета пользователя в порядке создания
+func (r *AccountRepository) ListAccounts(ctx context.Context, filter domain.AccountFilter) ([]domain.Account, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+accountColumns+`
+		FROM accounts
+		WHERE user_id = $1 AND ($2 OR closed_at IS NULL)
+		ORDER BY id
+	`, filter.UserID, filter.IncludeClosed)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var accounts []domain.Account
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, *account)
+	}
+
+	return accounts, rows.Err()
+}
+
+// UpdateAccount сохраняет название, вид, начальный остаток и момент закрытия счета
+func (r *AccountRepository) UpdateAccount(ctx context.Context, account *domain.Account) (*domain.Account, error) {
+	var closedAt *time.Time
+	if account.IsClosed() {
+		closedAt = &account.ClosedAt
+	}
+
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		UPDATE accounts
+		SET name = $3, type = $4, opening_balance = $5, closed_at = $6, updated_at = now()
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+accountColumns,
+		account.ID, account.UserID, account.Name, accountTypes[account.Type],
+		account.OpeningBalance.Decimal(), closedAt)
+
+	updated, err := scanAccount(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("account %d: %w", account.ID, domain.ErrNotFound)
+	}
+
+	return updated, err
+}
+
+// scanAccount читает счет из строки результата
+func scanAccount(row rowScanner) (*domain.Account, error) {
+	var (
+		a        domain.Account
+		kind     string
+		currency string
+		opening  string
+		balance  string
+		closedAt sql.NullTime
+	)
+
+	err := row.Scan(&a.ID, &a.UserID, &a.Name, &kind, &currency, &opening, &balance, &closedAt,
+		&a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	for t, v := range accountTypes {
+		if v == kind {
+			a.Type = t
+		}
+	}
+	if a.Type == 0 {
+		return nil, fmt.Errorf("unknown account type %q", kind)
+	}
+	if a.OpeningBalance, err = moneyFromDB(opening, currency); err != nil {
+		return nil, err
+	}
+	if a.Balance, err = moneyFromDB(balance, currency); err != nil {
+		return nil, err
+	}
+	a.ClosedAt = closedAt.Time
+
+	return &a, nil
+}
@@ -0,0 +1,70 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/testdb"
+)
+
+func truncateAccounts(t *testing.T) {
+	if err := testdb.TruncateTables(testdb.DB, testdb.UsersTable, testdb.IncomesTable, testdb.AccountsTable,
+		testdb.JournalTable, testdb.DailyBalancesTable); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func addTestAccount(t *testing.T, repo *infrastructure.AccountRepository, opening int64) *domain.Account {
+	account, err := repo.CreateAccount(context.Background(), &domain.Account{
+		UserID:         1,
+		Name:           "Kaspi Gold",
+		Type:           domain.AccountTypeCard,
+		OpeningBalance: domain.NewMoney(opening, kzt),
+		Balance:        domain.NewMoney(opening, kzt),
+	})
+	require.NoError(t, err)
+	return account
+}
+
+func Test_AccountRepository_CreateAccount_ReturnsStoredAccount_WhenValidInput(t *testing.T) {
+	defer truncateAccounts(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewAccountRepository(testdb.DB)
+
+	account := addTestAccount(t, repo, -1550)
+
+	got, err := repo.GetAccount(context.Background(), 1, account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AccountTypeCard, got.Type)
+	assert.Equal(t, domain.NewMoney(-1550, kzt), got.OpeningBalance)
+	assert.Equal(t, domain.NewMoney(-1550, kzt), got.Balance)
+	assert.False(t, got.IsClosed())
+}
+
+func Test_AccountRepository_ListAccounts_SkipsClosed_WhenNotRequested(t *testing.T) {
+	defer truncateAccounts(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewAccountRepository(testdb.DB)
+	ctx := context.Background()
+	open := addTestAccount(t, repo, 0)
+	closed := addTestAccount(t, repo, 0)
+	closed.ClosedAt = closed.CreatedAt
+	_, err := repo.UpdateAccount(ctx, closed)
+	require.NoError(t, err)
+
+	active, err := repo.ListAccounts(ctx, domain.AccountFilter{UserID: 1})
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, open.ID, active[0].ID)
+
+	all, err := repo.ListAccounts(ctx, domain.AccountFilter{UserID: 1, IncludeClosed: true})
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
@@ -0,0 +1,220 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+// budgetColumns колонки, из которых собирается domain.Budget
+const budgetColumns = `id, user_id, category_id, period, amount, currency, rollover, start_date, end_date,
+	created_at, updated_at`
+
+// budgetPeriods соответствие периодов бюджета значениям колонки period
+var budgetPeriods = map[domain.BudgetPeriod]string{
+	domain.BudgetPeriodMonthly: "monthly",
+	domain.BudgetPeriodWeekly:  "weekly",
+	domain.BudgetPeriodCustom:  "custom",
+}
+
+// operationTables таблицы операций по видам категорий
+var operationTables = map[domain.CategoryKind]string{
+	domain.CategoryKindIncome:  "incomes",
+	domain.CategoryKindExpense: "expenses",
+}
+
+// BudgetRepository реализует методы для работы с бюджетами
+type BudgetRepository struct {
+	db *sql.DB
+}
+
+// NewBudgetRepository создает новый экземпляр BudgetRepository
+func NewBudgetRepository(db *sql.DB) *BudgetRepository {
+	return &BudgetRepository{db: db}
+}
+
+// CreateBudget добавляет бюджет пользователя
+func (r *BudgetRepository) CreateBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO budgets (user_id, category_id, period, amount, currency, rollover, start_date, end_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING `+budgetColumns,
+		budget.UserID, budget.CategoryID, budgetPeriods[budget.Period], budget.Limit.Decimal(),
+		budget.Limit.Currency().Code, budget.Rollover, dateToDB(budget.StartDate), dateToDB(budget.EndDate))
+
+	return scanBudget(row)
+}
+
+// GetBudget возвращает бюджет пользователя по ID
+func (r *BudgetRepository) GetBudget(ctx context.Context, userID, id int64) (*domain.Budget, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+budgetColumns+`
+		FROM budgets
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+
+	budget, err := scanBudget(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("budget %d: %w", id, domain.ErrNotFound)
+	}
+
+	return budget, err
+}
+
+// ListBudgets возвращает бюджеты пользователя в порядке создания
+func (r *BudgetRepository) ListBudgets(ctx context.Context, userID int64) ([]domain.Budget, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+budgetColumns+`
+		FROM budgets
+		WHERE user_id = $1
+		ORDER BY id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var budgets []domain.Budget
+	for rows.Next() {
+		budget, err := scanBudget(rows)
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, *budget)
+	}
+
+	return budgets, rows.Err()
+}
+
+// UpdateBudget сохраняет лимит, перенос остатка и даты бюджета
+func (r *BudgetRepository) UpdateBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		UPDATE budgets
+		SET amount = $3, currency = $4, rollover = $5, start_date = $6, end_date = $7, updated_at = now()
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+budgetColumns,
+		budget.ID, budget.UserID, budget.Limit.Decimal(), budget.Limit.Currency().Code, budget.Rollover,
+		dateToDB(budget.StartDate), dateToDB(budget.EndDate))
+
+	updated, err := scanBudget(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("budget %d: %w", budget.ID, domain.ErrNotFound)
+	}
+
+	return updated, err
+}
+
+// DeleteBudget удаляет бюджет пользователя
+func (r *BudgetRepository) DeleteBudget(ctx context.Context, userID, id int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM budgets WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("budget %d: %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// SumByCategory возвращает суммы доходов или расходов категории и ее подкатегорий за [from, to) по валютам
+func (r *BudgetRepository) SumByCategory(ctx context.Context, userID int64, category *domain.Category,
+	from, to time.Time) ([]domain.Money, error) {
+	table, ok := operationTables[category.Kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown category kind %d", category.Kind)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT SUM(o.amount), o.currency
+		FROM `+table+` o
+		JOIN categories c ON c.id = o.category_id
+		WHERE o.user_id = $1
+		  AND (c.id = $2 OR c.parent_id = $2)
+		  AND o.occurred_at >= $3 AND o.occurred_at < $4
+		GROUP BY o.currency
+		ORDER BY o.currency
+	`, userID, category.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var sums []domain.Money
+	for rows.Next() {
+		var amount, currency string
+		if err := rows.Scan(&amount, &currency); err != nil {
+			return nil, err
+		}
+		sum, err := moneyFromDB(amount, currency)
+		if err != nil {
+			return nil, err
+		}
+		sums = append(sums, sum)
+	}
+
+	return sums, rows.Err()
+}
+
+// scanBudget читает бюджет из строки результата
+func scanBudget(row rowScanner) (*domain.Budget, error) {
+	var (
+		b         domain.Budget
+		period    string
+		amount    string
+		currency  string
+		startDate time.Time
+		endDate   sql.NullTime
+	)
+
+	err := row.Scan(&b.ID, &b.UserID, &b.CategoryID, &period, &amount, &currency, &b.Rollover,
+		&startDate, &endDate, &b.CreatedAt, &b.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	for p, v := range budgetPeriods {
+		if v == period {
+			b.Period = p
+		}
+	}
+	if b.Period == 0 {
+		return nil, fmt.Errorf("unknown budget period %q", period)
+	}
+	if b.Limit, err = moneyFromDB(amount, currency); err != nil {
+		return nil, err
+	}
+	b.StartDate = domain.DateOf(startDate)
+	if endDate.Valid {
+		b.EndDate = domain.DateOf(endDate.Time)
+	}
+
+	return &b, nil
+}
+
+// dateToDB возвращает значение для колонки DATE, нулевая дата хранится как NULL
+func dateToDB(d domain.Date) any {
+	if d.IsZero() {
+		return nil
+	}
+	return d.String()
+}
+
+// timeToDB возвращает значение для колонки TIMESTAMPTZ, нулевой момент передается как NULL
+func timeToDB(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
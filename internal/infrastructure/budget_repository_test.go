@@ -0,0 +1,80 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/testdb"
+)
+
+func truncateBudgets(t *testing.T) {
+	if err := testdb.TruncateTables(testdb.DB, testdb.UsersTable, testdb.ExpensesTable, testdb.BudgetsTable); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_BudgetRepository_CreateBudget_StoresCustomPeriod_WhenEndDateSet(t *testing.T) {
+	defer truncateBudgets(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewBudgetRepository(testdb.DB)
+	ctx := context.Background()
+
+	created, err := repo.CreateBudget(ctx, &domain.Budget{UserID: 1, CategoryID: 2, Period: domain.BudgetPeriodCustom,
+		Limit: domain.NewMoney(500000, kzt), StartDate: domain.Date{Year: 2024, Month: time.March, Day: 1},
+		EndDate: domain.Date{Year: 2024, Month: time.March, Day: 20}})
+	require.NoError(t, err)
+
+	got, err := repo.GetBudget(ctx, 1, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.BudgetPeriodCustom, got.Period)
+	assert.Equal(t, domain.NewMoney(500000, kzt), got.Limit)
+	assert.Equal(t, domain.Date{Year: 2024, Month: time.March, Day: 20}, got.EndDate)
+
+	require.NoError(t, repo.DeleteBudget(ctx, 1, created.ID))
+	_, err = repo.GetBudget(ctx, 1, created.ID)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
+
+func Test_BudgetRepository_SumByCategory_IncludesSubcategories_WhenInPeriod(t *testing.T) {
+	defer truncateBudgets(t)
+
+	seedDefaultUser(t)
+	parent := testdb.CategoryParams{ID: 6, Name: "Groceries", Kind: "expense"}
+	require.NoError(t, parent.SeedCategory(testdb.DB))
+	categories := infrastructure.NewCategoryRepository(testdb.DB)
+	expenses := infrastructure.NewExpenseRepository(testdb.DB)
+	repo := infrastructure.NewBudgetRepository(testdb.DB)
+	ctx := context.Background()
+
+	child, err := categories.CreateCategory(ctx, &domain.Category{UserID: 1, Name: "Bakery",
+		Kind: domain.CategoryKindExpense, ParentID: 6})
+	require.NoError(t, err)
+
+	april := time.Date(2024, time.April, 10, 12, 0, 0, 0, time.UTC)
+	usd := domain.MustCurrency("USD")
+	for _, e := range []domain.Expense{
+		{UserID: 1, CategoryID: 6, Amount: domain.NewMoney(1000, kzt), OccurredAt: april},
+		{UserID: 1, CategoryID: child.ID, Amount: domain.NewMoney(500, kzt), OccurredAt: april},
+		{UserID: 1, CategoryID: child.ID, Amount: domain.NewMoney(700, usd), OccurredAt: april},
+		{UserID: 1, CategoryID: 6, Amount: domain.NewMoney(9000, kzt), OccurredAt: april.AddDate(0, 1, 0)},
+	} {
+		_, err := expenses.AddExpense(ctx, &e)
+		require.NoError(t, err)
+	}
+
+	group, err := categories.GetCategory(ctx, 1, 6)
+	require.NoError(t, err)
+	got, err := repo.SumByCategory(ctx, 1, group,
+		time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Money{domain.NewMoney(1500, kzt), domain.NewMoney(700, usd)}, got)
+}
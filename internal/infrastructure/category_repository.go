@@ -0,0 +1,167 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"fincraft-finance/internal/domain"
+)
+
+// categoryColumns колонки, из которых собирается domain.Category
+const categoryColumns = `id, COALESCE(user_id, 0), name, kind, icon, COALESCE(parent_id, 0), archived, created_at`
+
+// categoryKinds соответствие типов категорий значениям колонки kind
+var categoryKinds = map[domain.CategoryKind]string{
+	domain.CategoryKindIncome:  "income",
+	domain.CategoryKindExpense: "expense",
+}
+
+// CategoryRepository реализует методы для работы с категориями
+type CategoryRepository struct {
+	db *sql.DB
+}
+
+// NewCategoryRepository создает новый экземпляр CategoryRepository
+func NewCategoryRepository(db *sql.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+// CreateCategory добавляет категорию пользователя
+func (r *CategoryRepository) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	row := r.db.QueryRowContext(ctx, `
+		INSERT INTO categories (user_id, name, kind, icon, parent_id)
+		VALUES ($1, $2, $3, $4, NULLIF($5, 0))
+		RETURNING `+categoryColumns,
+		category.UserID, category.Name, categoryKinds[category.Kind], category.Icon, category.ParentID)
+
+	return scanCategory(row)
+}
+
+// GetCategory возвращает категорию пользователя или системную категорию
+func (r *CategoryRepository) GetCategory(ctx context.Context, userID int64, id int) (*domain.Category, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+categoryColumns+`
+		FROM categories
+		WHERE id = $1 AND (user_id = $2 OR user_id IS NULL)
+	`, id, userID)
+
+	category, err := scanCategory(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("category %d: %w", id, domain.ErrNotFound)
+	}
+
+	return category, err
+}
+
+// ListCategories возвращает системные категории и категории пользователя: сначала системные, затем по названию
+func (r *CategoryRepository) ListCategories(ctx context.Context, filter domain.CategoryFilter) ([]domain.Category, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+categoryColumns+`
+		FROM categories
+		WHERE (user_id = $1 OR user_id IS NULL)
+		  AND ($2 = '' OR kind = $2)
+		  AND ($3 OR NOT archived)
+		ORDER BY user_id NULLS FIRST, name, id
+	`, filter.UserID, categoryKinds[filter.Kind], filter.IncludeArchived)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var categories []domain.Category
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, *category)
+	}
+
+	return categories, rows.Err()
+}
+
+// UpdateCategory сохраняет название, иконку и признак архива категории пользователя
+func (r *CategoryRepository) UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	row := r.db.QueryRowContext(ctx, `
+		UPDATE categories
+		SET name = $3, icon = $4, archived = $5
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+categoryColumns,
+		category.ID, category.UserID, category.Name, category.Icon, category.Archived)
+
+	updated, err := scanCategory(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("category %d: %w", category.ID, domain.ErrNotFound)
+	}
+
+	return updated, err
+}
+
+// MergeCategories в одной транзакции переносит доходы, расходы, подкатегории, проводки журнала, бюджеты,
+// правила повторяющихся доходов, правила категоризации и исходные версии объединенных доходов
+// категории пользователя sourceID в targetID и удаляет sourceID
+func (r *CategoryRepository) MergeCategories(ctx context.Context, userID int64, sourceID, targetID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer tx.Rollback()
+
+	moves := []string{
+		`UPDATE incomes SET category_id = $2, updated_at = now() WHERE category_id = $1`,
+		`UPDATE expenses SET category_id = $2, updated_at = now() WHERE category_id = $1`,
+		`UPDATE categories SET parent_id = $2 WHERE parent_id = $1`,
+		`UPDATE journal_postings SET ref_id = $2 WHERE account_kind IN ('income', 'expense') AND ref_id = $1`,
+		`UPDATE budgets SET category_id = $2, updated_at = now() WHERE category_id = $1`,
+		`UPDATE recurring_rules SET category_id = $2, updated_at = now() WHERE category_id = $1`,
+		`UPDATE rules SET category_id = $2, updated_at = now() WHERE category_id = $1`,
+		`UPDATE income_sources SET category_id = $2 WHERE category_id = $1`,
+	}
+	for _, query := range moves {
+		if _, err := tx.ExecContext(ctx, query, sourceID, targetID); err != nil {
+			return err
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM categories WHERE id = $1 AND user_id = $2`, sourceID, userID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("category %d: %w", sourceID, domain.ErrNotFound)
+	}
+
+	return tx.Commit()
+}
+
+// scanCategory читает категорию из строки результата
+func scanCategory(row rowScanner) (*domain.Category, error) {
+	var (
+		c    domain.Category
+		kind string
+	)
+
+	err := row.Scan(&c.ID, &c.UserID, &c.Name, &kind, &c.Icon, &c.ParentID, &c.Archived, &c.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range categoryKinds {
+		if v == kind {
+			c.Kind = k
+		}
+	}
+	if c.Kind == 0 {
+		return nil, fmt.Errorf("unknown category kind %q", kind)
+	}
+
+	return &c, nil
+}
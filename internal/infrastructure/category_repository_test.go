@@ -0,0 +1,148 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/testdb"
+)
+
+func truncateCategories(t *testing.T) {
+	if err := testdb.TruncateTables(testdb.DB, testdb.UsersTable, testdb.CategoriesTable); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_CategoryRepository_GetCategory_ReturnsSystemAndOwnCategories_WhenVisible(t *testing.T) {
+	defer truncateCategories(t)
+
+	seedDefaultUser(t)
+	other := testdb.UserParams{ID: 2, Email: "other@test.com"}
+	require.NoError(t, other.SeedUser(testdb.DB))
+	foreign := testdb.CategoryParams{ID: 30, UserID: 2, Kind: "expense"}
+	require.NoError(t, foreign.SeedCategory(testdb.DB))
+
+	repo := infrastructure.NewCategoryRepository(testdb.DB)
+	ctx := context.Background()
+
+	system, err := repo.GetCategory(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.True(t, system.IsSystem())
+	assert.Equal(t, domain.CategoryKindIncome, system.Kind)
+
+	_, err = repo.GetCategory(ctx, 1, 30)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func Test_CategoryRepository_CreateCategory_ReturnsStoredCategory_WhenValidInput(t *testing.T) {
+	defer truncateCategories(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewCategoryRepository(testdb.DB)
+
+	created, err := repo.CreateCategory(context.Background(), &domain.Category{
+		UserID: 1, Name: "Bonus", Kind: domain.CategoryKindIncome, Icon: "star", ParentID: 2,
+	})
+
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	assert.Equal(t, int64(1), created.UserID)
+	assert.Equal(t, 2, created.ParentID)
+	assert.Equal(t, "star", created.Icon)
+	assert.WithinDuration(t, time.Now(), created.CreatedAt, time.Minute)
+}
+
+func Test_CategoryRepository_ListCategories_FiltersByKindAndArchived_WhenFilterSet(t *testing.T) {
+	defer truncateCategories(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewCategoryRepository(testdb.DB)
+	ctx := context.Background()
+
+	food, err := repo.CreateCategory(ctx, &domain.Category{UserID: 1, Name: "Food", Kind: domain.CategoryKindExpense})
+	require.NoError(t, err)
+	old, err := repo.CreateCategory(ctx, &domain.Category{UserID: 1, Name: "Old", Kind: domain.CategoryKindExpense})
+	require.NoError(t, err)
+	old.Archived = true
+	_, err = repo.UpdateCategory(ctx, old)
+	require.NoError(t, err)
+
+	active, err := repo.ListCategories(ctx, domain.CategoryFilter{UserID: 1, Kind: domain.CategoryKindExpense})
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, food.ID, active[0].ID)
+
+	all, err := repo.ListCategories(ctx, domain.CategoryFilter{UserID: 1, IncludeArchived: true})
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+}
+
+func Test_CategoryRepository_UpdateCategory_ReturnsNotFound_WhenCategorySystem(t *testing.T) {
+	defer truncateCategories(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewCategoryRepository(testdb.DB)
+
+	_, err := repo.UpdateCategory(context.Background(), &domain.Category{ID: 2, UserID: 1, Name: "Mine"})
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func Test_CategoryRepository_MergeCategories_MovesIncomesAndChildren_WhenSourceOwned(t *testing.T) {
+	defer truncateCategories(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewCategoryRepository(testdb.DB)
+	incomes := infrastructure.NewIncomeRepository(testdb.DB)
+	ctx := context.Background()
+
+	source, err := repo.CreateCategory(ctx, &domain.Category{UserID: 1, Name: "Side jobs", Kind: domain.CategoryKindIncome})
+	require.NoError(t, err)
+	child, err := repo.CreateCategory(ctx, &domain.Category{UserID: 1, Name: "Tutoring", Kind: domain.CategoryKindIncome,
+		ParentID: source.ID})
+	require.NoError(t, err)
+	income, err := incomes.AddIncome(ctx, &domain.Income{UserID: 1, CategoryID: source.ID,
+		Amount: domain.NewMoney(10000, kzt), OccurredAt: time.Now()})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.MergeCategories(ctx, 1, source.ID, 2))
+
+	moved, err := incomes.GetIncome(ctx, 1, income.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, moved.CategoryID)
+
+	reparented, err := repo.GetCategory(ctx, 1, child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reparented.ParentID)
+
+	_, err = repo.GetCategory(ctx, 1, source.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func Test_CategoryRepository_MergeCategories_MovesRules_WhenRuleReferencesSource(t *testing.T) {
+	defer truncateCategories(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewCategoryRepository(testdb.DB)
+	rules := infrastructure.NewRuleRepository(testdb.DB)
+	ctx := context.Background()
+
+	source, err := repo.CreateCategory(ctx, &domain.Category{UserID: 1, Name: "Side jobs", Kind: domain.CategoryKindIncome})
+	require.NoError(t, err)
+	rule, err := rules.CreateRule(ctx, &domain.Rule{UserID: 1, Name: "Freelance", Direction: domain.RuleDirectionIncome,
+		Conditions: domain.RuleConditions{DescriptionContains: "upwork"},
+		Actions:    domain.RuleActions{CategoryID: source.ID}})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.MergeCategories(ctx, 1, source.ID, 2))
+
+	moved, err := rules.GetRule(ctx, 1, rule.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, moved.Actions.CategoryID)
+}
@@ -0,0 +1,175 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+// expenseColumns колонки, из которых собирается domain.Expense
+const expenseColumns = `id, user_id, category_id, COALESCE(account_id, 0), amount, currency, description, occurred_at, created_at, updated_at`
+
+// ExpenseRepository реализует методы для работы с расходами
+type ExpenseRepository struct {
+	db *sql.DB
+}
+
+// NewExpenseRepository создает новый экземпляр ExpenseRepository
+func NewExpenseRepository(db *sql.DB) *ExpenseRepository {
+	return &ExpenseRepository{db: db}
+}
+
+// AddExpense добавляет новый расход и возвращает его с ID и временем создания
+func (r *ExpenseRepository) AddExpense(ctx context.Context, expense *domain.Expense) (*domain.Expense, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO expenses (user_id, category_id, amount, currency, description, occurred_at, account_id)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7::bigint, 0))
+		RETURNING `+expenseColumns,
+		expense.UserID, expense.CategoryID, expense.Amount.Decimal(), expense.Amount.Currency().Code,
+		expense.Description, expense.OccurredAt, expense.AccountID)
+
+	return scanExpense(row)
+}
+
+// GetExpense возвращает расход пользователя по ID
+func (r *ExpenseRepository) GetExpense(ctx context.Context, userID, id int64) (*domain.Expense, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+expenseColumns+`
+		FROM expenses
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+
+	expense, err := scanExpense(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("expense %d: %w", id, domain.ErrNotFound)
+	}
+
+	return expense, err
+}
+
+// ListExpenses возвращает расходы пользователя по убыванию ID
+func (r *ExpenseRepository) ListExpenses(ctx context.Context, filter domain.ExpenseFilter) ([]domain.Expense, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+expenseColumns+`
+		FROM expenses
+		WHERE user_id = $1
+		  AND ($2 = 0 OR category_id = $2)
+		  AND ($3 = 0 OR id < $3)
+		  AND ($5 = 0 OR account_id = $5)
+		  AND ($6::timestamptz IS NULL OR occurred_at >= $6)
+		  AND ($7::timestamptz IS NULL OR occurred_at < $7)
+		ORDER BY id DESC
+		LIMIT $4
+	`, filter.UserID, filter.CategoryID, filter.AfterID, filter.PageSize, filter.AccountID,
+		timeToDB(filter.From), timeToDB(filter.To))
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var expenses []domain.Expense
+	for rows.Next() {
+		expense, err := scanExpense(rows)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, *expense)
+	}
+
+	return expenses, rows.Err()
+}
+
+// UpdateExpense обновляет изменяемые поля расхода пользователя
+func (r *ExpenseRepository) UpdateExpense(ctx context.Context, expense *domain.Expense) (*domain.Expense, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		UPDATE expenses
+		SET category_id = $3, amount = $4, currency = $5, description = $6, occurred_at = $7,
+		    account_id = NULLIF($8::bigint, 0), updated_at = now()
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+expenseColumns,
+		expense.ID, expense.UserID, expense.CategoryID,
+		expense.Amount.Decimal(), expense.Amount.Currency().Code, expense.Description, expense.OccurredAt,
+		expense.AccountID)
+
+	updated, err := scanExpense(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("expense %d: %w", expense.ID, domain.ErrNotFound)
+	}
+
+	return updated, err
+}
+
+// DeleteExpense удаляет расход пользователя
+func (r *ExpenseRepository) DeleteExpense(ctx context.Context, userID, id int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM expenses WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("expense %d: %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// ListExpensesInRange возвращает все расходы пользователя с датой в полуинтервале [from, to)
+func (r *ExpenseRepository) ListExpensesInRange(ctx context.Context, userID int64, from, to time.Time) ([]domain.Expense, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+expenseColumns+`
+		FROM expenses
+		WHERE user_id = $1 AND occurred_at >= $2 AND occurred_at < $3
+		ORDER BY occurred_at, id
+	`, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var expenses []domain.Expense
+	for rows.Next() {
+		expense, err := scanExpense(rows)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, *expense)
+	}
+
+	return expenses, rows.Err()
+}
+
+// rowScanner общий интерфейс sql.Row и sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanExpense читает расход из строки результата
+func scanExpense(row rowScanner) (*domain.Expense, error) {
+	var (
+		e        domain.Expense
+		amount   string
+		currency string
+	)
+
+	err := row.Scan(&e.ID, &e.UserID, &e.CategoryID, &e.AccountID, &amount, &currency, &e.Description,
+		&e.OccurredAt, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.Amount, err = moneyFromDB(amount, currency); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
@@ -0,0 +1,161 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/testdb"
+)
+
+func truncateExpenses(t *testing.T) {
+	if err := testdb.TruncateTables(testdb.DB, testdb.UsersTable, testdb.ExpensesTable); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestExpense(description string) *domain.Expense {
+	return &domain.Expense{
+		UserID:      1,
+		CategoryID:  2,
+		Amount:      domain.NewMoney(10050, kzt),
+		Description: description,
+		OccurredAt:  time.Now(),
+	}
+}
+
+func Test_ExpenseRepository_AddExpense_ReturnsStoredExpense_WhenValidInput(t *testing.T) {
+	defer truncateExpenses(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewExpenseRepository(testdb.DB)
+
+	ctx := context.Background()
+	created, err := repo.AddExpense(ctx, newTestExpense("test expense"))
+	require.NoError(t, err)
+	assert.Positive(t, created.ID)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	got, err := repo.GetExpense(ctx, 1, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(10050, kzt), got.Amount)
+	assert.Equal(t, "test expense", got.Description)
+}
+
+func Test_ExpenseRepository_GetExpense_ReturnsNotFound_WhenOtherUser(t *testing.T) {
+	defer truncateExpenses(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewExpenseRepository(testdb.DB)
+
+	ctx := context.Background()
+	created, err := repo.AddExpense(ctx, newTestExpense("test expense"))
+	require.NoError(t, err)
+
+	_, err = repo.GetExpense(ctx, 2, created.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func Test_ExpenseRepository_ListExpenses_ReturnsNewestFirst_WhenCursorSet(t *testing.T) {
+	defer truncateExpenses(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewExpenseRepository(testdb.DB)
+
+	ctx := context.Background()
+	for _, d := range []string{"first", "second", "third"} {
+		_, err := repo.AddExpense(ctx, newTestExpense(d))
+		require.NoError(t, err)
+	}
+
+	list, err := repo.ListExpenses(ctx, domain.ExpenseFilter{UserID: 1, PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "third", list[0].Description)
+
+	list, err = repo.ListExpenses(ctx, domain.ExpenseFilter{UserID: 1, AfterID: list[1].ID, PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "first", list[0].Description)
+}
+
+func Test_ExpenseRepository_ListExpenses_ReturnsExpensesInRange_WhenRangeSet(t *testing.T) {
+	defer truncateExpenses(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewExpenseRepository(testdb.DB)
+
+	ctx := context.Background()
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	for i, d := range []string{"before", "inside", "after"} {
+		expense := newTestExpense(d)
+		expense.OccurredAt = from.AddDate(0, 0, []int{-1, 0, 10}[i])
+		_, err := repo.AddExpense(ctx, expense)
+		require.NoError(t, err)
+	}
+
+	list, err := repo.ListExpenses(ctx, domain.ExpenseFilter{UserID: 1, From: from, To: from.AddDate(0, 0, 10),
+		PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "inside", list[0].Description)
+}
+
+func Test_ExpenseRepository_UpdateExpense_ChangesFields_WhenExpenseExists(t *testing.T) {
+	defer truncateExpenses(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewExpenseRepository(testdb.DB)
+
+	ctx := context.Background()
+	created, err := repo.AddExpense(ctx, newTestExpense("typo"))
+	require.NoError(t, err)
+
+	created.Description = "fixed"
+	created.Amount = domain.NewMoney(500, domain.MustCurrency("USD"))
+	updated, err := repo.UpdateExpense(ctx, created)
+	require.NoError(t, err)
+	assert.Equal(t, "fixed", updated.Description)
+	assert.Equal(t, "USD", updated.Amount.Currency().Code)
+}
+
+func Test_ExpenseRepository_DeleteExpense_ReturnsNotFound_WhenAlreadyDeleted(t *testing.T) {
+	defer truncateExpenses(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewExpenseRepository(testdb.DB)
+
+	ctx := context.Background()
+	created, err := repo.AddExpense(ctx, newTestExpense("test expense"))
+	require.NoError(t, err)
+
+	require.NoError(t, repo.DeleteExpense(ctx, 1, created.ID))
+	assert.ErrorIs(t, repo.DeleteExpense(ctx, 1, created.ID), domain.ErrNotFound)
+}
+
+func Test_ExpenseRepository_ListExpensesInRange_ReturnsExpensesByDate_WhenInRange(t *testing.T) {
+	defer truncateExpenses(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewExpenseRepository(testdb.DB)
+
+	ctx := context.Background()
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	for i, d := range []string{"before", "late", "early", "after"} {
+		expense := newTestExpense(d)
+		expense.OccurredAt = from.AddDate(0, 0, []int{-1, 5, 0, 10}[i])
+		_, err := repo.AddExpense(ctx, expense)
+		require.NoError(t, err)
+	}
+
+	list, err := repo.ListExpensesInRange(ctx, 1, from, from.AddDate(0, 0, 10))
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "early", list[0].Description)
+	assert.Equal(t, "late", list[1].Description)
+}
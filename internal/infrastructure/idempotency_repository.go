@@ -0,0 +1,111 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+// IdempotencyRepository реализует хранилище запросов с ключами идемпотентности
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+// NewIdempotencyRepository создает новый экземпляр IdempotencyRepository
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Reserve атомарно занимает ключ: вставка и перезапись устаревшей записи выполняются одним запросом,
+// поэтому из параллельных запросов с одним ключом выполняться будет только один
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *domain.IdempotencyRecord,
+	staleBefore time.Time) (*domain.IdempotencyRecord, bool, error) {
+	// Запись могут удалить между вставкой и чтением, тогда ключ занимается повторно
+	for {
+		var reserved bool
+		err := conn(ctx, r.db).QueryRowContext(ctx, `
+			INSERT INTO idempotency_keys AS k (user_id, method, key, fingerprint, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (user_id, method, key) DO UPDATE
+			SET fingerprint = EXCLUDED.fingerprint, response_type = '', response = NULL, completed = false,
+			    created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+			WHERE k.expires_at <= EXCLUDED.created_at OR (NOT k.completed AND k.created_at < $7)
+			RETURNING true
+		`, record.Key.UserID, record.Key.Method, record.Key.Key, record.Fingerprint, record.CreatedAt,
+			record.ExpiresAt, staleBefore).Scan(&reserved)
+		if err == nil {
+			return nil, true, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, false, err
+		}
+
+		existing, err := r.get(ctx, record.Key)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		return existing, false, err
+	}
+}
+
+// Complete сохраняет результат запроса и отмечает запись завершенной.
+// Если записи уже нет, возвращается ошибка: иначе повтор запроса выполнился бы заново.
+func (r *IdempotencyRepository) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE idempotency_keys
+		SET response_type = $4, response = $5, completed = true
+		WHERE user_id = $1 AND method = $2 AND key = $3
+	`, record.Key.UserID, record.Key.Method, record.Key.Key, record.Response.Type, record.Response.Body)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("idempotency key %q of %s is no longer reserved", record.Key.Key, record.Key.Method)
+	}
+	return nil
+}
+
+// Release удаляет незавершенную запись
+func (r *IdempotencyRepository) Release(ctx context.Context, key domain.IdempotencyKey) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE user_id = $1 AND method = $2 AND key = $3 AND NOT completed
+	`, key.UserID, key.Method, key.Key)
+	return err
+}
+
+// DeleteExpired удаляет записи, истекшие к моменту now
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// get возвращает запись по ключу
+func (r *IdempotencyRepository) get(ctx context.Context, key domain.IdempotencyKey) (*domain.IdempotencyRecord, error) {
+	record := domain.IdempotencyRecord{Key: key}
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT fingerprint, response_type, COALESCE(response, ''::bytea), completed, created_at, expires_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND method = $2 AND key = $3
+	`, key.UserID, key.Method, key.Key).Scan(&record.Fingerprint, &record.Response.Type, &record.Response.Body,
+		&record.Completed, &record.CreatedAt, &record.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
@@ -0,0 +1,54 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/testdb"
+)
+
+func Test_IdempotencyRepository_Reserve_ReturnsExistingRecord_WhenKeyTaken(t *testing.T) {
+	defer func() {
+		if err := testdb.TruncateTables(testdb.DB, testdb.UsersTable, testdb.IdempotencyTable); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewIdempotencyRepository(testdb.DB)
+	now := time.Now().UTC().Truncate(time.Second)
+	key := domain.IdempotencyKey{UserID: 1, Method: "/finance.FinanceService/AddIncome", Key: "c0ffee"}
+	record := &domain.IdempotencyRecord{Key: key, Fingerprint: []byte{1, 2, 3}, CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+
+	_, reserved, err := repo.Reserve(context.Background(), record, now.Add(-time.Minute))
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	record.Response = domain.IdempotentResponse{Type: "finance.Income", Body: []byte{4, 5}}
+	record.Completed = true
+	require.NoError(t, repo.Complete(context.Background(), record))
+
+	again := &domain.IdempotencyRecord{Key: key, Fingerprint: []byte{9}, CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+	existing, reserved, err := repo.Reserve(context.Background(), again, now.Add(-time.Minute))
+
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.Equal(t, []byte{1, 2, 3}, existing.Fingerprint)
+	assert.True(t, existing.Completed)
+	assert.Equal(t, record.Response, existing.Response)
+}
+
+func Test_IdempotencyRepository_Complete_ReturnsError_WhenKeyNotReserved(t *testing.T) {
+	repo := infrastructure.NewIdempotencyRepository(testdb.DB)
+	key := domain.IdempotencyKey{UserID: 1, Method: "/finance.FinanceService/AddIncome", Key: "released"}
+
+	err := repo.Complete(context.Background(), &domain.IdempotencyRecord{Key: key, Completed: true})
+
+	assert.EqualError(t, err, `idempotency key "released" of /finance.FinanceService/AddIncome is no longer reserved`)
+}
@@ -1,10 +1,33 @@
 package infrastructure
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"fincraft-finance/internal/domain"
 )
 
+// incomeColumns колонки, из которых собирается domain.Income
+const incomeColumns = `id, user_id, category_id, COALESCE(account_id, 0), amount, currency, description, occurred_at,
+	created_at, updated_at`
+
+// incomeSortColumns колонки сортировки и тип значения курсора для каждого поля
+var incomeSortColumns = map[domain.IncomeSortField][2]string{
+	domain.IncomeSortByDate:   {"occurred_at", "timestamptz"},
+	domain.IncomeSortByAmount: {"amount", "numeric"},
+}
+
+// incomeInsertChunk число строк в одном INSERT, чтобы не превысить лимит параметров запроса
+const incomeInsertChunk = 1000
+
 // IncomeRepository реализует методы для работы с доходами
 type IncomeRepository struct {
 	db *sql.DB
@@ -15,11 +38,448 @@ func NewIncomeRepository(db *sql.DB) *IncomeRepository {
 	return &IncomeRepository{db: db}
 }
 
-// AddIncome добавляет новый доход в базу данных
-func (r *IncomeRepository) AddIncome(ctx context.Context, userID int64, categoryID int, amount float64, description string) error {
-	_, err := r.db.ExecContext(ctx, `
-		SELECT * FROM add_income($1, $2, $3, $4)
-	`, userID, categoryID, amount, description)
+// AddIncome добавляет новый доход в базу данных и возвращает сохраненную запись
+func (r *IncomeRepository) AddIncome(ctx context.Context, income *domain.Income) (*domain.Income, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+incomeColumns+` FROM add_income($1, $2, $3, $4, $5, $6, NULLIF($7::bigint, 0))
+	`, income.UserID, income.CategoryID, income.Amount.Decimal(), income.Amount.Currency().Code,
+		income.Description, income.OccurredAt, income.AccountID)
+
+	created, err := scanIncome(row)
+	if err != nil {
+		return nil, err
+	}
+	created.Tags = income.Tags
+
+	incomes := []domain.Income{*created}
+	if err := r.saveTags(ctx, incomes, false); err != nil {
+		return nil, err
+	}
+	return &incomes[0], nil
+}
+
+// AddIncomes добавляет доходы многострочными вставками и возвращает сохраненные записи в исходном порядке.
+// Атомарность всего набора обеспечивает транзакция вызывающего use-case.
+func (r *IncomeRepository) AddIncomes(ctx context.Context, incomes []domain.Income) ([]domain.Income, error) {
+	created := make([]domain.Income, 0, len(incomes))
+	for start := 0; start < len(incomes); start += incomeInsertChunk {
+		chunk, err := r.insertIncomes(ctx, incomes[start:min(start+incomeInsertChunk, len(incomes))])
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, chunk...)
+	}
+
+	for i := range created {
+		created[i].Tags = incomes[i].Tags
+	}
+	if err := r.saveTags(ctx, created, false); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// insertIncomes сохраняет доходы одним INSERT
+func (r *IncomeRepository) insertIncomes(ctx context.Context, incomes []domain.Income) ([]domain.Income, error) {
+	const columns = 7
+	values := make([]string, 0, len(incomes))
+	args := make([]any, 0, len(incomes)*columns)
+	for i, income := range incomes {
+		n := i * columns
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, NULLIF($%d::bigint, 0))",
+			n+1, n+2, n+3, n+4, n+5, n+6, n+7))
+		args = append(args, income.UserID, income.CategoryID, income.Amount.Decimal(), income.Amount.Currency().Code,
+			income.Description, income.OccurredAt, income.AccountID)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		INSERT INTO incomes (user_id, category_id, amount, currency, description, occurred_at, account_id)
+		VALUES `+strings.Join(values, ", ")+`
+		RETURNING `+incomeColumns, args...)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	created := make([]domain.Income, 0, len(incomes))
+	for rows.Next() {
+		income, err := scanIncome(rows)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, *income)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Идентификаторы выдаются в порядке VALUES, а порядок строк RETURNING не гарантирован
+	slices.SortFunc(created, func(a, b domain.Income) int { return cmp.Compare(a.ID, b.ID) })
+	return created, nil
+}
+
+// GetIncome возвращает доход пользователя по ID
+func (r *IncomeRepository) GetIncome(ctx context.Context, userID, id int64) (*domain.Income, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+incomeColumns+`
+		FROM incomes
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+
+	income, err := scanIncome(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("income %d: %w", id, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	incomes := []domain.Income{*income}
+	if err := r.loadTags(ctx, incomes); err != nil {
+		return nil, err
+	}
+	return &incomes[0], nil
+}
+
+// ListIncomes возвращает доходы пользователя по фильтру с keyset-пагинацией
+func (r *IncomeRepository) ListIncomes(ctx context.Context, filter domain.IncomeFilter) ([]domain.Income, error) {
+	query, args := buildIncomeListQuery(filter)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	return r.scanIncomes(ctx, rows)
+}
+
+// UpdateIncome обновляет изменяемые поля дохода пользователя
+func (r *IncomeRepository) UpdateIncome(ctx context.Context, income *domain.Income) (*domain.Income, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		UPDATE incomes
+		SET category_id = $3, amount = $4, currency = $5, description = $6, occurred_at = $7,
+		    account_id = NULLIF($8::bigint, 0), updated_at = now()
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+incomeColumns,
+		income.ID, income.UserID, income.CategoryID,
+		income.Amount.Decimal(), income.Amount.Currency().Code, income.Description, income.OccurredAt, income.AccountID)
+
+	updated, err := scanIncome(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("income %d: %w", income.ID, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	updated.Tags = income.Tags
+
+	incomes := []domain.Income{*updated}
+	if err := r.saveTags(ctx, incomes, true); err != nil {
+		return nil, err
+	}
+	return &incomes[0], nil
+}
+
+// scanIncomes читает доходы из результата запроса вместе с их тегами
+func (r *IncomeRepository) scanIncomes(ctx context.Context, rows *sql.Rows) ([]domain.Income, error) {
+	var incomes []domain.Income
+	for rows.Next() {
+		income, err := scanIncome(rows)
+		if err != nil {
+			return nil, err
+		}
+		incomes = append(incomes, *income)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// Теги читаются отдельным запросом, поэтому текущий результат закрывается заранее
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadTags(ctx, incomes); err != nil {
+		return nil, err
+	}
+	return incomes, nil
+}
+
+// saveTags привязывает к доходам их теги, создавая недостающие теги пользователя.
+// replace сначала снимает прежние теги доходов. Теги доходов заменяются сохраненными названиями.
+func (r *IncomeRepository) saveTags(ctx context.Context, incomes []domain.Income, replace bool) error {
+	db := conn(ctx, r.db)
+	var (
+		ids, incomeIDs, userIDs []int64
+		names                   []string
+	)
+	for _, income := range incomes {
+		ids = append(ids, income.ID)
+		for _, tag := range income.Tags {
+			incomeIDs, userIDs, names = append(incomeIDs, income.ID), append(userIDs, income.UserID), append(names, tag)
+		}
+	}
+
+	if replace {
+		if _, err := db.ExecContext(ctx, `DELETE FROM income_tags WHERE income_id = ANY($1)`, pq.Array(ids)); err != nil {
+			return err
+		}
+	}
+	if len(names) == 0 {
+		for i := range incomes {
+			incomes[i].Tags = nil
+		}
+		return nil
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO tags (user_id, name)
+		SELECT user_id, name FROM unnest($1::bigint[], $2::text[]) AS t(user_id, name)
+		ON CONFLICT (user_id, lower(name)) DO NOTHING
+	`, pq.Array(userIDs), pq.Array(names))
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO income_tags (income_id, tag_id)
+		SELECT x.income_id, t.id
+		FROM unnest($1::bigint[], $2::bigint[], $3::text[]) AS x(income_id, user_id, name)
+		JOIN tags t ON t.user_id = x.user_id AND lower(t.name) = lower(x.name)
+		ON CONFLICT DO NOTHING
+	`, pq.Array(incomeIDs), pq.Array(userIDs), pq.Array(names))
+	if err != nil {
+		return err
+	}
+
+	return r.loadTags(ctx, incomes)
+}
+
+// loadTags заполняет теги доходов в алфавитном порядке без учета регистра
+func (r *IncomeRepository) loadTags(ctx context.Context, incomes []domain.Income) error {
+	if len(incomes) == 0 {
+		return nil
+	}
+
+	positions := make(map[int64]int, len(incomes))
+	ids := make([]int64, 0, len(incomes))
+	for i := range incomes {
+		incomes[i].Tags = nil
+		positions[incomes[i].ID] = i
+		ids = append(ids, incomes[i].ID)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT it.income_id, t.name
+		FROM income_tags it
+		JOIN tags t ON t.id = it.tag_id
+		WHERE it.income_id = ANY($1)
+		ORDER BY lower(t.name)
+	`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			incomeID int64
+			name     string
+		)
+		if err := rows.Scan(&incomeID, &name); err != nil {
+			return err
+		}
+		i := positions[incomeID]
+		incomes[i].Tags = append(incomes[i].Tags, name)
+	}
+
+	return rows.Err()
+}
+
+// DeleteIncome удаляет доход пользователя
+func (r *IncomeRepository) DeleteIncome(ctx context.Context, userID, id int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM incomes WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("income %d: %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// ListIncomesInRange возвращает все доходы пользователя с датой в полуинтервале [from, to)
+func (r *IncomeRepository) ListIncomesInRange(ctx context.Context, userID int64, from, to time.Time) ([]domain.Income, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+incomeColumns+`
+		FROM incomes
+		WHERE user_id = $1 AND occurred_at >= $2 AND occurred_at < $3
+		ORDER BY occurred_at, id
+	`, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	return r.scanIncomes(ctx, rows)
+}
+
+// MergeIncome сохраняет доход merged как источник дохода keptID и удаляет его.
+// Источники merged и отметки повторяющихся правил о нем переносятся на keptID.
+func (r *IncomeRepository) MergeIncome(ctx context.Context, keptID int64, merged *domain.Income) error {
+	db := conn(ctx, r.db)
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO income_sources (income_id, user_id, source_id, category_id, account_id, amount, currency,
+		                            description, occurred_at, created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5::bigint, 0), $6, $7, $8, $9, $10)
+	`, keptID, merged.UserID, merged.ID, merged.CategoryID, merged.AccountID, merged.Amount.Decimal(),
+		merged.Amount.Currency().Code, merged.Description, merged.OccurredAt, merged.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.ExecContext(ctx, `UPDATE income_sources SET income_id = $1 WHERE income_id = $2`,
+		keptID, merged.ID); err != nil {
+		return err
+	}
+	if _, err := db.ExecContext(ctx, `UPDATE recurring_occurrences SET income_id = $1 WHERE income_id = $2`,
+		keptID, merged.ID); err != nil {
+		return err
+	}
+
+	return r.DeleteIncome(ctx, merged.UserID, merged.ID)
+}
+
+// ListIncomeSources возвращает источники дохода пользователя в порядке объединения
+func (r *IncomeRepository) ListIncomeSources(ctx context.Context, userID, incomeID int64) ([]domain.IncomeSource, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT income_id, source_id, user_id, category_id, COALESCE(account_id, 0), amount, currency, description,
+		       occurred_at, created_at, merged_at
+		FROM income_sources
+		WHERE user_id = $1 AND income_id = $2
+		ORDER BY merged_at, id
+	`, userID, incomeID)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var sources []domain.IncomeSource
+	for rows.Next() {
+		var (
+			s                domain.IncomeSource
+			amount, currency string
+		)
+		err := rows.Scan(&s.IncomeID, &s.Income.ID, &s.Income.UserID, &s.Income.CategoryID, &s.Income.AccountID,
+			&amount, &currency, &s.Income.Description, &s.Income.OccurredAt, &s.Income.CreatedAt, &s.MergedAt)
+		if err != nil {
+			return nil, err
+		}
+		if s.Income.Amount, err = moneyFromDB(amount, currency); err != nil {
+			return nil, err
+		}
+		sources = append(sources, s)
+	}
+
+	return sources, rows.Err()
+}
+
+// buildIncomeListQuery собирает запрос списка доходов по фильтру
+func buildIncomeListQuery(filter domain.IncomeFilter) (string, []any) {
+	var (
+		where = []string{"user_id = $1"}
+		args  = []any{filter.UserID}
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.CategoryID > 0 {
+		where = append(where, "category_id = "+arg(filter.CategoryID))
+	}
+	if filter.AccountID > 0 {
+		where = append(where, "account_id = "+arg(filter.AccountID))
+	}
+	if !filter.From.IsZero() {
+		where = append(where, "occurred_at >= "+arg(filter.From))
+	}
+	if !filter.To.IsZero() {
+		where = append(where, "occurred_at < "+arg(filter.To))
+	}
+	if filter.MinAmount != nil {
+		where = append(where, "currency = "+arg(filter.MinAmount.Currency().Code))
+		where = append(where, "amount >= "+arg(filter.MinAmount.Decimal())+"::numeric")
+	}
+	if filter.MaxAmount != nil {
+		where = append(where, "currency = "+arg(filter.MaxAmount.Currency().Code))
+		where = append(where, "amount <= "+arg(filter.MaxAmount.Decimal())+"::numeric")
+	}
+	if len(filter.Tags) > 0 {
+		where = append(where, incomeTagsCondition(arg(pq.Array(filter.Tags)), arg(len(filter.Tags))))
+	}
+
+	sortColumn := incomeSortColumns[filter.SortBy]
+	direction, cmp := "DESC", "<"
+	if filter.Asc {
+		direction, cmp = "ASC", ">"
+	}
+
+	if filter.After != nil {
+		where = append(where, fmt.Sprintf("(%s, id) %s (%s::%s, %s)",
+			sortColumn[0], cmp, arg(filter.After.Value), sortColumn[1], arg(filter.After.ID)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM incomes
+		WHERE %s
+		ORDER BY %s %s, id %s
+		LIMIT %s`,
+		incomeColumns, strings.Join(where, " AND "), sortColumn[0], direction, direction, arg(filter.PageSize))
+
+	return query, args
+}
+
+// incomeTagsCondition возвращает условие на доходы пользователя $1, у которых есть все теги из массива tagsArg;
+// countArg - число тегов без повторов
+func incomeTagsCondition(tagsArg, countArg string) string {
+	return fmt.Sprintf(`id IN (
+		SELECT it.income_id
+		FROM income_tags it
+		JOIN tags t ON t.id = it.tag_id
+		WHERE t.user_id = $1 AND lower(t.name) IN (SELECT lower(unnest(%s::text[])))
+		GROUP BY it.income_id
+		HAVING count(*) = %s)`, tagsArg, countArg)
+}
+
+// scanIncome читает доход из строки результата
+func scanIncome(row rowScanner) (*domain.Income, error) {
+	var (
+		i        domain.Income
+		amount   string
+		currency string
+	)
+
+	err := row.Scan(&i.ID, &i.UserID, &i.CategoryID, &i.AccountID, &amount, &currency, &i.Description,
+		&i.OccurredAt, &i.CreatedAt, &i.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.Amount, err = moneyFromDB(amount, currency); err != nil {
+		return nil, err
+	}
 
-	return err
+	return &i, nil
 }
@@ -4,14 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"fincraft-finance/internal/domain"
 	"fincraft-finance/internal/infrastructure"
 	"fincraft-finance/internal/testdb"
 )
 
+var kzt = domain.MustCurrency("KZT")
+
 func TestMain(m *testing.M) {
 	if err := testdb.SetupTestDB(); err != nil {
 		panic(err)
@@ -21,10 +25,15 @@ func TestMain(m *testing.M) {
 	m.Run()
 }
 
+// seedDefaultUser добавляет пользователя 1 и категорию 2, на которую ссылаются тестовые операции
 func seedDefaultUser(t *testing.T) {
 	user := testdb.UserParams{}
 	err := user.SeedUser(testdb.DB)
 	require.NoError(t, err)
+
+	category := testdb.CategoryParams{}
+	err = category.SeedCategory(testdb.DB)
+	require.NoError(t, err)
 }
 
 func Test_IncomeRepository_AddIncome_ReturnsNoError_WhenValidInput(t *testing.T) {
@@ -38,10 +47,47 @@ func Test_IncomeRepository_AddIncome_ReturnsNoError_WhenValidInput(t *testing.T)
 	repo := infrastructure.NewIncomeRepository(testdb.DB)
 
 	ctx := context.Background()
-	err := repo.AddIncome(ctx, 1, 2, 100.50, "test income")
+	_, err := repo.AddIncome(ctx, &domain.Income{UserID: 1, CategoryID: 2, Amount: domain.NewMoney(10050, kzt), Description: "test income"})
 	assert.NoError(t, err)
 }
 
+func truncateIncomes(t *testing.T) {
+	if err := testdb.TruncateTables(testdb.DB, testdb.UsersTable, testdb.IncomesTable); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func addTestIncome(t *testing.T, repo *infrastructure.IncomeRepository, amount int64, description string) *domain.Income {
+	return addTestIncomeAt(t, repo, amount, description, time.Now())
+}
+
+func addTestIncomeAt(t *testing.T, repo *infrastructure.IncomeRepository, amount int64, description string,
+	occurredAt time.Time) *domain.Income {
+	income, err := repo.AddIncome(context.Background(), &domain.Income{
+		UserID:      1,
+		CategoryID:  2,
+		Amount:      domain.NewMoney(amount, kzt),
+		Description: description,
+		OccurredAt:  occurredAt,
+	})
+	require.NoError(t, err)
+	return income
+}
+
+func Test_IncomeRepository_AddIncome_ReturnsIDAndTimestamps_WhenValidInput(t *testing.T) {
+	defer truncateIncomes(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewIncomeRepository(testdb.DB)
+
+	income := addTestIncome(t, repo, 10050, "test income")
+
+	assert.Positive(t, income.ID)
+	assert.False(t, income.CreatedAt.IsZero())
+	assert.Equal(t, income.CreatedAt, income.UpdatedAt)
+	assert.Equal(t, domain.NewMoney(10050, kzt), income.Amount)
+}
+
 func Test_IncomeRepository_AddIncome_ReturnsError_WhenUserInvalid(t *testing.T) {
 	defer func() {
 		if err := testdb.TruncateTables(testdb.DB, testdb.UsersTable, testdb.IncomesTable); err != nil {
@@ -52,7 +98,7 @@ func Test_IncomeRepository_AddIncome_ReturnsError_WhenUserInvalid(t *testing.T)
 	repo := infrastructure.NewIncomeRepository(testdb.DB)
 
 	ctx := context.Background()
-	err := repo.AddIncome(ctx, 999, 2, 100.50, "Invalid user")
+	_, err := repo.AddIncome(ctx, &domain.Income{UserID: 999, CategoryID: 2, Amount: domain.NewMoney(10050, kzt), Description: "Invalid user"})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "violates foreign key constraint")
 }
@@ -68,7 +114,7 @@ func Test_IncomeRepository_AddIncome_ReturnsError_WhenInvalidAmount(t *testing.T
 	repo := infrastructure.NewIncomeRepository(testdb.DB)
 
 	ctx := context.Background()
-	err := repo.AddIncome(ctx, 1, 2, -100.50, "Negative amount")
+	_, err := repo.AddIncome(ctx, &domain.Income{UserID: 1, CategoryID: 2, Amount: domain.NewMoney(-10050, kzt), Description: "Negative amount"})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "violates check constraint")
 }
@@ -85,9 +131,181 @@ func Test_IncomeRepository_AddIncome_ReturnsError_WhenConnectionInvalid(t *testi
 	repo := infrastructure.NewIncomeRepository(invalidDB)
 
 	ctx := context.Background()
-	err = repo.AddIncome(ctx, 1, 2, 100.50, "Test income")
+	_, err = repo.AddIncome(ctx, &domain.Income{UserID: 1, CategoryID: 2, Amount: domain.NewMoney(10050, kzt), Description: "Test income"})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(),
 		"dial tcp [::1]:5434: connectex: "+
 			"No connection could be made because the target machine actively refused it.")
 }
+
+func Test_IncomeRepository_GetIncome_ReturnsNotFound_WhenOtherUser(t *testing.T) {
+	defer truncateIncomes(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewIncomeRepository(testdb.DB)
+	income := addTestIncome(t, repo, 10050, "test income")
+
+	_, err := repo.GetIncome(context.Background(), 2, income.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func Test_IncomeRepository_ListIncomes_PaginatesByAmount_WhenSortedAscending(t *testing.T) {
+	defer truncateIncomes(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewIncomeRepository(testdb.DB)
+	addTestIncome(t, repo, 300, "c")
+	addTestIncome(t, repo, 100, "a")
+	addTestIncome(t, repo, 200, "b")
+	addTestIncome(t, repo, 200, "b2")
+
+	ctx := context.Background()
+	filter := domain.IncomeFilter{UserID: 1, SortBy: domain.IncomeSortByAmount, Asc: true, PageSize: 2}
+
+	first, err := repo.ListIncomes(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	assert.Equal(t, "a", first[0].Description)
+	assert.Equal(t, "b", first[1].Description)
+
+	cursor := first[1].Cursor(filter.SortBy)
+	filter.After = &cursor
+	second, err := repo.ListIncomes(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, second, 2)
+	assert.Equal(t, "b2", second[0].Description)
+	assert.Equal(t, "c", second[1].Description)
+}
+
+func Test_IncomeRepository_ListIncomes_FiltersByAmountRange_WhenRangeSet(t *testing.T) {
+	defer truncateIncomes(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewIncomeRepository(testdb.DB)
+	addTestIncome(t, repo, 100, "a")
+	addTestIncome(t, repo, 200, "b")
+	addTestIncome(t, repo, 300, "c")
+
+	minAmount := domain.NewMoney(150, kzt)
+	maxAmount := domain.NewMoney(300, kzt)
+	list, err := repo.ListIncomes(context.Background(), domain.IncomeFilter{
+		UserID: 1, MinAmount: &minAmount, MaxAmount: &maxAmount, PageSize: 10,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "c", list[0].Description)
+}
+
+func Test_IncomeRepository_ListIncomes_FiltersByOccurredAt_WhenRangeSet(t *testing.T) {
+	defer truncateIncomes(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewIncomeRepository(testdb.DB)
+	march := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	addTestIncomeAt(t, repo, 100, "february", march.Add(-time.Second))
+	addTestIncomeAt(t, repo, 200, "march", march)
+	addTestIncomeAt(t, repo, 300, "april", march.AddDate(0, 1, 0))
+
+	list, err := repo.ListIncomes(context.Background(), domain.IncomeFilter{
+		UserID: 1, From: march, To: march.AddDate(0, 1, 0), PageSize: 10,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "march", list[0].Description)
+	assert.True(t, list[0].OccurredAt.Equal(march))
+}
+
+func Test_IncomeRepository_UpdateIncome_ChangesDescription_WhenIncomeExists(t *testing.T) {
+	defer truncateIncomes(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewIncomeRepository(testdb.DB)
+	income := addTestIncome(t, repo, 10050, "tpyo")
+
+	income.Description = "typo"
+	updated, err := repo.UpdateIncome(context.Background(), income)
+
+	require.NoError(t, err)
+	assert.Equal(t, "typo", updated.Description)
+	assert.True(t, updated.UpdatedAt.After(updated.CreatedAt) || updated.UpdatedAt.Equal(updated.CreatedAt))
+}
+
+func Test_IncomeRepository_DeleteIncome_ReturnsNotFound_WhenAlreadyDeleted(t *testing.T) {
+	defer truncateIncomes(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewIncomeRepository(testdb.DB)
+	income := addTestIncome(t, repo, 10050, "test income")
+
+	ctx := context.Background()
+	require.NoError(t, repo.DeleteIncome(ctx, 1, income.ID))
+	assert.ErrorIs(t, repo.DeleteIncome(ctx, 1, income.ID), domain.ErrNotFound)
+}
+
+func Test_IncomeRepository_AddIncomes_ReturnsIncomesInInputOrder_WhenSeveralChunks(t *testing.T) {
+	defer truncateIncomes(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewIncomeRepository(testdb.DB)
+	incomes := make([]domain.Income, 1500)
+	for i := range incomes {
+		incomes[i] = domain.Income{UserID: 1, CategoryID: 2, Amount: domain.NewMoney(int64(i+1), kzt),
+			Description: "batch", OccurredAt: time.Now()}
+	}
+
+	created, err := repo.AddIncomes(context.Background(), incomes)
+
+	require.NoError(t, err)
+	require.Len(t, created, len(incomes))
+	for i := range created {
+		assert.Equal(t, incomes[i].Amount, created[i].Amount)
+		assert.NotZero(t, created[i].ID)
+	}
+}
+
+func Test_IncomeRepository_ListIncomesInRange_ReturnsIncomesInRange_WhenStored(t *testing.T) {
+	defer truncateIncomes(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewIncomeRepository(testdb.DB)
+	at := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+	addTestIncomeAt(t, repo, 100, "before", at.Add(-time.Second))
+	inside := addTestIncomeAt(t, repo, 200, "inside", at)
+	addTestIncomeAt(t, repo, 300, "after", at.Add(24*time.Hour))
+
+	incomes, err := repo.ListIncomesInRange(context.Background(), 1, at, at.Add(24*time.Hour))
+
+	require.NoError(t, err)
+	require.Len(t, incomes, 1)
+	assert.Equal(t, inside.ID, incomes[0].ID)
+}
+
+func Test_IncomeRepository_MergeIncome_KeepsSource_WhenMerged(t *testing.T) {
+	defer func() {
+		if err := testdb.TruncateTables(testdb.DB, testdb.UsersTable, testdb.IncomesTable, testdb.IncomeSourcesTable); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewIncomeRepository(testdb.DB)
+	ctx := context.Background()
+	kept := addTestIncome(t, repo, 10000, "Salary")
+	first := addTestIncome(t, repo, 10000, "ACME salary")
+	second := addTestIncome(t, repo, 10000, "Salary again")
+	require.NoError(t, repo.MergeIncome(ctx, second.ID, first))
+
+	err := repo.MergeIncome(ctx, kept.ID, second)
+
+	require.NoError(t, err)
+	_, err = repo.GetIncome(ctx, 1, second.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	sources, err := repo.ListIncomeSources(ctx, 1, kept.ID)
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+	assert.Equal(t, first.ID, sources[0].Income.ID)
+	assert.Equal(t, "ACME salary", sources[0].Income.Description)
+	assert.Equal(t, second.ID, sources[1].Income.ID)
+}
@@ -0,0 +1,127 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"fincraft-finance/internal/domain"
+)
+
+// ledgerAccountKinds соответствие видов счетов главной книги значениям колонки account_kind
+var ledgerAccountKinds = map[domain.LedgerAccountKind]string{
+	domain.LedgerAccountAsset:      "asset",
+	domain.LedgerAccountUnassigned: "unassigned",
+	domain.LedgerAccountIncome:     "income",
+	domain.LedgerAccountExpense:    "expense",
+	domain.LedgerAccountExchange:   "exchange",
+}
+
+// journalSourceKinds соответствие видов операций значениям колонки source_kind
+var journalSourceKinds = map[domain.JournalSourceKind]string{
+	domain.JournalSourceIncome:   "income",
+	domain.JournalSourceExpense:  "expense",
+	domain.JournalSourceTransfer: "transfer",
+}
+
+// dailyBalancesQuery пересчитывает дневные обороты счетов пользователя $1 по всему журналу
+const dailyBalancesQuery = `
+	INSERT INTO daily_balances (user_id, account_id, day, currency, inflow, outflow, transfers)
+	SELECT e.user_id,
+	       p.ref_id,
+	       (e.occurred_at AT TIME ZONE u.timezone)::date,
+	       p.currency,
+	       COALESCE(SUM(p.amount) FILTER (WHERE e.source_kind = 'income'), 0),
+	       COALESCE(-SUM(p.amount) FILTER (WHERE e.source_kind = 'expense'), 0),
+	       COALESCE(SUM(p.amount) FILTER (WHERE e.source_kind = 'transfer'), 0)
+	FROM journal_postings p
+	JOIN journal_entries e ON e.id = p.entry_id
+	JOIN users u ON u.id = e.user_id
+	WHERE e.user_id = $1 AND p.account_kind IN ('asset', 'unassigned')
+	GROUP BY 1, 2, 3, 4`
+
+// LedgerRepository реализует журнал двойной записи.
+// Баланс каждой записи по валютам дополнительно проверяется отложенным триггером при фиксации транзакции.
+// Вместе с проводками по счетам пользователя обновляются их дневные обороты для отчетов.
+type LedgerRepository struct {
+	db *sql.DB
+}
+
+// NewLedgerRepository создает новый экземпляр LedgerRepository
+func NewLedgerRepository(db *sql.DB) *LedgerRepository {
+	return &LedgerRepository{db: db}
+}
+
+// PostEntry добавляет запись журнала вместе с проводками и возвращает ее с ID и временем создания
+func (r *LedgerRepository) PostEntry(ctx context.Context, entry *domain.JournalEntry) (*domain.JournalEntry, error) {
+	q := conn(ctx, r.db)
+
+	posted := *entry
+	err := q.QueryRowContext(ctx, `
+		INSERT INTO journal_entries (user_id, source_kind, source_id, occurred_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, entry.UserID, journalSourceKinds[entry.SourceKind], entry.SourceID, entry.OccurredAt).
+		Scan(&posted.ID, &posted.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range entry.Postings {
+		_, err := q.ExecContext(ctx, `
+			INSERT INTO journal_postings (entry_id, account_kind, ref_id, amount, currency)
+			VALUES ($1, $2, $3, $4, $5)
+		`, posted.ID, ledgerAccountKinds[p.Account.Kind], p.Account.ID, p.Amount.Decimal(), p.Amount.Currency().Code)
+		if err != nil {
+			return nil, err
+		}
+		if err := addDailyBalance(ctx, q, entry, p); err != nil {
+			return nil, err
+		}
+	}
+
+	return &posted, nil
+}
+
+// addDailyBalance добавляет проводку по счету пользователя к обороту дня операции в его часовом поясе.
+// Проводки по доходам, расходам и счету обмена оборотов счетов не меняют.
+func addDailyBalance(ctx context.Context, q querier, entry *domain.JournalEntry, p domain.Posting) error {
+	if p.Account.Kind != domain.LedgerAccountAsset && p.Account.Kind != domain.LedgerAccountUnassigned {
+		return nil
+	}
+
+	zero := domain.NewMoney(0, p.Amount.Currency())
+	inflow, outflow, transfers := zero, zero, zero
+	switch entry.SourceKind {
+	case domain.JournalSourceIncome:
+		inflow = p.Amount
+	case domain.JournalSourceExpense:
+		outflow = p.Amount.Neg()
+	default:
+		transfers = p.Amount
+	}
+
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO daily_balances (user_id, account_id, day, currency, inflow, outflow, transfers)
+		SELECT id, $2, ($3::timestamptz AT TIME ZONE timezone)::date, $4, $5, $6, $7
+		FROM users
+		WHERE id = $1
+		ON CONFLICT (user_id, account_id, day, currency) DO UPDATE
+		SET inflow    = daily_balances.inflow + EXCLUDED.inflow,
+		    outflow   = daily_balances.outflow + EXCLUDED.outflow,
+		    transfers = daily_balances.transfers + EXCLUDED.transfers
+	`, entry.UserID, p.Account.ID, entry.OccurredAt, p.Amount.Currency().Code,
+		inflow.Decimal(), outflow.Decimal(), transfers.Decimal())
+	return err
+}
+
+// RebuildDailyBalances пересчитывает дневные обороты счетов пользователя по журналу,
+// например после смены часового пояса. Вызывается внутри транзакции.
+func (r *LedgerRepository) RebuildDailyBalances(ctx context.Context, userID int64) error {
+	q := conn(ctx, r.db)
+	if _, err := q.ExecContext(ctx, `DELETE FROM daily_balances WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	_, err := q.ExecContext(ctx, dailyBalancesQuery, userID)
+	return err
+}
@@ -0,0 +1,152 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/reports"
+	"fincraft-finance/internal/testdb"
+)
+
+func Test_LedgerRepository_PostEntry_ChangesDerivedBalance_WhenEntryCommitted(t *testing.T) {
+	defer truncateAccounts(t)
+
+	seedDefaultUser(t)
+	accounts := infrastructure.NewAccountRepository(testdb.DB)
+	incomes := infrastructure.NewIncomeRepository(testdb.DB)
+	ledger := infrastructure.NewLedgerRepository(testdb.DB)
+	txManager := infrastructure.NewTxManager(testdb.DB)
+	ctx := context.Background()
+	account := addTestAccount(t, accounts, 1000)
+
+	err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		income, err := incomes.AddIncome(ctx, &domain.Income{UserID: 1, CategoryID: 2, AccountID: account.ID,
+			Amount: domain.NewMoney(5000, kzt), OccurredAt: account.CreatedAt})
+		if err != nil {
+			return err
+		}
+		entry := domain.IncomeEntry(income)
+		_, err = ledger.PostEntry(ctx, &entry)
+		return err
+	})
+
+	require.NoError(t, err)
+	got, err := accounts.GetAccount(ctx, 1, account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(1000, kzt), got.OpeningBalance)
+	assert.Equal(t, domain.NewMoney(6000, kzt), got.Balance)
+}
+
+func Test_LedgerRepository_PostEntry_FailsOnCommit_WhenEntryUnbalanced(t *testing.T) {
+	defer truncateAccounts(t)
+
+	seedDefaultUser(t)
+	accounts := infrastructure.NewAccountRepository(testdb.DB)
+	ledger := infrastructure.NewLedgerRepository(testdb.DB)
+	txManager := infrastructure.NewTxManager(testdb.DB)
+	ctx := context.Background()
+	account := addTestAccount(t, accounts, 0)
+
+	// Доменная проверка обходится намеренно: инвариант должна удержать и база
+	err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := ledger.PostEntry(ctx, &domain.JournalEntry{UserID: 1, SourceKind: domain.JournalSourceIncome,
+			SourceID: 1, OccurredAt: account.CreatedAt, Postings: []domain.Posting{
+				{Account: domain.LedgerAccount{Kind: domain.LedgerAccountAsset, ID: account.ID}, Amount: domain.NewMoney(100, kzt)},
+				{Account: domain.LedgerAccount{Kind: domain.LedgerAccountIncome, ID: 2}, Amount: domain.NewMoney(-90, kzt)},
+			}})
+		return err
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unbalanced")
+	got, err := accounts.GetAccount(ctx, 1, account.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Balance.IsZero())
+}
+
+func Test_TxManager_WithinTx_RollsBackAllWrites_WhenFnFails(t *testing.T) {
+	defer truncateAccounts(t)
+
+	seedDefaultUser(t)
+	accounts := infrastructure.NewAccountRepository(testdb.DB)
+	incomes := infrastructure.NewIncomeRepository(testdb.DB)
+	ledger := infrastructure.NewLedgerRepository(testdb.DB)
+	txManager := infrastructure.NewTxManager(testdb.DB)
+	ctx := context.Background()
+	account := addTestAccount(t, accounts, 0)
+	failure := errors.New("boom")
+
+	err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		income, err := incomes.AddIncome(ctx, &domain.Income{UserID: 1, CategoryID: 2, AccountID: account.ID,
+			Amount: domain.NewMoney(5000, kzt), OccurredAt: account.CreatedAt})
+		if err != nil {
+			return err
+		}
+		entry := domain.IncomeEntry(income)
+		if _, err := ledger.PostEntry(ctx, &entry); err != nil {
+			return err
+		}
+		return failure
+	})
+
+	assert.ErrorIs(t, err, failure)
+	got, err := accounts.GetAccount(ctx, 1, account.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Balance.IsZero())
+	stored, err := incomes.ListIncomes(ctx, domain.IncomeFilter{UserID: 1, AccountID: account.ID})
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+}
+
+func Test_TxManager_WithinReadOnlyTx_RejectsWrites_WhenFnWrites(t *testing.T) {
+	defer truncateAccounts(t)
+
+	seedDefaultUser(t)
+	accounts := infrastructure.NewAccountRepository(testdb.DB)
+	ctx := context.Background()
+
+	err := infrastructure.NewTxManager(testdb.DB).WithinReadOnlyTx(ctx, func(ctx context.Context) error {
+		_, err := accounts.CreateAccount(ctx, &domain.Account{UserID: 1, Name: "Cash", Type: domain.AccountTypeCash,
+			OpeningBalance: domain.NewMoney(0, kzt)})
+		return err
+	})
+
+	assert.Error(t, err)
+	stored, err := accounts.ListAccounts(ctx, domain.AccountFilter{UserID: 1})
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+}
+
+func Test_LedgerRepository_RebuildDailyBalances_MovesDays_WhenTimezoneChanged(t *testing.T) {
+	defer truncateAccounts(t)
+
+	seedDefaultUser(t)
+	account := addTestAccount(t, infrastructure.NewAccountRepository(testdb.DB), 0)
+	// 31 января 20:00 UTC - уже 1 февраля в Алматы
+	postTestIncome(t, account.ID, 10_000, time.Date(2024, time.January, 31, 20, 0, 0, 0, time.UTC))
+	almaty, err := time.LoadLocation("Asia/Almaty")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	err = infrastructure.NewTxManager(testdb.DB).WithinTx(ctx, func(ctx context.Context) error {
+		if err := infrastructure.NewUserRepository(testdb.DB).SetTimezone(ctx, 1, almaty); err != nil {
+			return err
+		}
+		return infrastructure.NewLedgerRepository(testdb.DB).RebuildDailyBalances(ctx, 1)
+	})
+	require.NoError(t, err)
+
+	rows, err := infrastructure.NewReportRepository(testdb.DB).SumFlows(ctx, reports.CashFlowQuery{UserID: 1,
+		Granularity: reports.GranularityDay, From: domain.Date{Year: 2024, Month: time.January, Day: 31},
+		To: domain.Date{Year: 2024, Month: time.February, Day: 1}})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, domain.Date{Year: 2024, Month: time.February, Day: 1}, rows[0].PeriodStart)
+}
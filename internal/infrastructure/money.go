@@ -0,0 +1,22 @@
+package infrastructure
+
+import (
+	"fmt"
+
+	"fincraft-finance/internal/domain"
+)
+
+// moneyFromDB собирает сумму из колонок numeric и кода валюты
+func moneyFromDB(amount, currencyCode string) (domain.Money, error) {
+	currency, err := domain.CurrencyByCode(currencyCode)
+	if err != nil {
+		return domain.Money{}, err
+	}
+
+	m, err := domain.ParseMoney(amount, currency, domain.RoundHalfEven)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("failed to read amount %q: %w", amount, err)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/rates"
+)
+
+// RateRepository реализует хранилище исторических курсов валют
+type RateRepository struct {
+	db *sql.DB
+}
+
+// NewRateRepository создает новый экземпляр RateRepository
+func NewRateRepository(db *sql.DB) *RateRepository {
+	return &RateRepository{db: db}
+}
+
+// RateOnOrBefore возвращает последний курс пары на дату date или раньше
+func (r *RateRepository) RateOnOrBefore(ctx context.Context, base, quote domain.Currency, date time.Time) (rates.Rate, error) {
+	var (
+		rateDate time.Time
+		value    string
+	)
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT rate_date, rate
+		FROM exchange_rates
+		WHERE base = $1 AND quote = $2 AND rate_date <= $3
+		ORDER BY rate_date DESC
+		LIMIT 1
+	`, base.Code, quote.Code, date).Scan(&rateDate, &value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return rates.Rate{}, fmt.Errorf("%w: %s/%s on %s", rates.ErrRateNotFound, base, quote, date.Format(time.DateOnly))
+	}
+	if err != nil {
+		return rates.Rate{}, err
+	}
+
+	v, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return rates.Rate{}, fmt.Errorf("%w: %q", rates.ErrInvalidRate, value)
+	}
+
+	return rates.Rate{Base: base, Quote: quote, Date: rates.Day(rateDate), Value: v}, nil
+}
+
+// SaveRates сохраняет курсы в одной транзакции, перезаписывая существующие на те же даты
+func (r *RateRepository) SaveRates(ctx context.Context, list []rates.Rate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO exchange_rates (base, quote, rate_date, rate)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (base, quote, rate_date) DO UPDATE SET rate = EXCLUDED.rate
+	`)
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer stmt.Close()
+
+	for _, rate := range list {
+		if _, err := stmt.ExecContext(ctx, rate.Base.Code, rate.Quote.Code, rate.Date, rate.Decimal()); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
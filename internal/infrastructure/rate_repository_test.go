@@ -0,0 +1,72 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/rates"
+	"fincraft-finance/internal/testdb"
+)
+
+func Test_RateRepository_RateOnOrBefore_ReturnsLatestRate_WhenRatesSaved(t *testing.T) {
+	defer func() {
+		if err := testdb.TruncateTables(testdb.DB, testdb.ExchangeRatesTable); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	usd := domain.MustCurrency("USD")
+	repo := infrastructure.NewRateRepository(testdb.DB)
+	ctx := context.Background()
+
+	day := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+	older, err := rates.NewRate(usd, kzt, day.AddDate(0, 0, -2), "449.5")
+	require.NoError(t, err)
+	newer, err := rates.NewRate(usd, kzt, day.AddDate(0, 0, -1), "450.123456789012")
+	require.NoError(t, err)
+	future, err := rates.NewRate(usd, kzt, day.AddDate(0, 0, 1), "451")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.SaveRates(ctx, []rates.Rate{older, newer, future}))
+
+	got, err := repo.RateOnOrBefore(ctx, usd, kzt, day)
+	require.NoError(t, err)
+	assert.Equal(t, newer.Date, got.Date)
+	assert.Equal(t, "450.123456789012", got.Decimal())
+}
+
+func Test_RateRepository_RateOnOrBefore_ReturnsRateNotFound_WhenNoRates(t *testing.T) {
+	repo := infrastructure.NewRateRepository(testdb.DB)
+
+	_, err := repo.RateOnOrBefore(context.Background(), domain.MustCurrency("USD"), kzt, time.Now())
+
+	assert.ErrorIs(t, err, rates.ErrRateNotFound)
+}
+
+func Test_RateRepository_SaveRates_OverwritesRate_WhenSameDay(t *testing.T) {
+	defer func() {
+		if err := testdb.TruncateTables(testdb.DB, testdb.ExchangeRatesTable); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	usd := domain.MustCurrency("USD")
+	repo := infrastructure.NewRateRepository(testdb.DB)
+	ctx := context.Background()
+	day := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	first, _ := rates.NewRate(usd, kzt, day, "450")
+	second, _ := rates.NewRate(usd, kzt, day, "455")
+	require.NoError(t, repo.SaveRates(ctx, []rates.Rate{first}))
+	require.NoError(t, repo.SaveRates(ctx, []rates.Rate{second}))
+
+	got, err := repo.RateOnOrBefore(ctx, usd, kzt, day)
+	require.NoError(t, err)
+	assert.Equal(t, "455.000000000000", got.Decimal())
+}
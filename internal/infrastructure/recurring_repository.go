@@ -0,0 +1,236 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"fincraft-finance/internal/domain"
+)
+
+// recurringColumns колонки таблицы recurring_rules (псевдоним r), из которых собирается domain.RecurringRule
+const recurringColumns = `r.id, r.user_id, r.category_id, COALESCE(r.account_id, 0), r.amount, r.currency, r.description,
+	r.frequency, r.step, r.day_of_month, r.start_date, r.end_date, r.max_count, r.paused, r.processed, r.next_date,
+	r.created_at, r.updated_at`
+
+// recurrenceFrequencies соответствие частот повторения значениям колонки frequency
+var recurrenceFrequencies = map[domain.RecurrenceFrequency]string{
+	domain.RecurrenceDaily:   "daily",
+	domain.RecurrenceWeekly:  "weekly",
+	domain.RecurrenceMonthly: "monthly",
+}
+
+// RecurringRuleRepository реализует методы для работы с правилами повторяющихся доходов
+type RecurringRuleRepository struct {
+	db *sql.DB
+}
+
+// NewRecurringRuleRepository создает новый экземпляр RecurringRuleRepository
+func NewRecurringRuleRepository(db *sql.DB) *RecurringRuleRepository {
+	return &RecurringRuleRepository{db: db}
+}
+
+// CreateRule добавляет правило повторяющегося дохода
+func (r *RecurringRuleRepository) CreateRule(ctx context.Context, rule *domain.RecurringRule) (*domain.RecurringRule, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO recurring_rules AS r (user_id, category_id, account_id, amount, currency, description, frequency,
+			step, day_of_month, start_date, end_date, max_count, paused, processed, next_date)
+		VALUES ($1, $2, NULLIF($3::bigint, 0), $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING `+recurringColumns,
+		rule.UserID, rule.CategoryID, rule.AccountID, rule.Amount.Decimal(), rule.Amount.Currency().Code,
+		rule.Description, recurrenceFrequencies[rule.Frequency], rule.Interval, rule.DayOfMonth,
+		dateToDB(rule.StartDate), dateToDB(rule.EndDate), rule.Count, rule.Paused, rule.Processed,
+		dateToDB(rule.NextDate))
+
+	return scanRecurringRule(row)
+}
+
+// GetRule возвращает правило пользователя по ID
+func (r *RecurringRuleRepository) GetRule(ctx context.Context, userID, id int64) (*domain.RecurringRule, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+recurringColumns+`
+		FROM recurring_rules r
+		WHERE r.id = $1 AND r.user_id = $2
+	`, id, userID)
+
+	rule, err := scanRecurringRule(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("recurring rule %d: %w", id, domain.ErrNotFound)
+	}
+
+	return rule, err
+}
+
+// ListRules возвращает правила пользователя в порядке создания
+func (r *RecurringRuleRepository) ListRules(ctx context.Context, userID int64) ([]domain.RecurringRule, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+recurringColumns+`
+		FROM recurring_rules r
+		WHERE r.user_id = $1
+		ORDER BY r.id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var rules []domain.RecurringRule
+	for rows.Next() {
+		rule, err := scanRecurringRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// UpdateRule сохраняет признак паузы и положение расписания правила
+func (r *RecurringRuleRepository) UpdateRule(ctx context.Context, rule *domain.RecurringRule) (*domain.RecurringRule, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		UPDATE recurring_rules r
+		SET paused = $3, processed = $4, next_date = $5, updated_at = now()
+		WHERE r.id = $1 AND r.user_id = $2
+		RETURNING `+recurringColumns,
+		rule.ID, rule.UserID, rule.Paused, rule.Processed, dateToDB(rule.NextDate))
+
+	updated, err := scanRecurringRule(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("recurring rule %d: %w", rule.ID, domain.ErrNotFound)
+	}
+
+	return updated, err
+}
+
+// ClaimDueRule блокирует и возвращает активное правило с наступившим повторением.
+// SKIP LOCKED позволяет нескольким экземплярам сервиса разбирать правила параллельно, не дожидаясь друг друга.
+func (r *RecurringRuleRepository) ClaimDueRule(ctx context.Context, now time.Time, skip []int64) (*domain.RecurringRule, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+recurringColumns+`
+		FROM recurring_rules r
+		JOIN users u ON u.id = r.user_id
+		WHERE NOT r.paused
+		  AND r.next_date <= ($1::timestamptz AT TIME ZONE u.timezone)::date
+		  AND r.id <> ALL(COALESCE($2, '{}'::bigint[]))
+		ORDER BY r.next_date, r.id
+		LIMIT 1
+		FOR UPDATE OF r SKIP LOCKED
+	`, now, pq.Array(skip))
+
+	rule, err := scanRecurringRule(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return rule, err
+}
+
+// ReserveOccurrence отмечает повторение проведенным, если оно еще не отмечено
+func (r *RecurringRuleRepository) ReserveOccurrence(ctx context.Context, ruleID int64, date domain.Date) (bool, error) {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO recurring_occurrences (rule_id, occurs_on)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`, ruleID, date.String())
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// LinkOccurrence сохраняет доход, созданный по повторению
+func (r *RecurringRuleRepository) LinkOccurrence(ctx context.Context, ruleID int64, date domain.Date, incomeID int64) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE recurring_occurrences SET income_id = $3 WHERE rule_id = $1 AND occurs_on = $2
+	`, ruleID, date.String(), incomeID)
+	return err
+}
+
+// SkipOccurrence отмечает повторение пропущенным; повторный пропуск ничего не меняет
+func (r *RecurringRuleRepository) SkipOccurrence(ctx context.Context, ruleID int64, date domain.Date) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO recurring_occurrences (rule_id, occurs_on, skipped)
+		VALUES ($1, $2, true)
+		ON CONFLICT DO NOTHING
+	`, ruleID, date.String())
+	return err
+}
+
+// SkippedOccurrences возвращает пропущенные повторения, до которых расписание правил еще не дошло
+func (r *RecurringRuleRepository) SkippedOccurrences(ctx context.Context, userID int64) (map[int64][]domain.Date, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT o.rule_id, o.occurs_on
+		FROM recurring_occurrences o
+		JOIN recurring_rules r ON r.id = o.rule_id
+		WHERE r.user_id = $1 AND o.skipped AND o.occurs_on >= r.next_date
+		ORDER BY o.rule_id, o.occurs_on
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	skipped := make(map[int64][]domain.Date)
+	for rows.Next() {
+		var (
+			ruleID int64
+			date   time.Time
+		)
+		if err := rows.Scan(&ruleID, &date); err != nil {
+			return nil, err
+		}
+		skipped[ruleID] = append(skipped[ruleID], domain.DateOf(date))
+	}
+
+	return skipped, rows.Err()
+}
+
+// scanRecurringRule читает правило повторяющегося дохода из строки результата
+func scanRecurringRule(row rowScanner) (*domain.RecurringRule, error) {
+	var (
+		rule      domain.RecurringRule
+		amount    string
+		currency  string
+		frequency string
+		startDate time.Time
+		endDate   sql.NullTime
+		nextDate  sql.NullTime
+	)
+
+	err := row.Scan(&rule.ID, &rule.UserID, &rule.CategoryID, &rule.AccountID, &amount, &currency, &rule.Description,
+		&frequency, &rule.Interval, &rule.DayOfMonth, &startDate, &endDate, &rule.Count, &rule.Paused,
+		&rule.Processed, &nextDate, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	for f, v := range recurrenceFrequencies {
+		if v == frequency {
+			rule.Frequency = f
+		}
+	}
+	if rule.Frequency == 0 {
+		return nil, fmt.Errorf("unknown recurrence frequency %q", frequency)
+	}
+	if rule.Amount, err = moneyFromDB(amount, currency); err != nil {
+		return nil, err
+	}
+	rule.StartDate = domain.DateOf(startDate)
+	if endDate.Valid {
+		rule.EndDate = domain.DateOf(endDate.Time)
+	}
+	if nextDate.Valid {
+		rule.NextDate = domain.DateOf(nextDate.Time)
+	}
+
+	return &rule, nil
+}
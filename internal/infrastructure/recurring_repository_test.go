@@ -0,0 +1,95 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/testdb"
+)
+
+func truncateRecurring(t *testing.T) {
+	if err := testdb.TruncateTables(testdb.DB, testdb.UsersTable, testdb.RecurringTable); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func addTestRule(t *testing.T, repo *infrastructure.RecurringRuleRepository, start domain.Date) *domain.RecurringRule {
+	rule := &domain.RecurringRule{UserID: 1, CategoryID: 2, Amount: domain.NewMoney(100000, kzt), Description: "Salary",
+		Frequency: domain.RecurrenceMonthly, Interval: 1, StartDate: start}
+	rule.Reschedule()
+
+	created, err := repo.CreateRule(context.Background(), rule)
+	require.NoError(t, err)
+	return created
+}
+
+func Test_RecurringRuleRepository_ClaimDueRule_SkipsRule_WhenLockedByAnotherTx(t *testing.T) {
+	defer truncateRecurring(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewRecurringRuleRepository(testdb.DB)
+	tx := infrastructure.NewTxManager(testdb.DB)
+	now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+	due := addTestRule(t, repo, domain.Date{Year: 2024, Month: time.March, Day: 1})
+	addTestRule(t, repo, domain.Date{Year: 2024, Month: time.April, Day: 1})
+
+	err := tx.WithinTx(context.Background(), func(ctx context.Context) error {
+		claimed, err := repo.ClaimDueRule(ctx, now, nil)
+		require.NoError(t, err)
+		require.NotNil(t, claimed)
+		assert.Equal(t, due.ID, claimed.ID)
+
+		// Другой экземпляр сервиса не видит заблокированное правило, а второе еще не наступило
+		return tx.WithinTx(context.Background(), func(other context.Context) error {
+			concurrent, err := repo.ClaimDueRule(other, now, nil)
+			assert.Nil(t, concurrent)
+			return err
+		})
+	})
+
+	require.NoError(t, err)
+}
+
+func Test_RecurringRuleRepository_ClaimDueRule_ReturnsNextRule_WhenEarlierRuleSkipped(t *testing.T) {
+	defer truncateRecurring(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewRecurringRuleRepository(testdb.DB)
+	now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+	failing := addTestRule(t, repo, domain.Date{Year: 2024, Month: time.March, Day: 1})
+	next := addTestRule(t, repo, domain.Date{Year: 2024, Month: time.March, Day: 5})
+
+	claimed, err := repo.ClaimDueRule(context.Background(), now, []int64{failing.ID})
+
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	assert.Equal(t, next.ID, claimed.ID)
+}
+
+func Test_RecurringRuleRepository_ReserveOccurrence_ReturnsFalse_WhenOccurrenceSkipped(t *testing.T) {
+	defer truncateRecurring(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewRecurringRuleRepository(testdb.DB)
+	ctx := context.Background()
+	rule := addTestRule(t, repo, domain.Date{Year: 2024, Month: time.March, Day: 10})
+	april := domain.Date{Year: 2024, Month: time.April, Day: 10}
+
+	require.NoError(t, repo.SkipOccurrence(ctx, rule.ID, april))
+	skipped, err := repo.SkippedOccurrences(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64][]domain.Date{rule.ID: {april}}, skipped)
+
+	reserved, err := repo.ReserveOccurrence(ctx, rule.ID, rule.NextDate)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	reserved, err = repo.ReserveOccurrence(ctx, rule.ID, april)
+	require.NoError(t, err)
+	assert.False(t, reserved)
+}
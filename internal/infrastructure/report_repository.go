@@ -0,0 +1,193 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/reports"
+)
+
+// ReportRepository агрегирует операции для отчетов средствами PostgreSQL
+type ReportRepository struct {
+	db *sql.DB
+}
+
+// NewReportRepository создает новый экземпляр ReportRepository
+func NewReportRepository(db *sql.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// SumIncomes суммирует доходы по периодам в часовом поясе loc, категориям, счетам и валютам.
+// Фильтр по категории учитывает и ее подкатегории.
+func (r *ReportRepository) SumIncomes(ctx context.Context, query reports.IncomeQuery,
+	loc *time.Location) ([]reports.Row, error) {
+	var (
+		where = []string{"user_id = $1", "occurred_at >= $2", "occurred_at < $3"}
+		args  = []any{query.UserID, query.From.Start(loc), query.To.AddDays(1).Start(loc)}
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query.CategoryID > 0 {
+		where = append(where, fmt.Sprintf(
+			"category_id IN (SELECT id FROM categories WHERE id = %[1]s OR parent_id = %[1]s)", arg(query.CategoryID)))
+	}
+	if query.AccountID > 0 {
+		where = append(where, "account_id = "+arg(query.AccountID))
+	}
+	if len(query.Tags) > 0 {
+		where = append(where, incomeTagsCondition(arg(pq.Array(query.Tags)), arg(len(query.Tags))))
+	}
+	period := fmt.Sprintf("date_trunc(%s, occurred_at AT TIME ZONE %s)::date",
+		arg(query.Granularity.String()), arg(loc.String()))
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s, category_id, COALESCE(account_id, 0), currency, SUM(amount)
+		FROM incomes
+		WHERE %s
+		GROUP BY 1, 2, 3, 4
+		ORDER BY 1, 2, 3, 4`, period, strings.Join(where, " AND ")), args...)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var result []reports.Row
+	for rows.Next() {
+		var (
+			row              reports.Row
+			start            time.Time
+			currency, amount string
+		)
+		if err := rows.Scan(&start, &row.CategoryID, &row.AccountID, &currency, &amount); err != nil {
+			return nil, err
+		}
+		row.PeriodStart = domain.DateOf(start)
+		if row.Amount, err = moneyFromDB(amount, currency); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+// OpeningBalances возвращает остатки счетов по валютам перед днем before: начальные остатки счетов,
+// открытых до этого дня по календарю пользователя, и дневные обороты до этого дня.
+// Нераспределенные деньги возвращаются с AccountID 0.
+func (r *ReportRepository) OpeningBalances(ctx context.Context, query reports.CashFlowQuery,
+	before domain.Date) ([]reports.Balance, error) {
+	var accountFilter, turnoverFilter string
+	args := []any{query.UserID, before.String()}
+	if query.AccountID > 0 {
+		args = append(args, query.AccountID)
+		accountFilter, turnoverFilter = "AND a.id = $3", "AND account_id = $3"
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`
+		SELECT account_id, currency, SUM(amount)
+		FROM (
+			SELECT a.id AS account_id, a.currency, a.opening_balance AS amount
+			FROM accounts a
+			JOIN users u ON u.id = a.user_id
+			WHERE a.user_id = $1 AND (a.created_at AT TIME ZONE u.timezone)::date < $2::date %s
+			UNION ALL
+			SELECT account_id, currency, inflow - outflow + transfers
+			FROM daily_balances
+			WHERE user_id = $1 AND day < $2::date %s
+		) b
+		GROUP BY 1, 2
+		ORDER BY 1, 2`, accountFilter, turnoverFilter), args...)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var result []reports.Balance
+	for rows.Next() {
+		var (
+			balance          reports.Balance
+			currency, amount string
+		)
+		if err := rows.Scan(&balance.AccountID, &currency, &amount); err != nil {
+			return nil, err
+		}
+		if balance.Amount, err = moneyFromDB(amount, currency); err != nil {
+			return nil, err
+		}
+		result = append(result, balance)
+	}
+
+	return result, rows.Err()
+}
+
+// SumFlows суммирует дневные обороты счетов по периодам, счетам и валютам.
+// Дни оборотов уже посчитаны в часовом поясе пользователя. Начальный остаток счета,
+// открытого в диапазоне запроса, попадает в период открытия по календарю пользователя.
+func (r *ReportRepository) SumFlows(ctx context.Context, query reports.CashFlowQuery) ([]reports.FlowRow, error) {
+	var accountFilter, turnoverFilter string
+	args := []any{query.UserID, query.From.String(), query.To.String(), query.Granularity.String()}
+	if query.AccountID > 0 {
+		args = append(args, query.AccountID)
+		accountFilter, turnoverFilter = "AND a.id = $5", "AND account_id = $5"
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`
+		SELECT date_trunc($4, day::timestamp)::date, account_id, currency,
+			SUM(inflow), SUM(outflow), SUM(transfers), SUM(opened)
+		FROM (
+			SELECT day, account_id, currency, inflow, outflow, transfers, 0 AS opened
+			FROM daily_balances
+			WHERE user_id = $1 AND day BETWEEN $2::date AND $3::date %s
+			UNION ALL
+			SELECT (a.created_at AT TIME ZONE u.timezone)::date, a.id, a.currency, 0, 0, 0, a.opening_balance
+			FROM accounts a
+			JOIN users u ON u.id = a.user_id
+			WHERE a.user_id = $1 AND (a.created_at AT TIME ZONE u.timezone)::date BETWEEN $2::date AND $3::date %s
+		) f
+		GROUP BY 1, 2, 3
+		ORDER BY 1, 2, 3`, turnoverFilter, accountFilter), args...)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var result []reports.FlowRow
+	for rows.Next() {
+		var (
+			row                                          reports.FlowRow
+			start                                        time.Time
+			currency, inflow, outflow, transfers, opened string
+		)
+		if err := rows.Scan(&start, &row.AccountID, &currency, &inflow, &outflow, &transfers, &opened); err != nil {
+			return nil, err
+		}
+		row.PeriodStart = domain.DateOf(start)
+		if row.Inflow, err = moneyFromDB(inflow, currency); err != nil {
+			return nil, err
+		}
+		if row.Outflow, err = moneyFromDB(outflow, currency); err != nil {
+			return nil, err
+		}
+		if row.Transfers, err = moneyFromDB(transfers, currency); err != nil {
+			return nil, err
+		}
+		if row.Opened, err = moneyFromDB(opened, currency); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
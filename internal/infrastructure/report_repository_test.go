@@ -0,0 +1,113 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/reports"
+	"fincraft-finance/internal/testdb"
+)
+
+func Test_ReportRepository_SumIncomes_GroupsByPeriodInTimezone_WhenIncomesStored(t *testing.T) {
+	defer truncateIncomes(t)
+
+	seedDefaultUser(t)
+	incomes := infrastructure.NewIncomeRepository(testdb.DB)
+	almaty, err := time.LoadLocation("Asia/Almaty")
+	require.NoError(t, err)
+
+	// 31 января 20:00 UTC - уже 1 февраля в Алматы
+	addTestIncomeAt(t, incomes, 10_000, "Bonus", time.Date(2024, time.January, 31, 20, 0, 0, 0, time.UTC))
+	addTestIncomeAt(t, incomes, 5_000, "Salary", time.Date(2024, time.February, 10, 9, 0, 0, 0, time.UTC))
+	addTestIncomeAt(t, incomes, 7_000, "Salary", time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC))
+
+	rows, err := infrastructure.NewReportRepository(testdb.DB).SumIncomes(context.Background(), reports.IncomeQuery{
+		UserID: 1, Granularity: reports.GranularityMonth,
+		From: domain.Date{Year: 2024, Month: time.January, Day: 1}, To: domain.Date{Year: 2024, Month: time.February, Day: 29},
+	}, almaty)
+
+	require.NoError(t, err)
+	assert.Equal(t, []reports.Row{
+		{PeriodStart: domain.Date{Year: 2024, Month: time.January, Day: 1}, CategoryID: 2,
+			Amount: domain.NewMoney(7_000, kzt)},
+		{PeriodStart: domain.Date{Year: 2024, Month: time.February, Day: 1}, CategoryID: 2,
+			Amount: domain.NewMoney(15_000, kzt)},
+	}, rows)
+}
+
+// postTestIncome добавляет доход на счет вместе с записью журнала
+func postTestIncome(t *testing.T, accountID, amount int64, occurredAt time.Time) {
+	incomes := infrastructure.NewIncomeRepository(testdb.DB)
+	ledger := infrastructure.NewLedgerRepository(testdb.DB)
+	err := infrastructure.NewTxManager(testdb.DB).WithinTx(context.Background(), func(ctx context.Context) error {
+		income, err := incomes.AddIncome(ctx, &domain.Income{UserID: 1, CategoryID: 2, AccountID: accountID,
+			Amount: domain.NewMoney(amount, kzt), OccurredAt: occurredAt})
+		if err != nil {
+			return err
+		}
+		entry := domain.IncomeEntry(income)
+		_, err = ledger.PostEntry(ctx, &entry)
+		return err
+	})
+	require.NoError(t, err)
+}
+
+// openTestAccountAt переносит дату открытия счета в прошлое
+func openTestAccountAt(t *testing.T, accountID int64, createdAt time.Time) {
+	_, err := testdb.DB.Exec(`UPDATE accounts SET created_at = $2 WHERE id = $1`, accountID, createdAt)
+	require.NoError(t, err)
+}
+
+func Test_ReportRepository_SumFlows_GroupsDailyBalancesByPeriod_WhenEntriesPosted(t *testing.T) {
+	defer truncateAccounts(t)
+
+	seedDefaultUser(t)
+	account := addTestAccount(t, infrastructure.NewAccountRepository(testdb.DB), 1_000)
+	openTestAccountAt(t, account.ID, time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC))
+	postTestIncome(t, account.ID, 7_000, time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC))
+	postTestIncome(t, account.ID, 5_000, time.Date(2024, time.February, 10, 9, 0, 0, 0, time.UTC))
+	postTestIncome(t, account.ID, 3_000, time.Date(2024, time.February, 20, 9, 0, 0, 0, time.UTC))
+	repo := infrastructure.NewReportRepository(testdb.DB)
+	ctx := context.Background()
+	query := reports.CashFlowQuery{UserID: 1, Granularity: reports.GranularityMonth,
+		From: domain.Date{Year: 2024, Month: time.February, Day: 1}, To: domain.Date{Year: 2024, Month: time.February, Day: 29}}
+
+	opening, err := repo.OpeningBalances(ctx, query, query.From)
+	require.NoError(t, err)
+	rows, err := repo.SumFlows(ctx, query)
+	require.NoError(t, err)
+
+	assert.Equal(t, []reports.Balance{{AccountID: account.ID, Amount: domain.NewMoney(8_000, kzt)}}, opening)
+	assert.Equal(t, []reports.FlowRow{{PeriodStart: query.From, AccountID: account.ID,
+		Inflow: domain.NewMoney(8_000, kzt), Outflow: domain.NewMoney(0, kzt), Transfers: domain.NewMoney(0, kzt),
+		Opened: domain.NewMoney(0, kzt)}}, rows)
+}
+
+func Test_ReportRepository_OpeningBalances_SkipsAccount_WhenOpenedWithinRange(t *testing.T) {
+	defer truncateAccounts(t)
+
+	seedDefaultUser(t)
+	account := addTestAccount(t, infrastructure.NewAccountRepository(testdb.DB), 1_000)
+	openTestAccountAt(t, account.ID, time.Date(2024, time.February, 10, 9, 0, 0, 0, time.UTC))
+	postTestIncome(t, account.ID, 5_000, time.Date(2024, time.February, 20, 9, 0, 0, 0, time.UTC))
+	repo := infrastructure.NewReportRepository(testdb.DB)
+	ctx := context.Background()
+	query := reports.CashFlowQuery{UserID: 1, Granularity: reports.GranularityMonth,
+		From: domain.Date{Year: 2024, Month: time.January, Day: 1}, To: domain.Date{Year: 2024, Month: time.February, Day: 29}}
+
+	opening, err := repo.OpeningBalances(ctx, query, query.From)
+	require.NoError(t, err)
+	rows, err := repo.SumFlows(ctx, query)
+	require.NoError(t, err)
+
+	assert.Empty(t, opening)
+	assert.Equal(t, []reports.FlowRow{{PeriodStart: domain.Date{Year: 2024, Month: time.February, Day: 1},
+		AccountID: account.ID, Inflow: domain.NewMoney(5_000, kzt), Outflow: domain.NewMoney(0, kzt),
+		Transfers: domain.NewMoney(0, kzt), Opened: domain.NewMoney(1_000, kzt)}}, rows)
+}
@@ -0,0 +1,197 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"fincraft-finance/internal/domain"
+)
+
+// ruleColumns колонки, из которых собирается domain.Rule
+const ruleColumns = `id, user_id, name, priority, direction, description_regex, description_contains,
+	min_amount, max_amount, COALESCE(amount_currency, ''), COALESCE(account_id, 0), counterparty,
+	COALESCE(category_id, 0), tags, new_description, COALESCE(transfer_account_id, 0), created_at, updated_at`
+
+// ruleDirections соответствие направлений правила значениям колонки direction
+var ruleDirections = map[domain.RuleDirection]string{
+	domain.RuleDirectionAny:     "any",
+	domain.RuleDirectionIncome:  "income",
+	domain.RuleDirectionExpense: "expense",
+}
+
+// RuleRepository реализует методы для работы с правилами категоризации
+type RuleRepository struct {
+	db *sql.DB
+}
+
+// NewRuleRepository создает новый экземпляр RuleRepository
+func NewRuleRepository(db *sql.DB) *RuleRepository {
+	return &RuleRepository{db: db}
+}
+
+// CreateRule добавляет правило пользователя
+func (r *RuleRepository) CreateRule(ctx context.Context, rule *domain.Rule) (*domain.Rule, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO rules (user_id, name, priority, direction, description_regex, description_contains,
+		                   min_amount, max_amount, amount_currency, account_id, counterparty,
+		                   category_id, tags, new_description, transfer_account_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10::bigint, 0), $11,
+		        NULLIF($12::integer, 0), $13, $14, NULLIF($15::bigint, 0))
+		RETURNING `+ruleColumns, ruleArgs(rule)...)
+
+	return scanRule(row)
+}
+
+// GetRule возвращает правило пользователя по ID
+func (r *RuleRepository) GetRule(ctx context.Context, userID, id int64) (*domain.Rule, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+ruleColumns+`
+		FROM rules
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+
+	rule, err := scanRule(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("rule %d: %w", id, domain.ErrNotFound)
+	}
+
+	return rule, err
+}
+
+// ListRules возвращает правила пользователя в порядке применения
+func (r *RuleRepository) ListRules(ctx context.Context, userID int64) ([]domain.Rule, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+ruleColumns+`
+		FROM rules
+		WHERE user_id = $1
+		ORDER BY priority, id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var rules []domain.Rule
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// UpdateRule сохраняет все изменяемые поля правила
+func (r *RuleRepository) UpdateRule(ctx context.Context, rule *domain.Rule) (*domain.Rule, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		UPDATE rules
+		SET name = $2, priority = $3, direction = $4, description_regex = $5, description_contains = $6,
+		    min_amount = $7, max_amount = $8, amount_currency = $9, account_id = NULLIF($10::bigint, 0),
+		    counterparty = $11, category_id = NULLIF($12::integer, 0), tags = $13, new_description = $14,
+		    transfer_account_id = NULLIF($15::bigint, 0), updated_at = now()
+		WHERE id = $16 AND user_id = $1
+		RETURNING `+ruleColumns, append(ruleArgs(rule), rule.ID)...)
+
+	updated, err := scanRule(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("rule %d: %w", rule.ID, domain.ErrNotFound)
+	}
+
+	return updated, err
+}
+
+// DeleteRule удаляет правило пользователя
+func (r *RuleRepository) DeleteRule(ctx context.Context, userID, id int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM rules WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("rule %d: %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// ruleArgs возвращает значения колонок правила в порядке $1-$15 запросов CreateRule и UpdateRule
+func ruleArgs(rule *domain.Rule) []any {
+	c, a := &rule.Conditions, &rule.Actions
+	var minAmount, maxAmount, currency any
+	if c.MinAmount != nil {
+		minAmount, currency = c.MinAmount.Decimal(), c.MinAmount.Currency().Code
+	}
+	if c.MaxAmount != nil {
+		maxAmount, currency = c.MaxAmount.Decimal(), c.MaxAmount.Currency().Code
+	}
+	tags := a.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return []any{rule.UserID, rule.Name, rule.Priority, ruleDirections[rule.Direction], c.DescriptionRegex,
+		c.DescriptionContains, minAmount, maxAmount, currency, c.AccountID, c.Counterparty,
+		a.CategoryID, pq.Array(tags), a.Description, a.TransferAccountID}
+}
+
+// scanRule читает правило из строки результата
+func scanRule(row rowScanner) (*domain.Rule, error) {
+	var (
+		rule                 domain.Rule
+		direction            string
+		minAmount, maxAmount sql.NullString
+		currency             string
+	)
+
+	c, a := &rule.Conditions, &rule.Actions
+	err := row.Scan(&rule.ID, &rule.UserID, &rule.Name, &rule.Priority, &direction, &c.DescriptionRegex,
+		&c.DescriptionContains, &minAmount, &maxAmount, &currency, &c.AccountID, &c.Counterparty,
+		&a.CategoryID, pq.Array(&a.Tags), &a.Description, &a.TransferAccountID, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	known := false
+	for d, v := range ruleDirections {
+		if v == direction {
+			rule.Direction, known = d, true
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("unknown rule direction %q", direction)
+	}
+	if c.MinAmount, err = nullMoneyFromDB(minAmount, currency); err != nil {
+		return nil, err
+	}
+	if c.MaxAmount, err = nullMoneyFromDB(maxAmount, currency); err != nil {
+		return nil, err
+	}
+	if len(a.Tags) == 0 {
+		a.Tags = nil
+	}
+
+	return &rule, nil
+}
+
+// nullMoneyFromDB собирает необязательную сумму, NULL дает nil
+func nullMoneyFromDB(amount sql.NullString, currencyCode string) (*domain.Money, error) {
+	if !amount.Valid {
+		return nil, nil
+	}
+	m, err := moneyFromDB(amount.String, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -0,0 +1,73 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/testdb"
+)
+
+func truncateRules(t *testing.T) {
+	if err := testdb.TruncateTables(testdb.DB, testdb.UsersTable, testdb.RulesTable); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_RuleRepository_CreateRule_StoresConditionsAndActions_WhenValidInput(t *testing.T) {
+	defer truncateRules(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewRuleRepository(testdb.DB)
+	ctx := context.Background()
+
+	minAmount := domain.NewMoney(100_000, kzt)
+	created, err := repo.CreateRule(ctx, &domain.Rule{UserID: 1, Name: "Salary", Priority: 5,
+		Direction:  domain.RuleDirectionIncome,
+		Conditions: domain.RuleConditions{DescriptionRegex: `(?i)salary`, MinAmount: &minAmount},
+		Actions:    domain.RuleActions{CategoryID: 2, Tags: []string{"work"}, Description: "Salary"}})
+	require.NoError(t, err)
+
+	got, err := repo.GetRule(ctx, 1, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RuleDirectionIncome, got.Direction)
+	assert.Equal(t, domain.RuleConditions{DescriptionRegex: `(?i)salary`, MinAmount: &minAmount}, got.Conditions)
+	assert.Equal(t, domain.RuleActions{CategoryID: 2, Tags: []string{"work"}, Description: "Salary"}, got.Actions)
+
+	got.Priority, got.Actions.Tags = 1, nil
+	updated, err := repo.UpdateRule(ctx, got)
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated.Priority)
+	assert.Nil(t, updated.Actions.Tags)
+}
+
+func Test_RuleRepository_ListRules_ReturnsRulesByPriority_WhenSeveralStored(t *testing.T) {
+	defer truncateRules(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewRuleRepository(testdb.DB)
+	ctx := context.Background()
+
+	var ids []int64
+	for _, priority := range []int{10, 0, 10} {
+		rule, err := repo.CreateRule(ctx, &domain.Rule{UserID: 1, Name: "Taxi", Priority: priority,
+			Conditions: domain.RuleConditions{DescriptionContains: "taxi"},
+			Actions:    domain.RuleActions{Description: "Taxi"}})
+		require.NoError(t, err)
+		ids = append(ids, rule.ID)
+	}
+
+	rules, err := repo.ListRules(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+	assert.Equal(t, []int64{ids[1], ids[0], ids[2]}, []int64{rules[0].ID, rules[1].ID, rules[2].ID})
+
+	require.NoError(t, repo.DeleteRule(ctx, 1, ids[1]))
+	err = repo.DeleteRule(ctx, 1, ids[1])
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
@@ -0,0 +1,58 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+// StatementRepository реализует хранилище идентификаторов импортированных операций
+type StatementRepository struct {
+	db *sql.DB
+}
+
+// NewStatementRepository создает новый экземпляр StatementRepository
+func NewStatementRepository(db *sql.DB) *StatementRepository {
+	return &StatementRepository{db: db}
+}
+
+// ImportedIDs возвращает те из ids, которые пользователь уже импортировал
+func (r *StatementRepository) ImportedIDs(ctx context.Context, userID int64, ids []string) (map[string]bool, error) {
+	imported := make(map[string]bool)
+	if len(ids) == 0 {
+		return imported, nil
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT external_id FROM imported_transactions WHERE user_id = $1 AND external_id = ANY($2)
+	`, userID, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		imported[id] = true
+	}
+
+	return imported, rows.Err()
+}
+
+// SaveImportedIDs запоминает импортированные идентификаторы одним запросом.
+// Конфликт первичного ключа означает, что операцию параллельно импортировал другой запрос.
+func (r *StatementRepository) SaveImportedIDs(ctx context.Context, userID int64, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO imported_transactions (user_id, external_id) SELECT $1, unnest($2::text[])
+	`, userID, pq.Array(ids))
+	return err
+}
@@ -0,0 +1,30 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/testdb"
+)
+
+func Test_StatementRepository_ImportedIDs_ReturnsSavedIDs_WhenImported(t *testing.T) {
+	defer func() {
+		if err := testdb.TruncateTables(testdb.DB, testdb.UsersTable, testdb.ImportedTable); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewStatementRepository(testdb.DB)
+	ctx := context.Background()
+	require.NoError(t, repo.SaveImportedIDs(ctx, 1, []string{"40817/A-1", "40817/A-2"}))
+
+	imported, err := repo.ImportedIDs(ctx, 1, []string{"40817/A-1", "40817/A-3"})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"40817/A-1": true}, imported)
+}
@@ -0,0 +1,134 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"fincraft-finance/internal/domain"
+)
+
+// tagQuery выбирает теги с числом операций, условия добавляются после WHERE
+const tagQuery = `
+	SELECT t.id, t.user_id, t.name, count(it.income_id), t.created_at
+	FROM tags t
+	LEFT JOIN income_tags it ON it.tag_id = t.id
+	WHERE `
+
+// TagRepository реализует методы для работы с тегами операций
+type TagRepository struct {
+	db *sql.DB
+}
+
+// NewTagRepository создает новый экземпляр TagRepository
+func NewTagRepository(db *sql.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// GetTag возвращает тег пользователя по ID
+func (r *TagRepository) GetTag(ctx context.Context, userID, id int64) (*domain.Tag, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, tagQuery+`t.id = $1 AND t.user_id = $2 GROUP BY t.id`, id, userID)
+
+	tag, err := scanTag(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("tag %d: %w", id, domain.ErrNotFound)
+	}
+
+	return tag, err
+}
+
+// FindTag возвращает тег пользователя по названию без учета регистра
+func (r *TagRepository) FindTag(ctx context.Context, userID int64, name string) (*domain.Tag, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		tagQuery+`t.user_id = $1 AND lower(t.name) = lower($2) GROUP BY t.id`, userID, name)
+
+	tag, err := scanTag(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("tag %q: %w", name, domain.ErrNotFound)
+	}
+
+	return tag, err
+}
+
+// ListTags возвращает теги пользователя с названием, начинающимся с prefix, от самых используемых
+func (r *TagRepository) ListTags(ctx context.Context, userID int64, prefix string, limit int) ([]domain.Tag, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, tagQuery+`t.user_id = $1 AND left(lower(t.name), length($2)) = lower($2)
+		GROUP BY t.id
+		ORDER BY count(it.income_id) DESC, lower(t.name), t.id
+		LIMIT $3
+	`, userID, prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var tags []domain.Tag
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, *tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// RenameTag меняет название тега пользователя
+func (r *TagRepository) RenameTag(ctx context.Context, userID, id int64, name string) (*domain.Tag, error) {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE tags SET name = $3 WHERE id = $1 AND user_id = $2`,
+		id, userID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("tag %d: %w", id, domain.ErrNotFound)
+	}
+
+	return r.GetTag(ctx, userID, id)
+}
+
+// MergeTags переносит доходы тега пользователя sourceID на targetID и удаляет sourceID.
+// Доходы, у которых уже есть оба тега, сохраняют одну связь с targetID.
+func (r *TagRepository) MergeTags(ctx context.Context, userID, sourceID, targetID int64) error {
+	db := conn(ctx, r.db)
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO income_tags (income_id, tag_id)
+		SELECT income_id, $2 FROM income_tags WHERE tag_id = $1
+		ON CONFLICT DO NOTHING
+	`, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+
+	res, err := db.ExecContext(ctx, `DELETE FROM tags WHERE id = $1 AND user_id = $2`, sourceID, userID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("tag %d: %w", sourceID, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// scanTag читает тег из строки результата
+func scanTag(row rowScanner) (*domain.Tag, error) {
+	var t domain.Tag
+	if err := row.Scan(&t.ID, &t.UserID, &t.Name, &t.UsageCount, &t.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
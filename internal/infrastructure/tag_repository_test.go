@@ -0,0 +1,124 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/testdb"
+)
+
+func truncateTags(t *testing.T) {
+	if err := testdb.TruncateTables(testdb.DB, testdb.UsersTable, testdb.IncomesTable, testdb.TagsTable,
+		testdb.IncomeTagsTable); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// addTaggedIncome сохраняет доход пользователя 1 с тегами
+func addTaggedIncome(t *testing.T, repo *infrastructure.IncomeRepository, tags ...string) *domain.Income {
+	income, err := repo.AddIncome(context.Background(), &domain.Income{UserID: 1, CategoryID: 2,
+		Amount: domain.NewMoney(1000, kzt), Tags: tags, OccurredAt: time.Now()})
+	require.NoError(t, err)
+	return income
+}
+
+func Test_IncomeRepository_AddIncome_StoresTags_WhenTagsSet(t *testing.T) {
+	defer truncateTags(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewIncomeRepository(testdb.DB)
+	ctx := context.Background()
+
+	addTaggedIncome(t, repo, "Travel")
+	income := addTaggedIncome(t, repo, "work", "travel")
+	assert.Equal(t, []string{"Travel", "work"}, income.Tags)
+
+	got, err := repo.GetIncome(ctx, 1, income.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Travel", "work"}, got.Tags)
+
+	got.Tags = []string{"reimbursable"}
+	updated, err := repo.UpdateIncome(ctx, got)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reimbursable"}, updated.Tags)
+}
+
+func Test_IncomeRepository_ListIncomes_ReturnsIncomesWithAllTags_WhenTagFilterSet(t *testing.T) {
+	defer truncateTags(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewIncomeRepository(testdb.DB)
+
+	addTaggedIncome(t, repo, "travel")
+	both := addTaggedIncome(t, repo, "travel", "reimbursable")
+	addTaggedIncome(t, repo)
+
+	got, err := repo.ListIncomes(context.Background(),
+		domain.IncomeFilter{UserID: 1, Tags: []string{"Travel", "reimbursable"}, PageSize: 10})
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, both.ID, got[0].ID)
+	assert.Equal(t, []string{"reimbursable", "travel"}, got[0].Tags)
+}
+
+func Test_TagRepository_ListTags_OrdersByUsage_WhenPrefixMatches(t *testing.T) {
+	defer truncateTags(t)
+
+	seedDefaultUser(t)
+	incomes := infrastructure.NewIncomeRepository(testdb.DB)
+	addTaggedIncome(t, incomes, "vat", "vacation")
+	addTaggedIncome(t, incomes, "vacation", "work")
+
+	tags, err := infrastructure.NewTagRepository(testdb.DB).ListTags(context.Background(), 1, "VA", 10)
+
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "vacation", tags[0].Name)
+	assert.Equal(t, 2, tags[0].UsageCount)
+	assert.Equal(t, "vat", tags[1].Name)
+}
+
+func Test_TagRepository_MergeTags_MovesIncomesToTarget_WhenBothTagsUsed(t *testing.T) {
+	defer truncateTags(t)
+
+	seedDefaultUser(t)
+	incomes := infrastructure.NewIncomeRepository(testdb.DB)
+	repo := infrastructure.NewTagRepository(testdb.DB)
+	ctx := context.Background()
+
+	first := addTaggedIncome(t, incomes, "trip", "travel")
+	addTaggedIncome(t, incomes, "trip")
+	source, err := repo.FindTag(ctx, 1, "TRIP")
+	require.NoError(t, err)
+	target, err := repo.FindTag(ctx, 1, "travel")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.MergeTags(ctx, 1, source.ID, target.ID))
+
+	merged, err := repo.GetTag(ctx, 1, target.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, merged.UsageCount)
+	_, err = repo.GetTag(ctx, 1, source.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	got, err := incomes.GetIncome(ctx, 1, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"travel"}, got.Tags)
+}
+
+func Test_TagRepository_RenameTag_ReturnsNotFound_WhenTagMissing(t *testing.T) {
+	defer truncateTags(t)
+
+	seedDefaultUser(t)
+
+	_, err := infrastructure.NewTagRepository(testdb.DB).RenameTag(context.Background(), 1, 42, "travel")
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,94 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"fincraft-finance/internal/domain"
+)
+
+// transferColumns колонки, из которых собирается domain.Transfer
+const transferColumns = `id, user_id, from_account_id, to_account_id, from_amount, from_currency, to_amount, to_currency,
+	description, occurred_at, created_at`
+
+// TransferRepository реализует методы для работы с переводами между счетами
+type TransferRepository struct {
+	db *sql.DB
+}
+
+// NewTransferRepository создает новый экземпляр TransferRepository
+func NewTransferRepository(db *sql.DB) *TransferRepository {
+	return &TransferRepository{db: db}
+}
+
+// AddTransfer добавляет перевод и возвращает его с ID и временем создания
+func (r *TransferRepository) AddTransfer(ctx context.Context, transfer *domain.Transfer) (*domain.Transfer, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO transfers (user_id, from_account_id, to_account_id, from_amount, from_currency,
+		                       to_amount, to_currency, description, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+transferColumns,
+		transfer.UserID, transfer.FromAccountID, transfer.ToAccountID,
+		transfer.FromAmount.Decimal(), transfer.FromAmount.Currency().Code,
+		transfer.ToAmount.Decimal(), transfer.ToAmount.Currency().Code,
+		transfer.Description, transfer.OccurredAt)
+
+	return scanTransfer(row)
+}
+
+// ListTransfers возвращает переводы пользователя по убыванию ID
+func (r *TransferRepository) ListTransfers(ctx context.Context, filter domain.TransferFilter) ([]domain.Transfer, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+transferColumns+`
+		FROM transfers
+		WHERE user_id = $1
+		  AND ($2 = 0 OR from_account_id = $2 OR to_account_id = $2)
+		  AND ($3 = 0 OR id < $3)
+		  AND ($5::timestamptz IS NULL OR occurred_at >= $5)
+		  AND ($6::timestamptz IS NULL OR occurred_at < $6)
+		ORDER BY id DESC
+		LIMIT $4
+	`, filter.UserID, filter.AccountID, filter.AfterID, filter.PageSize, timeToDB(filter.From), timeToDB(filter.To))
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	var transfers []domain.Transfer
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, *transfer)
+	}
+
+	return transfers, rows.Err()
+}
+
+// scanTransfer читает перевод из строки результата
+func scanTransfer(row rowScanner) (*domain.Transfer, error) {
+	var (
+		t            domain.Transfer
+		fromAmount   string
+		fromCurrency string
+		toAmount     string
+		toCurrency   string
+	)
+
+	err := row.Scan(&t.ID, &t.UserID, &t.FromAccountID, &t.ToAccountID, &fromAmount, &fromCurrency,
+		&toAmount, &toCurrency, &t.Description, &t.OccurredAt, &t.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.FromAmount, err = moneyFromDB(fromAmount, fromCurrency); err != nil {
+		return nil, err
+	}
+	if t.ToAmount, err = moneyFromDB(toAmount, toCurrency); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,52 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/testdb"
+)
+
+func truncateTransfers(t *testing.T) {
+	if err := testdb.TruncateTables(testdb.DB, testdb.UsersTable, testdb.AccountsTable, testdb.TransfersTable,
+		testdb.JournalTable); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_TransferRepository_ListTransfers_FiltersByEitherAccount_WhenAccountSet(t *testing.T) {
+	defer truncateTransfers(t)
+
+	seedDefaultUser(t)
+	accounts := infrastructure.NewAccountRepository(testdb.DB)
+	repo := infrastructure.NewTransferRepository(testdb.DB)
+	ctx := context.Background()
+	checking := addTestAccount(t, accounts, 0)
+	savings := addTestAccount(t, accounts, 0)
+	cash := addTestAccount(t, accounts, 0)
+
+	usd := domain.MustCurrency("USD")
+	in, err := repo.AddTransfer(ctx, &domain.Transfer{UserID: 1, FromAccountID: checking.ID, ToAccountID: savings.ID,
+		FromAmount: domain.NewMoney(4_700_000, kzt), ToAmount: domain.NewMoney(10000, usd), OccurredAt: time.Now()})
+	require.NoError(t, err)
+	out, err := repo.AddTransfer(ctx, &domain.Transfer{UserID: 1, FromAccountID: savings.ID, ToAccountID: cash.ID,
+		FromAmount: domain.NewMoney(500, kzt), ToAmount: domain.NewMoney(500, kzt), OccurredAt: time.Now()})
+	require.NoError(t, err)
+	_, err = repo.AddTransfer(ctx, &domain.Transfer{UserID: 1, FromAccountID: cash.ID, ToAccountID: checking.ID,
+		FromAmount: domain.NewMoney(100, kzt), ToAmount: domain.NewMoney(100, kzt), OccurredAt: time.Now()})
+	require.NoError(t, err)
+
+	got, err := repo.ListTransfers(ctx, domain.TransferFilter{UserID: 1, AccountID: savings.ID, PageSize: 10})
+
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, out.ID, got[0].ID)
+	assert.Equal(t, in.ID, got[1].ID)
+	assert.Equal(t, domain.NewMoney(10000, usd), got[1].ToAmount)
+}
@@ -0,0 +1,67 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+)
+
+// txKey ключ контекста, под которым хранится текущая транзакция
+type txKey struct{}
+
+// querier общие методы sql.DB и sql.Tx, которыми пользуются репозитории
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// TxManager выполняет операции репозиториев в одной транзакции
+type TxManager struct {
+	db *sql.DB
+}
+
+// NewTxManager создает новый экземпляр TxManager
+func NewTxManager(db *sql.DB) *TxManager {
+	return &TxManager{db: db}
+}
+
+// WithinTx выполняет fn в транзакции и фиксирует ее, если fn не вернула ошибку.
+// Вложенный вызов присоединяется к уже открытой транзакции.
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.within(ctx, nil, fn)
+}
+
+// WithinReadOnlyTx выполняет fn в транзакции только для чтения с уровнем изоляции REPEATABLE READ:
+// все запросы fn видят снимок данных на момент первого из них.
+// Вложенный вызов присоединяется к уже открытой транзакции с ее уровнем изоляции.
+func (m *TxManager) WithinReadOnlyTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.within(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}, fn)
+}
+
+// within выполняет fn в транзакции с параметрами opts или в уже открытой транзакции из контекста
+func (m *TxManager) within(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// conn возвращает транзакцию из контекста или, если ее нет, соединение с базой
+func conn(ctx context.Context, db *sql.DB) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,249 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"fincraft-finance/internal/domain"
+)
+
+// userDataQueries запросы одного раздела данных пользователя, $1 - ID пользователя
+type userDataQueries struct {
+	// export выбирает строки раздела одним столбцом JSON-объекта схемы архива.
+	// Суммы выгружаются строками, чтобы не терять точность.
+	export string
+	// erase удаляет строки раздела
+	erase string
+}
+
+// userDataSections запросы каждого раздела архива данных пользователя
+var userDataSections = map[domain.UserDataSection]userDataQueries{
+	domain.UserDataUser: {
+		export: `SELECT json_build_object('id', id, 'email', email, 'name', name, 'timezone', timezone,
+				'created_at', created_at)
+			FROM users WHERE id = $1`,
+		erase: `DELETE FROM users WHERE id = $1`,
+	},
+	domain.UserDataAccounts: {
+		export: `SELECT json_build_object('id', id, 'name', name, 'type', type, 'currency', currency,
+				'opening_balance', opening_balance::text, 'closed_at', closed_at, 'created_at', created_at,
+				'updated_at', updated_at)
+			FROM accounts WHERE user_id = $1 ORDER BY id`,
+		erase: `DELETE FROM accounts WHERE user_id = $1`,
+	},
+	domain.UserDataCategories: {
+		export: `SELECT json_build_object('id', id, 'name', name, 'kind', kind, 'icon', icon, 'parent_id', parent_id,
+				'archived', archived, 'created_at', created_at)
+			FROM categories WHERE user_id = $1 ORDER BY id`,
+		erase: `DELETE FROM categories WHERE user_id = $1`,
+	},
+	domain.UserDataTags: {
+		export: `SELECT json_build_object('id', id, 'name', name, 'created_at', created_at)
+			FROM tags WHERE user_id = $1 ORDER BY id`,
+		erase: `DELETE FROM tags WHERE user_id = $1`,
+	},
+	domain.UserDataIncomes: {
+		export: `SELECT json_build_object('id', id, 'category_id', category_id, 'account_id', account_id,
+				'amount', amount::text, 'currency', currency, 'description', description, 'occurred_at', occurred_at,
+				'created_at', created_at, 'updated_at', updated_at)
+			FROM incomes WHERE user_id = $1 ORDER BY id`,
+		erase: `DELETE FROM incomes WHERE user_id = $1`,
+	},
+	domain.UserDataIncomeTags: {
+		export: `SELECT json_build_object('income_id', it.income_id, 'tag_id', it.tag_id)
+			FROM income_tags it JOIN tags t ON t.id = it.tag_id
+			WHERE t.user_id = $1 ORDER BY it.income_id, it.tag_id`,
+		erase: `DELETE FROM income_tags it USING tags t WHERE t.id = it.tag_id AND t.user_id = $1`,
+	},
+	domain.UserDataIncomeSources: {
+		export: `SELECT json_build_object('id', id, 'income_id', income_id, 'source_id', source_id,
+				'category_id', category_id, 'account_id', account_id, 'amount', amount::text, 'currency', currency,
+				'description', description, 'occurred_at', occurred_at, 'created_at', created_at, 'merged_at', merged_at)
+			FROM income_sources WHERE user_id = $1 ORDER BY id`,
+		erase: `DELETE FROM income_sources WHERE user_id = $1`,
+	},
+	domain.UserDataExpenses: {
+		export: `SELECT json_build_object('id', id, 'category_id', category_id, 'account_id', account_id,
+				'amount', amount::text, 'currency', currency, 'description', description, 'occurred_at', occurred_at,
+				'created_at', created_at, 'updated_at', updated_at)
+			FROM expenses WHERE user_id = $1 ORDER BY id`,
+		erase: `DELETE FROM expenses WHERE user_id = $1`,
+	},
+	domain.UserDataTransfers: {
+		export: `SELECT json_build_object('id', id, 'from_account_id', from_account_id, 'to_account_id', to_account_id,
+				'from_amount', from_amount::text, 'from_currency', from_currency, 'to_amount', to_amount::text,
+				'to_currency', to_currency, 'description', description, 'occurred_at', occurred_at,
+				'created_at', created_at)
+			FROM transfers WHERE user_id = $1 ORDER BY id`,
+		erase: `DELETE FROM transfers WHERE user_id = $1`,
+	},
+	domain.UserDataBudgets: {
+		export: `SELECT json_build_object('id', id, 'category_id', category_id, 'period', period,
+				'amount', amount::text, 'currency', currency, 'rollover', rollover, 'start_date', start_date,
+				'end_date', end_date, 'created_at', created_at, 'updated_at', updated_at)
+			FROM budgets WHERE user_id = $1 ORDER BY id`,
+		erase: `DELETE FROM budgets WHERE user_id = $1`,
+	},
+	domain.UserDataRecurringRules: {
+		export: `SELECT json_build_object('id', id, 'category_id', category_id, 'account_id', account_id,
+				'amount', amount::text, 'currency', currency, 'description', description, 'frequency', frequency,
+				'step', step, 'day_of_month', day_of_month, 'start_date', start_date, 'end_date', end_date,
+				'max_count', max_count, 'paused', paused, 'processed', processed, 'next_date', next_date,
+				'created_at', created_at, 'updated_at', updated_at)
+			FROM recurring_rules WHERE user_id = $1 ORDER BY id`,
+		erase: `DELETE FROM recurring_rules WHERE user_id = $1`,
+	},
+	domain.UserDataRecurringOccurrences: {
+		export: `SELECT json_build_object('rule_id', o.rule_id, 'occurs_on', o.occurs_on, 'skipped', o.skipped,
+				'income_id', o.income_id, 'created_at', o.created_at)
+			FROM recurring_occurrences o JOIN recurring_rules r ON r.id = o.rule_id
+			WHERE r.user_id = $1 ORDER BY o.rule_id, o.occurs_on`,
+		erase: `DELETE FROM recurring_occurrences o USING recurring_rules r WHERE r.id = o.rule_id AND r.user_id = $1`,
+	},
+	domain.UserDataRules: {
+		export: `SELECT json_build_object('id', id, 'name', name, 'priority', priority, 'direction', direction,
+				'description_regex', description_regex, 'description_contains', description_contains,
+				'min_amount', min_amount::text, 'max_amount', max_amount::text, 'amount_currency', amount_currency,
+				'account_id', account_id, 'counterparty', counterparty, 'category_id', category_id, 'tags', tags,
+				'new_description', new_description, 'transfer_account_id', transfer_account_id,
+				'created_at', created_at, 'updated_at', updated_at)
+			FROM rules WHERE user_id = $1 ORDER BY id`,
+		erase: `DELETE FROM rules WHERE user_id = $1`,
+	},
+	domain.UserDataJournalEntries: {
+		export: `SELECT json_build_object('id', id, 'source_kind', source_kind, 'source_id', source_id,
+				'occurred_at', occurred_at, 'created_at', created_at)
+			FROM journal_entries WHERE user_id = $1 ORDER BY id`,
+		erase: `DELETE FROM journal_entries WHERE user_id = $1`,
+	},
+	domain.UserDataJournalPostings: {
+		export: `SELECT json_build_object('id', p.id, 'entry_id', p.entry_id, 'account_kind', p.account_kind,
+				'ref_id', p.ref_id, 'amount', p.amount::text, 'currency', p.currency)
+			FROM journal_postings p JOIN journal_entries e ON e.id = p.entry_id
+			WHERE e.user_id = $1 ORDER BY p.id`,
+		erase: `DELETE FROM journal_postings p USING journal_entries e WHERE e.id = p.entry_id AND e.user_id = $1`,
+	},
+	domain.UserDataDailyBalances: {
+		export: `SELECT json_build_object('account_id', account_id, 'day', day, 'currency', currency,
+				'inflow', inflow::text, 'outflow', outflow::text, 'transfers', transfers::text)
+			FROM daily_balances WHERE user_id = $1 ORDER BY day, account_id, currency`,
+		erase: `DELETE FROM daily_balances WHERE user_id = $1`,
+	},
+	domain.UserDataImportedTransactions: {
+		export: `SELECT json_build_object('external_id', external_id, 'imported_at', imported_at)
+			FROM imported_transactions WHERE user_id = $1 ORDER BY imported_at, external_id`,
+		erase: `DELETE FROM imported_transactions WHERE user_id = $1`,
+	},
+	// Сохраненные ответы и отпечатки запросов не выгружаются: это копии данных других разделов.
+	// Ключи выполняющихся запросов не трогаются: среди них ключ самого удаления, в который
+	// после удаления сохраняется отчет. Такие ключи удаляются по истечении срока.
+	domain.UserDataIdempotencyKeys: {
+		export: `SELECT json_build_object('method', method, 'key', key, 'completed', completed,
+				'response_type', response_type, 'created_at', created_at, 'expires_at', expires_at)
+			FROM idempotency_keys WHERE user_id = $1 AND completed ORDER BY created_at, method, key`,
+		erase: `DELETE FROM idempotency_keys WHERE user_id = $1 AND completed`,
+	},
+}
+
+// userDataErasureOrder порядок удаления разделов: ссылающиеся строки раньше тех, на которые они ссылаются.
+// Каскадное удаление не используется, чтобы посчитать удаленные строки каждого раздела.
+var userDataErasureOrder = []domain.UserDataSection{
+	domain.UserDataIncomeTags,
+	domain.UserDataTags,
+	domain.UserDataIncomeSources,
+	domain.UserDataRecurringOccurrences,
+	domain.UserDataRecurringRules,
+	domain.UserDataRules,
+	domain.UserDataBudgets,
+	domain.UserDataImportedTransactions,
+	domain.UserDataIdempotencyKeys,
+	domain.UserDataDailyBalances,
+	domain.UserDataJournalPostings,
+	domain.UserDataJournalEntries,
+	domain.UserDataTransfers,
+	domain.UserDataIncomes,
+	domain.UserDataExpenses,
+	domain.UserDataAccounts,
+	domain.UserDataCategories,
+	domain.UserDataUser,
+}
+
+// UserDataRepository реализует выгрузку и стирание всех данных пользователя
+type UserDataRepository struct {
+	db *sql.DB
+}
+
+// NewUserDataRepository создает новый экземпляр UserDataRepository
+func NewUserDataRepository(db *sql.DB) *UserDataRepository {
+	return &UserDataRepository{db: db}
+}
+
+// ExportSection передает fn строки раздела пользователя по одной, каждую - JSON-объектом схемы архива
+func (r *UserDataRepository) ExportSection(ctx context.Context, userID int64, section domain.UserDataSection,
+	fn func(row json.RawMessage) error) error {
+	queries, err := userDataQueriesOf(section)
+	if err != nil {
+		return err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, queries.export, userID)
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// CountSection возвращает число строк раздела пользователя
+func (r *UserDataRepository) CountSection(ctx context.Context, userID int64,
+	section domain.UserDataSection) (int64, error) {
+	queries, err := userDataQueriesOf(section)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int64
+	err = conn(ctx, r.db).QueryRowContext(ctx, `SELECT count(*) FROM (`+queries.export+`) s`, userID).Scan(&n)
+	return n, err
+}
+
+// EraseUser удаляет все данные пользователя вместе с ним самим и возвращает число удаленных строк по разделам.
+// Удаления нужно выполнять в транзакции: при ошибке посередине остаются частично удаленные данные.
+func (r *UserDataRepository) EraseUser(ctx context.Context, userID int64) (map[domain.UserDataSection]int64, error) {
+	q := conn(ctx, r.db)
+	removed := make(map[domain.UserDataSection]int64, len(userDataErasureOrder))
+	for _, section := range userDataErasureOrder {
+		res, err := q.ExecContext(ctx, userDataSections[section].erase, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to erase section %s: %w", section, err)
+		}
+		if removed[section], err = res.RowsAffected(); err != nil {
+			return nil, err
+		}
+	}
+
+	return removed, nil
+}
+
+// userDataQueriesOf возвращает запросы раздела архива
+func userDataQueriesOf(section domain.UserDataSection) (userDataQueries, error) {
+	queries, ok := userDataSections[section]
+	if !ok {
+		return userDataQueries{}, fmt.Errorf("unknown user data section %q", section)
+	}
+	return queries, nil
+}
@@ -0,0 +1,159 @@
+package infrastructure_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/testdb"
+)
+
+func truncateUserData(t *testing.T) {
+	if err := testdb.TruncateTables(testdb.DB, testdb.UsersTable, testdb.IncomesTable, testdb.AccountsTable,
+		testdb.JournalTable, testdb.DailyBalancesTable, testdb.TagsTable, testdb.IncomeTagsTable,
+		testdb.IdempotencyTable); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// seedUserData добавляет пользователю 1 счет, категорию, доход с тегом и проводкой и ключ идемпотентности,
+// а пользователю 2 - доход, который не должен попасть ни в выгрузку, ни в стирание
+func seedUserData(t *testing.T) *domain.Account {
+	seedDefaultUser(t)
+	other := testdb.UserParams{ID: 2, Email: "other@test.com"}
+	require.NoError(t, other.SeedUser(testdb.DB))
+	category := testdb.CategoryParams{ID: 30, UserID: 1, Name: "Bonus"}
+	require.NoError(t, category.SeedCategory(testdb.DB))
+
+	account := addTestAccount(t, infrastructure.NewAccountRepository(testdb.DB), 1_000)
+	postTestIncome(t, account.ID, 7_000, time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC))
+	addTaggedIncome(t, infrastructure.NewIncomeRepository(testdb.DB), "work")
+	_, err := infrastructure.NewIncomeRepository(testdb.DB).AddIncome(context.Background(), &domain.Income{
+		UserID: 2, CategoryID: 2, Amount: domain.NewMoney(500, kzt), OccurredAt: time.Now()})
+	require.NoError(t, err)
+	_, err = testdb.DB.Exec(`INSERT INTO idempotency_keys (user_id, method, key, fingerprint, completed, created_at,
+		expires_at) VALUES (1, '/finance.FinanceService/AddIncome', 'k1', '\x00', true, now(), now() + interval '1 day')`)
+	require.NoError(t, err)
+	return account
+}
+
+// exportSection возвращает строки раздела пользователя
+func exportSection(t *testing.T, repo *infrastructure.UserDataRepository, userID int64,
+	section domain.UserDataSection) []map[string]any {
+	var rows []map[string]any
+	err := repo.ExportSection(context.Background(), userID, section, func(row json.RawMessage) error {
+		var v map[string]any
+		if err := json.Unmarshal(row, &v); err != nil {
+			return err
+		}
+		rows = append(rows, v)
+		return nil
+	})
+	require.NoError(t, err)
+	return rows
+}
+
+func Test_UserDataRepository_ExportSection_ReturnsOnlyUserRows_WhenSeveralUsers(t *testing.T) {
+	defer truncateUserData(t)
+
+	account := seedUserData(t)
+	repo := infrastructure.NewUserDataRepository(testdb.DB)
+
+	user := exportSection(t, repo, 1, domain.UserDataUser)
+	require.Len(t, user, 1)
+	assert.Equal(t, "test@test.com", user[0]["email"])
+
+	incomes := exportSection(t, repo, 1, domain.UserDataIncomes)
+	require.Len(t, incomes, 2)
+	assert.Equal(t, "7000", incomes[0]["amount"])
+	assert.Equal(t, float64(account.ID), incomes[0]["account_id"])
+	assert.Nil(t, incomes[1]["account_id"])
+
+	assert.Len(t, exportSection(t, repo, 1, domain.UserDataCategories), 1)
+	assert.Len(t, exportSection(t, repo, 1, domain.UserDataIncomeTags), 1)
+	assert.Len(t, exportSection(t, repo, 1, domain.UserDataJournalEntries), 1)
+	assert.Len(t, exportSection(t, repo, 1, domain.UserDataJournalPostings), 2)
+	keys := exportSection(t, repo, 1, domain.UserDataIdempotencyKeys)
+	require.Len(t, keys, 1)
+	assert.NotContains(t, keys[0], "fingerprint")
+	assert.Len(t, exportSection(t, repo, 2, domain.UserDataIncomes), 1)
+}
+
+func Test_UserDataRepository_ExportSection_ReturnsError_WhenSectionUnknown(t *testing.T) {
+	repo := infrastructure.NewUserDataRepository(testdb.DB)
+
+	err := repo.ExportSection(context.Background(), 1, "secrets", func(json.RawMessage) error { return nil })
+
+	assert.ErrorContains(t, err, `unknown user data section "secrets"`)
+}
+
+func Test_UserDataRepository_EraseUser_RemovesAllUserRows_WhenInTx(t *testing.T) {
+	defer truncateUserData(t)
+
+	seedUserData(t)
+	repo := infrastructure.NewUserDataRepository(testdb.DB)
+	ctx := context.Background()
+
+	var removed map[domain.UserDataSection]int64
+	err := infrastructure.NewTxManager(testdb.DB).WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		removed, err = repo.EraseUser(ctx, 1)
+		return err
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed[domain.UserDataUser])
+	assert.Equal(t, int64(2), removed[domain.UserDataIncomes])
+	assert.Equal(t, int64(1), removed[domain.UserDataCategories])
+	assert.Equal(t, int64(1), removed[domain.UserDataTags])
+	assert.Equal(t, int64(2), removed[domain.UserDataJournalPostings])
+	assert.Equal(t, int64(1), removed[domain.UserDataIdempotencyKeys])
+	for _, section := range domain.UserDataSections {
+		n, err := repo.CountSection(ctx, 1, section)
+		require.NoError(t, err)
+		assert.Zero(t, n, section)
+	}
+	n, err := repo.CountSection(ctx, 2, domain.UserDataIncomes)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
+
+func Test_UserDataRepository_EraseUser_KeepsIdempotencyKey_WhenRequestInFlight(t *testing.T) {
+	defer truncateUserData(t)
+
+	seedUserData(t)
+	repo := infrastructure.NewUserDataRepository(testdb.DB)
+	keys := infrastructure.NewIdempotencyRepository(testdb.DB)
+	ctx := context.Background()
+	// Ключ самого запроса на удаление: интерцептор идемпотентности сохраняет в нем отчет после удаления
+	erasure := &domain.IdempotencyRecord{Key: domain.IdempotencyKey{UserID: 1,
+		Method: "/finance.FinanceService/EraseUser", Key: "e1"}, Fingerprint: []byte{1},
+		CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+	_, reserved, err := keys.Reserve(ctx, erasure, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	err = infrastructure.NewTxManager(testdb.DB).WithinTx(ctx, func(ctx context.Context) error {
+		removed, err := repo.EraseUser(ctx, 1)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, int64(1), removed[domain.UserDataIdempotencyKeys])
+		erasure.Completed = true
+		return keys.Complete(ctx, erasure)
+	})
+
+	require.NoError(t, err)
+	again := &domain.IdempotencyRecord{Key: erasure.Key, Fingerprint: []byte{1}, CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour)}
+	existing, reserved, err := keys.Reserve(ctx, again, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.True(t, existing.Completed)
+}
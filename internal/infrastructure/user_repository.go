@@ -0,0 +1,54 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+// UserRepository реализует методы для работы с настройками пользователя
+type UserRepository struct {
+	db *sql.DB
+}
+
+// NewUserRepository создает новый экземпляр UserRepository
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// GetTimezone возвращает часовой пояс пользователя
+func (r *UserRepository) GetTimezone(ctx context.Context, userID int64) (*time.Location, error) {
+	var name string
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT timezone FROM users WHERE id = $1`, userID).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("user %d: %w", userID, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return time.LoadLocation(name)
+}
+
+// SetTimezone сохраняет часовой пояс пользователя
+func (r *UserRepository) SetTimezone(ctx context.Context, userID int64, loc *time.Location) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE users SET timezone = $2 WHERE id = $1`, userID, loc.String())
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("user %d: %w", userID, domain.ErrNotFound)
+	}
+
+	return nil
+}
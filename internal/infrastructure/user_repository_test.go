@@ -0,0 +1,50 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/infrastructure"
+	"fincraft-finance/internal/testdb"
+)
+
+func Test_UserRepository_GetTimezone_ReturnsUTC_WhenNotChanged(t *testing.T) {
+	defer truncateIncomes(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewUserRepository(testdb.DB)
+
+	loc, err := repo.GetTimezone(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", loc.String())
+}
+
+func Test_UserRepository_SetTimezone_StoresLocation_WhenUserExists(t *testing.T) {
+	defer truncateIncomes(t)
+
+	seedDefaultUser(t)
+	repo := infrastructure.NewUserRepository(testdb.DB)
+	almaty, err := time.LoadLocation("Asia/Almaty")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, repo.SetTimezone(ctx, 1, almaty))
+
+	loc, err := repo.GetTimezone(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Almaty", loc.String())
+}
+
+func Test_UserRepository_SetTimezone_ReturnsNotFound_WhenUserMissing(t *testing.T) {
+	repo := infrastructure.NewUserRepository(testdb.DB)
+
+	err := repo.SetTimezone(context.Background(), 999, time.UTC)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,71 @@
+package interfaces
+
+import (
+	"context"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+)
+
+// CreateAccount создает счет пользователя
+func (h *FinanceHandler) CreateAccount(ctx context.Context, req *finance.CreateAccountRequest) (*finance.Account, error) {
+	opening, err := moneyFromProto(req.GetOpeningBalance(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := h.accounts.CreateAccount(ctx, &domain.Account{
+		UserID:         req.UserId,
+		Name:           req.Name,
+		Type:           accountTypes[req.Type],
+		OpeningBalance: opening,
+	})
+	if err != nil {
+		return nil, errorStatus(err, "failed to create account")
+	}
+
+	return accountToProto(account), nil
+}
+
+// ListAccounts возвращает счета пользователя с текущими остатками
+func (h *FinanceHandler) ListAccounts(ctx context.Context, req *finance.ListAccountsRequest) (*finance.ListAccountsResponse, error) {
+	accounts, err := h.accounts.ListAccounts(ctx, domain.AccountFilter{
+		UserID:        req.UserId,
+		IncludeClosed: req.IncludeClosed,
+	})
+	if err != nil {
+		return nil, errorStatus(err, "failed to list accounts")
+	}
+
+	resp := &finance.ListAccountsResponse{Accounts: make([]*finance.Account, 0, len(accounts))}
+	for i := range accounts {
+		resp.Accounts = append(resp.Accounts, accountToProto(&accounts[i]))
+	}
+
+	return resp, nil
+}
+
+// UpdateAccount изменяет поля счета, перечисленные в update_mask
+func (h *FinanceHandler) UpdateAccount(ctx context.Context, req *finance.UpdateAccountRequest) (*finance.Account, error) {
+	patch, err := accountPatchFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := h.accounts.UpdateAccount(ctx, req.UserId, req.Id, patch)
+	if err != nil {
+		return nil, errorStatus(err, "failed to update account")
+	}
+
+	return accountToProto(account), nil
+}
+
+// CloseAccount закрывает счет пользователя
+func (h *FinanceHandler) CloseAccount(ctx context.Context, req *finance.CloseAccountRequest) (*finance.Account, error) {
+	account, err := h.accounts.CloseAccount(ctx, req.UserId, req.Id)
+	if err != nil {
+		return nil, errorStatus(err, "failed to close account")
+	}
+
+	return accountToProto(account), nil
+}
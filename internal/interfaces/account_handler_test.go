@@ -0,0 +1,126 @@
+package interfaces_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupAccountTest(t *testing.T) (*gomock.Controller, *mocks.MockAccountService, *interfaces.FinanceHandler) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := mocks.NewMockAccountService(ctrl)
+	handler := interfaces.NewFinanceHandler(interfaces.Services{Accounts: mockUsecase})
+
+	return ctrl, mockUsecase, handler
+}
+
+func Test_FinanceHandler_CreateAccount_ReturnsAccountWithBalance_WhenValidRequest(t *testing.T) {
+	ctrl, mockUsecase, handler := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	opening := domain.NewMoney(-1550, kzt)
+	expected := &domain.Account{UserID: 1, Name: "Credit card", Type: domain.AccountTypeCard, OpeningBalance: opening}
+	mockUsecase.EXPECT().CreateAccount(ctx, expected).
+		Return(&domain.Account{ID: 3, UserID: 1, Name: "Credit card", Type: domain.AccountTypeCard,
+			OpeningBalance: opening, Balance: opening}, nil)
+
+	resp, err := handler.CreateAccount(ctx, &finance.CreateAccountRequest{
+		UserId:         1,
+		Name:           "Credit card",
+		Type:           finance.AccountType_ACCOUNT_TYPE_CARD,
+		Currency:       "KZT",
+		OpeningBalance: &finance.Decimal{Units: -15, Nanos: -500_000_000},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Id)
+	assert.Equal(t, finance.AccountType_ACCOUNT_TYPE_CARD, resp.Type)
+	assert.Equal(t, &finance.Decimal{Units: -15, Nanos: -500_000_000}, resp.Balance)
+	assert.False(t, resp.Closed)
+	assert.Nil(t, resp.ClosedAt)
+}
+
+func Test_FinanceHandler_CreateAccount_ReturnsInvalidArgument_WhenCurrencyUnknown(t *testing.T) {
+	_, _, handler := setupAccountTest(t)
+
+	_, err := handler.CreateAccount(context.Background(), &finance.CreateAccountRequest{UserId: 1, Name: "Cash", Currency: "XXX"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_FinanceHandler_ListAccounts_ReturnsClosedFlag_WhenAccountsFound(t *testing.T) {
+	ctrl, mockUsecase, handler := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().ListAccounts(ctx, domain.AccountFilter{UserID: 1, IncludeClosed: true}).
+		Return([]domain.Account{
+			{ID: 3, UserID: 1, Name: "Cash", Type: domain.AccountTypeCash, OpeningBalance: domain.NewMoney(0, kzt)},
+			{ID: 4, UserID: 1, Name: "Old", Type: domain.AccountTypeSavings, OpeningBalance: domain.NewMoney(0, kzt),
+				ClosedAt: time.Now()},
+		}, nil)
+
+	resp, err := handler.ListAccounts(ctx, &finance.ListAccountsRequest{UserId: 1, IncludeClosed: true})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Accounts, 2)
+	assert.False(t, resp.Accounts[0].Closed)
+	assert.True(t, resp.Accounts[1].Closed)
+	assert.NotNil(t, resp.Accounts[1].ClosedAt)
+}
+
+func Test_FinanceHandler_UpdateAccount_PassesPatchFromMask_WhenMaskValid(t *testing.T) {
+	ctrl, mockUsecase, handler := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	name := "Wallet"
+	opening := domain.NewMoney(10000, kzt)
+	mockUsecase.EXPECT().UpdateAccount(ctx, int64(1), int64(3), domain.AccountPatch{Name: &name, OpeningBalance: &opening}).
+		Return(&domain.Account{ID: 3, UserID: 1, Name: name, Type: domain.AccountTypeCash, OpeningBalance: opening}, nil)
+
+	resp, err := handler.UpdateAccount(ctx, &finance.UpdateAccountRequest{
+		UserId:         1,
+		Id:             3,
+		Name:           "Wallet",
+		OpeningBalance: &finance.Decimal{Units: 100},
+		Currency:       "KZT",
+		UpdateMask:     &fieldmaskpb.FieldMask{Paths: []string{"name", "opening_balance"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Wallet", resp.Name)
+}
+
+func Test_FinanceHandler_UpdateAccount_ReturnsInvalidArgument_WhenMaskEmpty(t *testing.T) {
+	_, _, handler := setupAccountTest(t)
+
+	_, err := handler.UpdateAccount(context.Background(), &finance.UpdateAccountRequest{UserId: 1, Id: 3})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_FinanceHandler_CloseAccount_ReturnsNotFound_WhenAccountMissing(t *testing.T) {
+	ctrl, mockUsecase, handler := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().CloseAccount(ctx, int64(1), int64(3)).Return(nil, domain.ErrNotFound)
+
+	_, err := handler.CloseAccount(ctx, &finance.CloseAccountRequest{UserId: 1, Id: 3})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
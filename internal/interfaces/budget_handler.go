@@ -0,0 +1,105 @@
+package interfaces
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+)
+
+// CreateBudget создает бюджет пользователя по категории
+func (h *FinanceHandler) CreateBudget(ctx context.Context, req *finance.CreateBudgetRequest) (*finance.Budget, error) {
+	limit, err := moneyFromProto(req.GetLimit(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+	start, err := dateFromProto(req.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	end, err := dateFromProto(req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	budget, err := h.budgets.CreateBudget(ctx, &domain.Budget{
+		UserID:     req.UserId,
+		CategoryID: int(req.CategoryId),
+		Period:     budgetPeriods[req.Period],
+		Limit:      limit,
+		Rollover:   req.Rollover,
+		StartDate:  start,
+		EndDate:    end,
+	})
+	if err != nil {
+		return nil, errorStatus(err, "failed to create budget")
+	}
+
+	return budgetToProto(budget), nil
+}
+
+// GetBudget возвращает бюджет пользователя по ID
+func (h *FinanceHandler) GetBudget(ctx context.Context, req *finance.GetBudgetRequest) (*finance.Budget, error) {
+	budget, err := h.budgets.GetBudget(ctx, req.UserId, req.Id)
+	if err != nil {
+		return nil, errorStatus(err, "failed to get budget")
+	}
+
+	return budgetToProto(budget), nil
+}
+
+// ListBudgets возвращает бюджеты пользователя
+func (h *FinanceHandler) ListBudgets(ctx context.Context, req *finance.ListBudgetsRequest) (*finance.ListBudgetsResponse, error) {
+	budgets, err := h.budgets.ListBudgets(ctx, req.UserId)
+	if err != nil {
+		return nil, errorStatus(err, "failed to list budgets")
+	}
+
+	resp := &finance.ListBudgetsResponse{Budgets: make([]*finance.Budget, 0, len(budgets))}
+	for i := range budgets {
+		resp.Budgets = append(resp.Budgets, budgetToProto(&budgets[i]))
+	}
+
+	return resp, nil
+}
+
+// UpdateBudget изменяет поля бюджета, перечисленные в update_mask
+func (h *FinanceHandler) UpdateBudget(ctx context.Context, req *finance.UpdateBudgetRequest) (*finance.Budget, error) {
+	patch, err := budgetPatchFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	budget, err := h.budgets.UpdateBudget(ctx, req.UserId, req.Id, patch)
+	if err != nil {
+		return nil, errorStatus(err, "failed to update budget")
+	}
+
+	return budgetToProto(budget), nil
+}
+
+// DeleteBudget удаляет бюджет пользователя
+func (h *FinanceHandler) DeleteBudget(ctx context.Context, req *finance.DeleteBudgetRequest) (*emptypb.Empty, error) {
+	if err := h.budgets.DeleteBudget(ctx, req.UserId, req.Id); err != nil {
+		return nil, errorStatus(err, "failed to delete budget")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetBudgetStatus возвращает положение по бюджету в периоде, содержащем дату запроса
+func (h *FinanceHandler) GetBudgetStatus(ctx context.Context, req *finance.GetBudgetStatusRequest) (*finance.BudgetStatus, error) {
+	date, err := dateFromProto(req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	budgetStatus, err := h.budgets.GetBudgetStatus(ctx, req.UserId, req.Id, date)
+	if err != nil {
+		return nil, errorStatus(err, "failed to get budget status")
+	}
+
+	return budgetStatusToProto(budgetStatus), nil
+}
@@ -0,0 +1,113 @@
+package interfaces_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupBudgetTest(t *testing.T) (*gomock.Controller, *mocks.MockBudgetService, *interfaces.FinanceHandler) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := mocks.NewMockBudgetService(ctrl)
+	handler := interfaces.NewFinanceHandler(interfaces.Services{Budgets: mockUsecase})
+
+	return ctrl, mockUsecase, handler
+}
+
+func Test_FinanceHandler_CreateBudget_ReturnsBudget_WhenValidRequest(t *testing.T) {
+	ctrl, mockUsecase, handler := setupBudgetTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	start := domain.Date{Year: 2024, Month: time.March, Day: 1}
+	end := domain.Date{Year: 2024, Month: time.March, Day: 20}
+	expected := &domain.Budget{UserID: 1, CategoryID: 6, Period: domain.BudgetPeriodCustom,
+		Limit: domain.NewMoney(5_000_000, kzt), StartDate: start, EndDate: end}
+	created := *expected
+	created.ID = 5
+	mockUsecase.EXPECT().CreateBudget(ctx, expected).Return(&created, nil)
+
+	resp, err := handler.CreateBudget(ctx, &finance.CreateBudgetRequest{
+		UserId:     1,
+		CategoryId: 6,
+		Period:     finance.BudgetPeriod_BUDGET_PERIOD_CUSTOM,
+		Limit:      &finance.Decimal{Units: 50_000},
+		Currency:   "KZT",
+		StartDate:  "2024-03-01",
+		EndDate:    "2024-03-20",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), resp.Id)
+	assert.Equal(t, finance.BudgetPeriod_BUDGET_PERIOD_CUSTOM, resp.Period)
+	assert.Equal(t, "2024-03-20", resp.EndDate)
+}
+
+func Test_FinanceHandler_CreateBudget_ReturnsInvalidArgument_WhenDateMalformed(t *testing.T) {
+	_, _, handler := setupBudgetTest(t)
+
+	_, err := handler.CreateBudget(context.Background(), &finance.CreateBudgetRequest{
+		UserId: 1, CategoryId: 6, Limit: &finance.Decimal{Units: 100}, Currency: "KZT", StartDate: "01.03.2024",
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_FinanceHandler_UpdateBudget_ReturnsInvalidArgument_WhenMaskPathUnsupported(t *testing.T) {
+	_, _, handler := setupBudgetTest(t)
+
+	_, err := handler.UpdateBudget(context.Background(), &finance.UpdateBudgetRequest{
+		UserId: 1, Id: 5, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"period"}},
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_FinanceHandler_GetBudgetStatus_ReturnsOverspendFlags_WhenStatusFound(t *testing.T) {
+	ctrl, mockUsecase, handler := setupBudgetTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	budget := domain.Budget{ID: 5, UserID: 1, CategoryID: 6, Period: domain.BudgetPeriodMonthly,
+		Limit: domain.NewMoney(10000, kzt)}
+	from, to := budget.PeriodOn(domain.Date{Year: 2024, Month: time.April, Day: 10})
+	budgetStatus, err := domain.NewBudgetStatus(budget, from, to, domain.NewMoney(0, kzt), domain.NewMoney(12000, kzt),
+		domain.Date{Year: 2024, Month: time.April, Day: 10})
+	require.NoError(t, err)
+	mockUsecase.EXPECT().GetBudgetStatus(ctx, int64(1), int64(5), domain.Date{Year: 2024, Month: time.April, Day: 10}).
+		Return(&budgetStatus, nil)
+
+	resp, err := handler.GetBudgetStatus(ctx, &finance.GetBudgetStatusRequest{UserId: 1, Id: 5, Date: "2024-04-10"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "2024-04-01", resp.PeriodStart)
+	assert.Equal(t, "2024-04-30", resp.PeriodEnd)
+	assert.Equal(t, &finance.Decimal{Units: -20}, resp.Remaining)
+	assert.InDelta(t, 120.0, resp.PercentUsed, 1e-9)
+	assert.True(t, resp.Overspent)
+	assert.True(t, resp.ProjectedOverspend)
+}
+
+func Test_FinanceHandler_GetBudget_ReturnsNotFound_WhenBudgetMissing(t *testing.T) {
+	ctrl, mockUsecase, handler := setupBudgetTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().GetBudget(ctx, int64(1), int64(9)).Return(nil, domain.ErrNotFound)
+
+	_, err := handler.GetBudget(ctx, &finance.GetBudgetRequest{UserId: 1, Id: 9})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
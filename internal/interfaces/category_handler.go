@@ -0,0 +1,81 @@
+package interfaces
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+)
+
+// CreateCategory создает категорию пользователя
+func (h *FinanceHandler) CreateCategory(ctx context.Context, req *finance.CreateCategoryRequest) (*finance.Category, error) {
+	category, err := h.categories.CreateCategory(ctx, &domain.Category{
+		UserID:   req.UserId,
+		Name:     req.Name,
+		Kind:     categoryKindFromProto(req.Kind),
+		Icon:     req.Icon,
+		ParentID: int(req.ParentId),
+	})
+	if err != nil {
+		return nil, errorStatus(err, "failed to create category")
+	}
+
+	return categoryToProto(category), nil
+}
+
+// ListCategories возвращает системные категории и категории пользователя
+func (h *FinanceHandler) ListCategories(ctx context.Context, req *finance.ListCategoriesRequest) (*finance.ListCategoriesResponse, error) {
+	kind := categoryKindFromProto(req.Kind)
+	if kind == 0 && req.Kind != finance.CategoryKind_CATEGORY_KIND_UNSPECIFIED {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown category kind %d", req.Kind)
+	}
+
+	categories, err := h.categories.ListCategories(ctx, domain.CategoryFilter{
+		UserID:          req.UserId,
+		Kind:            kind,
+		IncludeArchived: req.IncludeArchived,
+	})
+	if err != nil {
+		return nil, errorStatus(err, "failed to list categories")
+	}
+
+	resp := &finance.ListCategoriesResponse{Categories: make([]*finance.Category, 0, len(categories))}
+	for i := range categories {
+		resp.Categories = append(resp.Categories, categoryToProto(&categories[i]))
+	}
+
+	return resp, nil
+}
+
+// RenameCategory меняет название категории пользователя
+func (h *FinanceHandler) RenameCategory(ctx context.Context, req *finance.RenameCategoryRequest) (*finance.Category, error) {
+	category, err := h.categories.RenameCategory(ctx, req.UserId, int(req.Id), req.Name)
+	if err != nil {
+		return nil, errorStatus(err, "failed to rename category")
+	}
+
+	return categoryToProto(category), nil
+}
+
+// ArchiveCategory переводит категорию пользователя в архив
+func (h *FinanceHandler) ArchiveCategory(ctx context.Context, req *finance.ArchiveCategoryRequest) (*finance.Category, error) {
+	category, err := h.categories.ArchiveCategory(ctx, req.UserId, int(req.Id))
+	if err != nil {
+		return nil, errorStatus(err, "failed to archive category")
+	}
+
+	return categoryToProto(category), nil
+}
+
+// MergeCategories объединяет категорию source с категорией target
+func (h *FinanceHandler) MergeCategories(ctx context.Context, req *finance.MergeCategoriesRequest) (*finance.Category, error) {
+	category, err := h.categories.MergeCategories(ctx, req.UserId, int(req.SourceId), int(req.TargetId))
+	if err != nil {
+		return nil, errorStatus(err, "failed to merge categories")
+	}
+
+	return categoryToProto(category), nil
+}
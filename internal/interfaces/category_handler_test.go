@@ -0,0 +1,130 @@
+package interfaces_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupCategoryTest(t *testing.T) (*gomock.Controller, *mocks.MockCategoryService, *interfaces.FinanceHandler) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := mocks.NewMockCategoryService(ctrl)
+	handler := interfaces.NewFinanceHandler(interfaces.Services{Categories: mockUsecase})
+
+	return ctrl, mockUsecase, handler
+}
+
+func Test_FinanceHandler_CreateCategory_ReturnsCategory_WhenValidRequest(t *testing.T) {
+	ctrl, mockUsecase, handler := setupCategoryTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expected := &domain.Category{UserID: 1, Name: "Bonus", Kind: domain.CategoryKindIncome, Icon: "star", ParentID: 1}
+	created := *expected
+	created.ID = 20
+	mockUsecase.EXPECT().CreateCategory(ctx, expected).Return(&created, nil)
+
+	resp, err := handler.CreateCategory(ctx, &finance.CreateCategoryRequest{
+		UserId: 1, Name: "Bonus", Kind: finance.CategoryKind_CATEGORY_KIND_INCOME, Icon: "star", ParentId: 1,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(20), resp.Id)
+	assert.Equal(t, finance.CategoryKind_CATEGORY_KIND_INCOME, resp.Kind)
+	assert.False(t, resp.System)
+}
+
+func Test_FinanceHandler_CreateCategory_ReturnsInvalidArgument_WhenUsecaseRejects(t *testing.T) {
+	ctrl, mockUsecase, handler := setupCategoryTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().CreateCategory(ctx, gomock.Any()).
+		Return(nil, fmt.Errorf("%w: category kind must be income or expense", usecases.ErrValidation))
+
+	_, err := handler.CreateCategory(ctx, &finance.CreateCategoryRequest{UserId: 1, Name: "Bonus"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_FinanceHandler_ListCategories_ReturnsSystemFlag_WhenCategoriesFound(t *testing.T) {
+	ctrl, mockUsecase, handler := setupCategoryTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().ListCategories(ctx, domain.CategoryFilter{UserID: 1, Kind: domain.CategoryKindExpense}).
+		Return([]domain.Category{
+			{ID: 6, Name: "Groceries", Kind: domain.CategoryKindExpense},
+			{ID: 20, UserID: 1, Name: "Coffee", Kind: domain.CategoryKindExpense, ParentID: 6},
+		}, nil)
+
+	resp, err := handler.ListCategories(ctx, &finance.ListCategoriesRequest{
+		UserId: 1, Kind: finance.CategoryKind_CATEGORY_KIND_EXPENSE,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Categories, 2)
+	assert.True(t, resp.Categories[0].System)
+	assert.False(t, resp.Categories[1].System)
+	assert.Equal(t, int32(6), resp.Categories[1].ParentId)
+}
+
+func Test_FinanceHandler_ListCategories_ReturnsInvalidArgument_WhenKindUnknown(t *testing.T) {
+	_, _, handler := setupCategoryTest(t)
+
+	_, err := handler.ListCategories(context.Background(), &finance.ListCategoriesRequest{UserId: 1, Kind: 9})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_FinanceHandler_RenameCategory_ReturnsNotFound_WhenCategoryMissing(t *testing.T) {
+	ctrl, mockUsecase, handler := setupCategoryTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().RenameCategory(ctx, int64(1), 20, "Food").Return(nil, domain.ErrNotFound)
+
+	_, err := handler.RenameCategory(ctx, &finance.RenameCategoryRequest{UserId: 1, Id: 20, Name: "Food"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_FinanceHandler_ArchiveCategory_ReturnsArchivedCategory_WhenCategoryOwned(t *testing.T) {
+	ctrl, mockUsecase, handler := setupCategoryTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().ArchiveCategory(ctx, int64(1), 20).
+		Return(&domain.Category{ID: 20, UserID: 1, Name: "Old", Kind: domain.CategoryKindExpense, Archived: true}, nil)
+
+	resp, err := handler.ArchiveCategory(ctx, &finance.ArchiveCategoryRequest{UserId: 1, Id: 20})
+
+	require.NoError(t, err)
+	assert.True(t, resp.Archived)
+}
+
+func Test_FinanceHandler_MergeCategories_ReturnsTarget_WhenMerged(t *testing.T) {
+	ctrl, mockUsecase, handler := setupCategoryTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().MergeCategories(ctx, int64(1), 20, 6).
+		Return(&domain.Category{ID: 6, Name: "Groceries", Kind: domain.CategoryKindExpense}, nil)
+
+	resp, err := handler.MergeCategories(ctx, &finance.MergeCategoriesRequest{UserId: 1, SourceId: 20, TargetId: 6})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(6), resp.Id)
+}
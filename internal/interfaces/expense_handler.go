@@ -0,0 +1,107 @@
+package interfaces
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+)
+
+// AddExpense добавляет расход
+func (h *FinanceHandler) AddExpense(ctx context.Context, req *finance.AddExpenseRequest) (*finance.Expense, error) {
+	amount, err := moneyFromProto(req.GetAmount(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	expense, err := h.expenses.AddExpense(ctx, &domain.Expense{
+		UserID:      req.UserId,
+		CategoryID:  int(req.CategoryId),
+		Amount:      amount,
+		AccountID:   req.AccountId,
+		Description: req.Description,
+		OccurredAt:  timeFromProto(req.OccurredAt),
+	})
+	if err != nil {
+		return nil, errorStatus(err, "failed to add expense")
+	}
+
+	return expenseToProto(expense), nil
+}
+
+// GetExpense возвращает расход по ID
+func (h *FinanceHandler) GetExpense(ctx context.Context, req *finance.GetExpenseRequest) (*finance.Expense, error) {
+	expense, err := h.expenses.GetExpense(ctx, req.UserId, req.Id)
+	if err != nil {
+		return nil, errorStatus(err, "failed to get expense")
+	}
+
+	return expenseToProto(expense), nil
+}
+
+// ListExpenses возвращает страницу расходов пользователя
+func (h *FinanceHandler) ListExpenses(ctx context.Context, req *finance.ListExpensesRequest) (*finance.ListExpensesResponse, error) {
+	token, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := domain.ExpenseFilter{
+		UserID:     req.UserId,
+		CategoryID: int(req.CategoryId),
+		AccountID:  req.AccountId,
+		PageSize:   int(req.PageSize),
+	}
+	if token != nil {
+		filter.AfterID = token.ID
+	}
+
+	page, err := h.expenses.ListExpenses(ctx, filter)
+	if err != nil {
+		return nil, errorStatus(err, "failed to list expenses")
+	}
+
+	resp := &finance.ListExpensesResponse{Expenses: make([]*finance.Expense, 0, len(page.Items))}
+	for i := range page.Items {
+		resp.Expenses = append(resp.Expenses, expenseToProto(&page.Items[i]))
+	}
+	if page.HasMore {
+		resp.NextPageToken = encodePageToken(pageToken{ID: page.Items[len(page.Items)-1].ID})
+	}
+
+	return resp, nil
+}
+
+// UpdateExpense изменяет расход
+func (h *FinanceHandler) UpdateExpense(ctx context.Context, req *finance.UpdateExpenseRequest) (*finance.Expense, error) {
+	amount, err := moneyFromProto(req.GetAmount(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	expense, err := h.expenses.UpdateExpense(ctx, &domain.Expense{
+		ID:          req.Id,
+		UserID:      req.UserId,
+		CategoryID:  int(req.CategoryId),
+		Amount:      amount,
+		AccountID:   req.AccountId,
+		Description: req.Description,
+		OccurredAt:  timeFromProto(req.OccurredAt),
+	})
+	if err != nil {
+		return nil, errorStatus(err, "failed to update expense")
+	}
+
+	return expenseToProto(expense), nil
+}
+
+// DeleteExpense удаляет расход
+func (h *FinanceHandler) DeleteExpense(ctx context.Context, req *finance.DeleteExpenseRequest) (*emptypb.Empty, error) {
+	if err := h.expenses.DeleteExpense(ctx, req.UserId, req.Id); err != nil {
+		return nil, errorStatus(err, "failed to delete expense")
+	}
+
+	return &emptypb.Empty{}, nil
+}
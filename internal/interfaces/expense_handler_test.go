@@ -0,0 +1,211 @@
+package interfaces_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupExpenseTest(t *testing.T) (*gomock.Controller, *mocks.MockExpenseService, *interfaces.FinanceHandler) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := mocks.NewMockExpenseService(ctrl)
+	handler := interfaces.NewFinanceHandler(interfaces.Services{Expenses: mockUsecase})
+
+	return ctrl, mockUsecase, handler
+}
+
+func testExpense(id int64) *domain.Expense {
+	created := time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC)
+	return &domain.Expense{
+		ID:          id,
+		UserID:      1,
+		CategoryID:  2,
+		Amount:      domain.NewMoney(10050, kzt),
+		Description: "Test expense",
+		CreatedAt:   created,
+		UpdatedAt:   created,
+	}
+}
+
+func Test_FinanceHandler_AddExpense_ReturnsExpense_WhenValidInput(t *testing.T) {
+	ctrl, mockUsecase, handler := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	req := &finance.AddExpenseRequest{
+		UserId:      1,
+		CategoryId:  2,
+		Amount:      &finance.Decimal{Units: 100, Nanos: 500_000_000},
+		Currency:    "KZT",
+		Description: "Test expense",
+		AccountId:   3,
+	}
+
+	ctx := context.Background()
+	input := testExpense(0)
+	input.CreatedAt, input.UpdatedAt = time.Time{}, time.Time{}
+	input.AccountID = 3
+	created := testExpense(10)
+	created.AccountID = 3
+	mockUsecase.EXPECT().AddExpense(ctx, input).Return(created, nil)
+
+	resp, err := handler.AddExpense(ctx, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), resp.Id)
+	assert.Equal(t, int64(3), resp.AccountId)
+	assert.Equal(t, int64(100), resp.Amount.Units)
+	assert.Equal(t, int32(500_000_000), resp.Amount.Nanos)
+	assert.Equal(t, "KZT", resp.Currency)
+	assert.Equal(t, int64(1710496800), resp.CreatedAt.Seconds)
+}
+
+func Test_FinanceHandler_AddExpense_ReturnsInvalidArgument_WhenValidationFails(t *testing.T) {
+	ctrl, mockUsecase, handler := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	req := &finance.AddExpenseRequest{
+		UserId:     1,
+		CategoryId: 0,
+		Amount:     &finance.Decimal{Units: 100},
+		Currency:   "KZT",
+	}
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().AddExpense(ctx, gomock.Any()).
+		Return(nil, fmt.Errorf("%w: category ID must be valid", usecases.ErrValidation))
+
+	resp, err := handler.AddExpense(ctx, req)
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), "failed to add expense: validation failed: category ID must be valid")
+}
+
+func Test_FinanceHandler_AddExpense_ReturnsInternalError_WhenUseCaseFails(t *testing.T) {
+	ctrl, mockUsecase, handler := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	req := &finance.AddExpenseRequest{UserId: 1, CategoryId: 2, Amount: &finance.Decimal{Units: 1}, Currency: "KZT"}
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().AddExpense(ctx, gomock.Any()).Return(nil, errors.New("db error"))
+
+	resp, err := handler.AddExpense(ctx, req)
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Contains(t, err.Error(), "failed to add expense: db error")
+}
+
+func Test_FinanceHandler_GetExpense_ReturnsNotFound_WhenExpenseMissing(t *testing.T) {
+	ctrl, mockUsecase, handler := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().GetExpense(ctx, int64(1), int64(10)).
+		Return(nil, fmt.Errorf("expense 10: %w", domain.ErrNotFound))
+
+	resp, err := handler.GetExpense(ctx, &finance.GetExpenseRequest{UserId: 1, Id: 10})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_FinanceHandler_GetExpense_ReturnsExpense_WhenExists(t *testing.T) {
+	ctrl, mockUsecase, handler := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().GetExpense(ctx, int64(1), int64(10)).Return(testExpense(10), nil)
+
+	resp, err := handler.GetExpense(ctx, &finance.GetExpenseRequest{UserId: 1, Id: 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Test expense", resp.Description)
+}
+
+func Test_FinanceHandler_ListExpenses_ReturnsNextPageToken_WhenHasMore(t *testing.T) {
+	ctrl, mockUsecase, handler := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().
+		ListExpenses(ctx, domain.ExpenseFilter{UserID: 1, PageSize: 2}).
+		Return(domain.ExpensePage{Items: []domain.Expense{*testExpense(12), *testExpense(11)}, HasMore: true}, nil)
+
+	resp, err := handler.ListExpenses(ctx, &finance.ListExpensesRequest{UserId: 1, PageSize: 2})
+	require.NoError(t, err)
+	assert.Len(t, resp.Expenses, 2)
+	require.NotEmpty(t, resp.NextPageToken)
+
+	mockUsecase.EXPECT().
+		ListExpenses(ctx, domain.ExpenseFilter{UserID: 1, AfterID: 11, PageSize: 2}).
+		Return(domain.ExpensePage{Items: []domain.Expense{*testExpense(10)}}, nil)
+
+	resp, err = handler.ListExpenses(ctx, &finance.ListExpensesRequest{UserId: 1, PageSize: 2, PageToken: resp.NextPageToken})
+	require.NoError(t, err)
+	assert.Len(t, resp.Expenses, 1)
+	assert.Empty(t, resp.NextPageToken)
+}
+
+func Test_FinanceHandler_ListExpenses_ReturnsInvalidArgument_WhenPageTokenMalformed(t *testing.T) {
+	ctrl, _, handler := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	resp, err := handler.ListExpenses(context.Background(), &finance.ListExpensesRequest{UserId: 1, PageToken: "!!"})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_FinanceHandler_UpdateExpense_ReturnsExpense_WhenValidInput(t *testing.T) {
+	ctrl, mockUsecase, handler := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	req := &finance.UpdateExpenseRequest{
+		UserId:      1,
+		Id:          10,
+		CategoryId:  2,
+		Amount:      &finance.Decimal{Units: 100, Nanos: 500_000_000},
+		Currency:    "KZT",
+		Description: "Test expense",
+	}
+
+	ctx := context.Background()
+	input := testExpense(10)
+	input.CreatedAt, input.UpdatedAt = time.Time{}, time.Time{}
+	mockUsecase.EXPECT().UpdateExpense(ctx, input).Return(testExpense(10), nil)
+
+	resp, err := handler.UpdateExpense(ctx, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), resp.Id)
+}
+
+func Test_FinanceHandler_DeleteExpense_ReturnsNotFound_WhenExpenseMissing(t *testing.T) {
+	ctrl, mockUsecase, handler := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().DeleteExpense(ctx, int64(1), int64(10)).
+		Return(fmt.Errorf("expense 10: %w", domain.ErrNotFound))
+
+	resp, err := handler.DeleteExpense(ctx, &finance.DeleteExpenseRequest{UserId: 1, Id: 10})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
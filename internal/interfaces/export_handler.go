@@ -0,0 +1,74 @@
+package interfaces
+
+import (
+	"bufio"
+	"io"
+
+	"fincraft-finance/api/finance"
+)
+
+// exportChunkSize размер буфера выгрузки: заполненный буфер отправляется одним сообщением потока
+const exportChunkSize = 64 << 10
+
+// chunkStream поток ответа, передающий файл частями
+type chunkStream interface {
+	Send(*finance.ExportChunk) error
+}
+
+// chunkSender отправляет записанные байты сообщениями потока выгрузки.
+// Первое сообщение дополнительно несет тип содержимого и имя файла.
+type chunkSender struct {
+	stream chunkStream
+	header *finance.ExportChunk
+}
+
+// Write отправляет p одним сообщением
+func (c *chunkSender) Write(p []byte) (int, error) {
+	chunk := &finance.ExportChunk{Data: p}
+	if c.header != nil {
+		chunk.ContentType, chunk.FileName = c.header.ContentType, c.header.FileName
+	}
+	if err := c.stream.Send(chunk); err != nil {
+		return 0, err
+	}
+	c.header = nil
+	return len(p), nil
+}
+
+// sendFile передает потоком частей файл, который пишет write. Первая часть несет тип содержимого и имя файла
+// и отправляется, даже если файл пуст.
+func sendFile(stream chunkStream, contentType, fileName string, write func(w io.Writer) error) error {
+	sender := &chunkSender{stream: stream, header: &finance.ExportChunk{ContentType: contentType, FileName: fileName}}
+	buf := bufio.NewWriterSize(sender, exportChunkSize)
+	if err := write(buf); err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	if sender.header != nil {
+		if _, err := sender.Write(nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportTransactions выгружает операции пользователя в файл и передает его потоком частей.
+// Use-case читает операции страницами, поэтому ни операции, ни файл целиком в памяти не держатся.
+func (h *FinanceHandler) ExportTransactions(req *finance.ExportTransactionsRequest,
+	stream finance.FinanceService_ExportTransactionsServer) error {
+	query, err := exportQueryFromProto(req)
+	if err != nil {
+		return err
+	}
+
+	format := query.Options.Format
+	err = sendFile(stream, format.ContentType(), "transactions."+format.Extension(), func(w io.Writer) error {
+		return h.exports.ExportTransactions(stream.Context(), query, w)
+	})
+	if err != nil {
+		return errorStatus(err, "failed to export transactions")
+	}
+	return nil
+}
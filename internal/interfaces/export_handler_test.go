@@ -0,0 +1,103 @@
+package interfaces_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/export"
+	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+// exportStream поток выгрузки файла, запоминающий отправленные части
+type exportStream struct {
+	grpc.ServerStream
+	chunks []*finance.ExportChunk
+}
+
+// Context возвращает контекст потока
+func (s *exportStream) Context() context.Context {
+	return context.Background()
+}
+
+// Send запоминает копию части: буфер выгрузки переиспользуется после отправки
+func (s *exportStream) Send(chunk *finance.ExportChunk) error {
+	s.chunks = append(s.chunks, &finance.ExportChunk{Data: bytes.Clone(chunk.Data), ContentType: chunk.ContentType,
+		FileName: chunk.FileName})
+	return nil
+}
+
+func setupExportTest(t *testing.T) (*gomock.Controller, *mocks.MockExportService, *interfaces.FinanceHandler) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := mocks.NewMockExportService(ctrl)
+	handler := interfaces.NewFinanceHandler(interfaces.Services{Exports: mockUsecase})
+
+	return ctrl, mockUsecase, handler
+}
+
+func Test_FinanceHandler_ExportTransactions_StreamsFile_WhenValidRequest(t *testing.T) {
+	ctrl, mockUsecase, handler := setupExportTest(t)
+	defer ctrl.Finish()
+
+	query := export.Query{UserID: 1, Kinds: []export.Kind{export.KindIncome, export.KindTransfer},
+		FromDate: domain.Date{Year: 2024, Month: 1, Day: 1}, Tags: []string{"work"},
+		Options: export.Options{Format: export.FormatCSV, Locale: "ru", Delimiter: '\t'}}
+	mockUsecase.EXPECT().ExportTransactions(gomock.Any(), query, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ export.Query, w io.Writer) error {
+			_, err := io.WriteString(w, "Вид\tID\n")
+			return err
+		})
+	stream := &exportStream{}
+
+	err := handler.ExportTransactions(&finance.ExportTransactionsRequest{
+		UserId:    1,
+		Format:    finance.ExportFormat_EXPORT_FORMAT_CSV,
+		Kinds:     []finance.TransactionKind{finance.TransactionKind_TRANSACTION_KIND_INCOME, finance.TransactionKind_TRANSACTION_KIND_TRANSFER},
+		FromDate:  "2024-01-01",
+		Tags:      []string{"work"},
+		Locale:    "ru",
+		Delimiter: "\t",
+	}, stream)
+
+	require.NoError(t, err)
+	require.Len(t, stream.chunks, 1)
+	assert.Equal(t, "text/csv", stream.chunks[0].ContentType)
+	assert.Equal(t, "transactions.csv", stream.chunks[0].FileName)
+	assert.Equal(t, "Вид\tID\n", string(stream.chunks[0].Data))
+}
+
+func Test_FinanceHandler_ExportTransactions_SendsHeaderChunk_WhenExportEmpty(t *testing.T) {
+	ctrl, mockUsecase, handler := setupExportTest(t)
+	defer ctrl.Finish()
+
+	mockUsecase.EXPECT().ExportTransactions(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	stream := &exportStream{}
+
+	err := handler.ExportTransactions(&finance.ExportTransactionsRequest{UserId: 1,
+		Format: finance.ExportFormat_EXPORT_FORMAT_JSONL}, stream)
+
+	require.NoError(t, err)
+	require.Len(t, stream.chunks, 1)
+	assert.Equal(t, "transactions.jsonl", stream.chunks[0].FileName)
+	assert.Empty(t, stream.chunks[0].Data)
+}
+
+func Test_FinanceHandler_ExportTransactions_ReturnsInvalidArgument_WhenDelimiterTooLong(t *testing.T) {
+	ctrl, _, handler := setupExportTest(t)
+	defer ctrl.Finish()
+
+	err := handler.ExportTransactions(&finance.ExportTransactionsRequest{UserId: 1, Delimiter: ";;"}, &exportStream{})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
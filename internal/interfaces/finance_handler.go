@@ -2,32 +2,260 @@ package interfaces
 
 import (
 	"context"
+	"errors"
+	"io"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
 	"fincraft-finance/internal/usecases"
 )
 
+// Services набор use-case, которые обслуживает FinanceHandler
+type Services struct {
+	Incomes    usecases.IncomeService
+	Expenses   usecases.ExpenseService
+	Users      usecases.UserService
+	Categories usecases.CategoryService
+	Accounts   usecases.AccountService
+	Transfers  usecases.TransferService
+	Budgets    usecases.BudgetService
+	Recurring  usecases.RecurringService
+	Statements usecases.StatementService
+	Rules      usecases.RuleService
+	Tags       usecases.TagService
+	Reports    usecases.ReportService
+	Exports    usecases.ExportService
+	UserData   usecases.UserDataService
+}
+
 // FinanceHandler обрабатывает запросы к сервису финансов
 type FinanceHandler struct {
 	finance.UnimplementedFinanceServiceServer
-	usecase usecases.IncomeService
+	incomes    usecases.IncomeService
+	expenses   usecases.ExpenseService
+	users      usecases.UserService
+	categories usecases.CategoryService
+	accounts   usecases.AccountService
+	transfers  usecases.TransferService
+	budgets    usecases.BudgetService
+	recurring  usecases.RecurringService
+	statements usecases.StatementService
+	rules      usecases.RuleService
+	tags       usecases.TagService
+	reports    usecases.ReportService
+	exports    usecases.ExportService
+	userData   usecases.UserDataService
 }
 
 // NewFinanceHandler создает новый экземпляр FinanceHandler
-func NewFinanceHandler(usecase usecases.IncomeService) *FinanceHandler {
-	return &FinanceHandler{usecase: usecase}
+func NewFinanceHandler(services Services) *FinanceHandler {
+	return &FinanceHandler{
+		incomes:    services.Incomes,
+		expenses:   services.Expenses,
+		users:      services.Users,
+		categories: services.Categories,
+		accounts:   services.Accounts,
+		transfers:  services.Transfers,
+		budgets:    services.Budgets,
+		recurring:  services.Recurring,
+		statements: services.Statements,
+		rules:      services.Rules,
+		tags:       services.Tags,
+		reports:    services.Reports,
+		exports:    services.Exports,
+		userData:   services.UserData,
+	}
+}
+
+// AddIncome добавляет доход и возвращает его с присвоенным ID и вероятными дубликатами
+func (h *FinanceHandler) AddIncome(ctx context.Context, req *finance.AddIncomeRequest) (*finance.Income, error) {
+	income, err := incomeFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	income, duplicates, err := h.incomes.AddIncome(ctx, income)
+	if err != nil {
+		return nil, errorStatus(err, "failed to add income")
+	}
+
+	resp := incomeToProto(income)
+	resp.ProbableDuplicates = duplicatesToProto(duplicates)
+	return resp, nil
+}
+
+// GetIncome возвращает доход по ID
+func (h *FinanceHandler) GetIncome(ctx context.Context, req *finance.GetIncomeRequest) (*finance.Income, error) {
+	income, err := h.incomes.GetIncome(ctx, req.UserId, req.Id)
+	if err != nil {
+		return nil, errorStatus(err, "failed to get income")
+	}
+
+	return incomeToProto(income), nil
 }
 
-// AddIncome добавляет доход
-func (h *FinanceHandler) AddIncome(ctx context.Context, req *finance.AddIncomeRequest) (*emptypb.Empty, error) {
-	err := h.usecase.AddIncome(ctx, req.UserId, int(req.CategoryId), req.Amount, req.Description)
+// ListIncomes возвращает страницу доходов пользователя по фильтру
+func (h *FinanceHandler) ListIncomes(ctx context.Context, req *finance.ListIncomesRequest) (*finance.ListIncomesResponse, error) {
+	filter, err := incomeFilterFromProto(req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to add income: %v", err)
+		return nil, err
+	}
+
+	page, err := h.incomes.ListIncomes(ctx, filter)
+	if err != nil {
+		return nil, errorStatus(err, "failed to list incomes")
+	}
+
+	resp := &finance.ListIncomesResponse{Incomes: make([]*finance.Income, 0, len(page.Items))}
+	for i := range page.Items {
+		resp.Incomes = append(resp.Incomes, incomeToProto(&page.Items[i]))
+	}
+	if page.HasMore {
+		cursor := page.Items[len(page.Items)-1].Cursor(filter.SortBy)
+		resp.NextPageToken = encodePageToken(pageToken{ID: cursor.ID, Sort: int(cursor.SortBy), Value: cursor.Value})
+	}
+
+	return resp, nil
+}
+
+// UpdateIncome изменяет поля дохода, перечисленные в update_mask
+func (h *FinanceHandler) UpdateIncome(ctx context.Context, req *finance.UpdateIncomeRequest) (*finance.Income, error) {
+	patch, err := incomePatchFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	income, err := h.incomes.UpdateIncome(ctx, req.UserId, req.Id, patch)
+	if err != nil {
+		return nil, errorStatus(err, "failed to update income")
+	}
+
+	return incomeToProto(income), nil
+}
+
+// DeleteIncome удаляет доход
+func (h *FinanceHandler) DeleteIncome(ctx context.Context, req *finance.DeleteIncomeRequest) (*emptypb.Empty, error) {
+	if err := h.incomes.DeleteIncome(ctx, req.UserId, req.Id); err != nil {
+		return nil, errorStatus(err, "failed to delete income")
 	}
 
 	return &emptypb.Empty{}, nil
 }
+
+// MergeTransactions объединяет доход-дубликат с доходом keep_id
+func (h *FinanceHandler) MergeTransactions(ctx context.Context, req *finance.MergeTransactionsRequest) (*finance.MergeTransactionsResponse, error) {
+	income, sources, err := h.incomes.MergeIncomes(ctx, req.UserId, req.KeepId, req.DuplicateId)
+	if err != nil {
+		return nil, errorStatus(err, "failed to merge incomes")
+	}
+
+	resp := &finance.MergeTransactionsResponse{
+		Income:  incomeToProto(income),
+		Sources: make([]*finance.IncomeSource, 0, len(sources)),
+	}
+	for i := range sources {
+		resp.Sources = append(resp.Sources, &finance.IncomeSource{
+			Income:   incomeToProto(&sources[i].Income),
+			MergedAt: timestamppb.New(sources[i].MergedAt),
+		})
+	}
+	return resp, nil
+}
+
+// BatchAddIncomes добавляет пакет доходов одной транзакцией
+func (h *FinanceHandler) BatchAddIncomes(ctx context.Context, req *finance.BatchAddIncomesRequest) (*finance.BatchAddIncomesResponse, error) {
+	incomes := make([]*domain.Income, 0, len(req.Incomes))
+	for i, item := range req.Incomes {
+		income, err := batchIncomeFromProto(i, req.UserId, item)
+		if err != nil {
+			return nil, err
+		}
+		incomes = append(incomes, income)
+	}
+
+	return h.addIncomes(ctx, incomes, batchModeFromProto(req.Mode))
+}
+
+// ImportIncomes принимает поток доходов и записывает их одной транзакцией после завершения потока
+func (h *FinanceHandler) ImportIncomes(stream finance.FinanceService_ImportIncomesServer) error {
+	var (
+		userID  int64
+		mode    finance.BatchMode
+		incomes []*domain.Income
+	)
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(incomes) == 0 {
+			userID, mode = req.UserId, req.Mode
+		}
+		if req.UserId != 0 && req.UserId != userID {
+			return status.Errorf(codes.InvalidArgument, "item %d: user ID differs from the first message", len(incomes))
+		}
+		if len(incomes) == domain.MaxBatchSize {
+			return status.Errorf(codes.InvalidArgument, "import must not exceed %d incomes", domain.MaxBatchSize)
+		}
+
+		income, err := batchIncomeFromProto(len(incomes), userID, req.Income)
+		if err != nil {
+			return err
+		}
+		incomes = append(incomes, income)
+	}
+
+	resp, err := h.addIncomes(stream.Context(), incomes, batchModeFromProto(mode))
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(resp)
+}
+
+// addIncomes записывает пакет доходов и собирает результат по каждому элементу
+func (h *FinanceHandler) addIncomes(ctx context.Context, incomes []*domain.Income, mode domain.BatchMode) (*finance.BatchAddIncomesResponse, error) {
+	results, err := h.incomes.AddIncomes(ctx, incomes, mode)
+	if err != nil {
+		return nil, errorStatus(err, "failed to add incomes")
+	}
+
+	resp := &finance.BatchAddIncomesResponse{Results: make([]*finance.IncomeResult, 0, len(results))}
+	for i, result := range results {
+		item := &finance.IncomeResult{Index: int32(i)}
+		if result.Err != nil {
+			st := status.Convert(errorStatus(result.Err, "income rejected"))
+			item.ErrorCode, item.Error = int32(st.Code()), st.Message()
+		} else {
+			item.Income = incomeToProto(result.Income)
+		}
+		resp.Results = append(resp.Results, item)
+	}
+
+	return resp, nil
+}
+
+// errorStatus переводит ошибку use-case в gRPC статус с подходящим кодом
+func errorStatus(err error, msg string) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, usecases.ErrValidation):
+		code = codes.InvalidArgument
+	case errors.Is(err, domain.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, usecases.ErrIdempotencyKeyReused):
+		code = codes.FailedPrecondition
+	case errors.Is(err, usecases.ErrIdempotencyInProgress):
+		code = codes.Aborted
+	}
+
+	return status.Errorf(code, "%s: %v", msg, err)
+}
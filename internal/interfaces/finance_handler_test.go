@@ -3,26 +3,54 @@ package interfaces_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
 	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/usecases"
 	"fincraft-finance/internal/usecases/mocks"
 )
 
+var kzt = domain.MustCurrency("KZT")
+
 func setupTest(t *testing.T) (*gomock.Controller, *mocks.MockIncomeService, *interfaces.FinanceHandler) {
 	ctrl := gomock.NewController(t)
 	mockUsecase := mocks.NewMockIncomeService(ctrl)
-	handler := interfaces.NewFinanceHandler(mockUsecase)
+	handler := interfaces.NewFinanceHandler(interfaces.Services{Incomes: mockUsecase})
 
 	return ctrl, mockUsecase, handler
 }
 
+func newIncome(amount int64, description string) *domain.Income {
+	return &domain.Income{
+		UserID:      1,
+		CategoryID:  2,
+		Amount:      domain.NewMoney(amount, kzt),
+		Description: description,
+	}
+}
+
+func storedIncome(id int64, amount int64, description string) *domain.Income {
+	income := newIncome(amount, description)
+	income.ID = id
+	income.CreatedAt = time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC)
+	income.UpdatedAt = income.CreatedAt
+	return income
+}
+
 func Test_FinanceHandler_AddIncome_ReturnsNoError_WhenValidInput(t *testing.T) {
 	ctrl, mockUsecase, handler := setupTest(t)
 	defer ctrl.Finish()
@@ -30,19 +58,22 @@ func Test_FinanceHandler_AddIncome_ReturnsNoError_WhenValidInput(t *testing.T) {
 	req := &finance.AddIncomeRequest{
 		UserId:      1,
 		CategoryId:  2,
-		Amount:      100.50,
+		Amount:      &finance.Decimal{Units: 100, Nanos: 500_000_000},
 		Description: "Test income",
+		Currency:    "KZT",
 	}
 
 	ctx := context.Background()
 	mockUsecase.EXPECT().
-		AddIncome(ctx, int64(1), 2, 100.50, "Test income").
-		Return(nil)
+		AddIncome(ctx, newIncome(10050, "Test income")).
+		Return(storedIncome(7, 10050, "Test income"), nil, nil)
 
 	resp, err := handler.AddIncome(ctx, req)
 
 	assert.NoError(t, err)
-	assert.NotNil(t, resp)
+	require.NotNil(t, resp)
+	assert.Equal(t, int64(7), resp.Id)
+	assert.Equal(t, int64(1710496800), resp.CreatedAt.Seconds)
 }
 
 func Test_FinanceHandler_AddIncome_ReturnsInternalError_WhenUseCaseFails(t *testing.T) {
@@ -52,14 +83,15 @@ func Test_FinanceHandler_AddIncome_ReturnsInternalError_WhenUseCaseFails(t *test
 	req := &finance.AddIncomeRequest{
 		UserId:      1,
 		CategoryId:  2,
-		Amount:      100.50,
+		Amount:      &finance.Decimal{Units: 100, Nanos: 500_000_000},
 		Description: "Test income",
+		Currency:    "KZT",
 	}
 
 	ctx := context.Background()
 	mockUsecase.EXPECT().
-		AddIncome(ctx, int64(1), 2, 100.50, "Test income").
-		Return(errors.New("db error"))
+		AddIncome(ctx, newIncome(10050, "Test income")).
+		Return(nil, nil, errors.New("db error"))
 
 	resp, err := handler.AddIncome(ctx, req)
 
@@ -76,14 +108,15 @@ func Test_FinanceHandler_AddIncome_ReturnsValidationError_WhenInvalidAmount(t *t
 	req := &finance.AddIncomeRequest{
 		UserId:      1,
 		CategoryId:  2,
-		Amount:      -100.50,
+		Amount:      &finance.Decimal{Units: -100, Nanos: -500_000_000},
 		Description: "Negative income",
+		Currency:    "KZT",
 	}
 
 	ctx := context.Background()
 	mockUsecase.EXPECT().
-		AddIncome(ctx, int64(1), 2, -100.50, "Negative income").
-		Return(errors.New("validation failed: amount must be greater than 0"))
+		AddIncome(ctx, newIncome(-10050, "Negative income")).
+		Return(nil, nil, errors.New("validation failed: amount must be greater than 0"))
 
 	resp, err := handler.AddIncome(ctx, req)
 
@@ -92,3 +125,446 @@ func Test_FinanceHandler_AddIncome_ReturnsValidationError_WhenInvalidAmount(t *t
 	assert.Equal(t, codes.Internal, status.Code(err))
 	assert.Contains(t, err.Error(), "validation failed")
 }
+
+func Test_FinanceHandler_AddIncome_ReturnsInvalidArgument_WhenAmountMalformed(t *testing.T) {
+	ctrl, _, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	req := &finance.AddIncomeRequest{
+		UserId:      1,
+		CategoryId:  2,
+		Amount:      &finance.Decimal{Units: 100, Nanos: -500_000_000},
+		Description: "Malformed income",
+		Currency:    "KZT",
+	}
+
+	resp, err := handler.AddIncome(context.Background(), req)
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), "invalid amount")
+}
+
+func Test_FinanceHandler_AddIncome_ReturnsInvalidArgument_WhenCurrencyUnknown(t *testing.T) {
+	ctrl, _, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	req := &finance.AddIncomeRequest{
+		UserId:      1,
+		CategoryId:  2,
+		Amount:      &finance.Decimal{Units: 100},
+		Description: "Unknown currency",
+		Currency:    "XXX",
+	}
+
+	resp, err := handler.AddIncome(context.Background(), req)
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), "invalid currency")
+}
+
+func Test_FinanceHandler_GetIncome_ReturnsNotFound_WhenIncomeMissing(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().GetIncome(ctx, int64(1), int64(7)).Return(nil, domain.ErrNotFound)
+
+	resp, err := handler.GetIncome(ctx, &finance.GetIncomeRequest{UserId: 1, Id: 7})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_FinanceHandler_ListIncomes_PassesFilterAndCursor_WhenRequestComplete(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	minAmount := domain.NewMoney(1000, kzt)
+	req := &finance.ListIncomesRequest{
+		UserId:     1,
+		CategoryId: 2,
+		From:       timestamppb.New(from),
+		To:         timestamppb.New(to),
+		Currency:   "KZT",
+		MinAmount:  &finance.Decimal{Units: 10},
+		SortBy:     finance.IncomeSortField_INCOME_SORT_FIELD_AMOUNT,
+		PageSize:   1,
+		Tags:       []string{"reimbursable"},
+	}
+
+	ctx := context.Background()
+	filter := domain.IncomeFilter{
+		UserID:     1,
+		CategoryID: 2,
+		From:       from,
+		To:         to,
+		MinAmount:  &minAmount,
+		Tags:       []string{"reimbursable"},
+		SortBy:     domain.IncomeSortByAmount,
+		PageSize:   1,
+	}
+	mockUsecase.EXPECT().ListIncomes(ctx, filter).
+		Return(domain.IncomePage{Items: []domain.Income{*storedIncome(9, 2500, "a")}, HasMore: true}, nil)
+
+	resp, err := handler.ListIncomes(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, resp.Incomes, 1)
+	require.NotEmpty(t, resp.NextPageToken)
+
+	filter.After = &domain.IncomeCursor{ID: 9, SortBy: domain.IncomeSortByAmount, Value: "25.00"}
+	mockUsecase.EXPECT().ListIncomes(ctx, filter).Return(domain.IncomePage{}, nil)
+
+	req.PageToken = resp.NextPageToken
+	resp, err = handler.ListIncomes(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, resp.NextPageToken)
+}
+
+func Test_FinanceHandler_ListIncomes_ReturnsInvalidArgument_WhenAmountRangeWithoutCurrency(t *testing.T) {
+	ctrl, _, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	req := &finance.ListIncomesRequest{UserId: 1, MaxAmount: &finance.Decimal{Units: 10}}
+
+	resp, err := handler.ListIncomes(context.Background(), req)
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_FinanceHandler_UpdateIncome_PassesOnlyMaskedFields_WhenMaskSet(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	req := &finance.UpdateIncomeRequest{
+		UserId:      1,
+		Id:          7,
+		CategoryId:  99,
+		Description: "Fixed",
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"description"}},
+	}
+
+	ctx := context.Background()
+	description := "Fixed"
+	mockUsecase.EXPECT().
+		UpdateIncome(ctx, int64(1), int64(7), domain.IncomePatch{Description: &description}).
+		Return(storedIncome(7, 10050, "Fixed"), nil)
+
+	resp, err := handler.UpdateIncome(ctx, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Fixed", resp.Description)
+	assert.Equal(t, int32(2), resp.CategoryId)
+}
+
+func Test_FinanceHandler_UpdateIncome_ClearsTags_WhenTagsMaskedAndEmpty(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	req := &finance.UpdateIncomeRequest{
+		UserId:     1,
+		Id:         7,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"tags"}},
+	}
+
+	ctx := context.Background()
+	var tags []string
+	mockUsecase.EXPECT().
+		UpdateIncome(ctx, int64(1), int64(7), domain.IncomePatch{Tags: &tags}).
+		Return(storedIncome(7, 10050, "Salary"), nil)
+
+	resp, err := handler.UpdateIncome(ctx, req)
+
+	require.NoError(t, err)
+	assert.Empty(t, resp.Tags)
+}
+
+func Test_FinanceHandler_UpdateIncome_ReturnsInvalidArgument_WhenMaskInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		paths  []string
+		errMsg string
+	}{
+		{"Empty Mask", nil, "update_mask must not be empty"},
+		{"Unknown Path", []string{"user_id"}, `unsupported update_mask path "user_id"`},
+		{"Amount Without Currency", []string{"amount"}, "invalid currency"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, _, handler := setupTest(t)
+			defer ctrl.Finish()
+
+			req := &finance.UpdateIncomeRequest{
+				UserId:     1,
+				Id:         7,
+				Amount:     &finance.Decimal{Units: 1},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: tt.paths},
+			}
+
+			resp, err := handler.UpdateIncome(context.Background(), req)
+
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func Test_FinanceHandler_AddIncome_PassesOccurredAt_WhenProvided(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	occurredAt := time.Date(2024, time.March, 10, 9, 30, 0, 0, time.UTC)
+	req := &finance.AddIncomeRequest{
+		UserId:      1,
+		CategoryId:  2,
+		Amount:      &finance.Decimal{Units: 100, Nanos: 500_000_000},
+		Description: "Salary",
+		Currency:    "KZT",
+		OccurredAt:  timestamppb.New(occurredAt),
+	}
+
+	ctx := context.Background()
+	expected := newIncome(10050, "Salary")
+	expected.OccurredAt = occurredAt
+	stored := storedIncome(7, 10050, "Salary")
+	stored.OccurredAt = occurredAt
+	mockUsecase.EXPECT().AddIncome(ctx, expected).Return(stored, nil, nil)
+
+	resp, err := handler.AddIncome(ctx, req)
+
+	require.NoError(t, err)
+	assert.True(t, resp.OccurredAt.AsTime().Equal(occurredAt))
+}
+
+func Test_FinanceHandler_ListIncomes_PassesCalendarDates_WhenDatesSet(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().ListIncomes(ctx, domain.IncomeFilter{
+		UserID:   1,
+		FromDate: domain.Date{Year: 2024, Month: time.March, Day: 1},
+		ToDate:   domain.Date{Year: 2024, Month: time.March, Day: 31},
+	}).Return(domain.IncomePage{}, nil)
+
+	_, err := handler.ListIncomes(ctx, &finance.ListIncomesRequest{UserId: 1, FromDate: "2024-03-01", ToDate: "2024-03-31"})
+
+	assert.NoError(t, err)
+}
+
+func Test_FinanceHandler_ListIncomes_ReturnsInvalidArgument_WhenDateMalformed(t *testing.T) {
+	ctrl, _, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	resp, err := handler.ListIncomes(context.Background(), &finance.ListIncomesRequest{UserId: 1, FromDate: "01.03.2024"})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_FinanceHandler_UpdateIncome_PassesOccurredAt_WhenInMask(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	occurredAt := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	req := &finance.UpdateIncomeRequest{
+		UserId:     1,
+		Id:         7,
+		OccurredAt: timestamppb.New(occurredAt),
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"occurred_at"}},
+	}
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().
+		UpdateIncome(ctx, int64(1), int64(7), domain.IncomePatch{OccurredAt: &occurredAt}).
+		Return(storedIncome(7, 10050, "Salary"), nil)
+
+	_, err := handler.UpdateIncome(ctx, req)
+
+	assert.NoError(t, err)
+}
+
+func Test_FinanceHandler_DeleteIncome_ReturnsNoError_WhenIncomeDeleted(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().DeleteIncome(ctx, int64(1), int64(7)).Return(nil)
+
+	resp, err := handler.DeleteIncome(ctx, &finance.DeleteIncomeRequest{UserId: 1, Id: 7})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+}
+
+func Test_FinanceHandler_BatchAddIncomes_ReturnsPerItemResults_WhenBestEffort(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	item := &finance.AddIncomeRequest{UserId: 99, CategoryId: 2, Amount: &finance.Decimal{Units: 100, Nanos: 500_000_000},
+		Currency: "KZT", Description: "Test income"}
+	mockUsecase.EXPECT().
+		AddIncomes(ctx, []*domain.Income{newIncome(10050, "Test income"), newIncome(10050, "Test income")}, domain.BatchBestEffort).
+		Return([]domain.IncomeBatchResult{
+			{Income: storedIncome(7, 10050, "Test income")},
+			{Err: fmt.Errorf("%w: category 2 is archived", usecases.ErrValidation)},
+		}, nil)
+
+	resp, err := handler.BatchAddIncomes(ctx, &finance.BatchAddIncomesRequest{UserId: 1,
+		Mode: finance.BatchMode_BATCH_MODE_BEST_EFFORT, Incomes: []*finance.AddIncomeRequest{item, item}})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+	assert.Equal(t, int64(7), resp.Results[0].Income.Id)
+	assert.Equal(t, int32(1), resp.Results[1].Index)
+	assert.Nil(t, resp.Results[1].Income)
+	assert.Equal(t, int32(codes.InvalidArgument), resp.Results[1].ErrorCode)
+	assert.Contains(t, resp.Results[1].Error, "category 2 is archived")
+}
+
+func Test_FinanceHandler_BatchAddIncomes_ReturnsInvalidArgument_WhenItemMalformed(t *testing.T) {
+	ctrl, _, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	_, err := handler.BatchAddIncomes(context.Background(), &finance.BatchAddIncomesRequest{UserId: 1,
+		Incomes: []*finance.AddIncomeRequest{{CategoryId: 2, Currency: "XXX"}}})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "item 0")
+}
+
+func Test_FinanceHandler_BatchAddIncomes_ReturnsInvalidArgument_WhenAtomicItemRejected(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	mockUsecase.EXPECT().AddIncomes(gomock.Any(), gomock.Any(), domain.BatchAtomic).
+		Return(nil, &domain.BatchItemError{Index: 0, Err: usecases.ErrValidation})
+
+	_, err := handler.BatchAddIncomes(context.Background(), &finance.BatchAddIncomesRequest{UserId: 1,
+		Incomes: []*finance.AddIncomeRequest{{CategoryId: 2, Currency: "KZT", Amount: &finance.Decimal{Units: 1}}}})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// importStream поток ImportIncomes с заранее заданными сообщениями клиента
+type importStream struct {
+	grpc.ServerStream
+	requests []*finance.ImportIncomesRequest
+	resp     *finance.BatchAddIncomesResponse
+}
+
+// Context возвращает контекст потока
+func (s *importStream) Context() context.Context {
+	return context.Background()
+}
+
+// Recv возвращает следующее сообщение клиента или io.EOF
+func (s *importStream) Recv() (*finance.ImportIncomesRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+// SendAndClose запоминает ответ сервера
+func (s *importStream) SendAndClose(resp *finance.BatchAddIncomesResponse) error {
+	s.resp = resp
+	return nil
+}
+
+func Test_FinanceHandler_ImportIncomes_AddsAllStreamedIncomes_WhenStreamCompleted(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	item := &finance.AddIncomeRequest{CategoryId: 2, Amount: &finance.Decimal{Units: 100, Nanos: 500_000_000},
+		Currency: "KZT", Description: "Test income"}
+	stream := &importStream{requests: []*finance.ImportIncomesRequest{
+		{UserId: 1, Mode: finance.BatchMode_BATCH_MODE_BEST_EFFORT, Income: item},
+		{Income: item},
+	}}
+	mockUsecase.EXPECT().
+		AddIncomes(gomock.Any(), []*domain.Income{newIncome(10050, "Test income"), newIncome(10050, "Test income")}, domain.BatchBestEffort).
+		Return([]domain.IncomeBatchResult{
+			{Income: storedIncome(7, 10050, "Test income")},
+			{Income: storedIncome(8, 10050, "Test income")},
+		}, nil)
+
+	err := handler.ImportIncomes(stream)
+
+	require.NoError(t, err)
+	require.Len(t, stream.resp.Results, 2)
+	assert.Equal(t, int64(8), stream.resp.Results[1].Income.Id)
+}
+
+func Test_FinanceHandler_ImportIncomes_ReturnsInvalidArgument_WhenUserChangesMidStream(t *testing.T) {
+	ctrl, _, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	item := &finance.AddIncomeRequest{CategoryId: 2, Amount: &finance.Decimal{Units: 1}, Currency: "KZT"}
+	stream := &importStream{requests: []*finance.ImportIncomesRequest{
+		{UserId: 1, Income: item},
+		{UserId: 2, Income: item},
+	}}
+
+	err := handler.ImportIncomes(stream)
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_FinanceHandler_AddIncome_ReturnsProbableDuplicates_WhenFound(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	duplicate := storedIncome(5, 10050, "ACME salary")
+	mockUsecase.EXPECT().AddIncome(ctx, newIncome(10050, "Salary")).Return(storedIncome(7, 10050, "Salary"),
+		[]domain.DuplicateCandidate{{Income: *duplicate, Score: 0.9}}, nil)
+
+	resp, err := handler.AddIncome(ctx, &finance.AddIncomeRequest{UserId: 1, CategoryId: 2,
+		Amount: &finance.Decimal{Units: 100, Nanos: 500_000_000}, Currency: "KZT", Description: "Salary"})
+
+	require.NoError(t, err)
+	require.Len(t, resp.ProbableDuplicates, 1)
+	assert.Equal(t, int64(5), resp.ProbableDuplicates[0].Income.Id)
+	assert.Equal(t, 0.9, resp.ProbableDuplicates[0].Score)
+}
+
+func Test_FinanceHandler_MergeTransactions_ReturnsSources_WhenMerged(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mergedAt := time.Date(2024, time.March, 16, 8, 0, 0, 0, time.UTC)
+	mockUsecase.EXPECT().MergeIncomes(ctx, int64(1), int64(7), int64(5)).Return(storedIncome(7, 10050, "Salary"),
+		[]domain.IncomeSource{{IncomeID: 7, Income: *storedIncome(5, 10050, "ACME salary"), MergedAt: mergedAt}}, nil)
+
+	resp, err := handler.MergeTransactions(ctx, &finance.MergeTransactionsRequest{UserId: 1, KeepId: 7, DuplicateId: 5})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), resp.Income.Id)
+	require.Len(t, resp.Sources, 1)
+	assert.Equal(t, int64(5), resp.Sources[0].Income.Id)
+	assert.True(t, resp.Sources[0].MergedAt.AsTime().Equal(mergedAt))
+}
+
+func Test_FinanceHandler_MergeTransactions_ReturnsNotFound_WhenIncomeMissing(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().MergeIncomes(ctx, int64(1), int64(7), int64(5)).
+		Return(nil, nil, fmt.Errorf("income 5: %w", domain.ErrNotFound))
+
+	_, err := handler.MergeTransactions(ctx, &finance.MergeTransactionsRequest{UserId: 1, KeepId: 7, DuplicateId: 5})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
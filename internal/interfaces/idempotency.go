@@ -0,0 +1,144 @@
+package interfaces
+
+import (
+	"context"
+	"crypto/sha256"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/usecases"
+)
+
+const (
+	// idempotencyKeyField поле запроса с ключом идемпотентности
+	idempotencyKeyField protoreflect.Name = "idempotency_key"
+	// idempotencyKeyHeader метаданные с ключом идемпотентности для запросов без поля idempotency_key
+	idempotencyKeyHeader = "idempotency-key"
+	// idempotencyReplayedHeader заголовок ответа, отмечающий повторно выданный сохраненный результат
+	idempotencyReplayedHeader = "idempotency-replayed"
+)
+
+// IdempotencyInterceptor выполняет запросы с ключом идемпотентности не больше одного раза.
+// Ключ берется из поля idempotency_key запроса или из метаданных idempotency-key и действует
+// в пределах метода и пользователя. Запросы без ключа выполняются как обычно.
+func IdempotencyInterceptor(service usecases.IdempotencyService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		key := domain.IdempotencyKey{Method: info.FullMethod, Key: idempotencyKeyOf(ctx, msg)}
+		if key.Key == "" {
+			return handler(ctx, req)
+		}
+		if r, ok := req.(interface{ GetUserId() int64 }); ok {
+			key.UserID = r.GetUserId()
+		}
+
+		fingerprint, err := requestFingerprint(msg)
+		if err != nil {
+			return nil, errorStatus(err, "failed to fingerprint request")
+		}
+
+		var result any
+		saved, replayed, err := service.Do(ctx, key, fingerprint, func(ctx context.Context) (domain.IdempotentResponse, error) {
+			var err error
+			if result, err = handler(ctx, req); err != nil {
+				return domain.IdempotentResponse{}, err
+			}
+			return responseToRecord(result)
+		})
+		if err != nil {
+			if _, ok := status.FromError(err); ok {
+				return nil, err
+			}
+			return nil, errorStatus(err, "idempotent request failed")
+		}
+		if !replayed {
+			return result, nil
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(idempotencyReplayedHeader, "true"))
+		return responseFromRecord(saved)
+	}
+}
+
+// IdempotencyStreamInterceptor отклоняет потоковые запросы с ключом идемпотентности в метаданных.
+// Потоковые методы нельзя повторить с сохраненным результатом, поэтому ключ для них не поддерживается:
+// клиент должен узнать об этом, а не рассчитывать на защиту от повторного выполнения.
+func IdempotencyStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if values := metadata.ValueFromIncomingContext(ss.Context(), idempotencyKeyHeader); len(values) > 0 {
+			return status.Errorf(codes.InvalidArgument, "idempotency keys are not supported by streaming method %s",
+				info.FullMethod)
+		}
+		return handler(srv, ss)
+	}
+}
+
+// idempotencyKeyOf возвращает ключ идемпотентности из поля запроса или, если его нет, из метаданных
+func idempotencyKeyOf(ctx context.Context, msg proto.Message) string {
+	m := msg.ProtoReflect()
+	if fd := m.Descriptor().Fields().ByName(idempotencyKeyField); fd != nil {
+		if key := m.Get(fd).String(); key != "" {
+			return key
+		}
+	}
+
+	if values := metadata.ValueFromIncomingContext(ctx, idempotencyKeyHeader); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// requestFingerprint возвращает отпечаток содержимого запроса без учета ключа идемпотентности
+func requestFingerprint(msg proto.Message) ([]byte, error) {
+	clone := proto.Clone(msg)
+	m := clone.ProtoReflect()
+	if fd := m.Descriptor().Fields().ByName(idempotencyKeyField); fd != nil {
+		m.Clear(fd)
+	}
+
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(clone)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(body)
+	return sum[:], nil
+}
+
+// responseToRecord сериализует ответ обработчика для сохранения
+func responseToRecord(resp any) (domain.IdempotentResponse, error) {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return domain.IdempotentResponse{}, status.Errorf(codes.Internal, "unexpected response type %T", resp)
+	}
+
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return domain.IdempotentResponse{}, err
+	}
+	return domain.IdempotentResponse{Type: string(msg.ProtoReflect().Descriptor().FullName()), Body: body}, nil
+}
+
+// responseFromRecord восстанавливает сохраненный ответ
+func responseFromRecord(saved domain.IdempotentResponse) (proto.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(saved.Type))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to restore saved response: %v", err)
+	}
+
+	msg := mt.New().Interface()
+	if err := proto.Unmarshal(saved.Body, msg); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to restore saved response: %v", err)
+	}
+	return msg, nil
+}
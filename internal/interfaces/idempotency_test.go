@@ -0,0 +1,161 @@
+package interfaces_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+var addIncomeInfo = &grpc.UnaryServerInfo{FullMethod: "/finance.FinanceService/AddIncome"}
+
+func setupIdempotencyTest(t *testing.T) (*gomock.Controller, *mocks.MockIdempotencyService, grpc.UnaryServerInterceptor) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := mocks.NewMockIdempotencyService(ctrl)
+	return ctrl, mockUsecase, interfaces.IdempotencyInterceptor(mockUsecase)
+}
+
+// runFn имитирует первое выполнение запроса с ключом
+func runFn(ctx context.Context, _ domain.IdempotencyKey, _ []byte,
+	fn func(context.Context) (domain.IdempotentResponse, error)) (domain.IdempotentResponse, bool, error) {
+	resp, err := fn(ctx)
+	return resp, false, err
+}
+
+func Test_IdempotencyInterceptor_IgnoresKeyInFingerprint_WhenKeyInRequest(t *testing.T) {
+	ctrl, mockUsecase, interceptor := setupIdempotencyTest(t)
+	defer ctrl.Finish()
+
+	var fingerprints [][]byte
+	mockUsecase.EXPECT().Do(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key domain.IdempotencyKey, fingerprint []byte,
+			fn func(context.Context) (domain.IdempotentResponse, error)) (domain.IdempotentResponse, bool, error) {
+			assert.Equal(t, int64(1), key.UserID)
+			assert.Equal(t, addIncomeInfo.FullMethod, key.Method)
+			fingerprints = append(fingerprints, fingerprint)
+			return runFn(ctx, key, fingerprint, fn)
+		}).Times(2)
+	handler := func(context.Context, any) (any, error) {
+		return &finance.Income{Id: 7}, nil
+	}
+
+	for _, key := range []string{"first", "second"} {
+		resp, err := interceptor(context.Background(), &finance.AddIncomeRequest{UserId: 1, Description: "Salary",
+			IdempotencyKey: key}, addIncomeInfo, handler)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), resp.(*finance.Income).Id)
+	}
+
+	assert.Equal(t, fingerprints[0], fingerprints[1])
+}
+
+func Test_IdempotencyInterceptor_ReturnsSavedResponse_WhenReplayed(t *testing.T) {
+	ctrl, mockUsecase, interceptor := setupIdempotencyTest(t)
+	defer ctrl.Finish()
+
+	body, err := proto.Marshal(&finance.Income{Id: 7, Description: "Salary"})
+	require.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("idempotency-key", "c0ffee"))
+	mockUsecase.EXPECT().Do(ctx, domain.IdempotencyKey{UserID: 1, Method: addIncomeInfo.FullMethod, Key: "c0ffee"},
+		gomock.Any(), gomock.Any()).Return(domain.IdempotentResponse{Type: "finance.Income", Body: body}, true, nil)
+
+	resp, err := interceptor(ctx, &finance.AddIncomeRequest{UserId: 1}, addIncomeInfo, func(context.Context, any) (any, error) {
+		t.Fatal("handler must not be called on replay")
+		return nil, nil
+	})
+
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(&finance.Income{Id: 7, Description: "Salary"}, resp.(proto.Message)))
+}
+
+func Test_IdempotencyInterceptor_CallsHandler_WhenKeyMissing(t *testing.T) {
+	_, _, interceptor := setupIdempotencyTest(t)
+
+	resp, err := interceptor(context.Background(), &finance.AddIncomeRequest{UserId: 1}, addIncomeInfo,
+		func(context.Context, any) (any, error) {
+			return &finance.Income{Id: 8}, nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), resp.(*finance.Income).Id)
+}
+
+func Test_IdempotencyInterceptor_ReturnsFailedPrecondition_WhenKeyReusedWithOtherPayload(t *testing.T) {
+	ctrl, mockUsecase, interceptor := setupIdempotencyTest(t)
+	defer ctrl.Finish()
+
+	mockUsecase.EXPECT().Do(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(domain.IdempotentResponse{}, false, usecases.ErrIdempotencyKeyReused)
+
+	_, err := interceptor(context.Background(), &finance.AddIncomeRequest{UserId: 1, IdempotencyKey: "c0ffee"},
+		addIncomeInfo, func(context.Context, any) (any, error) {
+			return &finance.Income{}, nil
+		})
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func Test_IdempotencyInterceptor_KeepsHandlerStatus_WhenHandlerFails(t *testing.T) {
+	ctrl, mockUsecase, interceptor := setupIdempotencyTest(t)
+	defer ctrl.Finish()
+
+	mockUsecase.EXPECT().Do(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runFn)
+
+	_, err := interceptor(context.Background(), &finance.AddIncomeRequest{UserId: 1, IdempotencyKey: "c0ffee"},
+		addIncomeInfo, func(context.Context, any) (any, error) {
+			return nil, status.Error(codes.NotFound, "category not found")
+		})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// metadataStream поток с заданным контекстом входящего запроса
+type metadataStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context возвращает контекст потока
+func (s *metadataStream) Context() context.Context {
+	return s.ctx
+}
+
+func Test_IdempotencyStreamInterceptor_ReturnsInvalidArgument_WhenKeyInMetadata(t *testing.T) {
+	interceptor := interfaces.IdempotencyStreamInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/finance.FinanceService/ImportIncomes", IsClientStream: true}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("idempotency-key", "c0ffee"))
+
+	err := interceptor(nil, &metadataStream{ctx: ctx}, info, func(any, grpc.ServerStream) error {
+		t.Fatal("stream must not be handled")
+		return nil
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_IdempotencyStreamInterceptor_CallsHandler_WhenKeyNotSet(t *testing.T) {
+	interceptor := interfaces.IdempotencyStreamInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/finance.FinanceService/ImportIncomes", IsClientStream: true}
+	called := false
+
+	err := interceptor(nil, &metadataStream{ctx: context.Background()}, info, func(any, grpc.ServerStream) error {
+		called = true
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.True(t, called)
+}
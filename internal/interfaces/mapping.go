@@ -0,0 +1,1013 @@
+package interfaces
+
+import (
+	"math/big"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/export"
+	"fincraft-finance/internal/reports"
+	"fincraft-finance/internal/statements"
+)
+
+// amountRounding способ округления сумм, пришедших от клиентов
+const amountRounding = domain.RoundHalfEven
+
+// nanosPerUnit количество nanos в одной целой единице Decimal
+const nanosPerUnit = 1_000_000_000
+
+// moneyFromProto собирает сумму из Decimal и кода валюты, ошибки возвращаются как InvalidArgument
+func moneyFromProto(amount *finance.Decimal, currencyCode string) (domain.Money, error) {
+	currency, err := domain.CurrencyByCode(currencyCode)
+	if err != nil {
+		return domain.Money{}, status.Errorf(codes.InvalidArgument, "invalid currency: %v", err)
+	}
+
+	m, err := domain.NewMoneyFromUnitsNanos(amount.GetUnits(), amount.GetNanos(), currency, amountRounding)
+	if err != nil {
+		return domain.Money{}, status.Errorf(codes.InvalidArgument, "invalid amount: %v", err)
+	}
+
+	return m, nil
+}
+
+// moneyToProto возвращает сумму в виде Decimal и кода валюты
+func moneyToProto(m domain.Money) (*finance.Decimal, string) {
+	units, nanos := m.UnitsNanos()
+	return &finance.Decimal{Units: units, Nanos: nanos}, m.Currency().Code
+}
+
+// decimalToProto возвращает сумму в виде Decimal без кода валюты
+func decimalToProto(m domain.Money) *finance.Decimal {
+	amount, _ := moneyToProto(m)
+	return amount
+}
+
+// timeFromProto возвращает момент времени из Timestamp, отсутствующее значение дает нулевое время
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// dateFromProto разбирает календарную дату YYYY-MM-DD, пустая строка дает нулевую дату
+func dateFromProto(s string) (domain.Date, error) {
+	if s == "" {
+		return domain.Date{}, nil
+	}
+
+	d, err := domain.ParseDate(s)
+	if err != nil {
+		return domain.Date{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return d, nil
+}
+
+// dateToProto возвращает дату в формате YYYY-MM-DD, нулевая дата дает пустую строку
+func dateToProto(d domain.Date) string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.String()
+}
+
+// incomeFromProto собирает доход из запроса на добавление
+func incomeFromProto(req *finance.AddIncomeRequest) (*domain.Income, error) {
+	amount, err := moneyFromProto(req.GetAmount(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Income{
+		UserID:      req.GetUserId(),
+		CategoryID:  int(req.GetCategoryId()),
+		AccountID:   req.GetAccountId(),
+		Amount:      amount,
+		Description: req.GetDescription(),
+		Tags:        req.GetTags(),
+		OccurredAt:  timeFromProto(req.GetOccurredAt()),
+	}, nil
+}
+
+// batchIncomeFromProto собирает доход из элемента пакета с номером i от имени пользователя userID
+func batchIncomeFromProto(i int, userID int64, req *finance.AddIncomeRequest) (*domain.Income, error) {
+	income, err := incomeFromProto(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "item %d: %s", i, status.Convert(err).Message())
+	}
+
+	income.UserID = userID
+	return income, nil
+}
+
+// batchModeFromProto переводит режим пакетной записи из API
+func batchModeFromProto(m finance.BatchMode) domain.BatchMode {
+	if m == finance.BatchMode_BATCH_MODE_BEST_EFFORT {
+		return domain.BatchBestEffort
+	}
+	return domain.BatchAtomic
+}
+
+// incomeToProto переводит доход в сообщение API
+func incomeToProto(i *domain.Income) *finance.Income {
+	amount, currency := moneyToProto(i.Amount)
+	return &finance.Income{
+		Id:          i.ID,
+		UserId:      i.UserID,
+		CategoryId:  int32(i.CategoryID),
+		AccountId:   i.AccountID,
+		Amount:      amount,
+		Currency:    currency,
+		Description: i.Description,
+		Tags:        i.Tags,
+		OccurredAt:  timestamppb.New(i.OccurredAt),
+		CreatedAt:   timestamppb.New(i.CreatedAt),
+		UpdatedAt:   timestamppb.New(i.UpdatedAt),
+	}
+}
+
+// duplicatesToProto переводит вероятные дубликаты дохода в API
+func duplicatesToProto(candidates []domain.DuplicateCandidate) []*finance.DuplicateCandidate {
+	result := make([]*finance.DuplicateCandidate, 0, len(candidates))
+	for i := range candidates {
+		result = append(result, &finance.DuplicateCandidate{
+			Income: incomeToProto(&candidates[i].Income),
+			Score:  candidates[i].Score,
+		})
+	}
+	return result
+}
+
+// incomeFilterFromProto собирает фильтр списка доходов из запроса
+func incomeFilterFromProto(req *finance.ListIncomesRequest) (domain.IncomeFilter, error) {
+	filter := domain.IncomeFilter{
+		UserID:     req.UserId,
+		CategoryID: int(req.CategoryId),
+		AccountID:  req.AccountId,
+		Tags:       req.Tags,
+		Asc:        req.Ascending,
+		PageSize:   int(req.PageSize),
+	}
+
+	switch req.SortBy {
+	case finance.IncomeSortField_INCOME_SORT_FIELD_DATE:
+		filter.SortBy = domain.IncomeSortByDate
+	case finance.IncomeSortField_INCOME_SORT_FIELD_AMOUNT:
+		filter.SortBy = domain.IncomeSortByAmount
+	default:
+		return domain.IncomeFilter{}, status.Errorf(codes.InvalidArgument, "unsupported sort field %v", req.SortBy)
+	}
+
+	filter.From = timeFromProto(req.From)
+	filter.To = timeFromProto(req.To)
+
+	var err error
+	if filter.FromDate, err = dateFromProto(req.FromDate); err != nil {
+		return domain.IncomeFilter{}, err
+	}
+	if filter.ToDate, err = dateFromProto(req.ToDate); err != nil {
+		return domain.IncomeFilter{}, err
+	}
+
+	if req.MinAmount != nil {
+		m, err := moneyFromProto(req.MinAmount, req.Currency)
+		if err != nil {
+			return domain.IncomeFilter{}, err
+		}
+		filter.MinAmount = &m
+	}
+	if req.MaxAmount != nil {
+		m, err := moneyFromProto(req.MaxAmount, req.Currency)
+		if err != nil {
+			return domain.IncomeFilter{}, err
+		}
+		filter.MaxAmount = &m
+	}
+
+	token, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return domain.IncomeFilter{}, err
+	}
+	if token != nil {
+		filter.After = &domain.IncomeCursor{ID: token.ID, SortBy: domain.IncomeSortField(token.Sort), Value: token.Value}
+	}
+
+	return filter, nil
+}
+
+// incomePatchFromProto собирает частичное изменение дохода по update_mask
+func incomePatchFromProto(req *finance.UpdateIncomeRequest) (domain.IncomePatch, error) {
+	var patch domain.IncomePatch
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return patch, status.Error(codes.InvalidArgument, "update_mask must not be empty")
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "category_id":
+			categoryID := int(req.CategoryId)
+			patch.CategoryID = &categoryID
+		case "account_id":
+			accountID := req.AccountId
+			patch.AccountID = &accountID
+		case "amount", "currency":
+			amount, err := moneyFromProto(req.GetAmount(), req.GetCurrency())
+			if err != nil {
+				return patch, err
+			}
+			patch.Amount = &amount
+		case "description":
+			description := req.Description
+			patch.Description = &description
+		case "occurred_at":
+			if req.OccurredAt == nil {
+				return patch, status.Error(codes.InvalidArgument, "occurred_at must be set")
+			}
+			occurredAt := req.OccurredAt.AsTime()
+			patch.OccurredAt = &occurredAt
+		case "tags":
+			tags := req.Tags
+			patch.Tags = &tags
+		default:
+			return patch, status.Errorf(codes.InvalidArgument, "unsupported update_mask path %q", path)
+		}
+	}
+
+	return patch, nil
+}
+
+// expenseToProto переводит расход в сообщение API
+func expenseToProto(e *domain.Expense) *finance.Expense {
+	amount, currency := moneyToProto(e.Amount)
+	return &finance.Expense{
+		Id:          e.ID,
+		UserId:      e.UserID,
+		CategoryId:  int32(e.CategoryID),
+		AccountId:   e.AccountID,
+		Amount:      amount,
+		Currency:    currency,
+		Description: e.Description,
+		OccurredAt:  timestamppb.New(e.OccurredAt),
+		CreatedAt:   timestamppb.New(e.CreatedAt),
+		UpdatedAt:   timestamppb.New(e.UpdatedAt),
+	}
+}
+
+// categoryKinds соответствие типов категорий API и домена
+var categoryKinds = map[finance.CategoryKind]domain.CategoryKind{
+	finance.CategoryKind_CATEGORY_KIND_INCOME:  domain.CategoryKindIncome,
+	finance.CategoryKind_CATEGORY_KIND_EXPENSE: domain.CategoryKindExpense,
+}
+
+// categoryKindFromProto возвращает тип категории, CATEGORY_KIND_UNSPECIFIED и неизвестные значения дают 0
+func categoryKindFromProto(kind finance.CategoryKind) domain.CategoryKind {
+	return categoryKinds[kind]
+}
+
+// categoryToProto переводит категорию в сообщение API
+func categoryToProto(c *domain.Category) *finance.Category {
+	kind := finance.CategoryKind_CATEGORY_KIND_UNSPECIFIED
+	for k, v := range categoryKinds {
+		if v == c.Kind {
+			kind = k
+		}
+	}
+
+	return &finance.Category{
+		Id:        int32(c.ID),
+		UserId:    c.UserID,
+		Name:      c.Name,
+		Kind:      kind,
+		Icon:      c.Icon,
+		ParentId:  int32(c.ParentID),
+		Archived:  c.Archived,
+		System:    c.IsSystem(),
+		CreatedAt: timestamppb.New(c.CreatedAt),
+	}
+}
+
+// accountTypes соответствие видов счетов API и домена
+var accountTypes = map[finance.AccountType]domain.AccountType{
+	finance.AccountType_ACCOUNT_TYPE_CASH:      domain.AccountTypeCash,
+	finance.AccountType_ACCOUNT_TYPE_CARD:      domain.AccountTypeCard,
+	finance.AccountType_ACCOUNT_TYPE_CHECKING:  domain.AccountTypeChecking,
+	finance.AccountType_ACCOUNT_TYPE_SAVINGS:   domain.AccountTypeSavings,
+	finance.AccountType_ACCOUNT_TYPE_BROKERAGE: domain.AccountTypeBrokerage,
+}
+
+// accountToProto переводит счет в сообщение API
+func accountToProto(a *domain.Account) *finance.Account {
+	accountType := finance.AccountType_ACCOUNT_TYPE_UNSPECIFIED
+	for k, v := range accountTypes {
+		if v == a.Type {
+			accountType = k
+		}
+	}
+
+	opening, currency := moneyToProto(a.OpeningBalance)
+	balance, _ := moneyToProto(a.Balance)
+	resp := &finance.Account{
+		Id:             a.ID,
+		UserId:         a.UserID,
+		Name:           a.Name,
+		Type:           accountType,
+		Currency:       currency,
+		OpeningBalance: opening,
+		Balance:        balance,
+		Closed:         a.IsClosed(),
+		CreatedAt:      timestamppb.New(a.CreatedAt),
+		UpdatedAt:      timestamppb.New(a.UpdatedAt),
+	}
+	if a.IsClosed() {
+		resp.ClosedAt = timestamppb.New(a.ClosedAt)
+	}
+
+	return resp
+}
+
+// accountPatchFromProto собирает частичное изменение счета по update_mask
+func accountPatchFromProto(req *finance.UpdateAccountRequest) (domain.AccountPatch, error) {
+	var patch domain.AccountPatch
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return patch, status.Error(codes.InvalidArgument, "update_mask must not be empty")
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "name":
+			name := req.Name
+			patch.Name = &name
+		case "type":
+			accountType := accountTypes[req.Type]
+			patch.Type = &accountType
+		case "opening_balance", "currency":
+			opening, err := moneyFromProto(req.GetOpeningBalance(), req.GetCurrency())
+			if err != nil {
+				return patch, err
+			}
+			patch.OpeningBalance = &opening
+		default:
+			return patch, status.Errorf(codes.InvalidArgument, "unsupported update_mask path %q", path)
+		}
+	}
+
+	return patch, nil
+}
+
+// transferToProto переводит перевод между счетами в сообщение API
+func transferToProto(t *domain.Transfer) *finance.Transfer {
+	fromAmount, fromCurrency := moneyToProto(t.FromAmount)
+	toAmount, toCurrency := moneyToProto(t.ToAmount)
+	return &finance.Transfer{
+		Id:            t.ID,
+		UserId:        t.UserID,
+		FromAccountId: t.FromAccountID,
+		ToAccountId:   t.ToAccountID,
+		FromAmount:    fromAmount,
+		FromCurrency:  fromCurrency,
+		ToAmount:      toAmount,
+		ToCurrency:    toCurrency,
+		Rate:          rateToProto(t.Rate()),
+		Description:   t.Description,
+		OccurredAt:    timestamppb.New(t.OccurredAt),
+		CreatedAt:     timestamppb.New(t.CreatedAt),
+	}
+}
+
+// rateToProto переводит неотрицательный курс в Decimal, округляя до nanos (половина - вверх)
+func rateToProto(r *big.Rat) *finance.Decimal {
+	nanos := big.NewInt(nanosPerUnit)
+	scaled := new(big.Int).Mul(r.Num(), nanos)
+	q, rem := new(big.Int).QuoRem(scaled, r.Denom(), new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+
+	units, frac := new(big.Int).QuoRem(q, nanos, new(big.Int))
+	return &finance.Decimal{Units: units.Int64(), Nanos: int32(frac.Int64())}
+}
+
+// budgetPeriods соответствие периодов бюджета API и домена
+var budgetPeriods = map[finance.BudgetPeriod]domain.BudgetPeriod{
+	finance.BudgetPeriod_BUDGET_PERIOD_MONTHLY: domain.BudgetPeriodMonthly,
+	finance.BudgetPeriod_BUDGET_PERIOD_WEEKLY:  domain.BudgetPeriodWeekly,
+	finance.BudgetPeriod_BUDGET_PERIOD_CUSTOM:  domain.BudgetPeriodCustom,
+}
+
+// budgetToProto переводит бюджет в сообщение API
+func budgetToProto(b *domain.Budget) *finance.Budget {
+	period := finance.BudgetPeriod_BUDGET_PERIOD_UNSPECIFIED
+	for k, v := range budgetPeriods {
+		if v == b.Period {
+			period = k
+		}
+	}
+
+	limit, currency := moneyToProto(b.Limit)
+	return &finance.Budget{
+		Id:         b.ID,
+		UserId:     b.UserID,
+		CategoryId: int32(b.CategoryID),
+		Period:     period,
+		Limit:      limit,
+		Currency:   currency,
+		Rollover:   b.Rollover,
+		StartDate:  dateToProto(b.StartDate),
+		EndDate:    dateToProto(b.EndDate),
+		CreatedAt:  timestamppb.New(b.CreatedAt),
+		UpdatedAt:  timestamppb.New(b.UpdatedAt),
+	}
+}
+
+// budgetPatchFromProto собирает частичное изменение бюджета по update_mask
+func budgetPatchFromProto(req *finance.UpdateBudgetRequest) (domain.BudgetPatch, error) {
+	var patch domain.BudgetPatch
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return patch, status.Error(codes.InvalidArgument, "update_mask must not be empty")
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "limit", "currency":
+			limit, err := moneyFromProto(req.GetLimit(), req.GetCurrency())
+			if err != nil {
+				return patch, err
+			}
+			patch.Limit = &limit
+		case "rollover":
+			rollover := req.Rollover
+			patch.Rollover = &rollover
+		case "start_date":
+			start, err := dateFromProto(req.StartDate)
+			if err != nil {
+				return patch, err
+			}
+			patch.StartDate = &start
+		case "end_date":
+			end, err := dateFromProto(req.EndDate)
+			if err != nil {
+				return patch, err
+			}
+			patch.EndDate = &end
+		default:
+			return patch, status.Errorf(codes.InvalidArgument, "unsupported update_mask path %q", path)
+		}
+	}
+
+	return patch, nil
+}
+
+// budgetStatusToProto переводит положение по бюджету в сообщение API
+func budgetStatusToProto(s *domain.BudgetStatus) *finance.BudgetStatus {
+	limit, _ := moneyToProto(s.Limit)
+	carried, _ := moneyToProto(s.CarriedOver)
+	spent, _ := moneyToProto(s.Spent)
+	remaining, _ := moneyToProto(s.Remaining)
+	projected, _ := moneyToProto(s.Projected)
+	return &finance.BudgetStatus{
+		Budget:             budgetToProto(&s.Budget),
+		PeriodStart:        dateToProto(s.PeriodStart),
+		PeriodEnd:          dateToProto(s.PeriodEnd),
+		Limit:              limit,
+		CarriedOver:        carried,
+		Spent:              spent,
+		Remaining:          remaining,
+		PercentUsed:        s.PercentUsed,
+		Projected:          projected,
+		Overspent:          s.Overspent(),
+		ProjectedOverspend: s.ProjectedOverspend(),
+	}
+}
+
+// recurrenceFrequencies соответствие частот повторения API и домена
+var recurrenceFrequencies = map[finance.RecurrenceFrequency]domain.RecurrenceFrequency{
+	finance.RecurrenceFrequency_RECURRENCE_FREQUENCY_DAILY:   domain.RecurrenceDaily,
+	finance.RecurrenceFrequency_RECURRENCE_FREQUENCY_WEEKLY:  domain.RecurrenceWeekly,
+	finance.RecurrenceFrequency_RECURRENCE_FREQUENCY_MONTHLY: domain.RecurrenceMonthly,
+}
+
+// recurringRuleToProto переводит правило повторяющегося дохода в сообщение API
+func recurringRuleToProto(r *domain.RecurringRule) *finance.RecurringRule {
+	frequency := finance.RecurrenceFrequency_RECURRENCE_FREQUENCY_UNSPECIFIED
+	for k, v := range recurrenceFrequencies {
+		if v == r.Frequency {
+			frequency = k
+		}
+	}
+
+	amount, currency := moneyToProto(r.Amount)
+	return &finance.RecurringRule{
+		Id:          r.ID,
+		UserId:      r.UserID,
+		CategoryId:  int32(r.CategoryID),
+		AccountId:   r.AccountID,
+		Amount:      amount,
+		Currency:    currency,
+		Description: r.Description,
+		Frequency:   frequency,
+		Interval:    int32(r.Interval),
+		DayOfMonth:  int32(r.DayOfMonth),
+		StartDate:   dateToProto(r.StartDate),
+		EndDate:     dateToProto(r.EndDate),
+		Count:       int32(r.Count),
+		Paused:      r.Paused,
+		NextDate:    dateToProto(r.NextDate),
+		CreatedAt:   timestamppb.New(r.CreatedAt),
+		UpdatedAt:   timestamppb.New(r.UpdatedAt),
+	}
+}
+
+// upcomingOccurrenceToProto переводит предстоящее повторение в сообщение API
+func upcomingOccurrenceToProto(o *domain.UpcomingOccurrence) *finance.UpcomingOccurrence {
+	amount, currency := moneyToProto(o.Rule.Amount)
+	return &finance.UpcomingOccurrence{
+		RuleId:      o.Rule.ID,
+		Date:        dateToProto(o.Date),
+		CategoryId:  int32(o.Rule.CategoryID),
+		AccountId:   o.Rule.AccountID,
+		Amount:      amount,
+		Currency:    currency,
+		Description: o.Rule.Description,
+		Skipped:     o.Skipped,
+	}
+}
+
+// statementSigns соответствие правил знака суммы выписки API и профиля
+var statementSigns = map[finance.StatementSign]statements.SignConvention{
+	finance.StatementSign_STATEMENT_SIGN_AMOUNT:           statements.SignAmount,
+	finance.StatementSign_STATEMENT_SIGN_INVERTED:         statements.SignInverted,
+	finance.StatementSign_STATEMENT_SIGN_SEPARATE_COLUMNS: statements.SignSeparateColumns,
+}
+
+// statementFormats соответствие форматов выписки API и разборщика
+var statementFormats = map[finance.StatementFormat]statements.Format{
+	finance.StatementFormat_STATEMENT_FORMAT_CSV:     statements.FormatCSV,
+	finance.StatementFormat_STATEMENT_FORMAT_OFX:     statements.FormatOFX,
+	finance.StatementFormat_STATEMENT_FORMAT_QIF:     statements.FormatQIF,
+	finance.StatementFormat_STATEMENT_FORMAT_CAMT053: statements.FormatCAMT053,
+	finance.StatementFormat_STATEMENT_FORMAT_MT940:   statements.FormatMT940,
+}
+
+// statementProfileFromProto возвращает встроенный профиль по имени или профиль, описанный в запросе.
+// Профиль обязателен только для CSV.
+func statementProfileFromProto(req *finance.ImportStatementRequest) (statements.Profile, error) {
+	if req.ProfileName != "" {
+		profile, ok := statements.BuiltinProfile(req.ProfileName)
+		if !ok {
+			return statements.Profile{}, status.Errorf(codes.InvalidArgument, "unknown statement profile %q", req.ProfileName)
+		}
+		return profile, nil
+	}
+
+	p := req.GetProfile()
+	if p == nil {
+		if req.Format != finance.StatementFormat_STATEMENT_FORMAT_CSV {
+			return statements.Profile{}, nil
+		}
+		return statements.Profile{}, status.Error(codes.InvalidArgument, "profile name or profile must be set")
+	}
+	var delimiter rune
+	if p.Delimiter != "" {
+		if utf8.RuneCountInString(p.Delimiter) != 1 {
+			return statements.Profile{}, status.Errorf(codes.InvalidArgument, "delimiter %q must be a single character", p.Delimiter)
+		}
+		delimiter, _ = utf8.DecodeRuneInString(p.Delimiter)
+	}
+
+	return statements.Profile{
+		Delimiter:          delimiter,
+		Encoding:           statements.Encoding(p.Encoding),
+		SkipRows:           int(p.SkipRows),
+		HasHeader:          p.HasHeader,
+		Date:               statementColumnFromProto(p.Date),
+		Amount:             statementColumnFromProto(p.Amount),
+		Credit:             statementColumnFromProto(p.Credit),
+		Debit:              statementColumnFromProto(p.Debit),
+		Description:        statementColumnFromProto(p.Description),
+		Currency:           statementColumnFromProto(p.Currency),
+		DefaultCurrency:    p.DefaultCurrency,
+		Sign:               statementSigns[p.Sign],
+		DateFormat:         p.DateFormat,
+		DecimalSeparator:   p.DecimalSeparator,
+		ThousandsSeparator: p.ThousandsSeparator,
+	}, nil
+}
+
+// statementColumnFromProto переводит колонку выписки из API
+func statementColumnFromProto(c *finance.StatementColumn) statements.Column {
+	return statements.Column{Name: c.GetName(), Index: int(c.GetIndex())}
+}
+
+// statementLineToProto переводит строку выписки в сообщение API
+func statementLineToProto(l *domain.StatementLine) *finance.StatementLine {
+	line := &finance.StatementLine{
+		Line:              int32(l.Number),
+		Date:              dateToProto(l.Date),
+		Description:       l.Description,
+		OperationId:       l.OperationID,
+		ExternalId:        l.ExternalID,
+		TransferAccount:   l.TransferAccount,
+		CounterpartyName:  l.CounterpartyName,
+		CounterpartyIban:  l.CounterpartyIBAN,
+		ValueDate:         dateToProto(l.ValueDate),
+		DuplicateOf:       l.DuplicateOf,
+		DuplicateScore:    l.DuplicateScore,
+		CategoryId:        int32(l.CategoryID),
+		TransferAccountId: l.TransferAccountID,
+		Tags:              l.Tags,
+		RuleIds:           l.RuleIDs,
+	}
+	if !l.Amount.Currency().IsZero() {
+		line.Amount, line.Currency = moneyToProto(l.Amount)
+	}
+	if l.Err != nil {
+		line.Error = l.Err.Error()
+	}
+	return line
+}
+
+// ruleDirections соответствие направлений правила API и домена
+var ruleDirections = map[finance.RuleDirection]domain.RuleDirection{
+	finance.RuleDirection_RULE_DIRECTION_ANY:     domain.RuleDirectionAny,
+	finance.RuleDirection_RULE_DIRECTION_INCOME:  domain.RuleDirectionIncome,
+	finance.RuleDirection_RULE_DIRECTION_EXPENSE: domain.RuleDirectionExpense,
+}
+
+// operationKinds виды операций API по видам источника записи журнала
+var operationKinds = map[domain.JournalSourceKind]finance.OperationKind{
+	domain.JournalSourceIncome:  finance.OperationKind_OPERATION_KIND_INCOME,
+	domain.JournalSourceExpense: finance.OperationKind_OPERATION_KIND_EXPENSE,
+}
+
+// ruleFromProto собирает правило категоризации из полей запроса
+func ruleFromProto(userID int64, name string, priority int32, direction finance.RuleDirection,
+	conditions *finance.RuleConditions, actions *finance.RuleActions) (*domain.Rule, error) {
+	d, ok := ruleDirections[direction]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "unknown rule direction")
+	}
+
+	rule := &domain.Rule{
+		UserID:    userID,
+		Name:      name,
+		Priority:  int(priority),
+		Direction: d,
+		Conditions: domain.RuleConditions{
+			DescriptionRegex:    conditions.GetDescriptionRegex(),
+			DescriptionContains: conditions.GetDescriptionContains(),
+			AccountID:           conditions.GetAccountId(),
+			Counterparty:        conditions.GetCounterparty(),
+		},
+		Actions: domain.RuleActions{
+			CategoryID:        int(actions.GetCategoryId()),
+			Tags:              actions.GetTags(),
+			Description:       actions.GetDescription(),
+			TransferAccountID: actions.GetTransferAccountId(),
+		},
+	}
+	if conditions.GetMinAmount() != nil {
+		m, err := moneyFromProto(conditions.GetMinAmount(), conditions.GetAmountCurrency())
+		if err != nil {
+			return nil, err
+		}
+		rule.Conditions.MinAmount = &m
+	}
+	if conditions.GetMaxAmount() != nil {
+		m, err := moneyFromProto(conditions.GetMaxAmount(), conditions.GetAmountCurrency())
+		if err != nil {
+			return nil, err
+		}
+		rule.Conditions.MaxAmount = &m
+	}
+	return rule, nil
+}
+
+// ruleToProto переводит правило категоризации в сообщение API
+func ruleToProto(r *domain.Rule) *finance.Rule {
+	var direction finance.RuleDirection
+	for k, v := range ruleDirections {
+		if v == r.Direction {
+			direction = k
+		}
+	}
+
+	c, a := &r.Conditions, &r.Actions
+	conditions := &finance.RuleConditions{
+		DescriptionRegex:    c.DescriptionRegex,
+		DescriptionContains: c.DescriptionContains,
+		AccountId:           c.AccountID,
+		Counterparty:        c.Counterparty,
+	}
+	if c.MinAmount != nil {
+		conditions.MinAmount, conditions.AmountCurrency = moneyToProto(*c.MinAmount)
+	}
+	if c.MaxAmount != nil {
+		conditions.MaxAmount, conditions.AmountCurrency = moneyToProto(*c.MaxAmount)
+	}
+
+	return &finance.Rule{
+		Id:         r.ID,
+		UserId:     r.UserID,
+		Name:       r.Name,
+		Priority:   int32(r.Priority),
+		Direction:  direction,
+		Conditions: conditions,
+		Actions: &finance.RuleActions{
+			CategoryId:        int32(a.CategoryID),
+			Tags:              a.Tags,
+			Description:       a.Description,
+			TransferAccountId: a.TransferAccountID,
+		},
+		CreatedAt: timestamppb.New(r.CreatedAt),
+		UpdatedAt: timestamppb.New(r.UpdatedAt),
+	}
+}
+
+// ruleChangesToProto переводит изменения операций правилом в ответ API
+func ruleChangesToProto(changes []domain.RuleChange) *finance.RuleChangesResponse {
+	resp := &finance.RuleChangesResponse{Changes: make([]*finance.RuleChange, 0, len(changes))}
+	for _, c := range changes {
+		amount, currency := moneyToProto(c.Amount)
+		resp.Changes = append(resp.Changes, &finance.RuleChange{
+			Kind:           operationKinds[c.Kind],
+			OperationId:    c.OperationID,
+			Amount:         amount,
+			Currency:       currency,
+			OccurredAt:     timestamppb.New(c.OccurredAt),
+			OldCategoryId:  int32(c.OldCategoryID),
+			NewCategoryId:  int32(c.NewCategoryID),
+			OldDescription: c.OldDescription,
+			NewDescription: c.NewDescription,
+			AddedTags:      c.AddedTags,
+		})
+	}
+	return resp
+}
+
+// tagToProto переводит тег в сообщение API
+func tagToProto(t *domain.Tag) *finance.Tag {
+	return &finance.Tag{
+		Id:         t.ID,
+		UserId:     t.UserID,
+		Name:       t.Name,
+		UsageCount: int32(t.UsageCount),
+		CreatedAt:  timestamppb.New(t.CreatedAt),
+	}
+}
+
+// reportGranularities соответствие длин периодов отчета в API и в отчетах
+var reportGranularities = map[finance.ReportGranularity]reports.Granularity{
+	finance.ReportGranularity_REPORT_GRANULARITY_DAY:     reports.GranularityDay,
+	finance.ReportGranularity_REPORT_GRANULARITY_WEEK:    reports.GranularityWeek,
+	finance.ReportGranularity_REPORT_GRANULARITY_MONTH:   reports.GranularityMonth,
+	finance.ReportGranularity_REPORT_GRANULARITY_QUARTER: reports.GranularityQuarter,
+	finance.ReportGranularity_REPORT_GRANULARITY_YEAR:    reports.GranularityYear,
+}
+
+// incomeQueryFromProto собирает параметры отчета по доходам из запроса
+func incomeQueryFromProto(req *finance.GetIncomeSummaryRequest) (reports.IncomeQuery, error) {
+	granularity, ok := reportGranularities[req.Granularity]
+	if !ok {
+		return reports.IncomeQuery{}, status.Errorf(codes.InvalidArgument, "unknown report granularity %d", req.Granularity)
+	}
+	currency, err := domain.CurrencyByCode(req.Currency)
+	if err != nil {
+		return reports.IncomeQuery{}, status.Errorf(codes.InvalidArgument, "invalid currency: %v", err)
+	}
+
+	query := reports.IncomeQuery{
+		UserID:      req.UserId,
+		Granularity: granularity,
+		Currency:    currency,
+		CategoryID:  int(req.CategoryId),
+		AccountID:   req.AccountId,
+		Tags:        req.Tags,
+	}
+	if query.From, err = dateFromProto(req.FromDate); err != nil {
+		return reports.IncomeQuery{}, err
+	}
+	if query.To, err = dateFromProto(req.ToDate); err != nil {
+		return reports.IncomeQuery{}, err
+	}
+	return query, nil
+}
+
+// incomeSummaryToProto переводит отчет по доходам в сообщение API
+func incomeSummaryToProto(s *reports.IncomeSummary) *finance.IncomeSummary {
+	resp := &finance.IncomeSummary{
+		Currency:   s.Currency.Code,
+		Periods:    make([]*finance.PeriodSummary, 0, len(s.Periods)),
+		Total:      decimalToProto(s.Total),
+		Categories: categoryTotalsToProto(s.Categories),
+		Accounts:   accountTotalsToProto(s.Accounts),
+	}
+	resp.Granularity = granularityToProto(s.Granularity)
+	for _, p := range s.Periods {
+		resp.Periods = append(resp.Periods, &finance.PeriodSummary{
+			PeriodStart: dateToProto(p.From),
+			PeriodEnd:   dateToProto(p.To.AddDays(-1)),
+			Total:       decimalToProto(p.Total),
+			Previous:    decimalToProto(p.Previous),
+			Delta:       decimalToProto(p.Delta),
+			Categories:  categoryTotalsToProto(p.Categories),
+			Accounts:    accountTotalsToProto(p.Accounts),
+		})
+	}
+	return resp
+}
+
+// categoryTotalsToProto переводит суммы по категориям в API
+func categoryTotalsToProto(totals []reports.CategoryTotal) []*finance.CategoryTotal {
+	result := make([]*finance.CategoryTotal, 0, len(totals))
+	for _, t := range totals {
+		result = append(result, &finance.CategoryTotal{CategoryId: int32(t.CategoryID), Total: decimalToProto(t.Total)})
+	}
+	return result
+}
+
+// accountTotalsToProto переводит суммы по счетам в API
+func accountTotalsToProto(totals []reports.AccountTotal) []*finance.AccountTotal {
+	result := make([]*finance.AccountTotal, 0, len(totals))
+	for _, t := range totals {
+		result = append(result, &finance.AccountTotal{AccountId: t.AccountID, Total: decimalToProto(t.Total)})
+	}
+	return result
+}
+
+// granularityToProto переводит длину периода отчета в API
+func granularityToProto(g reports.Granularity) finance.ReportGranularity {
+	for k, v := range reportGranularities {
+		if v == g {
+			return k
+		}
+	}
+	return finance.ReportGranularity_REPORT_GRANULARITY_MONTH
+}
+
+// cashFlowQueryFromProto собирает параметры отчетов о движении денег и капитале из запроса
+func cashFlowQueryFromProto(req *finance.CashFlowReportRequest) (reports.CashFlowQuery, error) {
+	granularity, ok := reportGranularities[req.Granularity]
+	if !ok {
+		return reports.CashFlowQuery{}, status.Errorf(codes.InvalidArgument, "unknown report granularity %d", req.Granularity)
+	}
+	currency, err := domain.CurrencyByCode(req.Currency)
+	if err != nil {
+		return reports.CashFlowQuery{}, status.Errorf(codes.InvalidArgument, "invalid currency: %v", err)
+	}
+
+	query := reports.CashFlowQuery{
+		UserID:      req.UserId,
+		Granularity: granularity,
+		Currency:    currency,
+		AccountID:   req.AccountId,
+	}
+	if query.From, err = dateFromProto(req.FromDate); err != nil {
+		return reports.CashFlowQuery{}, err
+	}
+	if query.To, err = dateFromProto(req.ToDate); err != nil {
+		return reports.CashFlowQuery{}, err
+	}
+	return query, nil
+}
+
+// cashFlowToProto переводит ряд движения денег в сообщение API
+func cashFlowToProto(cf *reports.CashFlow) *finance.CashFlow {
+	resp := &finance.CashFlow{
+		Granularity:    granularityToProto(cf.Granularity),
+		Currency:       cf.Currency.Code,
+		OpeningBalance: decimalToProto(cf.Opening),
+		Periods:        make([]*finance.CashFlowPeriod, 0, len(cf.Periods)),
+	}
+	for _, p := range cf.Periods {
+		period := &finance.CashFlowPeriod{
+			PeriodStart: dateToProto(p.From),
+			PeriodEnd:   dateToProto(p.To.AddDays(-1)),
+			Total:       flowToProto(p.Flow),
+			Accounts:    make([]*finance.AccountFlow, 0, len(p.Accounts)),
+		}
+		for _, a := range p.Accounts {
+			period.Accounts = append(period.Accounts, &finance.AccountFlow{AccountId: a.AccountID, Flow: flowToProto(a.Flow)})
+		}
+		resp.Periods = append(resp.Periods, period)
+	}
+	return resp
+}
+
+// flowToProto переводит движение денег за период в API
+func flowToProto(f reports.Flow) *finance.Flow {
+	return &finance.Flow{
+		Inflow:    decimalToProto(f.Inflow),
+		Outflow:   decimalToProto(f.Outflow),
+		Transfers: decimalToProto(f.Transfers),
+		Net:       decimalToProto(f.Net),
+		Balance:   decimalToProto(f.Balance),
+	}
+}
+
+// netWorthToProto переводит остатки ряда движения денег в отчет о капитале
+func netWorthToProto(cf *reports.CashFlow) *finance.NetWorth {
+	resp := &finance.NetWorth{
+		Granularity:    granularityToProto(cf.Granularity),
+		Currency:       cf.Currency.Code,
+		OpeningBalance: decimalToProto(cf.Opening),
+		Points:         make([]*finance.NetWorthPoint, 0, len(cf.Periods)),
+	}
+	for _, p := range cf.Periods {
+		point := &finance.NetWorthPoint{
+			PeriodStart: dateToProto(p.From),
+			PeriodEnd:   dateToProto(p.To.AddDays(-1)),
+			Total:       decimalToProto(p.Balance),
+			Accounts:    make([]*finance.AccountBalance, 0, len(p.Accounts)),
+		}
+		for _, a := range p.Accounts {
+			point.Accounts = append(point.Accounts, &finance.AccountBalance{AccountId: a.AccountID,
+				Balance: decimalToProto(a.Balance)})
+		}
+		resp.Points = append(resp.Points, point)
+	}
+	return resp
+}
+
+// exportFormats соответствие форматов выгрузки API и export
+var exportFormats = map[finance.ExportFormat]export.Format{
+	finance.ExportFormat_EXPORT_FORMAT_CSV:   export.FormatCSV,
+	finance.ExportFormat_EXPORT_FORMAT_JSONL: export.FormatJSONL,
+	finance.ExportFormat_EXPORT_FORMAT_XLSX:  export.FormatXLSX,
+}
+
+// transactionKinds соответствие видов операций API и export
+var transactionKinds = map[finance.TransactionKind]export.Kind{
+	finance.TransactionKind_TRANSACTION_KIND_INCOME:   export.KindIncome,
+	finance.TransactionKind_TRANSACTION_KIND_EXPENSE:  export.KindExpense,
+	finance.TransactionKind_TRANSACTION_KIND_TRANSFER: export.KindTransfer,
+}
+
+// exportQueryFromProto собирает параметры выгрузки операций из запроса
+func exportQueryFromProto(req *finance.ExportTransactionsRequest) (export.Query, error) {
+	format, ok := exportFormats[req.Format]
+	if !ok {
+		return export.Query{}, status.Errorf(codes.InvalidArgument, "unknown export format %d", req.Format)
+	}
+	var delimiter rune
+	if req.Delimiter != "" {
+		if utf8.RuneCountInString(req.Delimiter) != 1 {
+			return export.Query{}, status.Errorf(codes.InvalidArgument, "delimiter %q must be a single character", req.Delimiter)
+		}
+		delimiter, _ = utf8.DecodeRuneInString(req.Delimiter)
+	}
+
+	query := export.Query{
+		UserID:     req.UserId,
+		CategoryID: int(req.CategoryId),
+		AccountID:  req.AccountId,
+		Tags:       req.Tags,
+		Options: export.Options{
+			Format:           format,
+			Locale:           req.Locale,
+			Delimiter:        delimiter,
+			DecimalSeparator: req.DecimalSeparator,
+		},
+	}
+	for _, k := range req.Kinds {
+		kind, ok := transactionKinds[k]
+		if !ok {
+			return export.Query{}, status.Errorf(codes.InvalidArgument, "unknown transaction kind %d", k)
+		}
+		query.Kinds = append(query.Kinds, kind)
+	}
+	var err error
+	if query.FromDate, err = dateFromProto(req.FromDate); err != nil {
+		return export.Query{}, err
+	}
+	if query.ToDate, err = dateFromProto(req.ToDate); err != nil {
+		return export.Query{}, err
+	}
+	return query, nil
+}
+
+// erasureReportToProto преобразует отчет о стирании данных в ответ API
+func erasureReportToProto(r *domain.ErasureReport) *finance.ErasureReport {
+	resp := &finance.ErasureReport{
+		UserId:       r.UserID,
+		ErasedAt:     timestamppb.New(r.ErasedAt),
+		Sections:     make([]*finance.SectionErasure, len(r.Sections)),
+		TotalRemoved: r.TotalRemoved(),
+	}
+	for i, s := range r.Sections {
+		resp.Sections[i] = &finance.SectionErasure{Section: string(s.Section), Removed: s.Removed}
+	}
+	return resp
+}
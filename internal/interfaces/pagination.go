@@ -0,0 +1,43 @@
+package interfaces
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pageToken содержимое непрозрачного токена страницы: позиция последнего элемента
+type pageToken struct {
+	ID int64 `json:"id"`
+	// Sort поле сортировки, при которой выдан токен, если сортировка не по ID
+	Sort int `json:"s,omitempty"`
+	// Value значение поля сортировки последнего элемента, если сортировка не по ID
+	Value string `json:"v,omitempty"`
+}
+
+// encodePageToken превращает позицию последнего элемента страницы в непрозрачный токен
+func encodePageToken(token pageToken) string {
+	raw, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodePageToken разбирает токен страницы, пустой токен означает начало списка и дает nil
+func decodePageToken(token string) (*pageToken, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	var t pageToken
+	if err := json.Unmarshal(raw, &t); err != nil || t.ID <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,114 @@
+package interfaces
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+)
+
+// CreateRecurringRule создает правило повторяющегося дохода
+func (h *FinanceHandler) CreateRecurringRule(ctx context.Context, req *finance.CreateRecurringRuleRequest) (*finance.RecurringRule, error) {
+	amount, err := moneyFromProto(req.GetAmount(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+	start, err := dateFromProto(req.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	end, err := dateFromProto(req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := h.recurring.CreateRule(ctx, &domain.RecurringRule{
+		UserID:      req.UserId,
+		CategoryID:  int(req.CategoryId),
+		AccountID:   req.AccountId,
+		Amount:      amount,
+		Description: req.Description,
+		Frequency:   recurrenceFrequencies[req.Frequency],
+		Interval:    int(req.Interval),
+		DayOfMonth:  int(req.DayOfMonth),
+		StartDate:   start,
+		EndDate:     end,
+		Count:       int(req.Count),
+	})
+	if err != nil {
+		return nil, errorStatus(err, "failed to create recurring rule")
+	}
+
+	return recurringRuleToProto(rule), nil
+}
+
+// ListRecurringRules возвращает правила повторяющихся доходов пользователя
+func (h *FinanceHandler) ListRecurringRules(ctx context.Context, req *finance.ListRecurringRulesRequest) (*finance.ListRecurringRulesResponse, error) {
+	rules, err := h.recurring.ListRules(ctx, req.UserId)
+	if err != nil {
+		return nil, errorStatus(err, "failed to list recurring rules")
+	}
+
+	resp := &finance.ListRecurringRulesResponse{Rules: make([]*finance.RecurringRule, 0, len(rules))}
+	for i := range rules {
+		resp.Rules = append(resp.Rules, recurringRuleToProto(&rules[i]))
+	}
+
+	return resp, nil
+}
+
+// PauseRecurringRule приостанавливает правило повторяющегося дохода
+func (h *FinanceHandler) PauseRecurringRule(ctx context.Context, req *finance.PauseRecurringRuleRequest) (*finance.RecurringRule, error) {
+	rule, err := h.recurring.PauseRule(ctx, req.UserId, req.Id)
+	if err != nil {
+		return nil, errorStatus(err, "failed to pause recurring rule")
+	}
+
+	return recurringRuleToProto(rule), nil
+}
+
+// ResumeRecurringRule возобновляет правило повторяющегося дохода
+func (h *FinanceHandler) ResumeRecurringRule(ctx context.Context, req *finance.ResumeRecurringRuleRequest) (*finance.RecurringRule, error) {
+	rule, err := h.recurring.ResumeRule(ctx, req.UserId, req.Id)
+	if err != nil {
+		return nil, errorStatus(err, "failed to resume recurring rule")
+	}
+
+	return recurringRuleToProto(rule), nil
+}
+
+// SkipRecurringOccurrence отменяет проведение одного предстоящего повторения
+func (h *FinanceHandler) SkipRecurringOccurrence(ctx context.Context, req *finance.SkipRecurringOccurrenceRequest) (*emptypb.Empty, error) {
+	date, err := dateFromProto(req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.recurring.SkipOccurrence(ctx, req.UserId, req.Id, date); err != nil {
+		return nil, errorStatus(err, "failed to skip recurring occurrence")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListUpcomingOccurrences возвращает предстоящие повторения доходов пользователя
+func (h *FinanceHandler) ListUpcomingOccurrences(ctx context.Context, req *finance.ListUpcomingOccurrencesRequest) (*finance.ListUpcomingOccurrencesResponse, error) {
+	until, err := dateFromProto(req.ToDate)
+	if err != nil {
+		return nil, err
+	}
+
+	upcoming, err := h.recurring.ListUpcoming(ctx, req.UserId, until)
+	if err != nil {
+		return nil, errorStatus(err, "failed to list upcoming occurrences")
+	}
+
+	resp := &finance.ListUpcomingOccurrencesResponse{Occurrences: make([]*finance.UpcomingOccurrence, 0, len(upcoming))}
+	for i := range upcoming {
+		resp.Occurrences = append(resp.Occurrences, upcomingOccurrenceToProto(&upcoming[i]))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,96 @@
+package interfaces_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupRecurringTest(t *testing.T) (*gomock.Controller, *mocks.MockRecurringService, *interfaces.FinanceHandler) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := mocks.NewMockRecurringService(ctrl)
+	handler := interfaces.NewFinanceHandler(interfaces.Services{Recurring: mockUsecase})
+
+	return ctrl, mockUsecase, handler
+}
+
+func Test_FinanceHandler_CreateRecurringRule_ReturnsNextDate_WhenValidRequest(t *testing.T) {
+	ctrl, mockUsecase, handler := setupRecurringTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expected := &domain.RecurringRule{UserID: 1, CategoryID: 1, AccountID: 3, Amount: domain.NewMoney(50_000_000, kzt),
+		Description: "Salary", Frequency: domain.RecurrenceMonthly, Interval: 1, DayOfMonth: 31,
+		StartDate: domain.Date{Year: 2024, Month: time.January, Day: 1}, Count: 12}
+	mockUsecase.EXPECT().CreateRule(ctx, expected).
+		DoAndReturn(func(_ context.Context, rule *domain.RecurringRule) (*domain.RecurringRule, error) {
+			created := *rule
+			created.ID = 4
+			created.Reschedule()
+			return &created, nil
+		})
+
+	resp, err := handler.CreateRecurringRule(ctx, &finance.CreateRecurringRuleRequest{
+		UserId:      1,
+		CategoryId:  1,
+		AccountId:   3,
+		Amount:      &finance.Decimal{Units: 500_000},
+		Currency:    "KZT",
+		Description: "Salary",
+		Frequency:   finance.RecurrenceFrequency_RECURRENCE_FREQUENCY_MONTHLY,
+		Interval:    1,
+		DayOfMonth:  31,
+		StartDate:   "2024-01-01",
+		Count:       12,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), resp.Id)
+	assert.Equal(t, "2024-01-31", resp.NextDate)
+	assert.Empty(t, resp.EndDate)
+}
+
+func Test_FinanceHandler_SkipRecurringOccurrence_ReturnsInvalidArgument_WhenDateNotScheduled(t *testing.T) {
+	ctrl, mockUsecase, handler := setupRecurringTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	date := domain.Date{Year: 2024, Month: time.April, Day: 11}
+	mockUsecase.EXPECT().SkipOccurrence(ctx, int64(1), int64(4), date).Return(fmt.Errorf("%w: 2024-04-11 is not an upcoming occurrence of rule 4", usecases.ErrValidation))
+
+	_, err := handler.SkipRecurringOccurrence(ctx, &finance.SkipRecurringOccurrenceRequest{UserId: 1, Id: 4, Date: "2024-04-11"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_FinanceHandler_ListUpcomingOccurrences_ReturnsSkippedFlag_WhenOccurrencesFound(t *testing.T) {
+	ctrl, mockUsecase, handler := setupRecurringTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	rule := domain.RecurringRule{ID: 4, UserID: 1, CategoryID: 1, Amount: domain.NewMoney(50_000_000, kzt)}
+	mockUsecase.EXPECT().ListUpcoming(ctx, int64(1), domain.Date{}).Return([]domain.UpcomingOccurrence{
+		{Rule: rule, Date: domain.Date{Year: 2024, Month: time.April, Day: 10}, Skipped: true},
+	}, nil)
+
+	resp, err := handler.ListUpcomingOccurrences(ctx, &finance.ListUpcomingOccurrencesRequest{UserId: 1})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Occurrences, 1)
+	assert.Equal(t, "2024-04-10", resp.Occurrences[0].Date)
+	assert.Equal(t, int64(4), resp.Occurrences[0].RuleId)
+	assert.True(t, resp.Occurrences[0].Skipped)
+}
@@ -0,0 +1,52 @@
+package interfaces
+
+import (
+	"context"
+
+	"fincraft-finance/api/finance"
+)
+
+// GetIncomeSummary возвращает отчет по доходам пользователя за период
+func (h *FinanceHandler) GetIncomeSummary(ctx context.Context, req *finance.GetIncomeSummaryRequest) (*finance.IncomeSummary, error) {
+	query, err := incomeQueryFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := h.reports.GetIncomeSummary(ctx, query)
+	if err != nil {
+		return nil, errorStatus(err, "failed to get income summary")
+	}
+
+	return incomeSummaryToProto(summary), nil
+}
+
+// GetCashFlow возвращает ряд движения денег и остатков пользователя по периодам
+func (h *FinanceHandler) GetCashFlow(ctx context.Context, req *finance.CashFlowReportRequest) (*finance.CashFlow, error) {
+	query, err := cashFlowQueryFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	cashFlow, err := h.reports.GetCashFlow(ctx, query)
+	if err != nil {
+		return nil, errorStatus(err, "failed to get cash flow")
+	}
+
+	return cashFlowToProto(cashFlow), nil
+}
+
+// GetNetWorth возвращает капитал пользователя на конец каждого периода
+func (h *FinanceHandler) GetNetWorth(ctx context.Context, req *finance.CashFlowReportRequest) (*finance.NetWorth, error) {
+	query, err := cashFlowQueryFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	cashFlow, err := h.reports.GetCashFlow(ctx, query)
+	if err != nil {
+		return nil, errorStatus(err, "failed to get net worth")
+	}
+
+	return netWorthToProto(cashFlow), nil
+}
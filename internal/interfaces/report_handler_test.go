@@ -0,0 +1,120 @@
+package interfaces_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/reports"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupReportTest(t *testing.T) (*gomock.Controller, *mocks.MockReportService, *interfaces.FinanceHandler) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := mocks.NewMockReportService(ctrl)
+	handler := interfaces.NewFinanceHandler(interfaces.Services{Reports: mockUsecase})
+
+	return ctrl, mockUsecase, handler
+}
+
+func Test_FinanceHandler_GetIncomeSummary_ReturnsSummary_WhenValidRequest(t *testing.T) {
+	ctrl, mockUsecase, handler := setupReportTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	query := reports.IncomeQuery{UserID: 1, Granularity: reports.GranularityQuarter,
+		From: domain.Date{Year: 2024, Month: time.January, Day: 1}, To: domain.Date{Year: 2024, Month: time.June, Day: 30},
+		Currency: kzt, AccountID: 5, Tags: []string{"work"}}
+	total := domain.NewMoney(150_000, kzt)
+	mockUsecase.EXPECT().GetIncomeSummary(ctx, query).Return(&reports.IncomeSummary{
+		Granularity: reports.GranularityQuarter, Currency: kzt, Total: total,
+		Periods: []reports.PeriodSummary{{
+			Period: reports.Period{From: domain.Date{Year: 2024, Month: time.April, Day: 1},
+				To: domain.Date{Year: 2024, Month: time.July, Day: 1}},
+			Total: total, Previous: domain.NewMoney(0, kzt), Delta: total,
+			Categories: []reports.CategoryTotal{{CategoryID: 2, Total: total}},
+		}},
+	}, nil)
+
+	resp, err := handler.GetIncomeSummary(ctx, &finance.GetIncomeSummaryRequest{
+		UserId:      1,
+		Granularity: finance.ReportGranularity_REPORT_GRANULARITY_QUARTER,
+		FromDate:    "2024-01-01",
+		ToDate:      "2024-06-30",
+		Currency:    "KZT",
+		AccountId:   5,
+		Tags:        []string{"work"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, finance.ReportGranularity_REPORT_GRANULARITY_QUARTER, resp.Granularity)
+	require.Len(t, resp.Periods, 1)
+	assert.Equal(t, "2024-04-01", resp.Periods[0].PeriodStart)
+	assert.Equal(t, "2024-06-30", resp.Periods[0].PeriodEnd)
+	assert.Equal(t, &finance.Decimal{Units: 1_500}, resp.Periods[0].Delta)
+	assert.Equal(t, int32(2), resp.Periods[0].Categories[0].CategoryId)
+}
+
+func Test_FinanceHandler_GetIncomeSummary_ReturnsInvalidArgument_WhenCurrencyMissing(t *testing.T) {
+	ctrl, _, handler := setupReportTest(t)
+	defer ctrl.Finish()
+
+	_, err := handler.GetIncomeSummary(context.Background(), &finance.GetIncomeSummaryRequest{UserId: 1,
+		FromDate: "2024-01-01", ToDate: "2024-12-31"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_FinanceHandler_GetNetWorth_ReturnsBalances_WhenValidRequest(t *testing.T) {
+	ctrl, mockUsecase, handler := setupReportTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	query := reports.CashFlowQuery{UserID: 1, Granularity: reports.GranularityYear,
+		From: domain.Date{Year: 2023, Month: time.January, Day: 1}, To: domain.Date{Year: 2023, Month: time.December, Day: 31},
+		Currency: kzt}
+	balance := domain.NewMoney(2_500_000, kzt)
+	zero := domain.NewMoney(0, kzt)
+	flow := reports.Flow{Inflow: balance, Outflow: zero, Transfers: zero, Net: balance, Balance: balance}
+	mockUsecase.EXPECT().GetCashFlow(ctx, query).Return(&reports.CashFlow{
+		Granularity: reports.GranularityYear, Currency: kzt, Opening: zero,
+		Periods: []reports.CashFlowPeriod{{
+			Period: reports.Period{From: query.From, To: domain.Date{Year: 2024, Month: time.January, Day: 1}},
+			Flow:   flow, Accounts: []reports.AccountFlow{{AccountID: 3, Flow: flow}},
+		}},
+	}, nil)
+
+	resp, err := handler.GetNetWorth(ctx, &finance.CashFlowReportRequest{
+		UserId:      1,
+		Granularity: finance.ReportGranularity_REPORT_GRANULARITY_YEAR,
+		FromDate:    "2023-01-01",
+		ToDate:      "2023-12-31",
+		Currency:    "KZT",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, finance.ReportGranularity_REPORT_GRANULARITY_YEAR, resp.Granularity)
+	require.Len(t, resp.Points, 1)
+	assert.Equal(t, "2023-12-31", resp.Points[0].PeriodEnd)
+	assert.Equal(t, &finance.Decimal{Units: 25_000}, resp.Points[0].Total)
+	assert.Equal(t, int64(3), resp.Points[0].Accounts[0].AccountId)
+}
+
+func Test_FinanceHandler_GetCashFlow_ReturnsInvalidArgument_WhenGranularityUnknown(t *testing.T) {
+	ctrl, _, handler := setupReportTest(t)
+	defer ctrl.Finish()
+
+	_, err := handler.GetCashFlow(context.Background(), &finance.CashFlowReportRequest{UserId: 1, Granularity: 42,
+		FromDate: "2024-01-01", ToDate: "2024-12-31", Currency: "KZT"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,106 @@
+package interfaces
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"fincraft-finance/api/finance"
+)
+
+// CreateRule создает правило автоматической категоризации
+func (h *FinanceHandler) CreateRule(ctx context.Context, req *finance.CreateRuleRequest) (*finance.Rule, error) {
+	rule, err := ruleFromProto(req.UserId, req.Name, req.Priority, req.Direction, req.Conditions, req.Actions)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err = h.rules.CreateRule(ctx, rule)
+	if err != nil {
+		return nil, errorStatus(err, "failed to create rule")
+	}
+
+	return ruleToProto(rule), nil
+}
+
+// ListRules возвращает правила пользователя в порядке применения
+func (h *FinanceHandler) ListRules(ctx context.Context, req *finance.ListRulesRequest) (*finance.ListRulesResponse, error) {
+	rules, err := h.rules.ListRules(ctx, req.UserId)
+	if err != nil {
+		return nil, errorStatus(err, "failed to list rules")
+	}
+
+	resp := &finance.ListRulesResponse{Rules: make([]*finance.Rule, 0, len(rules))}
+	for i := range rules {
+		resp.Rules = append(resp.Rules, ruleToProto(&rules[i]))
+	}
+
+	return resp, nil
+}
+
+// UpdateRule полностью заменяет изменяемые поля правила
+func (h *FinanceHandler) UpdateRule(ctx context.Context, req *finance.UpdateRuleRequest) (*finance.Rule, error) {
+	rule, err := ruleFromProto(req.UserId, req.Name, req.Priority, req.Direction, req.Conditions, req.Actions)
+	if err != nil {
+		return nil, err
+	}
+	rule.ID = req.Id
+
+	rule, err = h.rules.UpdateRule(ctx, rule)
+	if err != nil {
+		return nil, errorStatus(err, "failed to update rule")
+	}
+
+	return ruleToProto(rule), nil
+}
+
+// DeleteRule удаляет правило пользователя
+func (h *FinanceHandler) DeleteRule(ctx context.Context, req *finance.DeleteRuleRequest) (*emptypb.Empty, error) {
+	if err := h.rules.DeleteRule(ctx, req.UserId, req.Id); err != nil {
+		return nil, errorStatus(err, "failed to delete rule")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// DryRunRule возвращает изменения, которые правило внесло бы в операции за период, ничего не сохраняя
+func (h *FinanceHandler) DryRunRule(ctx context.Context, req *finance.DryRunRuleRequest) (*finance.RuleChangesResponse, error) {
+	rule, err := ruleFromProto(req.UserId, req.Name, req.Priority, req.Direction, req.Conditions, req.Actions)
+	if err != nil {
+		return nil, err
+	}
+	from, err := dateFromProto(req.FromDate)
+	if err != nil {
+		return nil, err
+	}
+	to, err := dateFromProto(req.ToDate)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := h.rules.DryRunRule(ctx, rule, from, to)
+	if err != nil {
+		return nil, errorStatus(err, "failed to dry run rule")
+	}
+
+	return ruleChangesToProto(changes), nil
+}
+
+// ApplyRule применяет сохраненное правило к операциям за период и возвращает внесенные изменения
+func (h *FinanceHandler) ApplyRule(ctx context.Context, req *finance.ApplyRuleRequest) (*finance.RuleChangesResponse, error) {
+	from, err := dateFromProto(req.FromDate)
+	if err != nil {
+		return nil, err
+	}
+	to, err := dateFromProto(req.ToDate)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := h.rules.ApplyRule(ctx, req.UserId, req.Id, from, to)
+	if err != nil {
+		return nil, errorStatus(err, "failed to apply rule")
+	}
+
+	return ruleChangesToProto(changes), nil
+}
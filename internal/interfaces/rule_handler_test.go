@@ -0,0 +1,95 @@
+package interfaces_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupRuleTest(t *testing.T) (*gomock.Controller, *mocks.MockRuleService, *interfaces.FinanceHandler) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := mocks.NewMockRuleService(ctrl)
+	handler := interfaces.NewFinanceHandler(interfaces.Services{Rules: mockUsecase})
+
+	return ctrl, mockUsecase, handler
+}
+
+func Test_FinanceHandler_CreateRule_ReturnsRule_WhenValidRequest(t *testing.T) {
+	ctrl, mockUsecase, handler := setupRuleTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	maxAmount := domain.NewMoney(500_000, kzt)
+	expected := &domain.Rule{UserID: 1, Name: "Taxi", Priority: 2, Direction: domain.RuleDirectionExpense,
+		Conditions: domain.RuleConditions{DescriptionContains: "uber", MaxAmount: &maxAmount},
+		Actions:    domain.RuleActions{CategoryID: 8, Tags: []string{"transport"}}}
+	created := *expected
+	created.ID = 4
+	mockUsecase.EXPECT().CreateRule(ctx, expected).Return(&created, nil)
+
+	resp, err := handler.CreateRule(ctx, &finance.CreateRuleRequest{
+		UserId:    1,
+		Name:      "Taxi",
+		Priority:  2,
+		Direction: finance.RuleDirection_RULE_DIRECTION_EXPENSE,
+		Conditions: &finance.RuleConditions{DescriptionContains: "uber", MaxAmount: &finance.Decimal{Units: 5_000},
+			AmountCurrency: "KZT"},
+		Actions: &finance.RuleActions{CategoryId: 8, Tags: []string{"transport"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), resp.Id)
+	assert.Equal(t, &finance.Decimal{Units: 5_000}, resp.Conditions.MaxAmount)
+	assert.Equal(t, "KZT", resp.Conditions.AmountCurrency)
+	assert.Nil(t, resp.Conditions.MinAmount)
+}
+
+func Test_FinanceHandler_DryRunRule_ReturnsChanges_WhenRuleMatches(t *testing.T) {
+	ctrl, mockUsecase, handler := setupRuleTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	at := time.Date(2024, time.March, 5, 9, 0, 0, 0, time.UTC)
+	mockUsecase.EXPECT().DryRunRule(ctx, gomock.Any(), domain.Date{Year: 2024, Month: time.March, Day: 1},
+		domain.Date{Year: 2024, Month: time.March, Day: 31}).
+		Return([]domain.RuleChange{{Kind: domain.JournalSourceExpense, OperationID: 20,
+			Amount: domain.NewMoney(2_000, kzt), OccurredAt: at, OldCategoryID: 5, NewCategoryID: 8,
+			OldDescription: "UBER trip", NewDescription: "Taxi"}}, nil)
+
+	resp, err := handler.DryRunRule(ctx, &finance.DryRunRuleRequest{
+		UserId: 1, Name: "Taxi", Direction: finance.RuleDirection_RULE_DIRECTION_EXPENSE,
+		Conditions: &finance.RuleConditions{DescriptionContains: "uber"},
+		Actions:    &finance.RuleActions{CategoryId: 8, Description: "Taxi"},
+		FromDate:   "2024-03-01", ToDate: "2024-03-31",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Changes, 1)
+	assert.Equal(t, finance.OperationKind_OPERATION_KIND_EXPENSE, resp.Changes[0].Kind)
+	assert.Equal(t, int32(8), resp.Changes[0].NewCategoryId)
+	assert.Equal(t, "Taxi", resp.Changes[0].NewDescription)
+}
+
+func Test_FinanceHandler_ApplyRule_ReturnsInvalidArgument_WhenValidationFails(t *testing.T) {
+	ctrl, mockUsecase, handler := setupRuleTest(t)
+	defer ctrl.Finish()
+
+	mockUsecase.EXPECT().ApplyRule(gomock.Any(), int64(1), int64(4), domain.Date{}, domain.Date{}).
+		Return(nil, usecases.ErrValidation)
+
+	_, err := handler.ApplyRule(context.Background(), &finance.ApplyRuleRequest{UserId: 1, Id: 4})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,54 @@
+package interfaces
+
+import (
+	"bytes"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/statements"
+)
+
+// ImportStatement разбирает выписку и создает по ней доходы, расходы и переводы
+func (h *FinanceHandler) ImportStatement(ctx context.Context, req *finance.ImportStatementRequest) (*finance.ImportStatementResponse, error) {
+	profile, err := statementProfileFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	format, ok := statementFormats[req.Format]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown statement format %v", req.Format)
+	}
+	lines, err := statements.Parse(format, bytes.NewReader(req.Content), profile)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := h.statements.ImportStatement(ctx, domain.StatementImport{
+		UserID:            req.UserId,
+		AccountID:         req.AccountId,
+		IncomeCategoryID:  int(req.IncomeCategoryId),
+		ExpenseCategoryID: int(req.ExpenseCategoryId),
+		Lines:             lines,
+		DryRun:            req.DryRun,
+	})
+	if err != nil {
+		return nil, errorStatus(err, "failed to import statement")
+	}
+
+	resp := &finance.ImportStatementResponse{
+		Lines:     make([]*finance.StatementLine, 0, len(result.Lines)),
+		Incomes:   int32(result.Incomes),
+		Expenses:  int32(result.Expenses),
+		Transfers: int32(result.Transfers),
+	}
+	for i := range result.Lines {
+		resp.Lines = append(resp.Lines, statementLineToProto(&result.Lines[i]))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,134 @@
+package interfaces_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupStatementTest(t *testing.T) (*gomock.Controller, *mocks.MockStatementService, *interfaces.FinanceHandler) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := mocks.NewMockStatementService(ctrl)
+	handler := interfaces.NewFinanceHandler(interfaces.Services{Statements: mockUsecase})
+
+	return ctrl, mockUsecase, handler
+}
+
+func Test_FinanceHandler_ImportStatement_PassesParsedLines_WhenCustomProfile(t *testing.T) {
+	ctrl, mockUsecase, handler := setupStatementTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	date := domain.Date{Year: 2024, Month: time.March, Day: 15}
+	mockUsecase.EXPECT().ImportStatement(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, imp domain.StatementImport) (domain.StatementImportResult, error) {
+			assert.Equal(t, 2, imp.IncomeCategoryID)
+			assert.True(t, imp.DryRun)
+			require.Len(t, imp.Lines, 2)
+			assert.Equal(t, domain.NewMoney(-123450, kzt), imp.Lines[0].Amount)
+			assert.Error(t, imp.Lines[1].Err)
+
+			lines := append([]domain.StatementLine(nil), imp.Lines...)
+			lines[0].OperationID = 9
+			return domain.StatementImportResult{Lines: lines, Expenses: 1}, nil
+		})
+
+	resp, err := handler.ImportStatement(ctx, &finance.ImportStatementRequest{
+		UserId:           1,
+		Content:          []byte("15.03.2024;-1 234,50;Магазин\n2024-03-16;100;Broken\n"),
+		IncomeCategoryId: 2,
+		DryRun:           true,
+		Profile: &finance.StatementProfile{
+			Delimiter:        ";",
+			Date:             &finance.StatementColumn{Index: 1},
+			Amount:           &finance.StatementColumn{Index: 2},
+			Description:      &finance.StatementColumn{Index: 3},
+			DefaultCurrency:  "KZT",
+			DateFormat:       "DD.MM.YYYY",
+			DecimalSeparator: ",",
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Lines, 2)
+	assert.Equal(t, date.String(), resp.Lines[0].Date)
+	assert.Equal(t, int64(-1234), resp.Lines[0].Amount.Units)
+	assert.Equal(t, int64(9), resp.Lines[0].OperationId)
+	assert.Equal(t, `invalid date "2024-03-16"`, resp.Lines[1].Error)
+	assert.Equal(t, int32(1), resp.Expenses)
+}
+
+func Test_FinanceHandler_ImportStatement_ReturnsInvalidArgument_WhenFileOrProfileInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *finance.ImportStatementRequest
+	}{
+		{"Unknown Profile", &finance.ImportStatementRequest{UserId: 1, ProfileName: "unknown"}},
+		{"Missing Profile", &finance.ImportStatementRequest{UserId: 1}},
+		{"Long Delimiter", &finance.ImportStatementRequest{UserId: 1, Profile: &finance.StatementProfile{Delimiter: ";;"}}},
+		{"Missing Column", &finance.ImportStatementRequest{UserId: 1, ProfileName: "generic", Content: []byte("date,sum\n")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, _, handler := setupStatementTest(t)
+			defer ctrl.Finish()
+
+			_, err := handler.ImportStatement(context.Background(), tt.req)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func Test_FinanceHandler_ImportStatement_ReturnsInternal_WhenUseCaseFails(t *testing.T) {
+	ctrl, mockUsecase, handler := setupStatementTest(t)
+	defer ctrl.Finish()
+
+	mockUsecase.EXPECT().ImportStatement(gomock.Any(), gomock.Any()).
+		Return(domain.StatementImportResult{}, errors.New("db error"))
+
+	_, err := handler.ImportStatement(context.Background(), &finance.ImportStatementRequest{UserId: 1,
+		ProfileName: "generic", Content: []byte("date,amount,currency,description\n2024-03-15,100,KZT,Salary\n")})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func Test_FinanceHandler_ImportStatement_ReturnsTransfers_WhenOFXWithoutProfile(t *testing.T) {
+	ctrl, mockUsecase, handler := setupStatementTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().ImportStatement(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, imp domain.StatementImport) (domain.StatementImportResult, error) {
+			require.Len(t, imp.Lines, 1)
+			assert.Equal(t, "40817/T-1", imp.Lines[0].ExternalID)
+			return domain.StatementImportResult{Lines: imp.Lines, Transfers: 1}, nil
+		})
+
+	resp, err := handler.ImportStatement(ctx, &finance.ImportStatementRequest{
+		UserId:    1,
+		AccountId: 3,
+		Format:    finance.StatementFormat_STATEMENT_FORMAT_OFX,
+		Content: []byte("OFXHEADER:100\nDATA:OFXSGML\n\n<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>KZT" +
+			"<BANKACCTFROM><ACCTID>40817</BANKACCTFROM><BANKTRANLIST>" +
+			"<STMTTRN><TRNTYPE>XFER<DTPOSTED>20240315<TRNAMT>-100.00<FITID>T-1<NAME>To savings</STMTTRN>" +
+			"</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>"),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), resp.Transfers)
+	assert.Equal(t, "40817/T-1", resp.Lines[0].ExternalId)
+}
@@ -0,0 +1,42 @@
+package interfaces
+
+import (
+	"context"
+
+	"fincraft-finance/api/finance"
+)
+
+// ListTags возвращает теги пользователя для автодополнения
+func (h *FinanceHandler) ListTags(ctx context.Context, req *finance.ListTagsRequest) (*finance.ListTagsResponse, error) {
+	tags, err := h.tags.ListTags(ctx, req.UserId, req.Prefix, int(req.Limit))
+	if err != nil {
+		return nil, errorStatus(err, "failed to list tags")
+	}
+
+	resp := &finance.ListTagsResponse{Tags: make([]*finance.Tag, 0, len(tags))}
+	for i := range tags {
+		resp.Tags = append(resp.Tags, tagToProto(&tags[i]))
+	}
+
+	return resp, nil
+}
+
+// RenameTag меняет название тега пользователя
+func (h *FinanceHandler) RenameTag(ctx context.Context, req *finance.RenameTagRequest) (*finance.Tag, error) {
+	tag, err := h.tags.RenameTag(ctx, req.UserId, req.Id, req.Name)
+	if err != nil {
+		return nil, errorStatus(err, "failed to rename tag")
+	}
+
+	return tagToProto(tag), nil
+}
+
+// MergeTags объединяет тег source с тегом target
+func (h *FinanceHandler) MergeTags(ctx context.Context, req *finance.MergeTagsRequest) (*finance.Tag, error) {
+	tag, err := h.tags.MergeTags(ctx, req.UserId, req.SourceId, req.TargetId)
+	if err != nil {
+		return nil, errorStatus(err, "failed to merge tags")
+	}
+
+	return tagToProto(tag), nil
+}
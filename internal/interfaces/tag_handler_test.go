@@ -0,0 +1,73 @@
+package interfaces_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupTagTest(t *testing.T) (*gomock.Controller, *mocks.MockTagService, *interfaces.FinanceHandler) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := mocks.NewMockTagService(ctrl)
+	handler := interfaces.NewFinanceHandler(interfaces.Services{Tags: mockUsecase})
+
+	return ctrl, mockUsecase, handler
+}
+
+func Test_FinanceHandler_ListTags_ReturnsTags_WhenValidRequest(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTagTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().ListTags(ctx, int64(1), "va", 5).Return([]domain.Tag{
+		{ID: 3, UserID: 1, Name: "vacation-2026", UsageCount: 12},
+		{ID: 9, UserID: 1, Name: "vat", UsageCount: 1},
+	}, nil)
+
+	resp, err := handler.ListTags(ctx, &finance.ListTagsRequest{UserId: 1, Prefix: "va", Limit: 5})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Tags, 2)
+	assert.Equal(t, "vacation-2026", resp.Tags[0].Name)
+	assert.Equal(t, int32(12), resp.Tags[0].UsageCount)
+}
+
+func Test_FinanceHandler_RenameTag_ReturnsInvalidArgument_WhenNameTaken(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTagTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().RenameTag(ctx, int64(1), int64(4), "travel").
+		Return(nil, fmt.Errorf("%w: tag \"Travel\" already exists, merge the tags instead", usecases.ErrValidation))
+
+	_, err := handler.RenameTag(ctx, &finance.RenameTagRequest{UserId: 1, Id: 4, Name: "travel"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_FinanceHandler_MergeTags_ReturnsTarget_WhenMerged(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTagTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().MergeTags(ctx, int64(1), int64(4), int64(7)).
+		Return(&domain.Tag{ID: 7, UserID: 1, Name: "travel", UsageCount: 5}, nil)
+
+	resp, err := handler.MergeTags(ctx, &finance.MergeTagsRequest{UserId: 1, SourceId: 4, TargetId: 7})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), resp.Id)
+	assert.Equal(t, int32(5), resp.UsageCount)
+}
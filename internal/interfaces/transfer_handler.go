@@ -0,0 +1,70 @@
+package interfaces
+
+import (
+	"context"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+)
+
+// AddTransfer переводит деньги между счетами пользователя
+func (h *FinanceHandler) AddTransfer(ctx context.Context, req *finance.AddTransferRequest) (*finance.Transfer, error) {
+	fromAmount, err := moneyFromProto(req.GetFromAmount(), req.GetFromCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := &domain.Transfer{
+		UserID:        req.UserId,
+		FromAccountID: req.FromAccountId,
+		ToAccountID:   req.ToAccountId,
+		FromAmount:    fromAmount,
+		Description:   req.Description,
+		OccurredAt:    timeFromProto(req.OccurredAt),
+	}
+	// Сумма зачисления указывается только для переводов между валютами
+	if req.ToAmount != nil || req.ToCurrency != "" {
+		if transfer.ToAmount, err = moneyFromProto(req.GetToAmount(), req.GetToCurrency()); err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := h.transfers.AddTransfer(ctx, transfer)
+	if err != nil {
+		return nil, errorStatus(err, "failed to add transfer")
+	}
+
+	return transferToProto(created), nil
+}
+
+// ListTransfers возвращает страницу переводов пользователя
+func (h *FinanceHandler) ListTransfers(ctx context.Context, req *finance.ListTransfersRequest) (*finance.ListTransfersResponse, error) {
+	token, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := domain.TransferFilter{
+		UserID:    req.UserId,
+		AccountID: req.AccountId,
+		PageSize:  int(req.PageSize),
+	}
+	if token != nil {
+		filter.AfterID = token.ID
+	}
+
+	page, err := h.transfers.ListTransfers(ctx, filter)
+	if err != nil {
+		return nil, errorStatus(err, "failed to list transfers")
+	}
+
+	resp := &finance.ListTransfersResponse{Transfers: make([]*finance.Transfer, 0, len(page.Items))}
+	for i := range page.Items {
+		resp.Transfers = append(resp.Transfers, transferToProto(&page.Items[i]))
+	}
+	if page.HasMore {
+		resp.NextPageToken = encodePageToken(pageToken{ID: page.Items[len(page.Items)-1].ID})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,98 @@
+package interfaces_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupTransferTest(t *testing.T) (*gomock.Controller, *mocks.MockTransferService, *interfaces.FinanceHandler) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := mocks.NewMockTransferService(ctrl)
+	handler := interfaces.NewFinanceHandler(interfaces.Services{Transfers: mockUsecase})
+
+	return ctrl, mockUsecase, handler
+}
+
+func Test_FinanceHandler_AddTransfer_LeavesToAmountEmpty_WhenNotProvided(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTransferTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	amount := domain.NewMoney(10000, kzt)
+	mockUsecase.EXPECT().AddTransfer(ctx, &domain.Transfer{UserID: 1, FromAccountID: 3, ToAccountID: 4, FromAmount: amount}).
+		Return(&domain.Transfer{ID: 7, UserID: 1, FromAccountID: 3, ToAccountID: 4, FromAmount: amount, ToAmount: amount,
+			OccurredAt: time.Now()}, nil)
+
+	resp, err := handler.AddTransfer(ctx, &finance.AddTransferRequest{
+		UserId: 1, FromAccountId: 3, ToAccountId: 4, FromAmount: &finance.Decimal{Units: 100}, FromCurrency: "KZT",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), resp.Id)
+	assert.Equal(t, "KZT", resp.ToCurrency)
+	assert.Equal(t, &finance.Decimal{Units: 1}, resp.Rate)
+}
+
+func Test_FinanceHandler_AddTransfer_ReturnsRate_WhenCrossCurrency(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTransferTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	usd := domain.MustCurrency("USD")
+	from := domain.NewMoney(4_700_000, kzt)
+	to := domain.NewMoney(10000, usd)
+	mockUsecase.EXPECT().AddTransfer(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, transfer *domain.Transfer) (*domain.Transfer, error) {
+			assert.Equal(t, to, transfer.ToAmount)
+			return transfer, nil
+		})
+
+	resp, err := handler.AddTransfer(ctx, &finance.AddTransferRequest{
+		UserId: 1, FromAccountId: 3, ToAccountId: 4,
+		FromAmount: &finance.Decimal{Units: 47000}, FromCurrency: "KZT",
+		ToAmount: &finance.Decimal{Units: 100}, ToCurrency: "USD",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, from.Currency().Code, resp.FromCurrency)
+	assert.Equal(t, &finance.Decimal{Nanos: 2_127_660}, resp.Rate)
+}
+
+func Test_FinanceHandler_AddTransfer_ReturnsInvalidArgument_WhenToCurrencyUnknown(t *testing.T) {
+	_, _, handler := setupTransferTest(t)
+
+	_, err := handler.AddTransfer(context.Background(), &finance.AddTransferRequest{
+		UserId: 1, FromAccountId: 3, ToAccountId: 4, FromAmount: &finance.Decimal{Units: 100}, FromCurrency: "KZT",
+		ToAmount: &finance.Decimal{Units: 1},
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_FinanceHandler_ListTransfers_ReturnsNextPageToken_WhenHasMore(t *testing.T) {
+	ctrl, mockUsecase, handler := setupTransferTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	amount := domain.NewMoney(100, kzt)
+	mockUsecase.EXPECT().ListTransfers(ctx, domain.TransferFilter{UserID: 1, AccountID: 3, PageSize: 1}).
+		Return(domain.TransferPage{Items: []domain.Transfer{{ID: 9, FromAmount: amount, ToAmount: amount}}, HasMore: true}, nil)
+
+	resp, err := handler.ListTransfers(ctx, &finance.ListTransfersRequest{UserId: 1, AccountId: 3, PageSize: 1})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Transfers, 1)
+	assert.NotEmpty(t, resp.NextPageToken)
+}
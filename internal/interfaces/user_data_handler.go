@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"fincraft-finance/api/finance"
+)
+
+// ExportUserData выгружает архив всех данных пользователя в JSON и передает его потоком частей
+func (h *FinanceHandler) ExportUserData(req *finance.ExportUserDataRequest,
+	stream finance.FinanceService_ExportUserDataServer) error {
+	fileName := fmt.Sprintf("user-%d-data.json", req.UserId)
+	err := sendFile(stream, "application/json", fileName, func(w io.Writer) error {
+		return h.userData.ExportUserData(stream.Context(), req.UserId, w)
+	})
+	if err != nil {
+		return errorStatus(err, "failed to export user data")
+	}
+	return nil
+}
+
+// EraseUser удаляет все данные пользователя и возвращает отчет об удаленных строках
+func (h *FinanceHandler) EraseUser(ctx context.Context, req *finance.EraseUserRequest) (*finance.ErasureReport, error) {
+	report, err := h.userData.EraseUser(ctx, req.UserId)
+	if err != nil {
+		return nil, errorStatus(err, "failed to erase user")
+	}
+
+	return erasureReportToProto(report), nil
+}
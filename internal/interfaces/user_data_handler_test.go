@@ -0,0 +1,96 @@
+package interfaces_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupUserDataTest(t *testing.T) (*gomock.Controller, *mocks.MockUserDataService, *interfaces.FinanceHandler) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := mocks.NewMockUserDataService(ctrl)
+	handler := interfaces.NewFinanceHandler(interfaces.Services{UserData: mockUsecase})
+
+	return ctrl, mockUsecase, handler
+}
+
+func Test_FinanceHandler_ExportUserData_StreamsArchive_WhenUserExists(t *testing.T) {
+	ctrl, mockUsecase, handler := setupUserDataTest(t)
+	defer ctrl.Finish()
+
+	mockUsecase.EXPECT().ExportUserData(gomock.Any(), int64(1), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int64, w io.Writer) error {
+			_, err := io.WriteString(w, `{"schema_version":1}`)
+			return err
+		})
+	stream := &exportStream{}
+
+	err := handler.ExportUserData(&finance.ExportUserDataRequest{UserId: 1}, stream)
+
+	require.NoError(t, err)
+	require.Len(t, stream.chunks, 1)
+	assert.Equal(t, "application/json", stream.chunks[0].ContentType)
+	assert.Equal(t, "user-1-data.json", stream.chunks[0].FileName)
+	assert.Equal(t, `{"schema_version":1}`, string(stream.chunks[0].Data))
+}
+
+func Test_FinanceHandler_ExportUserData_ReturnsNotFound_WhenUserMissing(t *testing.T) {
+	ctrl, mockUsecase, handler := setupUserDataTest(t)
+	defer ctrl.Finish()
+
+	mockUsecase.EXPECT().ExportUserData(gomock.Any(), int64(1), gomock.Any()).Return(domain.ErrNotFound)
+	stream := &exportStream{}
+
+	err := handler.ExportUserData(&finance.ExportUserDataRequest{UserId: 1}, stream)
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Empty(t, stream.chunks)
+}
+
+func Test_FinanceHandler_EraseUser_ReturnsReport_WhenErased(t *testing.T) {
+	ctrl, mockUsecase, handler := setupUserDataTest(t)
+	defer ctrl.Finish()
+
+	erasedAt := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	mockUsecase.EXPECT().EraseUser(gomock.Any(), int64(1)).Return(&domain.ErasureReport{
+		UserID:   1,
+		ErasedAt: erasedAt,
+		Sections: []domain.SectionErasure{
+			{Section: domain.UserDataUser, Removed: 1},
+			{Section: domain.UserDataIncomes, Removed: 4},
+		},
+	}, nil)
+
+	resp, err := handler.EraseUser(context.Background(), &finance.EraseUserRequest{UserId: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.UserId)
+	assert.Equal(t, erasedAt, resp.ErasedAt.AsTime())
+	assert.Equal(t, int64(5), resp.TotalRemoved)
+	require.Len(t, resp.Sections, 2)
+	assert.Equal(t, "incomes", resp.Sections[1].Section)
+	assert.Equal(t, int64(4), resp.Sections[1].Removed)
+}
+
+func Test_FinanceHandler_EraseUser_ReturnsNotFound_WhenUserMissing(t *testing.T) {
+	ctrl, mockUsecase, handler := setupUserDataTest(t)
+	defer ctrl.Finish()
+
+	mockUsecase.EXPECT().EraseUser(gomock.Any(), int64(1)).Return(nil, domain.ErrNotFound)
+
+	_, err := handler.EraseUser(context.Background(), &finance.EraseUserRequest{UserId: 1})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
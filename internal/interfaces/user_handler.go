@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"fincraft-finance/api/finance"
+)
+
+// GetUserTimezone возвращает часовой пояс пользователя
+func (h *FinanceHandler) GetUserTimezone(ctx context.Context, req *finance.GetUserTimezoneRequest) (*finance.UserTimezone, error) {
+	loc, err := h.users.GetTimezone(ctx, req.UserId)
+	if err != nil {
+		return nil, errorStatus(err, "failed to get user timezone")
+	}
+
+	return &finance.UserTimezone{UserId: req.UserId, Timezone: loc.String()}, nil
+}
+
+// SetUserTimezone задает часовой пояс пользователя, по которому строятся календарные выборки
+func (h *FinanceHandler) SetUserTimezone(ctx context.Context, req *finance.SetUserTimezoneRequest) (*emptypb.Empty, error) {
+	if err := h.users.SetTimezone(ctx, req.UserId, req.Timezone); err != nil {
+		return nil, errorStatus(err, "failed to set user timezone")
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,57 @@
+package interfaces_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"fincraft-finance/api/finance"
+	"fincraft-finance/internal/interfaces"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupUserTest(t *testing.T) (*gomock.Controller, *mocks.MockUserService, *interfaces.FinanceHandler) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := mocks.NewMockUserService(ctrl)
+	handler := interfaces.NewFinanceHandler(interfaces.Services{Users: mockUsecase})
+
+	return ctrl, mockUsecase, handler
+}
+
+func Test_FinanceHandler_GetUserTimezone_ReturnsTimezone_WhenUserExists(t *testing.T) {
+	ctrl, mockUsecase, handler := setupUserTest(t)
+	defer ctrl.Finish()
+
+	almaty, err := time.LoadLocation("Asia/Almaty")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().GetTimezone(ctx, int64(1)).Return(almaty, nil)
+
+	resp, err := handler.GetUserTimezone(ctx, &finance.GetUserTimezoneRequest{UserId: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Almaty", resp.Timezone)
+}
+
+func Test_FinanceHandler_SetUserTimezone_ReturnsInvalidArgument_WhenTimezoneUnknown(t *testing.T) {
+	ctrl, mockUsecase, handler := setupUserTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUsecase.EXPECT().SetTimezone(ctx, int64(1), "Mars/Olympus").
+		Return(fmt.Errorf("%w: unknown timezone", usecases.ErrValidation))
+
+	resp, err := handler.SetUserTimezone(ctx, &finance.SetUserTimezoneRequest{UserId: 1, Timezone: "Mars/Olympus"})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,106 @@
+package migrations
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// files встроенные SQL-миграции схемы сервиса
+//
+//go:embed sql/*.sql
+var files embed.FS
+
+// ErrInvalidMigration возвращается для некорректного набора файлов миграций
+var ErrInvalidMigration = errors.New("invalid migration")
+
+// fileNamePattern формат имени файла миграции: 0001_create_users.up.sql
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration описывает одну версию схемы
+type Migration struct {
+	// Version номер версии, миграции применяются по возрастанию
+	Version int64
+	// Name описание миграции из имени файла
+	Name string
+	// Up SQL применения миграции
+	Up string
+	// Down SQL отката миграции
+	Down string
+}
+
+// Embedded возвращает миграции, встроенные в бинарник
+func Embedded() ([]Migration, error) {
+	sub, err := fs.Sub(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return Load(sub)
+}
+
+// Load читает миграции из корня fsys и возвращает их упорядоченными по версии.
+// Каждая версия должна иметь пару файлов up и down.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		m := fileNamePattern.FindStringSubmatch(entry.Name())
+		if m == nil {
+			return nil, fmt.Errorf("%w: unexpected file name %q", ErrInvalidMigration, entry.Name())
+		}
+
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%w: bad version in %q", ErrInvalidMigration, entry.Name())
+		}
+
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != m[2] {
+			return nil, fmt.Errorf("%w: version %d has different names %q and %q",
+				ErrInvalidMigration, version, migration.Name, m[2])
+		}
+
+		switch m[3] {
+		case "up":
+			migration.Up = string(body)
+		case "down":
+			migration.Down = string(body)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("%w: version %d must have both up and down files",
+				ErrInvalidMigration, migration.Version)
+		}
+		list = append(list, *migration)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+
+	return list, nil
+}
@@ -0,0 +1,73 @@
+package migrations_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/migrations"
+)
+
+func Test_Load_ReturnsMigrationsSortedByVersion_WhenFilesValid(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0010_add_index.up.sql":      {Data: []byte("CREATE INDEX")},
+		"0010_add_index.down.sql":    {Data: []byte("DROP INDEX")},
+		"0002_create_users.up.sql":   {Data: []byte("CREATE TABLE")},
+		"0002_create_users.down.sql": {Data: []byte("DROP TABLE")},
+		"README.md":                  {Data: []byte("ignored")},
+	}
+
+	list, err := migrations.Load(fsys)
+
+	require.NoError(t, err)
+	assert.Equal(t, []migrations.Migration{
+		{Version: 2, Name: "create_users", Up: "CREATE TABLE", Down: "DROP TABLE"},
+		{Version: 10, Name: "add_index", Up: "CREATE INDEX", Down: "DROP INDEX"},
+	}, list)
+}
+
+func Test_Load_ReturnsError_WhenFilesInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"Missing Down", fstest.MapFS{
+			"0001_init.up.sql": {Data: []byte("CREATE TABLE")},
+		}},
+		{"Empty Up", fstest.MapFS{
+			"0001_init.up.sql":   {Data: []byte("")},
+			"0001_init.down.sql": {Data: []byte("DROP TABLE")},
+		}},
+		{"Bad File Name", fstest.MapFS{
+			"init.up.sql": {Data: []byte("CREATE TABLE")},
+		}},
+		{"Zero Version", fstest.MapFS{
+			"0000_init.up.sql":   {Data: []byte("CREATE TABLE")},
+			"0000_init.down.sql": {Data: []byte("DROP TABLE")},
+		}},
+		{"Name Mismatch", fstest.MapFS{
+			"0001_init.up.sql":  {Data: []byte("CREATE TABLE")},
+			"0001_other.up.sql": {Data: []byte("CREATE TABLE")},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := migrations.Load(tt.fsys)
+
+			assert.ErrorIs(t, err, migrations.ErrInvalidMigration)
+		})
+	}
+}
+
+func Test_Embedded_ReturnsContiguousVersions_WhenCalled(t *testing.T) {
+	list, err := migrations.Embedded()
+
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+	for i, migration := range list {
+		assert.Equal(t, int64(i+1), migration.Version, migration.Name)
+	}
+}
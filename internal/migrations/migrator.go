@@ -0,0 +1,172 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// advisoryLockKey ключ advisory lock, который сериализует миграции между репликами сервиса
+const advisoryLockKey = 7_310_425_001
+
+// Migrator применяет и откатывает миграции схемы.
+// Примененные версии хранятся в таблице schema_migrations.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator создает мигратор со встроенным набором миграций
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	list, err := Embedded()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMigratorWith(db, list), nil
+}
+
+// NewMigratorWith создает мигратор с заданным набором миграций, упорядоченным по версии
+func NewMigratorWith(db *sql.DB, migrations []Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+// Up применяет все еще не примененные миграции и возвращает их количество
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if versions[migration.Version] {
+				continue
+			}
+
+			err := inTx(ctx, conn, migration.Up,
+				`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, migration.Version, migration.Name)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+			}
+			applied++
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down откатывает steps последних примененных миграций и возвращает количество откаченных
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+			migration := m.migrations[i]
+			if !versions[migration.Version] {
+				continue
+			}
+
+			err := inTx(ctx, conn, migration.Down,
+				`DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+			}
+			reverted++
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Version возвращает номер последней примененной миграции или 0, если схема пуста
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	var version int64
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		return conn.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	})
+
+	return version, err
+}
+
+// withLock выполняет fn на выделенном соединении под advisory lock,
+// предварительно создав таблицу schema_migrations
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, advisoryLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	//noinspection GoUnhandledErrorResult
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, advisoryLockKey)
+
+	_, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			name       TEXT        NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// appliedVersions возвращает множество примененных версий
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]bool, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer rows.Close()
+
+	versions := make(map[int64]bool)
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		versions[version] = true
+	}
+
+	return versions, rows.Err()
+}
+
+// inTx выполняет SQL миграции и запись в schema_migrations в одной транзакции
+func inTx(ctx context.Context, conn *sql.Conn, migrationSQL, bookkeeping string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, migrationSQL); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,3 @@
+DROP FUNCTION add_income(BIGINT, INTEGER, NUMERIC, VARCHAR, VARCHAR, TIMESTAMPTZ);
+DROP TABLE incomes;
+DROP TABLE users;
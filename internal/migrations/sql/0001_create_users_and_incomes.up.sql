@@ -0,0 +1,37 @@
+CREATE TABLE users (
+    id         BIGSERIAL PRIMARY KEY,
+    email      TEXT        NOT NULL UNIQUE,
+    name       TEXT        NOT NULL,
+    timezone   TEXT        NOT NULL DEFAULT 'UTC',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE incomes (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    category_id INTEGER      NOT NULL CHECK (category_id > 0),
+    amount      NUMERIC      NOT NULL CHECK (amount > 0),
+    currency    VARCHAR(3)   NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ  NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX incomes_user_occurred_at_idx ON incomes (user_id, occurred_at DESC, id DESC);
+CREATE INDEX incomes_user_amount_idx ON incomes (user_id, amount DESC, id DESC);
+
+CREATE FUNCTION add_income(
+    p_user_id BIGINT,
+    p_category_id INTEGER,
+    p_amount NUMERIC,
+    p_currency VARCHAR,
+    p_description VARCHAR,
+    p_occurred_at TIMESTAMPTZ
+) RETURNS incomes
+    LANGUAGE sql AS
+$$
+INSERT INTO incomes (user_id, category_id, amount, currency, description, occurred_at)
+VALUES (p_user_id, p_category_id, p_amount, p_currency, p_description, p_occurred_at)
+RETURNING *;
+$$;
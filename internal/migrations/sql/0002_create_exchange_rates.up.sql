@@ -0,0 +1,7 @@
+CREATE TABLE exchange_rates (
+    base      VARCHAR(3) NOT NULL,
+    quote     VARCHAR(3) NOT NULL,
+    rate_date DATE       NOT NULL,
+    rate      NUMERIC    NOT NULL CHECK (rate > 0),
+    PRIMARY KEY (base, quote, rate_date)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE expenses (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    category_id INTEGER      NOT NULL CHECK (category_id > 0),
+    amount      NUMERIC      NOT NULL CHECK (amount > 0),
+    currency    VARCHAR(3)   NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ  NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX expenses_user_id_idx ON expenses (user_id, id DESC);
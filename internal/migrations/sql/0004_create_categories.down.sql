@@ -0,0 +1,3 @@
+ALTER TABLE expenses DROP CONSTRAINT expenses_category_id_fkey;
+ALTER TABLE incomes DROP CONSTRAINT incomes_category_id_fkey;
+DROP TABLE categories;
@@ -0,0 +1,34 @@
+-- user_id NULL - системная категория, доступная всем пользователям
+CREATE TABLE categories (
+    id         SERIAL PRIMARY KEY,
+    user_id    BIGINT REFERENCES users (id) ON DELETE CASCADE,
+    name       VARCHAR(64) NOT NULL,
+    kind       TEXT        NOT NULL CHECK (kind IN ('income', 'expense')),
+    icon       VARCHAR(64) NOT NULL DEFAULT '',
+    parent_id  INTEGER REFERENCES categories (id),
+    archived   BOOLEAN     NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX categories_user_id_idx ON categories (user_id);
+CREATE INDEX categories_parent_id_idx ON categories (parent_id);
+
+INSERT INTO categories (name, kind, icon)
+VALUES ('Salary', 'income', 'salary'),
+       ('Freelance', 'income', 'laptop'),
+       ('Interest and dividends', 'income', 'percent'),
+       ('Gifts', 'income', 'gift'),
+       ('Other income', 'income', 'plus'),
+       ('Groceries', 'expense', 'cart'),
+       ('Housing', 'expense', 'home'),
+       ('Utilities', 'expense', 'bulb'),
+       ('Transport', 'expense', 'car'),
+       ('Health', 'expense', 'heart'),
+       ('Entertainment', 'expense', 'ticket'),
+       ('Other expenses', 'expense', 'minus');
+
+-- NOT VALID: ссылки проверяются для новых строк, исторические данные без категорий не блокируют миграцию
+ALTER TABLE incomes
+    ADD CONSTRAINT incomes_category_id_fkey FOREIGN KEY (category_id) REFERENCES categories (id) NOT VALID;
+ALTER TABLE expenses
+    ADD CONSTRAINT expenses_category_id_fkey FOREIGN KEY (category_id) REFERENCES categories (id) NOT VALID;
@@ -0,0 +1,19 @@
+DROP FUNCTION add_income(BIGINT, INTEGER, NUMERIC, VARCHAR, VARCHAR, TIMESTAMPTZ, BIGINT);
+
+ALTER TABLE incomes DROP COLUMN account_id;
+DROP TABLE accounts;
+
+CREATE FUNCTION add_income(
+    p_user_id BIGINT,
+    p_category_id INTEGER,
+    p_amount NUMERIC,
+    p_currency VARCHAR,
+    p_description VARCHAR,
+    p_occurred_at TIMESTAMPTZ
+) RETURNS incomes
+    LANGUAGE sql AS
+$$
+INSERT INTO incomes (user_id, category_id, amount, currency, description, occurred_at)
+VALUES (p_user_id, p_category_id, p_amount, p_currency, p_description, p_occurred_at)
+RETURNING *;
+$$;
@@ -0,0 +1,36 @@
+CREATE TABLE accounts (
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name            VARCHAR(64) NOT NULL,
+    type            TEXT        NOT NULL CHECK (type IN ('cash', 'card', 'checking', 'savings', 'brokerage')),
+    currency        VARCHAR(3)  NOT NULL,
+    opening_balance NUMERIC     NOT NULL DEFAULT 0,
+    -- balance поддерживается сервисом: opening_balance плюс все операции по счету
+    balance         NUMERIC     NOT NULL DEFAULT 0,
+    closed_at       TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX accounts_user_id_idx ON accounts (user_id);
+
+ALTER TABLE incomes ADD COLUMN account_id BIGINT REFERENCES accounts (id);
+CREATE INDEX incomes_account_id_idx ON incomes (account_id);
+
+DROP FUNCTION add_income(BIGINT, INTEGER, NUMERIC, VARCHAR, VARCHAR, TIMESTAMPTZ);
+
+CREATE FUNCTION add_income(
+    p_user_id BIGINT,
+    p_category_id INTEGER,
+    p_amount NUMERIC,
+    p_currency VARCHAR,
+    p_description VARCHAR,
+    p_occurred_at TIMESTAMPTZ,
+    p_account_id BIGINT
+) RETURNS incomes
+    LANGUAGE sql AS
+$$
+INSERT INTO incomes (user_id, category_id, amount, currency, description, occurred_at, account_id)
+VALUES (p_user_id, p_category_id, p_amount, p_currency, p_description, p_occurred_at, p_account_id)
+RETURNING *;
+$$;
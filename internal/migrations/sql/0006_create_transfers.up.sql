@@ -0,0 +1,19 @@
+CREATE TABLE transfers (
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    from_account_id BIGINT       NOT NULL REFERENCES accounts (id),
+    to_account_id   BIGINT       NOT NULL REFERENCES accounts (id),
+    from_amount     NUMERIC      NOT NULL CHECK (from_amount > 0),
+    from_currency   VARCHAR(3)   NOT NULL,
+    -- to_amount в валюте счета-получателя, отношение to_amount / from_amount - курс перевода
+    to_amount       NUMERIC      NOT NULL CHECK (to_amount > 0),
+    to_currency     VARCHAR(3)   NOT NULL,
+    description     VARCHAR(255) NOT NULL DEFAULT '',
+    occurred_at     TIMESTAMPTZ  NOT NULL,
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    CHECK (from_account_id <> to_account_id)
+);
+
+CREATE INDEX transfers_user_id_idx ON transfers (user_id, id DESC);
+CREATE INDEX transfers_from_account_id_idx ON transfers (from_account_id);
+CREATE INDEX transfers_to_account_id_idx ON transfers (to_account_id);
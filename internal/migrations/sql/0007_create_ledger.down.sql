@@ -0,0 +1,12 @@
+ALTER TABLE accounts ADD COLUMN balance NUMERIC NOT NULL DEFAULT 0;
+
+UPDATE accounts a
+SET balance = a.opening_balance + COALESCE((
+    SELECT SUM(p.amount)
+    FROM journal_postings p
+    WHERE p.account_kind = 'asset' AND p.ref_id = a.id
+), 0);
+
+DROP TABLE journal_postings;
+DROP TABLE journal_entries;
+DROP FUNCTION check_journal_entry_balanced();
@@ -0,0 +1,102 @@
+CREATE TABLE journal_entries (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    source_kind TEXT        NOT NULL CHECK (source_kind IN ('income', 'expense', 'transfer')),
+    -- source_id без внешнего ключа: записи по удаленной операции остаются в журнале вместе со сторно
+    source_id   BIGINT      NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX journal_entries_source_idx ON journal_entries (source_kind, source_id);
+CREATE INDEX journal_entries_user_id_idx ON journal_entries (user_id, occurred_at);
+
+-- Положительная сумма - дебет, отрицательная - кредит.
+-- ref_id: счет для asset, категория для income и expense, 0 для unassigned и exchange.
+CREATE TABLE journal_postings (
+    id           BIGSERIAL PRIMARY KEY,
+    entry_id     BIGINT     NOT NULL REFERENCES journal_entries (id) ON DELETE CASCADE,
+    account_kind TEXT       NOT NULL CHECK (account_kind IN ('asset', 'unassigned', 'income', 'expense', 'exchange')),
+    ref_id       BIGINT     NOT NULL DEFAULT 0,
+    amount       NUMERIC    NOT NULL CHECK (amount <> 0),
+    currency     VARCHAR(3) NOT NULL,
+    CHECK ((account_kind IN ('asset', 'income', 'expense')) = (ref_id > 0))
+);
+
+CREATE INDEX journal_postings_entry_id_idx ON journal_postings (entry_id);
+CREATE INDEX journal_postings_account_idx ON journal_postings (account_kind, ref_id);
+
+-- Инвариант двойной записи: в каждой валюте сумма проводок записи равна нулю.
+-- Триггер отложен до фиксации транзакции, чтобы проводки одной записи можно было добавлять по одной.
+CREATE FUNCTION check_journal_entry_balanced() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+DECLARE
+    v_entry_id BIGINT := COALESCE(NEW.entry_id, OLD.entry_id);
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM journal_postings
+        WHERE entry_id = v_entry_id
+        GROUP BY currency
+        HAVING SUM(amount) <> 0
+    ) THEN
+        RAISE EXCEPTION 'journal entry % is unbalanced', v_entry_id USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE CONSTRAINT TRIGGER journal_postings_balanced
+    AFTER INSERT OR UPDATE OR DELETE ON journal_postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balanced();
+
+-- Переносим в журнал уже существующие операции
+DO
+$$
+DECLARE
+    r       RECORD;
+    v_entry BIGINT;
+BEGIN
+    FOR r IN SELECT * FROM incomes ORDER BY id LOOP
+        INSERT INTO journal_entries (user_id, source_kind, source_id, occurred_at)
+        VALUES (r.user_id, 'income', r.id, r.occurred_at)
+        RETURNING id INTO v_entry;
+
+        INSERT INTO journal_postings (entry_id, account_kind, ref_id, amount, currency)
+        VALUES (v_entry, CASE WHEN r.account_id IS NULL THEN 'unassigned' ELSE 'asset' END,
+                COALESCE(r.account_id, 0), r.amount, r.currency),
+               (v_entry, 'income', r.category_id, -r.amount, r.currency);
+    END LOOP;
+
+    FOR r IN SELECT * FROM expenses ORDER BY id LOOP
+        INSERT INTO journal_entries (user_id, source_kind, source_id, occurred_at)
+        VALUES (r.user_id, 'expense', r.id, r.occurred_at)
+        RETURNING id INTO v_entry;
+
+        INSERT INTO journal_postings (entry_id, account_kind, ref_id, amount, currency)
+        VALUES (v_entry, 'expense', r.category_id, r.amount, r.currency),
+               (v_entry, 'unassigned', 0, -r.amount, r.currency);
+    END LOOP;
+
+    FOR r IN SELECT * FROM transfers ORDER BY id LOOP
+        INSERT INTO journal_entries (user_id, source_kind, source_id, occurred_at)
+        VALUES (r.user_id, 'transfer', r.id, r.occurred_at)
+        RETURNING id INTO v_entry;
+
+        INSERT INTO journal_postings (entry_id, account_kind, ref_id, amount, currency)
+        VALUES (v_entry, 'asset', r.to_account_id, r.to_amount, r.to_currency),
+               (v_entry, 'asset', r.from_account_id, -r.from_amount, r.from_currency);
+
+        IF r.from_currency <> r.to_currency THEN
+            INSERT INTO journal_postings (entry_id, account_kind, ref_id, amount, currency)
+            VALUES (v_entry, 'exchange', 0, -r.to_amount, r.to_currency),
+                   (v_entry, 'exchange', 0, r.from_amount, r.from_currency);
+        END IF;
+    END LOOP;
+END;
+$$;
+
+-- Остаток счета теперь выводится из журнала
+ALTER TABLE accounts DROP COLUMN balance;
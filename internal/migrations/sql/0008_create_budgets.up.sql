@@ -0,0 +1,20 @@
+-- end_date задается только для произвольного периода (custom), повторяющиеся периоды бессрочны
+CREATE TABLE budgets (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    category_id INTEGER     NOT NULL REFERENCES categories (id),
+    period      TEXT        NOT NULL CHECK (period IN ('monthly', 'weekly', 'custom')),
+    amount      NUMERIC     NOT NULL CHECK (amount > 0),
+    currency    VARCHAR(3)  NOT NULL,
+    rollover    BOOLEAN     NOT NULL DEFAULT false,
+    start_date  DATE        NOT NULL,
+    end_date    DATE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK ((period = 'custom') = (end_date IS NOT NULL)),
+    CHECK (end_date IS NULL OR end_date >= start_date),
+    CHECK (NOT (rollover AND period = 'custom'))
+);
+
+CREATE INDEX budgets_user_id_idx ON budgets (user_id, id);
+CREATE INDEX budgets_category_id_idx ON budgets (category_id);
@@ -0,0 +1,35 @@
+-- step - интервал повторения в единицах frequency, processed - число обработанных повторений,
+-- next_date - дата следующего повторения, NULL - правило завершено
+CREATE TABLE recurring_rules (
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    category_id  INTEGER      NOT NULL REFERENCES categories (id),
+    account_id   BIGINT REFERENCES accounts (id),
+    amount       NUMERIC      NOT NULL CHECK (amount > 0),
+    currency     VARCHAR(3)   NOT NULL,
+    description  VARCHAR(255) NOT NULL DEFAULT '',
+    frequency    TEXT         NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly')),
+    step         INTEGER      NOT NULL CHECK (step >= 1),
+    day_of_month INTEGER      NOT NULL DEFAULT 0 CHECK (day_of_month BETWEEN 0 AND 31),
+    start_date   DATE         NOT NULL,
+    end_date     DATE,
+    max_count    INTEGER      NOT NULL DEFAULT 0 CHECK (max_count >= 0),
+    paused       BOOLEAN      NOT NULL DEFAULT false,
+    processed    INTEGER      NOT NULL DEFAULT 0,
+    next_date    DATE,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX recurring_rules_user_id_idx ON recurring_rules (user_id, id);
+CREATE INDEX recurring_rules_due_idx ON recurring_rules (next_date) WHERE NOT paused AND next_date IS NOT NULL;
+
+-- Первичный ключ гарантирует, что каждое повторение проводится или пропускается не больше одного раза
+CREATE TABLE recurring_occurrences (
+    rule_id    BIGINT      NOT NULL REFERENCES recurring_rules (id) ON DELETE CASCADE,
+    occurs_on  DATE        NOT NULL,
+    skipped    BOOLEAN     NOT NULL DEFAULT false,
+    income_id  BIGINT REFERENCES incomes (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (rule_id, occurs_on)
+);
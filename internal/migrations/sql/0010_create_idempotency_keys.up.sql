@@ -0,0 +1,16 @@
+-- user_id 0 - запрос не относится к конкретному пользователю.
+-- response хранит сериализованный ответ API, response_type - полное имя его типа.
+CREATE TABLE idempotency_keys (
+    user_id       BIGINT       NOT NULL,
+    method        TEXT         NOT NULL,
+    key           VARCHAR(128) NOT NULL,
+    fingerprint   BYTEA        NOT NULL,
+    response_type TEXT         NOT NULL DEFAULT '',
+    response      BYTEA,
+    completed     BOOLEAN      NOT NULL DEFAULT false,
+    created_at    TIMESTAMPTZ  NOT NULL,
+    expires_at    TIMESTAMPTZ  NOT NULL,
+    PRIMARY KEY (user_id, method, key)
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
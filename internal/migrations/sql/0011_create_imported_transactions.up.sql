@@ -0,0 +1,8 @@
+-- Идентификаторы банковских операций (FITID вместе со счетом), уже импортированных из выписок.
+-- Первичный ключ не дает импортировать одну операцию дважды, в том числе параллельными запросами.
+CREATE TABLE imported_transactions (
+    user_id     BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    external_id TEXT        NOT NULL,
+    imported_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, external_id)
+);
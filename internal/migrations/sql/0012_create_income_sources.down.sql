@@ -0,0 +1 @@
+DROP TABLE income_sources;
@@ -0,0 +1,18 @@
+-- Доходы, объединенные с другим доходом как дубликаты. Строка хранит объединенный доход в исходном виде
+-- (source_id - его прежний ID) и ссылается на доход, который остался после объединения.
+CREATE TABLE income_sources (
+    id          BIGSERIAL PRIMARY KEY,
+    income_id   BIGINT       NOT NULL REFERENCES incomes (id) ON DELETE CASCADE,
+    user_id     BIGINT       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    source_id   BIGINT       NOT NULL,
+    category_id INTEGER      NOT NULL,
+    account_id  BIGINT,
+    amount      NUMERIC      NOT NULL,
+    currency    VARCHAR(3)   NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ  NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL,
+    merged_at   TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX income_sources_income_id_idx ON income_sources (income_id);
@@ -0,0 +1,24 @@
+-- Правила автоматической категоризации операций. Условия с пустым значением (NULL, '' или 0) не проверяются,
+-- действия с пустым значением не применяются. direction: any - доходы и расходы, income или expense.
+CREATE TABLE rules (
+    id                   BIGSERIAL PRIMARY KEY,
+    user_id              BIGINT       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name                 VARCHAR(100) NOT NULL,
+    priority             INTEGER      NOT NULL DEFAULT 0,
+    direction            TEXT         NOT NULL CHECK (direction IN ('any', 'income', 'expense')),
+    description_regex    TEXT         NOT NULL DEFAULT '',
+    description_contains TEXT         NOT NULL DEFAULT '',
+    min_amount           NUMERIC CHECK (min_amount >= 0),
+    max_amount           NUMERIC CHECK (max_amount >= 0),
+    amount_currency      VARCHAR(3),
+    account_id           BIGINT REFERENCES accounts (id) ON DELETE CASCADE,
+    counterparty         TEXT         NOT NULL DEFAULT '',
+    category_id          INTEGER REFERENCES categories (id),
+    tags                 TEXT[]       NOT NULL DEFAULT '{}',
+    new_description      VARCHAR(255) NOT NULL DEFAULT '',
+    transfer_account_id  BIGINT REFERENCES accounts (id) ON DELETE CASCADE,
+    created_at           TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at           TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX rules_user_id_idx ON rules (user_id, priority, id);
@@ -0,0 +1,2 @@
+DROP TABLE income_tags;
+DROP TABLE tags;
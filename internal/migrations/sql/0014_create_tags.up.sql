@@ -0,0 +1,19 @@
+-- Теги пользователя: произвольные метки операций поверх иерархии категорий.
+-- Названия уникальны у пользователя без учета регистра.
+CREATE TABLE tags (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name       VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX tags_user_id_name_idx ON tags (user_id, lower(name));
+
+-- Теги доходов
+CREATE TABLE income_tags (
+    income_id BIGINT NOT NULL REFERENCES incomes (id) ON DELETE CASCADE,
+    tag_id    BIGINT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (income_id, tag_id)
+);
+
+CREATE INDEX income_tags_tag_id_idx ON income_tags (tag_id);
@@ -0,0 +1,30 @@
+-- Дневные обороты счетов по дням в часовом поясе пользователя: из них строятся отчеты
+-- о движении денег и капитале без обхода всего журнала.
+-- account_id 0 - нераспределенные деньги. Строки поддерживаются сервисом при каждой проводке журнала,
+-- а при смене часового пояса пользователя пересчитываются заново.
+CREATE TABLE daily_balances (
+    user_id    BIGINT     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    account_id BIGINT     NOT NULL,
+    day        DATE       NOT NULL,
+    currency   VARCHAR(3) NOT NULL,
+    -- inflow - доходы, outflow - расходы, transfers - сальдо переводов между счетами
+    inflow     NUMERIC    NOT NULL DEFAULT 0,
+    outflow    NUMERIC    NOT NULL DEFAULT 0,
+    transfers  NUMERIC    NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, account_id, day, currency)
+);
+
+-- Переносим обороты уже проведенных операций
+INSERT INTO daily_balances (user_id, account_id, day, currency, inflow, outflow, transfers)
+SELECT e.user_id,
+       p.ref_id,
+       (e.occurred_at AT TIME ZONE u.timezone)::date,
+       p.currency,
+       COALESCE(SUM(p.amount) FILTER (WHERE e.source_kind = 'income'), 0),
+       COALESCE(-SUM(p.amount) FILTER (WHERE e.source_kind = 'expense'), 0),
+       COALESCE(SUM(p.amount) FILTER (WHERE e.source_kind = 'transfer'), 0)
+FROM journal_postings p
+         JOIN journal_entries e ON e.id = p.entry_id
+         JOIN users u ON u.id = e.user_id
+WHERE p.account_kind IN ('asset', 'unassigned')
+GROUP BY 1, 2, 3, 4;
@@ -0,0 +1 @@
+ALTER TABLE expenses DROP COLUMN account_id;
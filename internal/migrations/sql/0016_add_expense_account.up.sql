@@ -0,0 +1,4 @@
+-- Счет, с которого списаны деньги; NULL - расход без счета (нераспределенные деньги)
+ALTER TABLE expenses ADD COLUMN account_id BIGINT REFERENCES accounts (id);
+
+CREATE INDEX expenses_account_id_idx ON expenses (account_id) WHERE account_id IS NOT NULL;
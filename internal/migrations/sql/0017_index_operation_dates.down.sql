@@ -0,0 +1,2 @@
+DROP INDEX transfers_user_occurred_at_idx;
+DROP INDEX expenses_user_occurred_at_idx;
@@ -0,0 +1,3 @@
+-- Выгрузка за период отбирает расходы и переводы по дате, а не только страницами по ID
+CREATE INDEX expenses_user_occurred_at_idx ON expenses (user_id, occurred_at);
+CREATE INDEX transfers_user_occurred_at_idx ON transfers (user_id, occurred_at);
@@ -0,0 +1,82 @@
+package rates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+// conversionRounding способ округления результата конвертации
+const conversionRounding = domain.RoundHalfEven
+
+// Converter конвертирует суммы между валютами по историческим курсам
+type Converter struct {
+	provider     RateProvider
+	fallbackDays int
+}
+
+// NewConverter создает новый экземпляр Converter.
+// fallbackDays задает, на сколько дней назад можно взять ближайший предыдущий курс,
+// если на нужную дату его нет; 0 требует курс ровно на дату.
+func NewConverter(provider RateProvider, fallbackDays int) *Converter {
+	return &Converter{provider: provider, fallbackDays: fallbackDays}
+}
+
+// Convert переводит сумму в валюту to по курсу на дату date.
+// Если прямого курса нет, используется обратный.
+func (c *Converter) Convert(ctx context.Context, m domain.Money, to domain.Currency, date time.Time) (domain.Money, error) {
+	from := m.Currency()
+	if from == to {
+		return m, nil
+	}
+
+	rate, err := c.rate(ctx, from, to, date)
+	if err != nil {
+		return domain.Money{}, err
+	}
+
+	converted := new(big.Rat).Mul(m.Rat(), rate)
+	return domain.NewMoneyFromRat(converted, to, conversionRounding)
+}
+
+// rate ищет прямой или обратный курс с учетом допустимого отката по датам
+func (c *Converter) rate(ctx context.Context, from, to domain.Currency, date time.Time) (*big.Rat, error) {
+	direct, err := c.lookup(ctx, from, to, date)
+	if err == nil {
+		return direct.Value, nil
+	}
+	if !errors.Is(err, ErrRateNotFound) {
+		return nil, err
+	}
+
+	inverse, err := c.lookup(ctx, to, from, date)
+	if err == nil {
+		return new(big.Rat).Inv(inverse.Value), nil
+	}
+	if !errors.Is(err, ErrRateNotFound) {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("%w: %s/%s on %s (fallback %d days)",
+		ErrRateNotFound, from, to, Day(date).Format(time.DateOnly), c.fallbackDays)
+}
+
+// lookup получает курс пары и проверяет, что он не старше допустимого
+func (c *Converter) lookup(ctx context.Context, base, quote domain.Currency, date time.Time) (Rate, error) {
+	day := Day(date)
+
+	rate, err := c.provider.RateOnOrBefore(ctx, base, quote, day)
+	if err != nil {
+		return Rate{}, err
+	}
+
+	if rate.Date.Before(day.AddDate(0, 0, -c.fallbackDays)) {
+		return Rate{}, ErrRateNotFound
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,132 @@
+package rates_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/rates"
+	"fincraft-finance/internal/rates/mocks"
+)
+
+var (
+	kzt = domain.MustCurrency("KZT")
+	usd = domain.MustCurrency("USD")
+	jpy = domain.MustCurrency("JPY")
+)
+
+var day = time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+func setupConverter(t *testing.T, fallbackDays int) (*gomock.Controller, *mocks.MockRateProvider, *rates.Converter) {
+	ctrl := gomock.NewController(t)
+	provider := mocks.NewMockRateProvider(ctrl)
+	converter := rates.NewConverter(provider, fallbackDays)
+	return ctrl, provider, converter
+}
+
+func rate(base, quote domain.Currency, date time.Time, value string) rates.Rate {
+	v, _ := new(big.Rat).SetString(value)
+	return rates.Rate{Base: base, Quote: quote, Date: date, Value: v}
+}
+
+func Test_Converter_Convert_ReturnsSameMoney_WhenSameCurrency(t *testing.T) {
+	ctrl, _, converter := setupConverter(t, 0)
+	defer ctrl.Finish()
+
+	m := domain.NewMoney(10050, kzt)
+	got, err := converter.Convert(context.Background(), m, kzt, day)
+
+	require.NoError(t, err)
+	assert.Equal(t, m, got)
+}
+
+func Test_Converter_Convert_ReturnsConvertedMoney_WhenDirectRateExists(t *testing.T) {
+	ctrl, provider, converter := setupConverter(t, 0)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	provider.EXPECT().RateOnOrBefore(ctx, usd, kzt, day).Return(rate(usd, kzt, day, "450.125"), nil)
+
+	got, err := converter.Convert(ctx, domain.NewMoney(1001, usd), kzt, day.Add(15*time.Hour))
+
+	require.NoError(t, err)
+	// 10.01 * 450.125 = 4505.75125
+	assert.Equal(t, domain.NewMoney(450575, kzt), got)
+}
+
+func Test_Converter_Convert_ReturnsConvertedMoney_WhenOnlyInverseRateExists(t *testing.T) {
+	ctrl, provider, converter := setupConverter(t, 0)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	notFound := rates.ErrRateNotFound
+	provider.EXPECT().RateOnOrBefore(ctx, kzt, usd, day).Return(rates.Rate{}, notFound)
+	provider.EXPECT().RateOnOrBefore(ctx, usd, kzt, day).Return(rate(usd, kzt, day, "500"), nil)
+
+	got, err := converter.Convert(ctx, domain.NewMoney(100000, kzt), usd, day)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(200, usd), got)
+}
+
+func Test_Converter_Convert_UsesTargetMinorUnits_WhenCurrencyHasNoMinorUnits(t *testing.T) {
+	ctrl, provider, converter := setupConverter(t, 0)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	provider.EXPECT().RateOnOrBefore(ctx, usd, jpy, day).Return(rate(usd, jpy, day, "149.5"), nil)
+
+	got, err := converter.Convert(ctx, domain.NewMoney(101, usd), jpy, day)
+
+	require.NoError(t, err)
+	// 1.01 * 149.5 = 150.995
+	assert.Equal(t, domain.NewMoney(151, jpy), got)
+}
+
+func Test_Converter_Convert_UsesPreviousDay_WhenWithinFallback(t *testing.T) {
+	ctrl, provider, converter := setupConverter(t, 3)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	provider.EXPECT().RateOnOrBefore(ctx, usd, kzt, day).
+		Return(rate(usd, kzt, day.AddDate(0, 0, -3), "450"), nil)
+
+	got, err := converter.Convert(ctx, domain.NewMoney(100, usd), kzt, day)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(45000, kzt), got)
+}
+
+func Test_Converter_Convert_ReturnsRateNotFound_WhenRateOlderThanFallback(t *testing.T) {
+	ctrl, provider, converter := setupConverter(t, 1)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	stale := rate(usd, kzt, day.AddDate(0, 0, -2), "450")
+	provider.EXPECT().RateOnOrBefore(ctx, usd, kzt, day).Return(stale, nil)
+	provider.EXPECT().RateOnOrBefore(ctx, kzt, usd, day).Return(rates.Rate{}, rates.ErrRateNotFound)
+
+	_, err := converter.Convert(ctx, domain.NewMoney(100, usd), kzt, day)
+
+	assert.ErrorIs(t, err, rates.ErrRateNotFound)
+	assert.Contains(t, err.Error(), "USD/KZT on 2024-03-15")
+}
+
+func Test_Converter_Convert_ReturnsError_WhenProviderFails(t *testing.T) {
+	ctrl, provider, converter := setupConverter(t, 0)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	provider.EXPECT().RateOnOrBefore(ctx, usd, kzt, day).Return(rates.Rate{}, errors.New("db error"))
+
+	_, err := converter.Convert(ctx, domain.NewMoney(100, usd), kzt, day)
+
+	assert.EqualError(t, err, "db error")
+}
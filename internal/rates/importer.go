@@ -0,0 +1,141 @@
+package rates
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+// ErrInvalidRateFile возвращается, если файл курсов не удалось разобрать
+var ErrInvalidRateFile = errors.New("invalid rate file")
+
+// csvColumns обязательные колонки CSV-файла курсов
+var csvColumns = []string{"date", "base", "quote", "rate"}
+
+// Importer загружает файлы курсов в хранилище
+type Importer struct {
+	store RateStore
+}
+
+// NewImporter создает новый экземпляр Importer
+func NewImporter(store RateStore) *Importer {
+	return &Importer{store: store}
+}
+
+// rateRecord запись файла курсов до валидации
+type rateRecord struct {
+	Date  string      `json:"date"`
+	Base  string      `json:"base"`
+	Quote string      `json:"quote"`
+	Rate  json.Number `json:"rate"`
+}
+
+// ImportCSV загружает курсы из CSV с заголовком date,base,quote,rate (порядок колонок произвольный).
+// Файл сохраняется целиком или не сохраняется совсем. Возвращает количество загруженных курсов.
+func (i *Importer) ImportCSV(ctx context.Context, r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to read header: %v", ErrInvalidRateFile, err)
+	}
+
+	index := make(map[string]int, len(header))
+	for n, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = n
+	}
+	for _, col := range csvColumns {
+		if _, ok := index[col]; !ok {
+			return 0, fmt.Errorf("%w: missing column %q", ErrInvalidRateFile, col)
+		}
+	}
+
+	var rates []Rate
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrInvalidRateFile, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		rate, err := parseRecord(rateRecord{
+			Date:  row[index["date"]],
+			Base:  row[index["base"]],
+			Quote: row[index["quote"]],
+			Rate:  json.Number(row[index["rate"]]),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("%w: line %d: %v", ErrInvalidRateFile, line, err)
+		}
+		rates = append(rates, rate)
+	}
+
+	return i.save(ctx, rates)
+}
+
+// ImportJSON загружает курсы из JSON-массива объектов {"date","base","quote","rate"}.
+// Курс допускается как строкой, так и числом. Возвращает количество загруженных курсов.
+func (i *Importer) ImportJSON(ctx context.Context, r io.Reader) (int, error) {
+	var records []rateRecord
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&records); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidRateFile, err)
+	}
+
+	rates := make([]Rate, 0, len(records))
+	for n, rec := range records {
+		rate, err := parseRecord(rec)
+		if err != nil {
+			return 0, fmt.Errorf("%w: item %d: %v", ErrInvalidRateFile, n, err)
+		}
+		rates = append(rates, rate)
+	}
+
+	return i.save(ctx, rates)
+}
+
+// save сохраняет разобранные курсы одним пакетом
+func (i *Importer) save(ctx context.Context, rates []Rate) (int, error) {
+	if len(rates) == 0 {
+		return 0, nil
+	}
+
+	if err := i.store.SaveRates(ctx, rates); err != nil {
+		return 0, fmt.Errorf("failed to save rates: %w", err)
+	}
+
+	return len(rates), nil
+}
+
+// parseRecord валидирует запись файла и превращает ее в курс
+func parseRecord(rec rateRecord) (Rate, error) {
+	date, err := time.Parse(time.DateOnly, strings.TrimSpace(rec.Date))
+	if err != nil {
+		return Rate{}, fmt.Errorf("invalid date %q", rec.Date)
+	}
+
+	base, err := domain.CurrencyByCode(strings.TrimSpace(rec.Base))
+	if err != nil {
+		return Rate{}, err
+	}
+
+	quote, err := domain.CurrencyByCode(strings.TrimSpace(rec.Quote))
+	if err != nil {
+		return Rate{}, err
+	}
+
+	return NewRate(base, quote, date, strings.TrimSpace(rec.Rate.String()))
+}
@@ -0,0 +1,111 @@
+package rates_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/rates"
+	"fincraft-finance/internal/rates/mocks"
+)
+
+func setupImporter(t *testing.T) (*gomock.Controller, *mocks.MockRateStore, *rates.Importer) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockRateStore(ctrl)
+	return ctrl, store, rates.NewImporter(store)
+}
+
+func Test_Importer_ImportCSV_SavesRates_WhenFileValid(t *testing.T) {
+	ctrl, store, importer := setupImporter(t)
+	defer ctrl.Finish()
+
+	file := "rate,date,base,quote\n450.12,2024-03-15,USD,KZT\n 5.1 , 2024-03-15 ,rub,KZT\n"
+
+	var saved []rates.Rate
+	store.EXPECT().SaveRates(gomock.Any(), gomock.Len(2)).
+		DoAndReturn(func(_ context.Context, list []rates.Rate) error {
+			saved = list
+			return nil
+		})
+
+	n, err := importer.ImportCSV(context.Background(), strings.NewReader(file))
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, usd, saved[0].Base)
+	assert.Equal(t, time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), saved[0].Date)
+	assert.Equal(t, "450.120000000000", saved[0].Decimal())
+	assert.Equal(t, "RUB", saved[1].Base.Code)
+}
+
+func Test_Importer_ImportCSV_ReturnsError_WhenFileInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		errMsg string
+	}{
+		{"Missing Column", "date,base,rate\n2024-03-15,USD,1\n", `missing column "quote"`},
+		{"Bad Date", "date,base,quote,rate\n15.03.2024,USD,KZT,1\n", `line 2: invalid date "15.03.2024"`},
+		{"Unknown Currency", "date,base,quote,rate\n2024-03-15,XXX,KZT,1\n", "line 2: unknown currency"},
+		{"Negative Rate", "date,base,quote,rate\n2024-03-15,USD,KZT,-1\n", "must be positive"},
+		{"Same Currency", "date,base,quote,rate\n2024-03-15,USD,USD,1\n", "pair USD/USD"},
+		{"Too Precise", "date,base,quote,rate\n2024-03-15,USD,KZT,1.0000000000001\n", "fractional digits"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, _, importer := setupImporter(t)
+			defer ctrl.Finish()
+
+			_, err := importer.ImportCSV(context.Background(), strings.NewReader(tt.file))
+
+			assert.ErrorIs(t, err, rates.ErrInvalidRateFile)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func Test_Importer_ImportJSON_SavesRates_WhenFileValid(t *testing.T) {
+	ctrl, store, importer := setupImporter(t)
+	defer ctrl.Finish()
+
+	file := `[
+		{"date": "2024-03-15", "base": "USD", "quote": "KZT", "rate": "450.12"},
+		{"date": "2024-03-16", "base": "EUR", "quote": "KZT", "rate": 490.5}
+	]`
+	store.EXPECT().SaveRates(gomock.Any(), gomock.Len(2)).Return(nil)
+
+	n, err := importer.ImportJSON(context.Background(), strings.NewReader(file))
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func Test_Importer_ImportJSON_ReturnsError_WhenStoreFails(t *testing.T) {
+	ctrl, store, importer := setupImporter(t)
+	defer ctrl.Finish()
+
+	file := `[{"date": "2024-03-15", "base": "USD", "quote": "KZT", "rate": "450.12"}]`
+	store.EXPECT().SaveRates(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+	_, err := importer.ImportJSON(context.Background(), strings.NewReader(file))
+
+	assert.EqualError(t, err, "failed to save rates: db error")
+}
+
+func Test_Importer_ImportJSON_ReturnsError_WhenUnknownField(t *testing.T) {
+	ctrl, _, importer := setupImporter(t)
+	defer ctrl.Finish()
+
+	file := `[{"day": "2024-03-15", "base": "USD", "quote": "KZT", "rate": "1"}]`
+
+	_, err := importer.ImportJSON(context.Background(), strings.NewReader(file))
+
+	assert.ErrorIs(t, err, rates.ErrInvalidRateFile)
+}
@@ -0,0 +1,76 @@
+package rates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=rate.go -destination=mocks/rate_mock.go -package=mocks
+
+// maxRateFractionDigits максимальное количество знаков после запятой в курсе
+const maxRateFractionDigits = 12
+
+// Ошибки работы с курсами
+var (
+	ErrRateNotFound = errors.New("exchange rate not found")
+	ErrInvalidRate  = errors.New("invalid exchange rate")
+)
+
+// Rate курс обмена: 1 единица Base стоит Value единиц Quote на дату Date
+type Rate struct {
+	Base  domain.Currency
+	Quote domain.Currency
+	Date  time.Time
+	Value *big.Rat
+}
+
+// RateProvider источник исторических курсов
+type RateProvider interface {
+	// RateOnOrBefore возвращает последний известный курс на дату date или раньше.
+	// Если курса нет, возвращает ошибку, обернутую в ErrRateNotFound.
+	RateOnOrBefore(ctx context.Context, base, quote domain.Currency, date time.Time) (Rate, error)
+}
+
+// RateStore хранилище курсов, в которое пишет импорт
+type RateStore interface {
+	SaveRates(ctx context.Context, rates []Rate) error
+}
+
+// NewRate создает курс из десятичной строки, дата приводится к началу дня
+func NewRate(base, quote domain.Currency, date time.Time, value string) (Rate, error) {
+	if base.IsZero() || quote.IsZero() || base == quote {
+		return Rate{}, fmt.Errorf("%w: pair %s/%s", ErrInvalidRate, base, quote)
+	}
+
+	_, frac, _ := strings.Cut(value, ".")
+	if len(frac) > maxRateFractionDigits {
+		return Rate{}, fmt.Errorf("%w: more than %d fractional digits in %q", ErrInvalidRate, maxRateFractionDigits, value)
+	}
+
+	v, ok := new(big.Rat).SetString(value)
+	if !ok || strings.ContainsAny(value, "/eE") {
+		return Rate{}, fmt.Errorf("%w: %q is not a decimal", ErrInvalidRate, value)
+	}
+	if v.Sign() <= 0 {
+		return Rate{}, fmt.Errorf("%w: %q must be positive", ErrInvalidRate, value)
+	}
+
+	return Rate{Base: base, Quote: quote, Date: Day(date), Value: v}, nil
+}
+
+// Decimal возвращает курс в виде десятичной строки
+func (r Rate) Decimal() string {
+	return r.Value.FloatString(maxRateFractionDigits)
+}
+
+// Day отбрасывает время, оставляя календарную дату в UTC
+func Day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
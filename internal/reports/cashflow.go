@@ -0,0 +1,285 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+// CashFlowQuery параметры отчетов о движении денег и капитале
+type CashFlowQuery struct {
+	UserID      int64
+	Granularity Granularity
+	// From и To календарные дни в часовом поясе пользователя, включительно.
+	// Отчет расширяет их до целых периодов.
+	From domain.Date
+	To   domain.Date
+	// Currency валюта отчета, в которую пересчитываются все суммы
+	Currency domain.Currency
+	// AccountID ограничивает отчет одним счетом, 0 - все счета вместе с нераспределенными деньгами
+	AccountID int64
+}
+
+// Validate проверяет параметры отчета
+func (q *CashFlowQuery) Validate() error {
+	if q.UserID <= 0 {
+		return errors.New("user ID must be valid")
+	}
+	if err := validateRange(q.Granularity, q.From, q.To); err != nil {
+		return err
+	}
+	if q.Currency.IsZero() {
+		return errors.New("report currency must be valid")
+	}
+	if q.AccountID < 0 {
+		return errors.New("account ID must be valid")
+	}
+	return nil
+}
+
+// Periods возвращает целые периоды, покрывающие дни [From, To], в хронологическом порядке
+func (q *CashFlowQuery) Periods() []Period {
+	return periods(q.Granularity, q.From, q.To)
+}
+
+// Balance остаток счета в одной валюте, AccountID 0 - нераспределенные деньги
+type Balance struct {
+	AccountID int64
+	Amount    domain.Money
+}
+
+// FlowRow обороты счета в одной валюте за период, посчитанные хранилищем
+type FlowRow struct {
+	// PeriodStart первый день периода в часовом поясе пользователя
+	PeriodStart domain.Date
+	// AccountID 0 - нераспределенные деньги
+	AccountID int64
+	// Inflow доходы, Outflow расходы, Transfers сальдо переводов между счетами
+	Inflow    domain.Money
+	Outflow   domain.Money
+	Transfers domain.Money
+	// Opened начальный остаток счета, открытого в этом периоде. В обороты не входит, но меняет остаток.
+	// Нулевое значение - счет открыт раньше.
+	Opened domain.Money
+}
+
+// Flow движение денег за период в валюте отчета
+type Flow struct {
+	Inflow    domain.Money
+	Outflow   domain.Money
+	Transfers domain.Money
+	// Net чистый поток: Inflow - Outflow + Transfers
+	Net domain.Money
+	// Balance остаток на конец периода
+	Balance domain.Money
+}
+
+// AccountFlow движение денег по счету, AccountID 0 - нераспределенные деньги
+type AccountFlow struct {
+	AccountID int64
+	Flow
+}
+
+// CashFlowPeriod движение денег за один период отчета в целом и по счетам
+type CashFlowPeriod struct {
+	Period
+	Flow
+	// Accounts по возрастанию ID, включая счета без оборотов за период
+	Accounts []AccountFlow
+}
+
+// CashFlow ряд движения денег и остатков по периодам, все суммы в валюте отчета.
+// Остатки пересчитываются по курсу на конец каждого периода, поэтому изменение остатка
+// может отличаться от Net на курсовую переоценку.
+type CashFlow struct {
+	Granularity Granularity
+	Currency    domain.Currency
+	// Opening остаток перед первым периодом
+	Opening domain.Money
+	Periods []CashFlowPeriod
+}
+
+// balanceKey остаток счета хранится отдельно по каждой валюте
+type balanceKey struct {
+	accountID int64
+	currency  domain.Currency
+}
+
+// flowSums накапливает обороты в минимальных единицах валюты отчета
+type flowSums struct {
+	inflow, outflow, transfers, balance int64
+}
+
+// add добавляет обороты other
+func (s *flowSums) add(other flowSums) {
+	s.inflow += other.inflow
+	s.outflow += other.outflow
+	s.transfers += other.transfers
+	s.balance += other.balance
+}
+
+// flow возвращает обороты в валюте currency
+func (s *flowSums) flow(currency domain.Currency) Flow {
+	return Flow{
+		Inflow:    domain.NewMoney(s.inflow, currency),
+		Outflow:   domain.NewMoney(s.outflow, currency),
+		Transfers: domain.NewMoney(s.transfers, currency),
+		Net:       domain.NewMoney(s.inflow-s.outflow+s.transfers, currency),
+		Balance:   domain.NewMoney(s.balance, currency),
+	}
+}
+
+// BuildCashFlow собирает ряд движения денег по начальным остаткам opening перед первым периодом
+// и оборотам rows за периоды запроса. Обороты пересчитываются в валюту отчета по курсу
+// на последний день периода, но не позже today; остатки - по тому же курсу.
+func BuildCashFlow(ctx context.Context, conv Converter, q CashFlowQuery, opening []Balance, rows []FlowRow,
+	today domain.Date) (*CashFlow, error) {
+	periods := q.Periods()
+	positions := make(map[domain.Date]int, len(periods))
+	for i, p := range periods {
+		positions[p.From] = i
+	}
+
+	balances := make(map[balanceKey]domain.Money)
+	accountIDs := make(map[int64]struct{})
+	for _, b := range opening {
+		if err := addBalance(balances, b.AccountID, b.Amount); err != nil {
+			return nil, err
+		}
+		accountIDs[b.AccountID] = struct{}{}
+	}
+	periodRows := make([][]FlowRow, len(periods))
+	for _, row := range rows {
+		i, ok := positions[row.PeriodStart]
+		if !ok {
+			return nil, fmt.Errorf("report row for %s does not start a report period", row.PeriodStart)
+		}
+		periodRows[i] = append(periodRows[i], row)
+		accountIDs[row.AccountID] = struct{}{}
+	}
+	accounts := slices.Sorted(maps.Keys(accountIDs))
+
+	openingDate := periods[0].From.AddDays(-1)
+	if today.Before(openingDate) {
+		openingDate = today
+	}
+	openingSums, err := valuate(ctx, conv, q.Currency, balances, openingDate)
+	if err != nil {
+		return nil, err
+	}
+	var openingTotal flowSums
+	for _, s := range openingSums {
+		openingTotal.add(s)
+	}
+
+	result := &CashFlow{Granularity: q.Granularity, Currency: q.Currency,
+		Opening: domain.NewMoney(openingTotal.balance, q.Currency)}
+	for i, p := range periods {
+		date := rateDate(p, today)
+		flows := make(map[int64]*flowSums, len(accounts))
+		for _, id := range accounts {
+			flows[id] = &flowSums{}
+		}
+
+		for _, row := range periodRows[i] {
+			converted, err := convertFlow(ctx, conv, q.Currency, row, date)
+			if err != nil {
+				return nil, err
+			}
+			flows[row.AccountID].add(converted)
+
+			net, err := row.Inflow.Sub(row.Outflow)
+			if err == nil {
+				net, err = net.Add(row.Transfers)
+			}
+			if err == nil && !row.Opened.Currency().IsZero() {
+				net, err = net.Add(row.Opened)
+			}
+			if err == nil {
+				err = addBalance(balances, row.AccountID, net)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		valued, err := valuate(ctx, conv, q.Currency, balances, date)
+		if err != nil {
+			return nil, err
+		}
+		var total flowSums
+		period := CashFlowPeriod{Period: p, Accounts: make([]AccountFlow, 0, len(accounts))}
+		for _, id := range accounts {
+			flows[id].balance = valued[id].balance
+			total.add(*flows[id])
+			period.Accounts = append(period.Accounts, AccountFlow{AccountID: id, Flow: flows[id].flow(q.Currency)})
+		}
+		period.Flow = total.flow(q.Currency)
+		result.Periods = append(result.Periods, period)
+	}
+	return result, nil
+}
+
+// addBalance прибавляет amount к остатку счета в валюте суммы
+func addBalance(balances map[balanceKey]domain.Money, accountID int64, amount domain.Money) error {
+	key := balanceKey{accountID: accountID, currency: amount.Currency()}
+	balance, err := sum(balances[key], amount)
+	if err != nil {
+		return err
+	}
+	balances[key] = balance
+	return nil
+}
+
+// valuate пересчитывает остатки счетов в валюту отчета по курсу на дату date
+func valuate(ctx context.Context, conv Converter, currency domain.Currency, balances map[balanceKey]domain.Money,
+	date domain.Date) (map[int64]flowSums, error) {
+	result := make(map[int64]flowSums)
+	for key, balance := range balances {
+		converted, err := convert(ctx, conv, balance, currency, date)
+		if err != nil {
+			return nil, err
+		}
+		s := result[key.accountID]
+		s.balance += converted
+		result[key.accountID] = s
+	}
+	return result, nil
+}
+
+// convertFlow пересчитывает обороты row в валюту отчета по курсу на дату date
+func convertFlow(ctx context.Context, conv Converter, currency domain.Currency, row FlowRow,
+	date domain.Date) (flowSums, error) {
+	var (
+		s   flowSums
+		err error
+	)
+	if s.inflow, err = convert(ctx, conv, row.Inflow, currency, date); err != nil {
+		return flowSums{}, err
+	}
+	if s.outflow, err = convert(ctx, conv, row.Outflow, currency, date); err != nil {
+		return flowSums{}, err
+	}
+	if s.transfers, err = convert(ctx, conv, row.Transfers, currency, date); err != nil {
+		return flowSums{}, err
+	}
+	return s, nil
+}
+
+// convert возвращает сумму m в минимальных единицах валюты currency; нулевые суммы курса не требуют
+func convert(ctx context.Context, conv Converter, m domain.Money, currency domain.Currency,
+	date domain.Date) (int64, error) {
+	if m.IsZero() {
+		return 0, nil
+	}
+	converted, err := conv.Convert(ctx, m, currency, date.Start(time.UTC))
+	if err != nil {
+		return 0, err
+	}
+	return converted.Amount(), nil
+}
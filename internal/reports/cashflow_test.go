@@ -0,0 +1,99 @@
+package reports_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/reports"
+)
+
+func Test_BuildCashFlow_ReturnsFlowsAndBalances_WhenAccountsInSeveralCurrencies(t *testing.T) {
+	usd := domain.MustCurrency("USD")
+	q := reports.CashFlowQuery{UserID: 1, Granularity: reports.GranularityMonth, From: date(2024, time.February, 1),
+		To: date(2024, time.March, 31), Currency: kzt}
+	opening := []reports.Balance{
+		{AccountID: 1, Amount: domain.NewMoney(1_000, kzt)},
+		{AccountID: 2, Amount: domain.NewMoney(10, usd)},
+	}
+	rows := []reports.FlowRow{
+		{PeriodStart: date(2024, time.February, 1), AccountID: 1, Inflow: domain.NewMoney(500, kzt),
+			Outflow: domain.NewMoney(0, kzt), Transfers: domain.NewMoney(-200, kzt)},
+		{PeriodStart: date(2024, time.February, 1), AccountID: 2, Inflow: domain.NewMoney(0, usd),
+			Outflow: domain.NewMoney(0, usd), Transfers: domain.NewMoney(1, usd)},
+		{PeriodStart: date(2024, time.March, 1), AccountID: 0, Inflow: domain.NewMoney(0, kzt),
+			Outflow: domain.NewMoney(300, kzt), Transfers: domain.NewMoney(0, kzt)},
+	}
+	rates := &fixedRate{}
+
+	cf, err := reports.BuildCashFlow(context.Background(), rates, q, opening, rows, date(2024, time.March, 15))
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(6_000, kzt), cf.Opening)
+	require.Len(t, cf.Periods, 2)
+
+	feb := cf.Periods[0]
+	assert.Equal(t, domain.NewMoney(500, kzt), feb.Inflow)
+	assert.Equal(t, domain.NewMoney(300, kzt), feb.Transfers)
+	assert.Equal(t, domain.NewMoney(800, kzt), feb.Net)
+	assert.Equal(t, domain.NewMoney(6_800, kzt), feb.Balance)
+	require.Len(t, feb.Accounts, 3)
+	assert.Equal(t, int64(0), feb.Accounts[0].AccountID)
+	assert.True(t, feb.Accounts[0].Balance.IsZero())
+	assert.Equal(t, domain.NewMoney(1_300, kzt), feb.Accounts[1].Balance)
+	assert.Equal(t, domain.NewMoney(5_500, kzt), feb.Accounts[2].Balance)
+
+	mar := cf.Periods[1]
+	assert.Equal(t, domain.NewMoney(300, kzt), mar.Outflow)
+	assert.Equal(t, domain.NewMoney(-300, kzt), mar.Net)
+	assert.Equal(t, domain.NewMoney(6_500, kzt), mar.Balance)
+	assert.Equal(t, domain.NewMoney(-300, kzt), mar.Accounts[0].Balance)
+
+	// Курсы берутся на день перед отчетом и на конец каждого периода, но не позже сегодняшнего дня
+	assert.Equal(t, []time.Time{
+		date(2024, time.January, 31).Start(time.UTC),
+		date(2024, time.February, 29).Start(time.UTC),
+		date(2024, time.February, 29).Start(time.UTC),
+		date(2024, time.March, 15).Start(time.UTC),
+	}, rates.dates)
+}
+
+func Test_BuildCashFlow_AddsOpeningBalanceToBalanceOnly_WhenAccountOpenedInPeriod(t *testing.T) {
+	q := reports.CashFlowQuery{UserID: 1, Granularity: reports.GranularityMonth, From: date(2024, time.February, 1),
+		To: date(2024, time.March, 31), Currency: kzt}
+	rows := []reports.FlowRow{
+		{PeriodStart: date(2024, time.March, 1), AccountID: 1, Inflow: domain.NewMoney(500, kzt),
+			Outflow: domain.NewMoney(0, kzt), Transfers: domain.NewMoney(0, kzt), Opened: domain.NewMoney(1_000, kzt)},
+	}
+
+	cf, err := reports.BuildCashFlow(context.Background(), &fixedRate{}, q, nil, rows, date(2024, time.March, 15))
+
+	require.NoError(t, err)
+	assert.True(t, cf.Opening.IsZero())
+	require.Len(t, cf.Periods, 2)
+	assert.True(t, cf.Periods[0].Balance.IsZero())
+	assert.Equal(t, domain.NewMoney(500, kzt), cf.Periods[1].Net)
+	assert.Equal(t, domain.NewMoney(1_500, kzt), cf.Periods[1].Balance)
+}
+
+func Test_BuildCashFlow_ReturnsError_WhenRowOutsidePeriods(t *testing.T) {
+	q := reports.CashFlowQuery{UserID: 1, Granularity: reports.GranularityMonth, From: date(2024, time.February, 1),
+		To: date(2024, time.February, 29), Currency: kzt}
+	rows := []reports.FlowRow{{PeriodStart: date(2024, time.January, 1), Inflow: domain.NewMoney(500, kzt),
+		Outflow: domain.NewMoney(0, kzt), Transfers: domain.NewMoney(0, kzt)}}
+
+	_, err := reports.BuildCashFlow(context.Background(), &fixedRate{}, q, nil, rows, date(2024, time.March, 1))
+
+	assert.EqualError(t, err, "report row for 2024-01-01 does not start a report period")
+}
+
+func Test_CashFlowQuery_Validate_ReturnsError_WhenTooManyPeriods(t *testing.T) {
+	q := reports.CashFlowQuery{UserID: 1, Granularity: reports.GranularityDay, From: date(2020, time.January, 1),
+		To: date(2024, time.December, 31), Currency: kzt}
+
+	assert.EqualError(t, q.Validate(), "report must not contain more than 400 periods, got 401")
+}
@@ -0,0 +1,167 @@
+package reports
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+// MaxPeriods наибольшее число периодов в одном отчете
+const MaxPeriods = 400
+
+// Granularity длина периода, по которому группируются операции отчета
+type Granularity int
+
+const (
+	// GranularityDay календарный день
+	GranularityDay Granularity = iota + 1
+	// GranularityWeek календарная неделя с понедельника
+	GranularityWeek
+	// GranularityMonth календарный месяц
+	GranularityMonth
+	// GranularityQuarter календарный квартал
+	GranularityQuarter
+	// GranularityYear календарный год
+	GranularityYear
+)
+
+// Valid сообщает, что длина периода известна
+func (g Granularity) Valid() bool {
+	return g >= GranularityDay && g <= GranularityYear
+}
+
+// String возвращает название периода в терминах date_trunc PostgreSQL
+func (g Granularity) String() string {
+	switch g {
+	case GranularityDay:
+		return "day"
+	case GranularityWeek:
+		return "week"
+	case GranularityMonth:
+		return "month"
+	case GranularityQuarter:
+		return "quarter"
+	case GranularityYear:
+		return "year"
+	default:
+		return fmt.Sprintf("Granularity(%d)", int(g))
+	}
+}
+
+// Start возвращает первый день периода, в который попадает дата d
+func (g Granularity) Start(d domain.Date) domain.Date {
+	switch g {
+	case GranularityWeek:
+		return d.AddDays(-((int(d.Weekday()) + 6) % 7))
+	case GranularityMonth:
+		return domain.Date{Year: d.Year, Month: d.Month, Day: 1}
+	case GranularityQuarter:
+		return domain.Date{Year: d.Year, Month: d.Month - (d.Month-1)%3, Day: 1}
+	case GranularityYear:
+		return domain.Date{Year: d.Year, Month: time.January, Day: 1}
+	default:
+		return d
+	}
+}
+
+// next возвращает первый день периода, следующего за периодом, который начинается с start
+func (g Granularity) next(start domain.Date) domain.Date {
+	t := start.Start(time.UTC)
+	switch g {
+	case GranularityWeek:
+		return start.AddDays(7)
+	case GranularityMonth:
+		return domain.DateOf(t.AddDate(0, 1, 0))
+	case GranularityQuarter:
+		return domain.DateOf(t.AddDate(0, 3, 0))
+	case GranularityYear:
+		return domain.DateOf(t.AddDate(1, 0, 0))
+	default:
+		return start.AddDays(1)
+	}
+}
+
+// Period календарные дни [From, To) одного периода отчета
+type Period struct {
+	From domain.Date
+	To   domain.Date
+}
+
+// IncomeQuery параметры отчета по доходам
+type IncomeQuery struct {
+	UserID      int64
+	Granularity Granularity
+	// From и To календарные дни в часовом поясе пользователя, включительно.
+	// Отчет расширяет их до целых периодов.
+	From domain.Date
+	To   domain.Date
+	// Currency валюта отчета, в которую пересчитываются все суммы
+	Currency domain.Currency
+	// CategoryID ограничивает отчет категорией вместе с ее подкатегориями, 0 - все категории
+	CategoryID int
+	// AccountID 0 - все счета
+	AccountID int64
+	// Tags оставляет доходы, у которых есть все перечисленные теги
+	Tags []string
+}
+
+// Validate проверяет параметры отчета
+func (q *IncomeQuery) Validate() error {
+	if q.UserID <= 0 {
+		return errors.New("user ID must be valid")
+	}
+	if err := validateRange(q.Granularity, q.From, q.To); err != nil {
+		return err
+	}
+	if q.Currency.IsZero() {
+		return errors.New("report currency must be valid")
+	}
+	if q.CategoryID < 0 || q.AccountID < 0 {
+		return errors.New("category ID and account ID must be valid")
+	}
+	if len(q.Tags) > domain.MaxOperationTags {
+		return fmt.Errorf("tag filter must not contain more than %d tags", domain.MaxOperationTags)
+	}
+	return nil
+}
+
+// Periods возвращает целые периоды, покрывающие дни [From, To], в хронологическом порядке
+func (q *IncomeQuery) Periods() []Period {
+	return periods(q.Granularity, q.From, q.To)
+}
+
+// Previous возвращает период перед первым периодом отчета: с ним сравнивается первый период
+func (q *IncomeQuery) Previous() Period {
+	to := q.Granularity.Start(q.From)
+	return Period{From: q.Granularity.Start(to.AddDays(-1)), To: to}
+}
+
+// validateRange проверяет длину периода и дни отчета
+func validateRange(g Granularity, from, to domain.Date) error {
+	if !g.Valid() {
+		return errors.New("report granularity must be valid")
+	}
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return errors.New("from and to dates must be set and from must not be after to")
+	}
+	if n := len(periods(g, from, to)); n > MaxPeriods {
+		return fmt.Errorf("report must not contain more than %d periods, got %d", MaxPeriods, n)
+	}
+	return nil
+}
+
+// periods возвращает целые периоды длины g, покрывающие дни [from, to]; перебор останавливается после MaxPeriods
+func periods(g Granularity, from, to domain.Date) []Period {
+	var result []Period
+	for start := g.Start(from); !to.Before(start); {
+		end := g.next(start)
+		result = append(result, Period{From: start, To: end})
+		if len(result) > MaxPeriods {
+			break
+		}
+		start = end
+	}
+	return result
+}
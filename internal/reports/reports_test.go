@@ -0,0 +1,80 @@
+package reports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/reports"
+)
+
+var kzt = domain.MustCurrency("KZT")
+
+func date(year int, month time.Month, day int) domain.Date {
+	return domain.Date{Year: year, Month: month, Day: day}
+}
+
+func Test_IncomeQuery_Periods_ReturnsWholePeriods_WhenRangeInsidePeriods(t *testing.T) {
+	tests := []struct {
+		name        string
+		granularity reports.Granularity
+		from, to    domain.Date
+		expected    []reports.Period
+		previous    reports.Period
+	}{
+		{"Week", reports.GranularityWeek, date(2024, time.March, 6), date(2024, time.March, 11),
+			[]reports.Period{
+				{From: date(2024, time.March, 4), To: date(2024, time.March, 11)},
+				{From: date(2024, time.March, 11), To: date(2024, time.March, 18)},
+			},
+			reports.Period{From: date(2024, time.February, 26), To: date(2024, time.March, 4)}},
+		{"Quarter", reports.GranularityQuarter, date(2024, time.May, 15), date(2024, time.July, 1),
+			[]reports.Period{
+				{From: date(2024, time.April, 1), To: date(2024, time.July, 1)},
+				{From: date(2024, time.July, 1), To: date(2024, time.October, 1)},
+			},
+			reports.Period{From: date(2024, time.January, 1), To: date(2024, time.April, 1)}},
+		{"Year", reports.GranularityYear, date(2024, time.February, 29), date(2024, time.December, 31),
+			[]reports.Period{{From: date(2024, time.January, 1), To: date(2025, time.January, 1)}},
+			reports.Period{From: date(2023, time.January, 1), To: date(2024, time.January, 1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := reports.IncomeQuery{Granularity: tt.granularity, From: tt.from, To: tt.to}
+
+			assert.Equal(t, tt.expected, q.Periods())
+			assert.Equal(t, tt.previous, q.Previous())
+		})
+	}
+}
+
+func Test_IncomeQuery_Validate_ReturnsError_WhenInvalid(t *testing.T) {
+	valid := reports.IncomeQuery{UserID: 1, Granularity: reports.GranularityMonth, From: date(2024, time.January, 1),
+		To: date(2024, time.December, 31), Currency: kzt}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(q *reports.IncomeQuery)
+		errMsg string
+	}{
+		{"Unknown Granularity", func(q *reports.IncomeQuery) { q.Granularity = 0 }, "report granularity must be valid"},
+		{"Reversed Range", func(q *reports.IncomeQuery) { q.To = date(2023, time.December, 31) },
+			"from and to dates must be set and from must not be after to"},
+		{"No Currency", func(q *reports.IncomeQuery) { q.Currency = domain.Currency{} }, "report currency must be valid"},
+		{"Too Many Periods", func(q *reports.IncomeQuery) { q.Granularity = reports.GranularityDay; q.To = date(2025, time.June, 1) },
+			"report must not contain more than 400 periods, got 401"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := valid
+			tt.modify(&q)
+
+			assert.EqualError(t, q.Validate(), tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package reports
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+// Converter конвертирует суммы между валютами по курсу на дату
+type Converter interface {
+	Convert(ctx context.Context, m domain.Money, to domain.Currency, date time.Time) (domain.Money, error)
+}
+
+// Row сумма доходов одного периода, категории, счета и валюты, посчитанная хранилищем
+type Row struct {
+	// PeriodStart первый день периода в часовом поясе пользователя
+	PeriodStart domain.Date
+	CategoryID  int
+	// AccountID 0 - доходы без счета
+	AccountID int64
+	Amount    domain.Money
+}
+
+// CategoryTotal сумма доходов категории
+type CategoryTotal struct {
+	CategoryID int
+	Total      domain.Money
+}
+
+// AccountTotal сумма доходов на счет, AccountID 0 - доходы без счета
+type AccountTotal struct {
+	AccountID int64
+	Total     domain.Money
+}
+
+// PeriodSummary доходы одного периода отчета
+type PeriodSummary struct {
+	Period
+	Total domain.Money
+	// Previous сумма за предыдущий период, Delta - изменение относительно нее
+	Previous domain.Money
+	Delta    domain.Money
+	// Categories и Accounts по убыванию суммы
+	Categories []CategoryTotal
+	Accounts   []AccountTotal
+}
+
+// IncomeSummary отчет по доходам, все суммы в валюте отчета
+type IncomeSummary struct {
+	Granularity Granularity
+	Currency    domain.Currency
+	Periods     []PeriodSummary
+	Total       domain.Money
+	// Categories и Accounts итоги за все периоды по убыванию суммы
+	Categories []CategoryTotal
+	Accounts   []AccountTotal
+}
+
+// totals накапливает суммы в валюте отчета в целом, по категориям и по счетам
+type totals struct {
+	total      domain.Money
+	categories map[int]domain.Money
+	accounts   map[int64]domain.Money
+}
+
+// newTotals создает пустые итоги в валюте currency
+func newTotals(currency domain.Currency) *totals {
+	return &totals{total: domain.NewMoney(0, currency), categories: make(map[int]domain.Money),
+		accounts: make(map[int64]domain.Money)}
+}
+
+// add добавляет сумму row, уже пересчитанную в валюту отчета
+func (t *totals) add(row Row, amount domain.Money) error {
+	var err error
+	if t.total, err = t.total.Add(amount); err != nil {
+		return err
+	}
+	if t.categories[row.CategoryID], err = sum(t.categories[row.CategoryID], amount); err != nil {
+		return err
+	}
+	t.accounts[row.AccountID], err = sum(t.accounts[row.AccountID], amount)
+	return err
+}
+
+// sum складывает суммы; нулевое значение acc считается нулем в валюте amount
+func sum(acc, amount domain.Money) (domain.Money, error) {
+	if acc.Currency().IsZero() {
+		return amount, nil
+	}
+	return acc.Add(amount)
+}
+
+// byCategory возвращает суммы категорий по убыванию, при равенстве - по ID
+func (t *totals) byCategory() []CategoryTotal {
+	result := make([]CategoryTotal, 0, len(t.categories))
+	for _, id := range slices.Sorted(maps.Keys(t.categories)) {
+		result = append(result, CategoryTotal{CategoryID: id, Total: t.categories[id]})
+	}
+	slices.SortStableFunc(result, func(a, b CategoryTotal) int { return cmp.Compare(b.Total.Amount(), a.Total.Amount()) })
+	return result
+}
+
+// byAccount возвращает суммы счетов по убыванию, при равенстве - по ID
+func (t *totals) byAccount() []AccountTotal {
+	result := make([]AccountTotal, 0, len(t.accounts))
+	for _, id := range slices.Sorted(maps.Keys(t.accounts)) {
+		result = append(result, AccountTotal{AccountID: id, Total: t.accounts[id]})
+	}
+	slices.SortStableFunc(result, func(a, b AccountTotal) int { return cmp.Compare(b.Total.Amount(), a.Total.Amount()) })
+	return result
+}
+
+// BuildIncomeSummary собирает отчет по суммам rows за периоды запроса и предыдущий период.
+// Суммы пересчитываются в валюту отчета по курсу на последний день периода, но не позже today.
+func BuildIncomeSummary(ctx context.Context, conv Converter, q IncomeQuery, rows []Row,
+	today domain.Date) (*IncomeSummary, error) {
+	periods := q.Periods()
+	positions := make(map[domain.Date]int, len(periods))
+	periodTotals := make([]*totals, len(periods))
+	for i, p := range periods {
+		positions[p.From] = i
+		periodTotals[i] = newTotals(q.Currency)
+	}
+	previous := q.Previous()
+	previousTotals := newTotals(q.Currency)
+	overall := newTotals(q.Currency)
+
+	for _, row := range rows {
+		period, target := previous, previousTotals
+		if i, ok := positions[row.PeriodStart]; ok {
+			period, target = periods[i], periodTotals[i]
+		} else if row.PeriodStart != previous.From {
+			return nil, fmt.Errorf("report row for %s does not start a report period", row.PeriodStart)
+		}
+
+		amount, err := conv.Convert(ctx, row.Amount, q.Currency, rateDate(period, today).Start(time.UTC))
+		if err != nil {
+			return nil, err
+		}
+		if err := target.add(row, amount); err != nil {
+			return nil, err
+		}
+		if target != previousTotals {
+			if err := overall.add(row, amount); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	summary := &IncomeSummary{Granularity: q.Granularity, Currency: q.Currency, Total: overall.total,
+		Categories: overall.byCategory(), Accounts: overall.byAccount()}
+	before := previousTotals.total
+	for i, p := range periods {
+		t := periodTotals[i]
+		delta, err := t.total.Sub(before)
+		if err != nil {
+			return nil, err
+		}
+		summary.Periods = append(summary.Periods, PeriodSummary{Period: p, Total: t.total, Previous: before,
+			Delta: delta, Categories: t.byCategory(), Accounts: t.byAccount()})
+		before = t.total
+	}
+	return summary, nil
+}
+
+// rateDate возвращает день курса для сумм периода p: последний день периода, но не позже today
+func rateDate(p Period, today domain.Date) domain.Date {
+	last := p.To.AddDays(-1)
+	if today.Before(last) {
+		return today
+	}
+	return last
+}
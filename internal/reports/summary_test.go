@@ -0,0 +1,72 @@
+package reports_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/reports"
+)
+
+// fixedRate конвертирует USD в KZT по курсу 500 и запоминает даты курсов
+type fixedRate struct {
+	dates []time.Time
+}
+
+func (r *fixedRate) Convert(_ context.Context, m domain.Money, to domain.Currency, date time.Time) (domain.Money, error) {
+	if m.Currency() == to {
+		return m, nil
+	}
+	r.dates = append(r.dates, date)
+	return domain.NewMoney(m.Amount()*500, to), nil
+}
+
+func Test_BuildIncomeSummary_ReturnsTotalsAndDeltas_WhenRowsInSeveralPeriods(t *testing.T) {
+	usd := domain.MustCurrency("USD")
+	q := reports.IncomeQuery{UserID: 1, Granularity: reports.GranularityMonth, From: date(2024, time.February, 1),
+		To: date(2024, time.March, 31), Currency: kzt}
+	rows := []reports.Row{
+		{PeriodStart: date(2024, time.January, 1), CategoryID: 2, Amount: domain.NewMoney(100_000, kzt)},
+		{PeriodStart: date(2024, time.February, 1), CategoryID: 2, AccountID: 5, Amount: domain.NewMoney(150_000, kzt)},
+		{PeriodStart: date(2024, time.March, 1), CategoryID: 2, AccountID: 5, Amount: domain.NewMoney(50_000, kzt)},
+		{PeriodStart: date(2024, time.March, 1), CategoryID: 3, Amount: domain.NewMoney(200, usd)},
+	}
+	conv := &fixedRate{}
+
+	summary, err := reports.BuildIncomeSummary(context.Background(), conv, q, rows, date(2024, time.March, 20))
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(300_000, kzt), summary.Total)
+	assert.Equal(t, []reports.CategoryTotal{
+		{CategoryID: 2, Total: domain.NewMoney(200_000, kzt)},
+		{CategoryID: 3, Total: domain.NewMoney(100_000, kzt)},
+	}, summary.Categories)
+	assert.Equal(t, []reports.AccountTotal{
+		{AccountID: 5, Total: domain.NewMoney(200_000, kzt)},
+		{AccountID: 0, Total: domain.NewMoney(100_000, kzt)},
+	}, summary.Accounts)
+
+	require.Len(t, summary.Periods, 2)
+	feb, mar := summary.Periods[0], summary.Periods[1]
+	assert.Equal(t, domain.NewMoney(100_000, kzt), feb.Previous)
+	assert.Equal(t, domain.NewMoney(50_000, kzt), feb.Delta)
+	assert.Equal(t, domain.NewMoney(150_000, kzt), mar.Total)
+	assert.Equal(t, domain.NewMoney(0, kzt), mar.Delta)
+	// Курс текущего периода берется на сегодня, а не на конец месяца
+	assert.Equal(t, []time.Time{time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC)}, conv.dates)
+}
+
+func Test_BuildIncomeSummary_ReturnsError_WhenRowOutsidePeriods(t *testing.T) {
+	q := reports.IncomeQuery{UserID: 1, Granularity: reports.GranularityMonth, From: date(2024, time.February, 1),
+		To: date(2024, time.February, 29), Currency: kzt}
+	rows := []reports.Row{{PeriodStart: date(2024, time.February, 15), CategoryID: 2,
+		Amount: domain.NewMoney(100, kzt)}}
+
+	_, err := reports.BuildIncomeSummary(context.Background(), &fixedRate{}, q, rows, date(2024, time.March, 1))
+
+	assert.EqualError(t, err, "report row for 2024-02-15 does not start a report period")
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Job периодическая задача: обрабатывает все, что наступило к моменту now, и возвращает число обработанных элементов
+type Job func(ctx context.Context, now time.Time) (int, error)
+
+// Scheduler запускает задачу сразу после старта и затем с заданным интервалом
+type Scheduler struct {
+	name     string
+	interval time.Duration
+	job      Job
+	log      *zap.Logger
+}
+
+// New создает новый экземпляр Scheduler
+func New(name string, interval time.Duration, job Job, log *zap.Logger) *Scheduler {
+	return &Scheduler{name: name, interval: interval, job: job, log: log}
+}
+
+// Run выполняет задачу до отмены ctx. Ошибка запуска логируется и не останавливает планировщик:
+// необработанное будет подхвачено следующим запуском.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce выполняет задачу один раз
+func (s *Scheduler) runOnce(ctx context.Context) {
+	n, err := s.job(ctx, time.Now())
+	if err != nil {
+		s.log.Error("Scheduled job failed", zap.String("job", s.name), zap.Int("processed", n), zap.Error(err))
+		return
+	}
+	if n > 0 {
+		s.log.Info("Scheduled job completed", zap.String("job", s.name), zap.Int("processed", n))
+	}
+}
@@ -0,0 +1,38 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"fincraft-finance/internal/scheduler"
+)
+
+func Test_Scheduler_Run_RepeatsJob_WhenJobFails(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	job := func(context.Context, time.Time) (int, error) {
+		if calls.Add(1) == 3 {
+			cancel()
+		}
+		return 0, errors.New("database is unavailable")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		scheduler.New("test", time.Millisecond, job, zap.NewNop()).Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after context cancellation")
+	}
+	assert.Equal(t, int32(3), calls.Load())
+}
@@ -8,9 +8,10 @@ import (
 	"fincraft-finance/api/finance"
 )
 
-// RunGRPCServer запускает gRPC сервер на указанном порту
-func RunGRPCServer(port string, handler finance.FinanceServiceServer) error {
-	grpcServer := grpc.NewServer()
+// RunGRPCServer запускает gRPC сервер на указанном порту.
+// opts задают параметры сервера, например цепочки перехватчиков unary- и потоковых запросов.
+func RunGRPCServer(port string, handler finance.FinanceServiceServer, opts ...grpc.ServerOption) error {
+	grpcServer := grpc.NewServer(opts...)
 
 	finance.RegisterFinanceServiceServer(grpcServer, handler)
 
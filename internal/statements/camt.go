@@ -0,0 +1,290 @@
+package statements
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/htmlindex"
+
+	"fincraft-finance/internal/domain"
+)
+
+// camtDocument корневой элемент camt.053: одна или несколько выписок
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+// camtStatement выписка по одному счету
+type camtStatement struct {
+	ID       string        `xml:"Id"`
+	Account  camtAccount   `xml:"Acct"`
+	Balances []camtBalance `xml:"Bal"`
+	Entries  []camtEntry   `xml:"Ntry"`
+}
+
+// camtAccount счет по IBAN или по другому идентификатору
+type camtAccount struct {
+	IBAN  string `xml:"Id>IBAN"`
+	Other string `xml:"Id>Othr>Id"`
+}
+
+// camtAmount сумма с валютой в атрибуте Ccy
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+// camtDate дата или дата со временем
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// camtBalance остаток выписки: OPBD - начальный, CLBD - конечный
+type camtBalance struct {
+	Code      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+}
+
+// camtStatus статус проводки: текстом до версии 08, кодом Cd начиная с нее
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+// camtEntry проводка по счету
+type camtEntry struct {
+	Amount         camtAmount      `xml:"Amt"`
+	Indicator      string          `xml:"CdtDbtInd"`
+	Status         camtStatus      `xml:"Sts"`
+	BookingDate    camtDate        `xml:"BookgDt"`
+	ValueDate      camtDate        `xml:"ValDt"`
+	Reference      string          `xml:"AcctSvcrRef"`
+	EntryReference string          `xml:"NtryRef"`
+	AdditionalInfo string          `xml:"AddtlNtryInf"`
+	Details        []camtTxDetails `xml:"NtryDtls>TxDtls"`
+}
+
+// camtTxDetails детали платежа внутри проводки
+type camtTxDetails struct {
+	Reference      string      `xml:"Refs>AcctSvcrRef"`
+	Parties        camtParties `xml:"RltdPties"`
+	Unstructured   []string    `xml:"RmtInf>Ustrd"`
+	Structured     []string    `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+	AdditionalInfo string      `xml:"AddtlTxInf"`
+}
+
+// camtParties плательщик и получатель платежа
+type camtParties struct {
+	Debtor          camtParty   `xml:"Dbtr"`
+	DebtorAccount   camtAccount `xml:"DbtrAcct"`
+	Creditor        camtParty   `xml:"Cdtr"`
+	CreditorAccount camtAccount `xml:"CdtrAcct"`
+}
+
+// camtParty сторона платежа: имя в Nm или, начиная с версии 08, в Pty>Nm
+type camtParty struct {
+	Name      string `xml:"Nm"`
+	PartyName string `xml:"Pty>Nm"`
+}
+
+// ParseCAMT053 разбирает выписку ISO 20022 camt.053 любой версии.
+// Импортируются только проведенные проводки (статус BOOK), описание берется из назначения платежа (Ustrd),
+// а при его отсутствии - из структурированной ссылки, дополнительной информации или имени контрагента.
+// ExternalID составляется из счета выписки и AcctSvcrRef проводки. Если у выписки есть начальный (OPBD)
+// и конечный (CLBD) остатки, конечный должен совпасть с начальным плюс сумма проводок.
+func ParseCAMT053(r io.Reader) ([]domain.StatementLine, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		enc, err := htmlindex.Get(label)
+		if err != nil {
+			return nil, err
+		}
+		return enc.NewDecoder().Reader(input), nil
+	}
+
+	var doc camtDocument
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+	if len(doc.Statements) == 0 {
+		return nil, fmt.Errorf("%w: missing BkToCstmrStmt/Stmt element", ErrInvalidStatement)
+	}
+
+	var lines []domain.StatementLine
+	for i, stmt := range doc.Statements {
+		account := stmt.Account.IBAN
+		if account == "" {
+			account = stmt.Account.Other
+		}
+
+		first := len(lines)
+		for _, entry := range stmt.Entries {
+			if !entry.booked() {
+				continue
+			}
+			lines = append(lines, entry.line(len(lines)+1, account))
+		}
+
+		if err := stmt.checkBalance(i, lines[first:]); err != nil {
+			return nil, err
+		}
+	}
+
+	return lines, nil
+}
+
+// checkBalance сверяет остатки выписки с суммой ее проводок, если оба остатка указаны
+func (s *camtStatement) checkBalance(index int, lines []domain.StatementLine) error {
+	name := s.ID
+	if name == "" {
+		name = strconv.Itoa(index + 1)
+	}
+
+	var opening, closing *camtBalance
+	for i := range s.Balances {
+		switch s.Balances[i].Code {
+		case "OPBD", "PRCD":
+			if opening == nil {
+				opening = &s.Balances[i]
+			}
+		case "CLBD":
+			closing = &s.Balances[i]
+		}
+	}
+	if opening == nil || closing == nil {
+		return nil
+	}
+
+	openingAmount, err := camtMoney(opening.Amount, opening.Indicator)
+	if err != nil {
+		return fmt.Errorf("%w: statement %s: opening balance: %v", ErrInvalidStatement, name, err)
+	}
+	closingAmount, err := camtMoney(closing.Amount, closing.Indicator)
+	if err != nil {
+		return fmt.Errorf("%w: statement %s: closing balance: %v", ErrInvalidStatement, name, err)
+	}
+	return checkBalance(name, openingAmount, closingAmount, lines)
+}
+
+// booked сообщает, что проводка проведена; ожидающие и информационные проводки не меняют остаток
+func (e *camtEntry) booked() bool {
+	status := strings.TrimSpace(e.Status.Code)
+	if status == "" {
+		status = strings.TrimSpace(e.Status.Text)
+	}
+	return status == "" || status == "BOOK"
+}
+
+// line переводит проводку в строку выписки с номером number
+func (e *camtEntry) line(number int, account string) domain.StatementLine {
+	line := domain.StatementLine{Number: number, Description: e.AdditionalInfo}
+
+	reference := e.Reference
+	var details camtTxDetails
+	if len(e.Details) > 0 {
+		details = e.Details[0]
+		if reference == "" {
+			reference = details.Reference
+		}
+		if info := details.description(); info != "" {
+			line.Description = info
+		}
+	}
+	if reference == "" {
+		reference = e.EntryReference
+	}
+	if reference != "" {
+		line.ExternalID = account + "/" + reference
+	}
+
+	party, partyAccount := details.Parties.Creditor, details.Parties.CreditorAccount
+	if e.Indicator == "CRDT" {
+		party, partyAccount = details.Parties.Debtor, details.Parties.DebtorAccount
+	}
+	line.CounterpartyName = strings.TrimSpace(party.Name)
+	if line.CounterpartyName == "" {
+		line.CounterpartyName = strings.TrimSpace(party.PartyName)
+	}
+	line.CounterpartyIBAN = partyAccount.IBAN
+	if line.Description == "" {
+		line.Description = line.CounterpartyName
+	}
+
+	var err error
+	if line.ValueDate, err = e.ValueDate.date(); err != nil {
+		line.Err = err
+		return line
+	}
+	if line.Date, err = e.BookingDate.date(); err != nil {
+		line.Err = err
+		return line
+	}
+	if line.Date.IsZero() {
+		if line.ValueDate.IsZero() {
+			line.Err = errors.New("missing booking date")
+			return line
+		}
+		line.Date = line.ValueDate
+	}
+
+	line.Amount, line.Err = camtMoney(e.Amount, e.Indicator)
+	return line
+}
+
+// description возвращает назначение платежа или другую доступную информацию о нем
+func (d *camtTxDetails) description() string {
+	if info := strings.TrimSpace(strings.Join(d.Unstructured, " ")); info != "" {
+		return info
+	}
+	if info := strings.TrimSpace(strings.Join(d.Structured, " ")); info != "" {
+		return info
+	}
+	return strings.TrimSpace(d.AdditionalInfo)
+}
+
+// date разбирает дату camt.053; нулевая дата, если элемент отсутствует
+func (d camtDate) date() (domain.Date, error) {
+	raw := strings.TrimSpace(d.Date)
+	if raw == "" {
+		raw = strings.TrimSpace(d.DateTime)
+	}
+	if raw == "" {
+		return domain.Date{}, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, raw[:min(len(raw), len(time.DateOnly))])
+	if err != nil {
+		return domain.Date{}, fmt.Errorf("invalid date %q", raw)
+	}
+	return domain.DateOf(t), nil
+}
+
+// camtMoney переводит сумму camt.053 в деньги со знаком: DBIT - списание
+func camtMoney(amount camtAmount, indicator string) (domain.Money, error) {
+	currency, err := domain.CurrencyByCode(amount.Currency)
+	if err != nil {
+		return domain.Money{}, err
+	}
+
+	raw := strings.TrimSpace(amount.Value)
+	money, err := domain.ParseMoney(raw, currency, amountRounding)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("invalid amount %q", raw)
+	}
+
+	switch indicator {
+	case "CRDT":
+		return money, nil
+	case "DBIT":
+		return money.Neg(), nil
+	default:
+		return domain.Money{}, fmt.Errorf("invalid credit/debit indicator %q", indicator)
+	}
+}
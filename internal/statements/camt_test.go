@@ -0,0 +1,90 @@
+package statements_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/statements"
+)
+
+// camt053 выписка camt.053.001.08 с остатками, проведенной и ожидающей проводками
+const camt053 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+  <BkToCstmrStmt>
+    <GrpHdr><MsgId>MSG-1</MsgId></GrpHdr>
+    <Stmt>
+      <Id>STMT-2024-03</Id>
+      <Acct><Id><IBAN>KZ86125KZT5004100100</IBAN></Id><Ccy>KZT</Ccy></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="KZT">1000.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2024-03-01</Dt></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="KZT">1250.50</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2024-03-31</Dt></Dt>
+      </Bal>
+      <Ntry>
+        <Amt Ccy="KZT">500.50</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2024-03-15</Dt></BookgDt><ValDt><Dt>2024-03-14</Dt></ValDt>
+        <AcctSvcrRef>REF-1</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <RltdPties>
+            <Dbtr><Pty><Nm>ACME LLP</Nm></Pty></Dbtr>
+            <DbtrAcct><Id><IBAN>KZ12345</IBAN></Id></DbtrAcct>
+          </RltdPties>
+          <RmtInf><Ustrd>Salary</Ustrd><Ustrd>March 2024</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="KZT">250.00</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><DtTm>2024-03-20T10:15:00+05:00</DtTm></BookgDt>
+        <AcctSvcrRef>REF-2</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <RltdPties><Cdtr><Nm>Coffee House</Nm></Cdtr></RltdPties>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="KZT">99.00</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>PDNG</Cd></Sts>
+        <BookgDt><Dt>2024-03-31</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+func Test_ParseCAMT053_ReturnsBookedEntries_WhenBalancesMatch(t *testing.T) {
+	lines, err := statements.ParseCAMT053(strings.NewReader(camt053))
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.StatementLine{
+		{Number: 1, Date: domain.Date{Year: 2024, Month: time.March, Day: 15},
+			ValueDate: domain.Date{Year: 2024, Month: time.March, Day: 14}, Amount: domain.NewMoney(50050, kzt),
+			Description: "Salary March 2024", CounterpartyName: "ACME LLP", CounterpartyIBAN: "KZ12345",
+			ExternalID: "KZ86125KZT5004100100/REF-1"},
+		{Number: 2, Date: domain.Date{Year: 2024, Month: time.March, Day: 20}, Amount: domain.NewMoney(-25000, kzt),
+			Description: "Coffee House", CounterpartyName: "Coffee House", ExternalID: "KZ86125KZT5004100100/REF-2"},
+	}, lines)
+}
+
+func Test_ParseCAMT053_ReturnsError_WhenClosingBalanceDiffers(t *testing.T) {
+	content := strings.Replace(camt053, "1250.50", "1300.00", 1)
+
+	_, err := statements.ParseCAMT053(strings.NewReader(content))
+
+	assert.ErrorIs(t, err, statements.ErrInvalidStatement)
+	assert.ErrorContains(t, err, "closing balance 1300.00 KZT does not match opening balance 1000.00 KZT plus transactions 250.50 KZT")
+}
+
+func Test_ParseCAMT053_ReturnsError_WhenNotCAMT(t *testing.T) {
+	_, err := statements.ParseCAMT053(strings.NewReader("<OFX></OFX>"))
+
+	assert.ErrorIs(t, err, statements.ErrInvalidStatement)
+}
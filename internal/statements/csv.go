@@ -0,0 +1,216 @@
+package statements
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+// csvColumns номера колонок профиля в разбираемом файле с нуля, -1 - колонка не задана
+type csvColumns struct {
+	date, amount, credit, debit, description, currency int
+}
+
+// ParseCSV разбирает CSV-выписку по профилю p.
+// Строка, которую не удалось разобрать, возвращается с заполненным Err; ошибка функции означает,
+// что файл не читается целиком (неверный профиль, кодировка, заголовок или синтаксис CSV).
+func ParseCSV(r io.Reader, p Profile) ([]domain.StatementLine, error) {
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+	r, err := p.Encoding.decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = ','
+	if p.Delimiter != 0 {
+		reader.Comma = p.Delimiter
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	for n := 0; n < p.SkipRows; n++ {
+		if _, err := reader.Read(); err != nil {
+			return nil, fmt.Errorf("%w: failed to skip row %d: %v", ErrInvalidStatement, n+1, err)
+		}
+	}
+
+	var header []string
+	if p.HasHeader {
+		if header, err = reader.Read(); err != nil {
+			return nil, fmt.Errorf("%w: failed to read header: %v", ErrInvalidStatement, err)
+		}
+	}
+	cols, err := p.resolveColumns(header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+
+	var lines []domain.StatementLine
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+		}
+		if isBlank(row) {
+			continue
+		}
+
+		number, _ := reader.FieldPos(0)
+		line := p.parseRow(row, cols)
+		line.Number = number
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// resolveColumns находит номера колонок профиля по заголовку файла
+func (p *Profile) resolveColumns(header []string) (csvColumns, error) {
+	names := make(map[string]int, len(header))
+	for n, name := range header {
+		names[strings.ToLower(strings.TrimSpace(name))] = n
+	}
+
+	resolve := func(c Column) (int, error) {
+		switch {
+		case c.Name != "":
+			n, ok := names[strings.ToLower(c.Name)]
+			if !ok {
+				return 0, fmt.Errorf("missing column %q", c.Name)
+			}
+			return n, nil
+		case c.Index > 0:
+			return c.Index - 1, nil
+		default:
+			return -1, nil
+		}
+	}
+
+	var cols csvColumns
+	for _, target := range []struct {
+		column Column
+		index  *int
+	}{
+		{p.Date, &cols.date},
+		{p.Amount, &cols.amount},
+		{p.Credit, &cols.credit},
+		{p.Debit, &cols.debit},
+		{p.Description, &cols.description},
+		{p.Currency, &cols.currency},
+	} {
+		n, err := resolve(target.column)
+		if err != nil {
+			return csvColumns{}, err
+		}
+		*target.index = n
+	}
+
+	return cols, nil
+}
+
+// parseRow разбирает строку операции; ошибка разбора сохраняется в Err
+func (p *Profile) parseRow(row []string, cols csvColumns) domain.StatementLine {
+	field := func(n int) string {
+		if n < 0 || n >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[n])
+	}
+
+	line := domain.StatementLine{Description: field(cols.description)}
+
+	raw := field(cols.date)
+	date, err := time.Parse(p.dateLayout(), raw)
+	if err != nil {
+		line.Err = fmt.Errorf("invalid date %q", raw)
+		return line
+	}
+	line.Date = domain.DateOf(date)
+
+	code := field(cols.currency)
+	if code == "" {
+		code = p.DefaultCurrency
+	}
+	currency, err := domain.CurrencyByCode(code)
+	if err != nil {
+		line.Err = err
+		return line
+	}
+
+	line.Amount, line.Err = p.signedAmount(field(cols.amount), field(cols.credit), field(cols.debit), currency)
+	return line
+}
+
+// signedAmount возвращает сумму операции со знаком по правилу профиля
+func (p *Profile) signedAmount(amount, credit, debit string, currency domain.Currency) (domain.Money, error) {
+	if p.Sign != SignSeparateColumns {
+		m, err := p.parseAmount(amount, currency)
+		if err != nil || p.Sign == SignAmount {
+			return m, err
+		}
+		return m.Neg(), nil
+	}
+
+	if credit != "" {
+		m, err := p.parseAmount(credit, currency)
+		if err != nil || !m.IsZero() {
+			return abs(m), err
+		}
+	}
+	if debit != "" {
+		m, err := p.parseAmount(debit, currency)
+		return abs(m).Neg(), err
+	}
+	return domain.Money{}, errors.New("both credit and debit are empty")
+}
+
+// parseAmount разбирает сумму с разделителями профиля; сумма в скобках считается отрицательной
+func (p *Profile) parseAmount(s string, currency domain.Currency) (domain.Money, error) {
+	raw := s
+	s = strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "").Replace(s)
+	if p.ThousandsSeparator != "" {
+		s = strings.ReplaceAll(s, p.ThousandsSeparator, "")
+	}
+	if p.DecimalSeparator != "" && p.DecimalSeparator != "." {
+		s = strings.ReplaceAll(s, p.DecimalSeparator, ".")
+	}
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		s = "-" + s[1:len(s)-1]
+	}
+
+	m, err := domain.ParseMoney(s, currency, amountRounding)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("invalid amount %q", raw)
+	}
+	return m, nil
+}
+
+// abs возвращает сумму без знака
+func abs(m domain.Money) domain.Money {
+	if m.IsNegative() {
+		return m.Neg()
+	}
+	return m
+}
+
+// isBlank сообщает, что все поля строки пусты
+func isBlank(row []string) bool {
+	for _, f := range row {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package statements_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/charmap"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/statements"
+)
+
+var (
+	kzt = domain.MustCurrency("KZT")
+	usd = domain.MustCurrency("USD")
+)
+
+func builtin(t *testing.T, name string) statements.Profile {
+	p, ok := statements.BuiltinProfile(name)
+	require.True(t, ok)
+	return p
+}
+
+func Test_ParseCSV_ReturnsSignedLines_WhenGenericProfile(t *testing.T) {
+	file := "\xef\xbb\xbfDate,Amount,Currency,Description\n2024-03-15,250000,KZT,Salary\n\n2024-03-16,-12.5,usd,\"Coffee, large\"\n"
+
+	lines, err := statements.ParseCSV(strings.NewReader(file), builtin(t, "generic"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.StatementLine{
+		{Number: 2, Date: domain.Date{Year: 2024, Month: time.March, Day: 15}, Amount: domain.NewMoney(25_000_000, kzt),
+			Description: "Salary"},
+		{Number: 4, Date: domain.Date{Year: 2024, Month: time.March, Day: 16}, Amount: domain.NewMoney(-1250, usd),
+			Description: "Coffee, large"},
+	}, lines)
+}
+
+func Test_ParseCSV_DecodesWindows1251_WhenCyrillicProfile(t *testing.T) {
+	file, err := charmap.Windows1251.NewEncoder().String(
+		"Дата операции;Сумма операции;Валюта операции;Описание\n15.03.2024;-1 234,50;KZT;Магазин\n")
+	require.NoError(t, err)
+
+	lines, err := statements.ParseCSV(strings.NewReader(file), builtin(t, "cyrillic-semicolon"))
+
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	assert.NoError(t, lines[0].Err)
+	assert.Equal(t, domain.NewMoney(-123450, kzt), lines[0].Amount)
+	assert.Equal(t, "Магазин", lines[0].Description)
+	assert.False(t, lines[0].IsIncome())
+}
+
+func Test_ParseCSV_UsesDebitAndCreditColumns_WhenSeparateColumns(t *testing.T) {
+	file := "Date,Description,Debit,Credit,Currency\n03/15/2024,Rent,\"1,200.00\",,USD\n03/16/2024,Refund,,35.10,USD\n"
+
+	lines, err := statements.ParseCSV(strings.NewReader(file), builtin(t, "debit-credit"))
+
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.Equal(t, domain.NewMoney(-120000, usd), lines[0].Amount)
+	assert.Equal(t, domain.NewMoney(3510, usd), lines[1].Amount)
+	assert.True(t, lines[1].IsIncome())
+}
+
+func Test_ParseCSV_ReadsColumnsByIndex_WhenNoHeader(t *testing.T) {
+	profile := statements.Profile{
+		SkipRows:        2,
+		Date:            statements.Column{Index: 2},
+		Amount:          statements.Column{Index: 1},
+		Description:     statements.Column{Index: 3},
+		DefaultCurrency: "KZT",
+		Sign:            statements.SignInverted,
+		DateFormat:      "DD/MM/YY",
+	}
+	file := "Card statement\nAccount KZ00\n(500),15/03/24,Cashback\n1500,16/03/24,Taxi\n"
+
+	lines, err := statements.ParseCSV(strings.NewReader(file), profile)
+
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.Equal(t, domain.NewMoney(50000, kzt), lines[0].Amount)
+	assert.Equal(t, domain.NewMoney(-150000, kzt), lines[1].Amount)
+	assert.Equal(t, 4, lines[1].Number)
+}
+
+func Test_ParseCSV_ReturnsLineError_WhenRowInvalid(t *testing.T) {
+	file := "date,amount,currency,description\n15.03.2024,100,KZT,Bad date\n2024-03-15,1O0,KZT,Bad amount\n" +
+		"2024-03-15,100,ABC,Bad currency\n2024-03-15,100,KZT,Good\n"
+
+	lines, err := statements.ParseCSV(strings.NewReader(file), builtin(t, "generic"))
+
+	require.NoError(t, err)
+	require.Len(t, lines, 4)
+	assert.EqualError(t, lines[0].Err, `invalid date "15.03.2024"`)
+	assert.EqualError(t, lines[1].Err, `invalid amount "1O0"`)
+	assert.Error(t, lines[2].Err)
+	assert.NoError(t, lines[3].Err)
+}
+
+func Test_ParseCSV_ReturnsError_WhenFileUnreadable(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		profile statements.Profile
+		errMsg  string
+	}{
+		{"Missing Column", "date,sum,currency\n", builtin(t, "generic"), `invalid statement file: missing column "amount"`},
+		{"Empty File", "", builtin(t, "generic"), "invalid statement file: failed to read header: EOF"},
+		{"Unknown Encoding", "date,amount\n", statements.Profile{Encoding: "koi8-r", Date: statements.Column{Index: 1},
+			Amount: statements.Column{Index: 2}, DefaultCurrency: "KZT"}, `invalid statement file: unsupported encoding "koi8-r"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := statements.ParseCSV(strings.NewReader(tt.file), tt.profile)
+
+			assert.ErrorIs(t, err, statements.ErrInvalidStatement)
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
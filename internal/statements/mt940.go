@@ -0,0 +1,305 @@
+package statements
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+var (
+	// mt940Field начало поля MT940, например :61:
+	mt940Field = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+	// mt940Balance остаток :60F:/:62F:: признак C/D, дата YYMMDD, валюта и сумма с запятой
+	mt940Balance = regexp.MustCompile(`^([CD])(\d{6})([A-Z]{3})(\d[\d,]*)$`)
+	// mt940Entry строка операции :61:: дата валютирования, дата проводки MMDD, признак, сумма,
+	// тип операции, референс клиента, референс банка после // и дополнительные сведения со следующей строки
+	mt940Entry = regexp.MustCompile(`^(\d{6})(\d{4})?(R?[CD])[A-Z]?(\d[\d,]*)[NSF][A-Z0-9]{3}([^/\n]*)(?://([^\n]*))?(?:\n([\s\S]*))?$`)
+	// mt940SubfieldKeys коды структурированного поля :86: вида /NAME/.../REMI/...
+	mt940SubfieldKeys = map[string]bool{
+		"TRTP": true, "IBAN": true, "BIC": true, "NAME": true, "REMI": true, "EREF": true, "MARF": true,
+		"CSID": true, "ORDP": true, "BENM": true, "CNTP": true, "ADDR": true, "PURP": true, "RTRN": true,
+		"ULTB": true, "ULTD": true, "SVCL": true, "ISDT": true, "PREF": true, "ID": true,
+	}
+)
+
+// mt940Statement состояние разбора текущей выписки MT940
+type mt940Statement struct {
+	account, reference, number string
+	opening                    *domain.Money
+	first                      int
+}
+
+// mt940Tag поле MT940 вместе с продолжением на следующих строках
+type mt940Tag struct {
+	line       int
+	tag, value string
+}
+
+// mt940Info сведения о платеже из поля :86:
+type mt940Info struct {
+	description, name, iban string
+}
+
+// ParseMT940 разбирает выписку SWIFT MT940, в том числе с заголовками блоков {1:}{2:}{4: и несколькими
+// выписками в одном файле. Кодировка берется из профиля. Поле :86: разбирается в форматах ?20-?29 и
+// /NAME/.../REMI/..., иначе целиком используется как описание. Датой операции считается дата проводки,
+// если она указана в :61:, иначе дата валютирования. ExternalID составляется из счета :25: и референса
+// банка. Конечный остаток :62F: должен совпасть с начальным :60F: плюс сумма операций.
+func ParseMT940(r io.Reader, p Profile) ([]domain.StatementLine, error) {
+	r, err := p.Encoding.decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+
+	var (
+		lines  []domain.StatementLine
+		stmt   mt940Statement
+		fields []mt940Tag
+	)
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimRight(scanner.Text(), "\r ")
+		if i := strings.Index(text, "{4:"); i >= 0 {
+			text = text[i+len("{4:"):]
+		}
+		// Пустые строки, конец сообщения и служебные блоки SWIFT
+		if text == "" || text == "-" || strings.HasPrefix(text, "-}") || strings.HasPrefix(text, "{") {
+			continue
+		}
+
+		if m := mt940Field.FindStringSubmatch(text); m != nil {
+			fields = append(fields, mt940Tag{line: number, tag: m[1], value: m[2]})
+		} else if len(fields) > 0 {
+			fields[len(fields)-1].value += "\n" + text
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: no MT940 fields found", ErrInvalidStatement)
+	}
+
+	currency := p.DefaultCurrency
+	for i, field := range fields {
+		switch field.tag {
+		case "20":
+			stmt = mt940Statement{reference: field.value, first: len(lines)}
+		case "25":
+			stmt.account = strings.TrimSpace(field.value)
+		case "28", "28C":
+			stmt.number = strings.TrimSpace(field.value)
+		case "60F", "60M":
+			opening, err := mt940BalanceAmount(field.value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: opening balance: %v", ErrInvalidStatement, field.line, err)
+			}
+			stmt.opening, stmt.first = &opening, len(lines)
+			currency = opening.Currency().Code
+		case "61":
+			line := mt940Line(field.value, stmt.account, currency)
+			line.Number = len(lines) + 1
+			if i+1 < len(fields) && fields[i+1].tag == "86" {
+				info := parseMT940Info(fields[i+1].value)
+				if info.description != "" {
+					line.Description = info.description
+				}
+				line.CounterpartyName, line.CounterpartyIBAN = info.name, info.iban
+			}
+			if line.Description == "" {
+				line.Description = line.CounterpartyName
+			}
+			lines = append(lines, line)
+		case "62F", "62M":
+			closing, err := mt940BalanceAmount(field.value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: closing balance: %v", ErrInvalidStatement, field.line, err)
+			}
+			if stmt.opening == nil {
+				return nil, fmt.Errorf("%w: line %d: closing balance without opening balance", ErrInvalidStatement, field.line)
+			}
+			if err := checkBalance(stmt.name(), *stmt.opening, closing, lines[stmt.first:]); err != nil {
+				return nil, err
+			}
+			stmt.opening, stmt.first = nil, len(lines)
+		}
+	}
+
+	return lines, nil
+}
+
+// name возвращает название выписки для сообщений об ошибках
+func (s *mt940Statement) name() string {
+	name := strings.TrimSpace(s.account + " " + s.number)
+	if name == "" {
+		name = strings.TrimSpace(s.reference)
+	}
+	return name
+}
+
+// mt940Line переводит поле :61: в строку выписки
+func mt940Line(value, account, currencyCode string) domain.StatementLine {
+	var line domain.StatementLine
+	m := mt940Entry.FindStringSubmatch(value)
+	if m == nil {
+		line.Err = fmt.Errorf("invalid statement line %q", value)
+		return line
+	}
+
+	if reference := strings.TrimSpace(m[6]); reference != "" && reference != "NONREF" {
+		line.ExternalID = account + "/" + reference
+	}
+	line.Description = strings.Join(strings.Fields(m[7]), " ")
+
+	valueDate, err := time.Parse("060102", m[1])
+	if err != nil {
+		line.Err = fmt.Errorf("invalid value date %q", m[1])
+		return line
+	}
+	line.ValueDate, line.Date = domain.DateOf(valueDate), domain.DateOf(valueDate)
+	if m[2] != "" {
+		booking, err := time.Parse("0102", m[2])
+		if err != nil {
+			line.Err = fmt.Errorf("invalid booking date %q", m[2])
+			return line
+		}
+		// Дата проводки указана без года: берется год валютирования с поправкой на переход через новый год
+		year := valueDate.Year()
+		switch diff := booking.Month() - valueDate.Month(); {
+		case diff > 6:
+			year--
+		case diff < -6:
+			year++
+		}
+		line.Date = domain.Date{Year: year, Month: booking.Month(), Day: booking.Day()}
+	}
+
+	if currencyCode == "" {
+		line.Err = errors.New("currency is unknown: opening balance is missing")
+		return line
+	}
+	currency, err := domain.CurrencyByCode(currencyCode)
+	if err != nil {
+		line.Err = err
+		return line
+	}
+	if line.Amount, err = mt940Amount(m[4], currency); err != nil {
+		line.Err = err
+		return line
+	}
+	// RC - сторно поступления, то есть списание; RD - сторно списания
+	if m[3] == "D" || m[3] == "RC" {
+		line.Amount = line.Amount.Neg()
+	}
+	return line
+}
+
+// mt940BalanceAmount разбирает остаток :60F: или :62F: в деньги со знаком
+func mt940BalanceAmount(value string) (domain.Money, error) {
+	m := mt940Balance.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return domain.Money{}, fmt.Errorf("invalid balance %q", value)
+	}
+
+	currency, err := domain.CurrencyByCode(m[3])
+	if err != nil {
+		return domain.Money{}, err
+	}
+	amount, err := mt940Amount(m[4], currency)
+	if err != nil {
+		return domain.Money{}, err
+	}
+	if m[1] == "D" {
+		amount = amount.Neg()
+	}
+	return amount, nil
+}
+
+// mt940Amount разбирает сумму с десятичной запятой, например 1234,5 или 100,
+func mt940Amount(s string, currency domain.Currency) (domain.Money, error) {
+	amount, err := domain.ParseMoney(strings.TrimSuffix(strings.Replace(s, ",", ".", 1), "."), currency, amountRounding)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("invalid amount %q", s)
+	}
+	return amount, nil
+}
+
+// parseMT940Info разбирает поле :86: в одном из распространенных форматов
+func parseMT940Info(value string) mt940Info {
+	flat := strings.ReplaceAll(value, "\n", "")
+	switch {
+	case len(flat) > 3 && flat[3] == '?' || strings.HasPrefix(flat, "?"):
+		return parseMT940Question(flat)
+	case strings.HasPrefix(flat, "/"):
+		if info, ok := parseMT940Slash(flat); ok {
+			return info
+		}
+	}
+	return mt940Info{description: strings.Join(strings.Fields(value), " ")}
+}
+
+// parseMT940Question разбирает поле :86: с подполями ?NN: ?20-?29 и ?60-?63 - назначение платежа,
+// ?31 - счет контрагента, ?32-?33 - его имя, ?00 - вид операции
+func parseMT940Question(value string) mt940Info {
+	var (
+		info                   mt940Info
+		remittance, name, kind []string
+	)
+	for _, part := range strings.Split(value, "?")[1:] {
+		if len(part) < 2 {
+			continue
+		}
+		code, text := part[:2], part[2:]
+		switch {
+		case code >= "20" && code <= "29" || code >= "60" && code <= "63":
+			remittance = append(remittance, text)
+		case code == "31":
+			info.iban = strings.TrimSpace(text)
+		case code == "32" || code == "33":
+			name = append(name, text)
+		case code == "00":
+			kind = append(kind, text)
+		}
+	}
+
+	info.name = strings.TrimSpace(strings.Join(name, ""))
+	info.description = strings.TrimSpace(strings.Join(remittance, ""))
+	if info.description == "" {
+		info.description = strings.TrimSpace(strings.Join(kind, " "))
+	}
+	return info
+}
+
+// parseMT940Slash разбирает поле :86: с кодами /NAME/.../REMI/...; false, если известных кодов нет
+func parseMT940Slash(value string) (mt940Info, bool) {
+	values := make(map[string][]string)
+	key := ""
+	for _, part := range strings.Split(strings.Trim(value, "/"), "/") {
+		if mt940SubfieldKeys[part] {
+			key = part
+			values[key] = []string{}
+		} else if key != "" {
+			values[key] = append(values[key], part)
+		}
+	}
+	if len(values) == 0 {
+		return mt940Info{}, false
+	}
+
+	info := mt940Info{name: strings.Join(values["NAME"], "/"), iban: strings.Join(values["IBAN"], "/")}
+	// CNTP: IBAN/BIC/имя/город контрагента
+	if cntp := values["CNTP"]; len(cntp) >= 3 {
+		info.iban, info.name = cntp[0], cntp[2]
+	}
+	remittance := strings.Join(values["REMI"], "/")
+	remittance = strings.TrimPrefix(remittance, "USTD//")
+	info.description = strings.TrimSpace(remittance)
+	info.name = strings.TrimSpace(info.name)
+	return info, true
+}
@@ -0,0 +1,66 @@
+package statements_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/statements"
+)
+
+// mt940 сообщение MT940 с блоками SWIFT и полями :86: в двух распространенных форматах
+const mt940 = `{1:F01BANKKZKAXXXX0000000000}{2:O9401200240401BANKKZKAXXXX00000000002404011200N}{4:
+:20:STMT240331
+:25:KZ86125KZT5004100100
+:28C:00012/001
+:60F:C240301EUR1000,00
+:61:2403150314CR500,5NTRFNONREF//BNK-1
+:86:166?00SEPA-GUTSCHRIFT?20Salary?21 March 2024?31DE89370400440532013000
+?32ACME?33 GmbH
+:61:2312311229D250,NMSCCARD-77//BNK-2
+Card payment
+:86:/CNTP/NL91ABNA0417164300/ABNANL2A/Coffee House/Amsterdam/REMI/USTD//Latte/
+:61:240320RD10,NTRFNONREF
+:86:Refund of fee
+:62F:C240331EUR1260,50
+-}
+`
+
+func Test_ParseMT940_ReturnsLines_WhenBalancesMatch(t *testing.T) {
+	lines, err := statements.ParseMT940(strings.NewReader(mt940), statements.Profile{})
+
+	require.NoError(t, err)
+	eur := domain.MustCurrency("EUR")
+	assert.Equal(t, []domain.StatementLine{
+		{Number: 1, Date: domain.Date{Year: 2024, Month: time.March, Day: 14},
+			ValueDate: domain.Date{Year: 2024, Month: time.March, Day: 15}, Amount: domain.NewMoney(50050, eur),
+			Description: "Salary March 2024", CounterpartyName: "ACME GmbH", CounterpartyIBAN: "DE89370400440532013000",
+			ExternalID: "KZ86125KZT5004100100/BNK-1"},
+		{Number: 2, Date: domain.Date{Year: 2023, Month: time.December, Day: 29},
+			ValueDate: domain.Date{Year: 2023, Month: time.December, Day: 31}, Amount: domain.NewMoney(-25000, eur),
+			Description: "Latte", CounterpartyName: "Coffee House", CounterpartyIBAN: "NL91ABNA0417164300",
+			ExternalID: "KZ86125KZT5004100100/BNK-2"},
+		{Number: 3, Date: domain.Date{Year: 2024, Month: time.March, Day: 20},
+			ValueDate: domain.Date{Year: 2024, Month: time.March, Day: 20}, Amount: domain.NewMoney(1000, eur),
+			Description: "Refund of fee"},
+	}, lines)
+}
+
+func Test_ParseMT940_ReturnsError_WhenClosingBalanceDiffers(t *testing.T) {
+	content := strings.Replace(mt940, ":62F:C240331EUR1260,50", ":62F:C240331EUR1250,50", 1)
+
+	_, err := statements.ParseMT940(strings.NewReader(content), statements.Profile{})
+
+	assert.ErrorIs(t, err, statements.ErrInvalidStatement)
+	assert.ErrorContains(t, err, "statement KZ86125KZT5004100100 00012/001: closing balance 1250.50 EUR")
+}
+
+func Test_ParseMT940_ReturnsError_WhenNoFields(t *testing.T) {
+	_, err := statements.ParseMT940(strings.NewReader("date;amount\n"), statements.Profile{})
+
+	assert.ErrorIs(t, err, statements.ErrInvalidStatement)
+}
@@ -0,0 +1,172 @@
+package statements
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+
+	"fincraft-finance/internal/domain"
+)
+
+// ofxCharset находит кодировку в заголовке OFX 1.x (CHARSET:1251) или в XML-декларации OFX 2.x
+var ofxCharset = regexp.MustCompile(`(?i)(?:CHARSET:\s*|encoding=")([\w-]+)`)
+
+// ofxTransaction поля одной операции STMTTRN
+type ofxTransaction struct {
+	fields   map[string]string
+	currency string
+}
+
+// ParseOFX разбирает выписку OFX 1.x (SGML, листовые теги могут быть не закрыты) или OFX 2.x (XML).
+// Сумма берется из TRNAMT в валюте выписки CURDEF или CURRENCY операции, описание - из NAME или MEMO.
+// ExternalID составляется из ACCTID счета выписки (BANKACCTFROM или CCACCTFROM вне операций) и FITID операции;
+// ACCTID второго счета перевода (BANKACCTTO, CCACCTTO) его не меняет. Переводы (TRNTYPE XFER) не указывают
+// второй счет, поэтому импортируются как поступления и списания.
+func ParseOFX(r io.Reader) ([]domain.StatementLine, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+
+	start := bytes.Index(bytes.ToUpper(content), []byte("<OFX>"))
+	if start < 0 {
+		return nil, fmt.Errorf("%w: missing OFX element", ErrInvalidStatement)
+	}
+	body, err := decodeOFX(content[:start], content[start:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+
+	var (
+		lines                      []domain.StatementLine
+		account, curdef            string
+		txn                        *ofxTransaction
+		inCurrency, inOrigCurrency bool
+		inAccountFrom              bool
+	)
+	for body != "" {
+		open := strings.IndexByte(body, '<')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(body[open:], '>')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated tag", ErrInvalidStatement)
+		}
+		tag := strings.ToUpper(strings.TrimSpace(body[open+1 : open+end]))
+		body = body[open+end+1:]
+
+		next := strings.IndexByte(body, '<')
+		if next < 0 {
+			next = len(body)
+		}
+		value := unescapeOFX(strings.TrimSpace(body[:next]))
+
+		switch tag {
+		case "STMTTRN":
+			txn = &ofxTransaction{fields: make(map[string]string)}
+		case "/STMTTRN":
+			if txn != nil {
+				currency := curdef
+				if txn.currency != "" {
+					currency = txn.currency
+				}
+				lines = append(lines, txn.line(len(lines)+1, account, currency))
+				txn = nil
+			}
+		case "CURRENCY":
+			inCurrency = true
+		case "/CURRENCY":
+			inCurrency = false
+		case "ORIGCURRENCY":
+			inOrigCurrency = true
+		case "/ORIGCURRENCY":
+			inOrigCurrency = false
+		case "BANKACCTFROM", "CCACCTFROM":
+			inAccountFrom = true
+		case "/BANKACCTFROM", "/CCACCTFROM":
+			inAccountFrom = false
+		case "CURDEF":
+			curdef = value
+		case "ACCTID":
+			if inAccountFrom && txn == nil {
+				account = value
+			}
+		default:
+			switch {
+			case strings.HasPrefix(tag, "/") || value == "" || txn == nil:
+			case tag == "CURSYM" && inCurrency && !inOrigCurrency:
+				txn.currency = value
+			default:
+				txn.fields[tag] = value
+			}
+		}
+	}
+
+	return lines, nil
+}
+
+// line переводит операцию OFX в строку выписки с номером number
+func (t *ofxTransaction) line(number int, account, currencyCode string) domain.StatementLine {
+	line := domain.StatementLine{Number: number, Description: t.fields["NAME"]}
+	if line.Description == "" {
+		line.Description = t.fields["MEMO"]
+	}
+	if fitID := t.fields["FITID"]; fitID != "" {
+		line.ExternalID = account + "/" + fitID
+	}
+
+	posted := t.fields["DTPOSTED"]
+	date, err := time.Parse("20060102", posted[:min(len(posted), 8)])
+	if err != nil {
+		line.Err = fmt.Errorf("invalid date %q", posted)
+		return line
+	}
+	line.Date = domain.DateOf(date)
+
+	currency, err := domain.CurrencyByCode(currencyCode)
+	if err != nil {
+		line.Err = err
+		return line
+	}
+
+	raw := t.fields["TRNAMT"]
+	if line.Amount, err = domain.ParseMoney(strings.ReplaceAll(raw, ",", "."), currency, amountRounding); err != nil {
+		line.Err = fmt.Errorf("invalid amount %q", raw)
+	}
+	return line
+}
+
+// decodeOFX перекодирует тело файла в UTF-8 по кодировке из заголовка
+func decodeOFX(header, body []byte) (string, error) {
+	charset := ""
+	if m := ofxCharset.FindSubmatch(header); m != nil {
+		charset = strings.ToUpper(string(m[1]))
+	}
+
+	var cm *charmap.Charmap
+	switch charset {
+	case "", "NONE", "UTF-8", "USASCII", "US-ASCII":
+		return string(body), nil
+	case "1251", "WINDOWS-1251", "CP1251":
+		cm = charmap.Windows1251
+	case "1252", "WINDOWS-1252", "CP1252", "ISO-8859-1", "8859-1":
+		cm = charmap.Windows1252
+	default:
+		return "", fmt.Errorf("unsupported charset %q", charset)
+	}
+
+	decoded, err := cm.NewDecoder().Bytes(body)
+	return string(decoded), err
+}
+
+// unescapeOFX заменяет сущности XML и SGML в значении элемента
+func unescapeOFX(s string) string {
+	return strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">", "&quot;", `"`, "&apos;", "'", "&nbsp;", " ").
+		Replace(s)
+}
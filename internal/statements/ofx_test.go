@@ -0,0 +1,134 @@
+package statements_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/statements"
+)
+
+// ofxSGML выписка OFX 1.x с незакрытыми листовыми тегами и переводом на другой счет банка
+const ofxSGML = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+ENCODING:USASCII
+CHARSET:1252
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>USD
+<BANKACCTFROM><BANKID>121000248<ACCTID>1234567890<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20240301<DTEND>20240331
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240315120000.000[-5:EST]
+<TRNAMT>2500.00
+<FITID>20240315001
+<NAME>ACME PAYROLL &amp; CO
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240316
+<TRNAMT>-45.10
+<FITID>20240316002
+<MEMO>Grocery store
+<CURRENCY><CURRATE>1.0<CURSYM>EUR</CURRENCY>
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>XFER
+<DTPOSTED>20240317
+<TRNAMT>-300.00
+<FITID>20240317003
+<NAME>To savings
+<BANKACCTTO><BANKID>121000248<ACCTID>9876543210<ACCTTYPE>SAVINGS</BANKACCTTO>
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240318
+<TRNAMT>-12.00
+<FITID>20240318004
+<NAME>Bank fee
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+// ofxXML выписка OFX 2.x по кредитной карте
+const ofxXML = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="211" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <CCSTMTRS>
+        <CURDEF>KZT</CURDEF>
+        <CCACCTFROM><ACCTID>4000</ACCTID></CCACCTFROM>
+        <BANKTRANLIST>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20240315</DTPOSTED>
+            <TRNAMT>-1500.50</TRNAMT>
+            <FITID>A-1</FITID>
+            <NAME>Кофейня</NAME>
+          </STMTTRN>
+          <STMTTRN>
+            <TRNTYPE>CREDIT</TRNTYPE>
+            <DTPOSTED>2024-03-16</DTPOSTED>
+            <TRNAMT>100</TRNAMT>
+            <FITID>A-2</FITID>
+          </STMTTRN>
+        </BANKTRANLIST>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+func Test_ParseOFX_ReturnsLines_WhenSGML(t *testing.T) {
+	lines, err := statements.ParseOFX(strings.NewReader(ofxSGML))
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.StatementLine{
+		{Number: 1, Date: domain.Date{Year: 2024, Month: time.March, Day: 15}, Amount: domain.NewMoney(250000, usd),
+			Description: "ACME PAYROLL & CO", ExternalID: "1234567890/20240315001"},
+		{Number: 2, Date: domain.Date{Year: 2024, Month: time.March, Day: 16},
+			Amount: domain.NewMoney(-4510, domain.MustCurrency("EUR")), Description: "Grocery store",
+			ExternalID: "1234567890/20240316002"},
+		{Number: 3, Date: domain.Date{Year: 2024, Month: time.March, Day: 17}, Amount: domain.NewMoney(-30000, usd),
+			Description: "To savings", ExternalID: "1234567890/20240317003"},
+		{Number: 4, Date: domain.Date{Year: 2024, Month: time.March, Day: 18}, Amount: domain.NewMoney(-1200, usd),
+			Description: "Bank fee", ExternalID: "1234567890/20240318004"},
+	}, lines)
+}
+
+func Test_ParseOFX_KeepsStatementAccount_WhenTransferNamesAccountTo(t *testing.T) {
+	lines, err := statements.ParseOFX(strings.NewReader(ofxSGML))
+
+	require.NoError(t, err)
+	require.Len(t, lines, 4)
+	assert.Equal(t, "1234567890/20240317003", lines[2].ExternalID)
+	assert.Equal(t, "1234567890/20240318004", lines[3].ExternalID)
+}
+
+func Test_ParseOFX_ReturnsLines_WhenXML(t *testing.T) {
+	lines, err := statements.ParseOFX(strings.NewReader(ofxXML))
+
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.Equal(t, domain.NewMoney(-150050, kzt), lines[0].Amount)
+	assert.Equal(t, "Кофейня", lines[0].Description)
+	assert.Equal(t, "4000/A-1", lines[0].ExternalID)
+	assert.EqualError(t, lines[1].Err, `invalid date "2024-03-16"`)
+}
+
+func Test_ParseOFX_ReturnsError_WhenNotOFX(t *testing.T) {
+	_, err := statements.ParseOFX(strings.NewReader("date,amount\n"))
+
+	assert.ErrorIs(t, err, statements.ErrInvalidStatement)
+}
@@ -0,0 +1,183 @@
+package statements
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Encoding кодировка файла выписки
+type Encoding string
+
+const (
+	// EncodingUTF8 кодировка по умолчанию, BOM в начале файла пропускается
+	EncodingUTF8 Encoding = "utf-8"
+	// EncodingWindows1251 кириллическая кодировка выгрузок из старых банковских систем
+	EncodingWindows1251 Encoding = "windows-1251"
+)
+
+// decode возвращает reader, перекодирующий содержимое в UTF-8
+func (e Encoding) decode(r io.Reader) (io.Reader, error) {
+	switch strings.ToLower(string(e)) {
+	case "", string(EncodingUTF8):
+		return skipBOM(r), nil
+	case string(EncodingWindows1251), "cp1251":
+		return charmap.Windows1251.NewDecoder().Reader(r), nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", e)
+	}
+}
+
+// skipBOM пропускает метку порядка байтов UTF-8, которую добавляют табличные редакторы
+func skipBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if bom, err := br.Peek(3); err == nil && string(bom) == "\xef\xbb\xbf" {
+		_, _ = br.Discard(3)
+	}
+	return br
+}
+
+// SignConvention способ определения направления операции
+type SignConvention int
+
+const (
+	// SignAmount поступление положительно, списание отрицательно
+	SignAmount SignConvention = iota
+	// SignInverted списание положительно, поступление отрицательно (выписки по кредитным картам)
+	SignInverted
+	// SignSeparateColumns поступления и списания в разных колонках Credit и Debit, суммы без знака
+	SignSeparateColumns
+)
+
+// Column колонка CSV: по имени в заголовке или по номеру
+type Column struct {
+	// Name имя колонки в заголовке без учета регистра
+	Name string
+	// Index номер колонки с единицы, используется, если Name пустое
+	Index int
+}
+
+// IsZero сообщает, что колонка не задана
+func (c Column) IsZero() bool {
+	return c.Name == "" && c.Index == 0
+}
+
+// Profile описание раскладки CSV-выписки конкретного банка
+type Profile struct {
+	// Delimiter разделитель полей, по умолчанию запятая
+	Delimiter rune
+	Encoding  Encoding
+	// SkipRows число строк шапки перед заголовком или первой операцией
+	SkipRows int
+	// HasHeader первая строка после шапки - заголовок; обязателен, если колонки заданы по имени
+	HasHeader bool
+
+	Date        Column
+	Amount      Column
+	Credit      Column
+	Debit       Column
+	Description Column
+	// Currency колонка с кодом валюты; если не задана или пуста, используется DefaultCurrency
+	Currency        Column
+	DefaultCurrency string
+	Sign            SignConvention
+
+	// DateFormat формат даты из токенов YYYY, YY, MM, DD, HH, mm, ss, по умолчанию YYYY-MM-DD
+	DateFormat string
+	// DecimalSeparator разделитель дробной части, по умолчанию точка
+	DecimalSeparator string
+	// ThousandsSeparator разделитель разрядов, пустой - не используется. Пробелы в суммах игнорируются всегда.
+	ThousandsSeparator string
+}
+
+// Validate проверяет, что профиль описывает все нужные колонки
+func (p *Profile) Validate() error {
+	if p.Date.IsZero() {
+		return errors.New("date column must be set")
+	}
+	if p.Sign == SignSeparateColumns {
+		if p.Credit.IsZero() || p.Debit.IsZero() {
+			return errors.New("credit and debit columns must be set")
+		}
+	} else if p.Amount.IsZero() {
+		return errors.New("amount column must be set")
+	}
+	if p.Sign < SignAmount || p.Sign > SignSeparateColumns {
+		return errors.New("sign convention must be valid")
+	}
+	if p.Currency.IsZero() && p.DefaultCurrency == "" {
+		return errors.New("currency column or default currency must be set")
+	}
+	if p.SkipRows < 0 {
+		return errors.New("skip rows must not be negative")
+	}
+	if p.DecimalSeparator != "" && p.DecimalSeparator == p.ThousandsSeparator {
+		return errors.New("decimal and thousands separators must differ")
+	}
+
+	for _, c := range []Column{p.Date, p.Amount, p.Credit, p.Debit, p.Description, p.Currency} {
+		if c.Index < 0 {
+			return errors.New("column index must be positive")
+		}
+		if c.Name != "" && !p.HasHeader {
+			return fmt.Errorf("column %q is referenced by name, but the file has no header", c.Name)
+		}
+	}
+	return nil
+}
+
+// dateLayout переводит DateFormat в формат пакета time
+func (p *Profile) dateLayout() string {
+	if p.DateFormat == "" {
+		return "2006-01-02"
+	}
+	return strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02",
+		"HH", "15", "mm", "04", "ss", "05").Replace(p.DateFormat)
+}
+
+// builtinProfiles профили распространенных раскладок выписок
+var builtinProfiles = map[string]Profile{
+	// Выгрузка с колонками date, amount, currency, description и суммой со знаком
+	"generic": {
+		HasHeader:   true,
+		Date:        Column{Name: "date"},
+		Amount:      Column{Name: "amount"},
+		Currency:    Column{Name: "currency"},
+		Description: Column{Name: "description"},
+	},
+	// Выгрузка российских и казахстанских банков: Windows-1251, точка с запятой, дата ДД.ММ.ГГГГ,
+	// дробная часть через запятую и разряды через пробел
+	"cyrillic-semicolon": {
+		Delimiter:        ';',
+		Encoding:         EncodingWindows1251,
+		HasHeader:        true,
+		Date:             Column{Name: "Дата операции"},
+		Amount:           Column{Name: "Сумма операции"},
+		Currency:         Column{Name: "Валюта операции"},
+		Description:      Column{Name: "Описание"},
+		DateFormat:       "DD.MM.YYYY",
+		DecimalSeparator: ",",
+	},
+	// Выгрузка с раздельными колонками Debit и Credit, датой ММ/ДД/ГГГГ и разрядами через запятую
+	"debit-credit": {
+		HasHeader:          true,
+		Date:               Column{Name: "Date"},
+		Debit:              Column{Name: "Debit"},
+		Credit:             Column{Name: "Credit"},
+		Currency:           Column{Name: "Currency"},
+		Description:        Column{Name: "Description"},
+		Sign:               SignSeparateColumns,
+		DateFormat:         "MM/DD/YYYY",
+		ThousandsSeparator: ",",
+	},
+}
+
+// BuiltinProfile возвращает встроенный профиль по имени
+func BuiltinProfile(name string) (Profile, bool) {
+	p, ok := builtinProfiles[name]
+	return p, ok
+}
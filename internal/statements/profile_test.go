@@ -0,0 +1,44 @@
+package statements_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fincraft-finance/internal/statements"
+)
+
+func Test_Profile_Validate_ReturnsError_WhenMappingIncomplete(t *testing.T) {
+	date := statements.Column{Index: 1}
+	amount := statements.Column{Index: 2}
+	tests := []struct {
+		name    string
+		profile statements.Profile
+		errMsg  string
+	}{
+		{"Missing Date", statements.Profile{Amount: amount, DefaultCurrency: "KZT"}, "date column must be set"},
+		{"Missing Amount", statements.Profile{Date: date, DefaultCurrency: "KZT"}, "amount column must be set"},
+		{"Missing Debit", statements.Profile{Date: date, Credit: amount, Sign: statements.SignSeparateColumns,
+			DefaultCurrency: "KZT"}, "credit and debit columns must be set"},
+		{"Missing Currency", statements.Profile{Date: date, Amount: amount}, "currency column or default currency must be set"},
+		{"Name Without Header", statements.Profile{Date: statements.Column{Name: "date"}, Amount: amount,
+			DefaultCurrency: "KZT"}, `column "date" is referenced by name, but the file has no header`},
+		{"Same Separators", statements.Profile{Date: date, Amount: amount, DefaultCurrency: "KZT",
+			DecimalSeparator: ",", ThousandsSeparator: ","}, "decimal and thousands separators must differ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.profile.Validate(), tt.errMsg)
+		})
+	}
+}
+
+func Test_BuiltinProfile_ReturnsValidProfiles(t *testing.T) {
+	for _, name := range []string{"generic", "cyrillic-semicolon", "debit-credit"} {
+		p, ok := statements.BuiltinProfile(name)
+
+		assert.True(t, ok, name)
+		assert.NoError(t, p.Validate(), name)
+	}
+}
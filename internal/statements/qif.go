@@ -0,0 +1,154 @@
+package statements
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+// qifRecord поля одной операции QIF до разбора
+type qifRecord struct {
+	number                              int
+	date, amount, payee, memo, category string
+}
+
+// ParseQIF разбирает выписку QIF с разделами !Type:Bank, !Type:CCard, !Type:Cash, !Type:Oth A и !Type:Oth L.
+// QIF не содержит валюты, она берется из DefaultCurrency профиля. Дата по умолчанию в порядке месяц/день/год
+// (в том числе 3/15'24), если DateFormat профиля не задан. Категория вида [Счет] означает перевод на счет
+// или со счета с этим названием. Разбивки (S, $) не учитываются: используется итоговая сумма T.
+func ParseQIF(r io.Reader, p Profile) ([]domain.StatementLine, error) {
+	currency, err := domain.CurrencyByCode(p.DefaultCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: default currency: %v", ErrInvalidStatement, err)
+	}
+	r, err = p.Encoding.decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+	if p.DecimalSeparator == "" && p.ThousandsSeparator == "" {
+		p.ThousandsSeparator = ","
+	}
+
+	var (
+		lines        []domain.StatementLine
+		record       qifRecord
+		transactions bool
+	)
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "!") {
+			header := strings.ToLower(strings.TrimSpace(text))
+			switch {
+			case strings.HasPrefix(header, "!type:invst"):
+				return nil, fmt.Errorf("%w: line %d: investment accounts are not supported", ErrInvalidStatement, number)
+			case strings.HasPrefix(header, "!type:"):
+				transactions = !strings.HasPrefix(header, "!type:cat") && !strings.HasPrefix(header, "!type:class") &&
+					!strings.HasPrefix(header, "!type:memorized")
+			default:
+				// !Account, !Option и другие служебные разделы
+				transactions = false
+			}
+			continue
+		}
+		if !transactions {
+			continue
+		}
+
+		if record.number == 0 {
+			record.number = number
+		}
+		value := strings.TrimSpace(text[1:])
+		switch text[0] {
+		case '^':
+			lines = append(lines, p.qifLine(record, currency))
+			record = qifRecord{}
+		case 'D':
+			record.date = value
+		case 'T', 'U':
+			record.amount = value
+		case 'P':
+			record.payee = value
+		case 'M':
+			record.memo = value
+		case 'L':
+			record.category = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+
+	return lines, nil
+}
+
+// qifLine переводит запись QIF в строку выписки
+func (p *Profile) qifLine(record qifRecord, currency domain.Currency) domain.StatementLine {
+	line := domain.StatementLine{Number: record.number, Description: record.payee}
+	if line.Description == "" {
+		line.Description = record.memo
+	}
+	if account, ok := strings.CutPrefix(record.category, "["); ok {
+		line.TransferAccount, _, _ = strings.Cut(account, "]")
+	}
+
+	date, err := p.qifDate(record.date)
+	if err != nil {
+		line.Err = err
+		return line
+	}
+	line.Date = date
+
+	line.Amount, line.Err = p.parseAmount(record.amount, currency)
+	return line
+}
+
+// qifDate разбирает дату QIF по формату профиля или в порядке месяц/день/год
+func (p *Profile) qifDate(s string) (domain.Date, error) {
+	invalid := fmt.Errorf("invalid date %q", s)
+	if p.DateFormat != "" {
+		t, err := time.Parse(p.dateLayout(), s)
+		if err != nil {
+			return domain.Date{}, invalid
+		}
+		return domain.DateOf(t), nil
+	}
+
+	// Quicken отделяет год после 1999 апострофом и дополняет однозначные числа пробелами: " 3/ 5'24"
+	parts := strings.FieldsFunc(strings.ReplaceAll(s, " ", ""), func(r rune) bool {
+		return r == '/' || r == '\'' || r == '-' || r == '.'
+	})
+	if len(parts) != 3 {
+		return domain.Date{}, invalid
+	}
+	var values [3]int
+	for n, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return domain.Date{}, invalid
+		}
+		values[n] = v
+	}
+
+	month, day, year := values[0], values[1], values[2]
+	switch {
+	case year < 70 && len(parts[2]) <= 2:
+		year += 2000
+	case year < 100 && len(parts[2]) <= 2:
+		year += 1900
+	}
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Month() != time.Month(month) || t.Day() != day {
+		return domain.Date{}, invalid
+	}
+	return domain.DateOf(t), nil
+}
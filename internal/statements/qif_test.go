@@ -0,0 +1,79 @@
+package statements_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/statements"
+)
+
+// qifBank выписка QIF со списком счетов, доходом, переводом и записью с ошибкой
+const qifBank = `!Account
+NChecking
+TBank
+^
+!Type:Bank
+D 3/15'24
+T2,500.00
+PACME Payroll
+LSalary
+^
+D3/16/2024
+T-300.00
+MMove to savings
+L[Savings]
+^
+D13/45/2024
+T-1.00
+^
+`
+
+func Test_ParseQIF_ReturnsLines_WhenBankSection(t *testing.T) {
+	lines, err := statements.ParseQIF(strings.NewReader(qifBank), statements.Profile{DefaultCurrency: "USD"})
+
+	require.NoError(t, err)
+	require.Len(t, lines, 3)
+	assert.Equal(t, domain.StatementLine{Number: 6, Date: domain.Date{Year: 2024, Month: time.March, Day: 15},
+		Amount: domain.NewMoney(250000, usd), Description: "ACME Payroll"}, lines[0])
+	assert.Equal(t, "Savings", lines[1].TransferAccount)
+	assert.True(t, lines[1].IsTransfer())
+	assert.Equal(t, "Move to savings", lines[1].Description)
+	assert.EqualError(t, lines[2].Err, `invalid date "13/45/2024"`)
+}
+
+func Test_ParseQIF_UsesProfileFormat_WhenDateFormatSet(t *testing.T) {
+	file := "!Type:CCard\nD15.03.2024\nT-1.234,50\nPMagnum\n^\n"
+	profile := statements.Profile{DefaultCurrency: "KZT", DateFormat: "DD.MM.YYYY", DecimalSeparator: ",",
+		ThousandsSeparator: "."}
+
+	lines, err := statements.ParseQIF(strings.NewReader(file), profile)
+
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	assert.Equal(t, domain.Date{Year: 2024, Month: time.March, Day: 15}, lines[0].Date)
+	assert.Equal(t, domain.NewMoney(-123450, kzt), lines[0].Amount)
+}
+
+func Test_ParseQIF_ReturnsError_WhenFileUnsupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		profile statements.Profile
+	}{
+		{"Missing Currency", qifBank, statements.Profile{}},
+		{"Investments", "!Type:Invst\nD3/15/2024\n^\n", statements.Profile{DefaultCurrency: "USD"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := statements.ParseQIF(strings.NewReader(tt.file), tt.profile)
+
+			assert.ErrorIs(t, err, statements.ErrInvalidStatement)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package statements
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"fincraft-finance/internal/domain"
+)
+
+// ErrInvalidStatement возвращается, если файл выписки не удалось разобрать
+var ErrInvalidStatement = errors.New("invalid statement file")
+
+// amountRounding способ округления сумм с лишними знаками после запятой
+const amountRounding = domain.RoundHalfEven
+
+// Format формат файла выписки
+type Format int
+
+const (
+	// FormatCSV таблица с раскладкой, описанной профилем
+	FormatCSV Format = iota
+	// FormatOFX Open Financial Exchange 1.x (SGML) и 2.x (XML), включая QFX
+	FormatOFX
+	// FormatQIF Quicken Interchange Format
+	FormatQIF
+	// FormatCAMT053 выписка ISO 20022 camt.053 (XML)
+	FormatCAMT053
+	// FormatMT940 выписка SWIFT MT940
+	FormatMT940
+)
+
+// Parse разбирает выписку в формате format.
+// Для CSV профиль описывает раскладку файла, для QIF задает валюту, кодировку, формат даты и разделители
+// суммы, для MT940 - кодировку. OFX и camt.053 профиль не используют: кодировка и валюта указаны в самом файле.
+func Parse(format Format, r io.Reader, p Profile) ([]domain.StatementLine, error) {
+	switch format {
+	case FormatCSV:
+		return ParseCSV(r, p)
+	case FormatOFX:
+		return ParseOFX(r)
+	case FormatQIF:
+		return ParseQIF(r, p)
+	case FormatCAMT053:
+		return ParseCAMT053(r)
+	case FormatMT940:
+		return ParseMT940(r, p)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format", ErrInvalidStatement)
+	}
+}
+
+// checkBalance сверяет конечный остаток выписки с начальным остатком и суммой операций.
+// Если сумма хотя бы одной операции не разобрана, сверка не выполняется: ошибка уже указана в строке.
+func checkBalance(statement string, opening, closing domain.Money, lines []domain.StatementLine) error {
+	total := domain.NewMoney(0, opening.Currency())
+	for i := range lines {
+		if lines[i].Err != nil {
+			return nil
+		}
+		var err error
+		if total, err = total.Add(lines[i].Amount); err != nil {
+			return fmt.Errorf("%w: statement %s: line %d: %v", ErrInvalidStatement, statement, lines[i].Number, err)
+		}
+	}
+
+	expected, err := opening.Add(total)
+	if err != nil {
+		return fmt.Errorf("%w: statement %s: %v", ErrInvalidStatement, statement, err)
+	}
+	if cmp, err := expected.Cmp(closing); err != nil || cmp != 0 {
+		return fmt.Errorf("%w: statement %s: closing balance %s does not match opening balance %s plus transactions %s",
+			ErrInvalidStatement, statement, closing, opening, total)
+	}
+	return nil
+}
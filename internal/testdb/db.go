@@ -1,6 +1,7 @@
 package testdb
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
@@ -8,6 +9,8 @@ import (
 
 	// Импортируем PostgreSQL-драйвер
 	_ "github.com/lib/pq"
+
+	"fincraft-finance/internal/migrations"
 )
 
 // Для конфигурации тестовой базы
@@ -18,14 +21,29 @@ const (
 
 // Имена таблиц
 const (
-	UsersTable   = "users"
-	IncomesTable = "incomes"
+	UsersTable         = "users"
+	IncomesTable       = "incomes"
+	ExpensesTable      = "expenses"
+	ExchangeRatesTable = "exchange_rates"
+	CategoriesTable    = "categories"
+	AccountsTable      = "accounts"
+	TransfersTable     = "transfers"
+	JournalTable       = "journal_entries"
+	BudgetsTable       = "budgets"
+	RecurringTable     = "recurring_rules"
+	IdempotencyTable   = "idempotency_keys"
+	ImportedTable      = "imported_transactions"
+	IncomeSourcesTable = "income_sources"
+	RulesTable         = "rules"
+	TagsTable          = "tags"
+	IncomeTagsTable    = "income_tags"
+	DailyBalancesTable = "daily_balances"
 )
 
 // DB хранит соединение с тестовой базой данных
 var DB *sql.DB
 
-// SetupTestDB инициализирует подключение к тестовой базе
+// SetupTestDB инициализирует подключение к тестовой базе и применяет к ней миграции
 func SetupTestDB() error {
 	dsn := os.Getenv(envDSN)
 	if dsn == "" {
@@ -38,6 +56,16 @@ func SetupTestDB() error {
 		return fmt.Errorf("failed to connect to test db: %w", err)
 	}
 
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		_ = db.Close()
+		return fmt.Errorf("failed to migrate test db: %w", err)
+	}
+
 	DB = db
 	return nil
 }
@@ -65,9 +93,10 @@ func TruncateTables(db *sql.DB, tables ...string) error {
 
 // UserParams содержит параметры для создания тестового пользователя.
 type UserParams struct {
-	ID    int
-	Email string
-	Name  string
+	ID       int
+	Email    string
+	Name     string
+	Timezone string
 }
 
 // SeedUser добавляет тестового пользователя.
@@ -82,10 +111,52 @@ func (p *UserParams) SeedUser(db *sql.DB) error {
 	if p.Name == "" {
 		p.Name = "test tester"
 	}
+	if p.Timezone == "" {
+		p.Timezone = "UTC"
+	}
 
-	_, err := db.Exec(`INSERT INTO users (id, email, name) VALUES ($1, $2, $3)`, p.ID, p.Email, p.Name)
+	_, err := db.Exec(`INSERT INTO users (id, email, name, timezone) VALUES ($1, $2, $3, $4)`,
+		p.ID, p.Email, p.Name, p.Timezone)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// CategoryParams содержит параметры для создания тестовой категории.
+type CategoryParams struct {
+	ID int
+	// UserID владелец категории, 0 - системная категория
+	UserID int64
+	Name   string
+	// Kind значение колонки kind: income или expense
+	Kind string
+}
+
+// SeedCategory добавляет тестовую категорию с заданным ID, перезаписывая существующую с тем же ID
+// (например, из набора категорий по умолчанию).
+// Указание параметров необязательно, по умолчанию создается системная категория доходов с ID 2.
+func (p *CategoryParams) SeedCategory(db *sql.DB) error {
+	if p.ID == 0 {
+		p.ID = 2
+	}
+	if p.Name == "" {
+		p.Name = "test category"
+	}
+	if p.Kind == "" {
+		p.Kind = "income"
+	}
+
+	_, err := db.Exec(`
+		INSERT INTO categories (id, user_id, name, kind) VALUES ($1, NULLIF($2, 0), $3, $4)
+		ON CONFLICT (id) DO UPDATE
+		SET user_id = EXCLUDED.user_id, name = EXCLUDED.name, kind = EXCLUDED.kind, parent_id = NULL, archived = false
+	`, p.ID, p.UserID, p.Name, p.Kind)
+	if err != nil {
+		return err
+	}
+
+	// Сдвигаем последовательность, чтобы категории без явного ID не конфликтовали с тестовыми
+	_, err = db.Exec(`SELECT setval(pg_get_serial_sequence('categories', 'id'), (SELECT MAX(id) FROM categories))`)
+	return err
+}
@@ -0,0 +1,19 @@
+package usecases
+
+import (
+	"context"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=account_repository.go -destination=mocks/account_repository_mock.go -package=mocks
+
+// AccountRepository репозиторий для работы со счетами.
+// Текущий остаток счета - начальный остаток плюс сумма проводок журнала по счету.
+type AccountRepository interface {
+	CreateAccount(ctx context.Context, account *domain.Account) (*domain.Account, error)
+	GetAccount(ctx context.Context, userID, id int64) (*domain.Account, error)
+	ListAccounts(ctx context.Context, filter domain.AccountFilter) ([]domain.Account, error)
+	// UpdateAccount сохраняет название, вид, начальный остаток и момент закрытия
+	UpdateAccount(ctx context.Context, account *domain.Account) (*domain.Account, error)
+}
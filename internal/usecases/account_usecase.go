@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=account_usecase.go -destination=mocks/account_usecase_mock.go -package=mocks
+
+// AccountService контракт сервиса для работы со счетами
+type AccountService interface {
+	CreateAccount(ctx context.Context, account *domain.Account) (*domain.Account, error)
+	ListAccounts(ctx context.Context, filter domain.AccountFilter) ([]domain.Account, error)
+	UpdateAccount(ctx context.Context, userID, id int64, patch domain.AccountPatch) (*domain.Account, error)
+	CloseAccount(ctx context.Context, userID, id int64) (*domain.Account, error)
+}
+
+// AccountUseCase use-case для работы со счетами
+type AccountUseCase struct {
+	repo AccountRepository
+	tx   TxManager
+}
+
+// NewAccountUseCase создает новый экземпляр AccountUseCase
+func NewAccountUseCase(repo AccountRepository, tx TxManager) *AccountUseCase {
+	return &AccountUseCase{repo: repo, tx: tx}
+}
+
+// CreateAccount создает счет; текущий остаток нового счета равен начальному
+func (u *AccountUseCase) CreateAccount(ctx context.Context, account *domain.Account) (*domain.Account, error) {
+	if err := account.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	account.Balance = account.OpeningBalance
+	return u.repo.CreateAccount(ctx, account)
+}
+
+// ListAccounts возвращает счета пользователя с текущими остатками
+func (u *AccountUseCase) ListAccounts(ctx context.Context, filter domain.AccountFilter) ([]domain.Account, error) {
+	if filter.UserID <= 0 {
+		return nil, fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+
+	return u.repo.ListAccounts(ctx, filter)
+}
+
+// UpdateAccount применяет частичное изменение к счету.
+// Текущий остаток выводится из начального остатка и журнала, поэтому смена начального остатка сдвигает и его.
+func (u *AccountUseCase) UpdateAccount(ctx context.Context, userID, id int64, patch domain.AccountPatch) (*domain.Account, error) {
+	if userID <= 0 || id <= 0 {
+		return nil, fmt.Errorf("%w: user ID and account ID must be valid", ErrValidation)
+	}
+
+	var updated *domain.Account
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		account, err := u.repo.GetAccount(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		currency := account.Currency()
+		account.Apply(patch)
+		if err := account.Validate(); err != nil {
+			return fmt.Errorf("%w: %w", ErrValidation, err)
+		}
+		if account.Currency() != currency {
+			return fmt.Errorf("%w: opening balance: %w: account is in %s", ErrValidation, domain.ErrCurrencyMismatch, currency)
+		}
+
+		updated, err = u.repo.UpdateAccount(ctx, account)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// CloseAccount закрывает счет: он остается в истории, но новые операции по нему запрещены
+func (u *AccountUseCase) CloseAccount(ctx context.Context, userID, id int64) (*domain.Account, error) {
+	if userID <= 0 || id <= 0 {
+		return nil, fmt.Errorf("%w: user ID and account ID must be valid", ErrValidation)
+	}
+
+	account, err := u.repo.GetAccount(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if account.IsClosed() {
+		return account, nil
+	}
+
+	account.ClosedAt = time.Now()
+	return u.repo.UpdateAccount(ctx, account)
+}
+
+// findAccount возвращает счет пользователя.
+// Отсутствующий или чужой счет считается ошибкой валидации ссылающегося объекта.
+func findAccount(ctx context.Context, repo AccountRepository, userID, id int64) (*domain.Account, error) {
+	account, err := repo.GetAccount(ctx, userID, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("%w: account %d does not exist", ErrValidation, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// checkPostable проверяет, что на счет пользователя можно провести сумму amount.
+// accountID 0 означает операцию без счета.
+func checkPostable(ctx context.Context, repo AccountRepository, userID, accountID int64, amount domain.Money) error {
+	if accountID == 0 {
+		return nil
+	}
+
+	account, err := findAccount(ctx, repo, userID, accountID)
+	if err != nil {
+		return err
+	}
+	if err := account.CheckCanPost(amount); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupAccountTest(t *testing.T) (*gomock.Controller, *mocks.MockAccountRepository, *usecases.AccountUseCase) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	useCase := usecases.NewAccountUseCase(mockRepo, inlineTx{})
+	return ctrl, mockRepo, useCase
+}
+
+func newAccount(opening int64) *domain.Account {
+	return &domain.Account{
+		ID:             3,
+		UserID:         1,
+		Name:           "Kaspi Gold",
+		Type:           domain.AccountTypeCard,
+		OpeningBalance: domain.NewMoney(opening, kzt),
+		Balance:        domain.NewMoney(opening, kzt),
+	}
+}
+
+func Test_AccountUseCase_CreateAccount_SetsBalanceToOpeningBalance_WhenValidInput(t *testing.T) {
+	ctrl, mockRepo, useCase := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	input := &domain.Account{UserID: 1, Name: "Cash", Type: domain.AccountTypeCash, OpeningBalance: domain.NewMoney(5000, kzt)}
+	mockRepo.EXPECT().CreateAccount(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, account *domain.Account) (*domain.Account, error) {
+			return account, nil
+		})
+
+	got, err := useCase.CreateAccount(ctx, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(5000, kzt), got.Balance)
+}
+
+func Test_AccountUseCase_CreateAccount_ReturnsValidationError_WhenInvalidInput(t *testing.T) {
+	_, _, useCase := setupAccountTest(t)
+
+	tests := []struct {
+		name    string
+		account domain.Account
+		errMsg  string
+	}{
+		{"Zero UserID", domain.Account{Name: "Cash", Type: domain.AccountTypeCash, OpeningBalance: domain.NewMoney(0, kzt)},
+			"validation failed: user ID must be valid"},
+		{"Empty Name", domain.Account{UserID: 1, Type: domain.AccountTypeCash, OpeningBalance: domain.NewMoney(0, kzt)},
+			"validation failed: account name must not be empty"},
+		{"Unknown Type", domain.Account{UserID: 1, Name: "Cash", OpeningBalance: domain.NewMoney(0, kzt)},
+			"validation failed: account type must be valid"},
+		{"Missing Currency", domain.Account{UserID: 1, Name: "Cash", Type: domain.AccountTypeCash},
+			"validation failed: currency must be valid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.CreateAccount(context.Background(), &tt.account)
+
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
+
+func Test_AccountUseCase_UpdateAccount_SavesOpeningBalance_WhenOpeningBalanceChanged(t *testing.T) {
+	ctrl, mockRepo, useCase := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(newAccount(1000), nil)
+	stored := newAccount(1500)
+	mockRepo.EXPECT().UpdateAccount(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, account *domain.Account) (*domain.Account, error) {
+			assert.Equal(t, domain.NewMoney(1500, kzt), account.OpeningBalance)
+			return stored, nil
+		})
+
+	opening := domain.NewMoney(1500, kzt)
+	got, err := useCase.UpdateAccount(ctx, 1, 3, domain.AccountPatch{OpeningBalance: &opening})
+
+	require.NoError(t, err)
+	assert.Equal(t, stored, got)
+}
+
+func Test_AccountUseCase_UpdateAccount_ReturnsValidationError_WhenCurrencyChanged(t *testing.T) {
+	ctrl, mockRepo, useCase := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(newAccount(1000), nil)
+
+	opening := domain.NewMoney(1000, domain.MustCurrency("USD"))
+	_, err := useCase.UpdateAccount(ctx, 1, 3, domain.AccountPatch{OpeningBalance: &opening})
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+	assert.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+}
+
+func Test_AccountUseCase_UpdateAccount_KeepsBalance_WhenOnlyNameChanged(t *testing.T) {
+	ctrl, mockRepo, useCase := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(newAccount(1000), nil)
+	expected := newAccount(1000)
+	expected.Name = "Wallet"
+	mockRepo.EXPECT().UpdateAccount(ctx, expected).Return(expected, nil)
+
+	name := "Wallet"
+	got, err := useCase.UpdateAccount(ctx, 1, 3, domain.AccountPatch{Name: &name})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Wallet", got.Name)
+}
+
+func Test_AccountUseCase_CloseAccount_SetsClosedAt_WhenAccountOpen(t *testing.T) {
+	ctrl, mockRepo, useCase := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(newAccount(0), nil)
+	mockRepo.EXPECT().UpdateAccount(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, account *domain.Account) (*domain.Account, error) {
+			return account, nil
+		})
+
+	got, err := useCase.CloseAccount(ctx, 1, 3)
+
+	require.NoError(t, err)
+	assert.True(t, got.IsClosed())
+	assert.WithinDuration(t, time.Now(), got.ClosedAt, time.Minute)
+}
+
+func Test_AccountUseCase_CloseAccount_ReturnsNotFound_WhenAccountMissing(t *testing.T) {
+	ctrl, mockRepo, useCase := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(nil, domain.ErrNotFound)
+
+	_, err := useCase.CloseAccount(ctx, 1, 3)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
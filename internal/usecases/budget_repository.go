@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=budget_repository.go -destination=mocks/budget_repository_mock.go -package=mocks
+
+// BudgetRepository репозиторий для работы с бюджетами
+type BudgetRepository interface {
+	CreateBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error)
+	GetBudget(ctx context.Context, userID, id int64) (*domain.Budget, error)
+	ListBudgets(ctx context.Context, userID int64) ([]domain.Budget, error)
+	// UpdateBudget сохраняет лимит, перенос остатка и даты бюджета
+	UpdateBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error)
+	DeleteBudget(ctx context.Context, userID, id int64) error
+	// SumByCategory возвращает суммы операций категории и ее подкатегорий за [from, to), по одной на валюту.
+	// Вид категории определяет, суммируются доходы или расходы.
+	SumByCategory(ctx context.Context, userID int64, category *domain.Category, from, to time.Time) ([]domain.Money, error)
+}
+
+// MoneyConverter конвертирует суммы между валютами по курсу на дату
+type MoneyConverter interface {
+	Convert(ctx context.Context, m domain.Money, to domain.Currency, date time.Time) (domain.Money, error)
+}
@@ -0,0 +1,224 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=budget_usecase.go -destination=mocks/budget_usecase_mock.go -package=mocks
+
+// BudgetService контракт сервиса для работы с бюджетами
+type BudgetService interface {
+	CreateBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error)
+	GetBudget(ctx context.Context, userID, id int64) (*domain.Budget, error)
+	ListBudgets(ctx context.Context, userID int64) ([]domain.Budget, error)
+	UpdateBudget(ctx context.Context, userID, id int64, patch domain.BudgetPatch) (*domain.Budget, error)
+	DeleteBudget(ctx context.Context, userID, id int64) error
+	GetBudgetStatus(ctx context.Context, userID, id int64, date domain.Date) (*domain.BudgetStatus, error)
+}
+
+// BudgetDeps зависимости BudgetUseCase
+type BudgetDeps struct {
+	Budgets    BudgetRepository
+	Categories CategoryRepository
+	Users      UserRepository
+	Converter  MoneyConverter
+	Tx         TxManager
+}
+
+// BudgetUseCase use-case для работы с бюджетами
+type BudgetUseCase struct {
+	repo       BudgetRepository
+	categories CategoryRepository
+	users      UserRepository
+	converter  MoneyConverter
+	tx         TxManager
+}
+
+// NewBudgetUseCase создает новый экземпляр BudgetUseCase
+func NewBudgetUseCase(deps BudgetDeps) *BudgetUseCase {
+	return &BudgetUseCase{
+		repo:       deps.Budgets,
+		categories: deps.Categories,
+		users:      deps.Users,
+		converter:  deps.Converter,
+		tx:         deps.Tx,
+	}
+}
+
+// CreateBudget создает бюджет по категории доходов или расходов.
+// Без даты начала бюджет действует с сегодняшнего дня в часовом поясе пользователя.
+func (u *BudgetUseCase) CreateBudget(ctx context.Context, budget *domain.Budget) (*domain.Budget, error) {
+	if budget.StartDate.IsZero() && budget.UserID > 0 {
+		loc, err := u.users.GetTimezone(ctx, budget.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user timezone: %w", err)
+		}
+		budget.StartDate = domain.DateOf(time.Now().In(loc))
+	}
+
+	if err := budget.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	if _, err := findCategory(ctx, u.categories, budget.UserID, budget.CategoryID); err != nil {
+		return nil, err
+	}
+
+	return u.repo.CreateBudget(ctx, budget)
+}
+
+// GetBudget возвращает бюджет пользователя
+func (u *BudgetUseCase) GetBudget(ctx context.Context, userID, id int64) (*domain.Budget, error) {
+	if userID <= 0 || id <= 0 {
+		return nil, fmt.Errorf("%w: user ID and budget ID must be valid", ErrValidation)
+	}
+
+	return u.repo.GetBudget(ctx, userID, id)
+}
+
+// ListBudgets возвращает все бюджеты пользователя
+func (u *BudgetUseCase) ListBudgets(ctx context.Context, userID int64) ([]domain.Budget, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+
+	return u.repo.ListBudgets(ctx, userID)
+}
+
+// UpdateBudget применяет частичное изменение к бюджету; категория и период не меняются
+func (u *BudgetUseCase) UpdateBudget(ctx context.Context, userID, id int64, patch domain.BudgetPatch) (*domain.Budget, error) {
+	if userID <= 0 || id <= 0 {
+		return nil, fmt.Errorf("%w: user ID and budget ID must be valid", ErrValidation)
+	}
+
+	var updated *domain.Budget
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		budget, err := u.repo.GetBudget(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		budget.Apply(patch)
+		if err := budget.Validate(); err != nil {
+			return fmt.Errorf("%w: %w", ErrValidation, err)
+		}
+
+		updated, err = u.repo.UpdateBudget(ctx, budget)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteBudget удаляет бюджет пользователя
+func (u *BudgetUseCase) DeleteBudget(ctx context.Context, userID, id int64) error {
+	if userID <= 0 || id <= 0 {
+		return fmt.Errorf("%w: user ID and budget ID must be valid", ErrValidation)
+	}
+
+	return u.repo.DeleteBudget(ctx, userID, id)
+}
+
+// GetBudgetStatus возвращает положение по бюджету в периоде, содержащем дату date.
+// Нулевая дата означает сегодняшний день в часовом поясе пользователя.
+// Операции в других валютах пересчитываются в валюту лимита по курсу на конец периода
+// или на сегодня, если период еще не закончился.
+func (u *BudgetUseCase) GetBudgetStatus(ctx context.Context, userID, id int64, date domain.Date) (*domain.BudgetStatus, error) {
+	budget, err := u.GetBudget(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	category, err := u.categories.GetCategory(ctx, userID, budget.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := u.users.GetTimezone(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user timezone: %w", err)
+	}
+
+	today := domain.DateOf(time.Now().In(loc))
+	if date.IsZero() {
+		date = today
+	}
+	if date.Before(budget.StartDate) {
+		return nil, fmt.Errorf("%w: budget starts on %s", ErrValidation, budget.StartDate)
+	}
+
+	from, to := budget.PeriodOn(date)
+	spent, err := u.spent(ctx, budget, category, from, to, today, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	carried := domain.NewMoney(0, budget.Limit.Currency())
+	if budget.Rollover {
+		if carried, err = u.carriedOver(ctx, budget, category, from, today, loc); err != nil {
+			return nil, err
+		}
+	}
+
+	status, err := domain.NewBudgetStatus(*budget, from, to, carried, spent, today)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// carriedOver возвращает неизрасходованный остаток предыдущего периода, но не больше его лимита.
+// Переносится только один период назад, перерасход на текущий период не переносится.
+func (u *BudgetUseCase) carriedOver(ctx context.Context, budget *domain.Budget, category *domain.Category,
+	from, today domain.Date, loc *time.Location) (domain.Money, error) {
+	none := domain.NewMoney(0, budget.Limit.Currency())
+
+	prevFrom, prevTo, ok := budget.PreviousPeriod(from)
+	if !ok {
+		return none, nil
+	}
+	spent, err := u.spent(ctx, budget, category, prevFrom, prevTo, today, loc)
+	if err != nil {
+		return none, err
+	}
+
+	left, err := budget.Limit.Sub(spent)
+	if err != nil || !left.IsPositive() {
+		return none, err
+	}
+
+	return left, nil
+}
+
+// spent возвращает сумму операций по бюджету за [from, to) в валюте лимита
+func (u *BudgetUseCase) spent(ctx context.Context, budget *domain.Budget, category *domain.Category,
+	from, to, today domain.Date, loc *time.Location) (domain.Money, error) {
+	currency := budget.Limit.Currency()
+	total := domain.NewMoney(0, currency)
+
+	sums, err := u.repo.SumByCategory(ctx, budget.UserID, category, from.Start(loc), to.Start(loc))
+	if err != nil {
+		return total, err
+	}
+
+	rateDate := to.AddDays(-1)
+	if today.Before(rateDate) {
+		rateDate = today
+	}
+	for _, sum := range sums {
+		converted, err := u.converter.Convert(ctx, sum, currency, rateDate.Start(time.UTC))
+		if err != nil {
+			return total, fmt.Errorf("failed to convert %s spending to %s: %w", sum.Currency(), currency, err)
+		}
+		if total, err = total.Add(converted); err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
@@ -0,0 +1,167 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+type budgetMocks struct {
+	budgets    *mocks.MockBudgetRepository
+	categories *mocks.MockCategoryRepository
+	users      *mocks.MockUserRepository
+	converter  *mocks.MockMoneyConverter
+}
+
+func setupBudgetTest(t *testing.T) (*gomock.Controller, budgetMocks, *usecases.BudgetUseCase) {
+	ctrl := gomock.NewController(t)
+	m := budgetMocks{
+		budgets:    mocks.NewMockBudgetRepository(ctrl),
+		categories: mocks.NewMockCategoryRepository(ctrl),
+		users:      mocks.NewMockUserRepository(ctrl),
+		converter:  mocks.NewMockMoneyConverter(ctrl),
+	}
+	useCase := usecases.NewBudgetUseCase(usecases.BudgetDeps{
+		Budgets:    m.budgets,
+		Categories: m.categories,
+		Users:      m.users,
+		Converter:  m.converter,
+		Tx:         inlineTx{},
+	})
+	return ctrl, m, useCase
+}
+
+// groceries категория расходов, на которую заведены бюджеты в тестах
+var groceries = &domain.Category{ID: 6, Name: "Groceries", Kind: domain.CategoryKindExpense}
+
+func newBudget(period domain.BudgetPeriod, rollover bool) *domain.Budget {
+	return &domain.Budget{
+		ID:         5,
+		UserID:     1,
+		CategoryID: groceries.ID,
+		Period:     period,
+		Limit:      domain.NewMoney(100000, kzt),
+		Rollover:   rollover,
+		StartDate:  domain.Date{Year: 2024, Month: time.January, Day: 1},
+	}
+}
+
+func Test_BudgetUseCase_CreateBudget_ReturnsValidationError_WhenCategoryMissing(t *testing.T) {
+	ctrl, m, useCase := setupBudgetTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	budget := newBudget(domain.BudgetPeriodMonthly, false)
+	m.categories.EXPECT().GetCategory(ctx, int64(1), groceries.ID).Return(nil, domain.ErrNotFound)
+
+	_, err := useCase.CreateBudget(ctx, budget)
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+}
+
+func Test_BudgetUseCase_CreateBudget_StartsToday_WhenStartDateMissing(t *testing.T) {
+	ctrl, m, useCase := setupBudgetTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	budget := newBudget(domain.BudgetPeriodWeekly, false)
+	budget.StartDate = domain.Date{}
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	m.categories.EXPECT().GetCategory(ctx, int64(1), groceries.ID).Return(groceries, nil)
+	m.budgets.EXPECT().CreateBudget(ctx, budget).Return(budget, nil)
+
+	got, err := useCase.CreateBudget(ctx, budget)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.DateOf(time.Now().UTC()), got.StartDate)
+}
+
+func Test_BudgetUseCase_GetBudgetStatus_ConvertsAndCarriesOver_WhenRolloverEnabled(t *testing.T) {
+	ctrl, m, useCase := setupBudgetTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	budget := newBudget(domain.BudgetPeriodMonthly, true)
+	march := domain.Date{Year: 2024, Month: time.March, Day: 1}
+	april := domain.Date{Year: 2024, Month: time.April, Day: 1}
+	may := domain.Date{Year: 2024, Month: time.May, Day: 1}
+	m.budgets.EXPECT().GetBudget(ctx, int64(1), int64(5)).Return(budget, nil)
+	m.categories.EXPECT().GetCategory(ctx, int64(1), groceries.ID).Return(groceries, nil)
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+
+	// Апрель: 20000 KZT и 100 USD (47000 KZT по курсу на 30 апреля)
+	usdSpent := domain.NewMoney(10000, usd)
+	m.budgets.EXPECT().SumByCategory(ctx, int64(1), groceries, april.Start(time.UTC), may.Start(time.UTC)).
+		Return([]domain.Money{domain.NewMoney(20000, kzt), usdSpent}, nil)
+	m.converter.EXPECT().Convert(ctx, domain.NewMoney(20000, kzt), kzt, gomock.Any()).Return(domain.NewMoney(20000, kzt), nil)
+	m.converter.EXPECT().Convert(ctx, usdSpent, kzt, april.AddDays(29).Start(time.UTC)).Return(domain.NewMoney(47000, kzt), nil)
+	// Март: израсходовано 70000 из 100000, остаток 30000 переносится
+	m.budgets.EXPECT().SumByCategory(ctx, int64(1), groceries, march.Start(time.UTC), april.Start(time.UTC)).
+		Return([]domain.Money{domain.NewMoney(70000, kzt)}, nil)
+	m.converter.EXPECT().Convert(ctx, domain.NewMoney(70000, kzt), kzt, gomock.Any()).Return(domain.NewMoney(70000, kzt), nil)
+
+	got, err := useCase.GetBudgetStatus(ctx, 1, 5, domain.Date{Year: 2024, Month: time.April, Day: 15})
+
+	require.NoError(t, err)
+	assert.Equal(t, april, got.PeriodStart)
+	assert.Equal(t, domain.NewMoney(30000, kzt), got.CarriedOver)
+	assert.Equal(t, domain.NewMoney(130000, kzt), got.Limit)
+	assert.Equal(t, domain.NewMoney(67000, kzt), got.Spent)
+	assert.Equal(t, domain.NewMoney(63000, kzt), got.Remaining)
+}
+
+func Test_BudgetUseCase_GetBudgetStatus_ReturnsError_WhenRateMissing(t *testing.T) {
+	ctrl, m, useCase := setupBudgetTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	budget := newBudget(domain.BudgetPeriodMonthly, false)
+	m.budgets.EXPECT().GetBudget(ctx, int64(1), int64(5)).Return(budget, nil)
+	m.categories.EXPECT().GetCategory(ctx, int64(1), groceries.ID).Return(groceries, nil)
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	m.budgets.EXPECT().SumByCategory(ctx, int64(1), groceries, gomock.Any(), gomock.Any()).
+		Return([]domain.Money{domain.NewMoney(10000, usd)}, nil)
+	m.converter.EXPECT().Convert(ctx, gomock.Any(), kzt, gomock.Any()).Return(domain.Money{}, errors.New("exchange rate not found"))
+
+	_, err := useCase.GetBudgetStatus(ctx, 1, 5, domain.Date{Year: 2024, Month: time.April, Day: 15})
+
+	assert.EqualError(t, err, "failed to convert USD spending to KZT: exchange rate not found")
+}
+
+func Test_BudgetUseCase_GetBudgetStatus_ReturnsValidationError_WhenDateBeforeStart(t *testing.T) {
+	ctrl, m, useCase := setupBudgetTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.budgets.EXPECT().GetBudget(ctx, int64(1), int64(5)).Return(newBudget(domain.BudgetPeriodMonthly, false), nil)
+	m.categories.EXPECT().GetCategory(ctx, int64(1), groceries.ID).Return(groceries, nil)
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+
+	_, err := useCase.GetBudgetStatus(ctx, 1, 5, domain.Date{Year: 2023, Month: time.December, Day: 31})
+
+	assert.EqualError(t, err, "validation failed: budget starts on 2024-01-01")
+}
+
+func Test_BudgetUseCase_UpdateBudget_ReturnsValidationError_WhenRolloverOnCustom(t *testing.T) {
+	ctrl, m, useCase := setupBudgetTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	budget := newBudget(domain.BudgetPeriodCustom, false)
+	budget.EndDate = budget.StartDate.AddDays(10)
+	m.budgets.EXPECT().GetBudget(ctx, int64(1), int64(5)).Return(budget, nil)
+	rollover := true
+
+	_, err := useCase.UpdateBudget(ctx, 1, 5, domain.BudgetPatch{Rollover: &rollover})
+
+	assert.EqualError(t, err, "validation failed: rollover requires a recurring period")
+}
@@ -0,0 +1,21 @@
+package usecases
+
+import (
+	"context"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=category_repository.go -destination=mocks/category_repository_mock.go -package=mocks
+
+// CategoryRepository репозиторий для работы с категориями
+type CategoryRepository interface {
+	CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
+	// GetCategory возвращает категорию пользователя или системную категорию
+	GetCategory(ctx context.Context, userID int64, id int) (*domain.Category, error)
+	ListCategories(ctx context.Context, filter domain.CategoryFilter) ([]domain.Category, error)
+	// UpdateCategory сохраняет название, иконку и признак архива категории пользователя
+	UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
+	// MergeCategories переносит операции и подкатегории source в target и удаляет source
+	MergeCategories(ctx context.Context, userID int64, sourceID, targetID int) error
+}
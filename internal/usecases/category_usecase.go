@@ -0,0 +1,196 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=category_usecase.go -destination=mocks/category_usecase_mock.go -package=mocks
+
+// CategoryService контракт сервиса для работы с категориями
+type CategoryService interface {
+	CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
+	ListCategories(ctx context.Context, filter domain.CategoryFilter) ([]domain.Category, error)
+	RenameCategory(ctx context.Context, userID int64, id int, name string) (*domain.Category, error)
+	ArchiveCategory(ctx context.Context, userID int64, id int) (*domain.Category, error)
+	MergeCategories(ctx context.Context, userID int64, sourceID, targetID int) (*domain.Category, error)
+}
+
+// CategoryUseCase use-case для работы с категориями
+type CategoryUseCase struct {
+	repo CategoryRepository
+}
+
+// NewCategoryUseCase создает новый экземпляр CategoryUseCase
+func NewCategoryUseCase(repo CategoryRepository) *CategoryUseCase {
+	return &CategoryUseCase{repo: repo}
+}
+
+// CreateCategory создает категорию пользователя.
+// Родительская категория должна быть доступна пользователю, иметь тот же тип и быть категорией верхнего уровня.
+func (u *CategoryUseCase) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	if category.UserID <= 0 {
+		return nil, fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+	if err := category.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	if category.ParentID != 0 {
+		parent, err := findCategory(ctx, u.repo, category.UserID, category.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if err := parent.CheckAssignable(category.Kind); err != nil {
+			return nil, fmt.Errorf("%w: parent %w", ErrValidation, err)
+		}
+		if parent.ParentID != 0 {
+			return nil, fmt.Errorf("%w: parent category %d is itself a subcategory", ErrValidation, parent.ID)
+		}
+	}
+
+	return u.repo.CreateCategory(ctx, category)
+}
+
+// ListCategories возвращает системные категории и категории пользователя
+func (u *CategoryUseCase) ListCategories(ctx context.Context, filter domain.CategoryFilter) ([]domain.Category, error) {
+	if filter.UserID <= 0 {
+		return nil, fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+	if filter.Kind != 0 && !filter.Kind.Valid() {
+		return nil, fmt.Errorf("%w: unknown category kind %d", ErrValidation, filter.Kind)
+	}
+
+	return u.repo.ListCategories(ctx, filter)
+}
+
+// RenameCategory меняет название категории пользователя
+func (u *CategoryUseCase) RenameCategory(ctx context.Context, userID int64, id int, name string) (*domain.Category, error) {
+	if err := domain.ValidateCategoryName(name); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	category, err := u.ownCategory(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	category.Name = name
+	return u.repo.UpdateCategory(ctx, category)
+}
+
+// ArchiveCategory переводит категорию пользователя в архив.
+// Существующие операции сохраняют категорию, новые с ней создать нельзя.
+func (u *CategoryUseCase) ArchiveCategory(ctx context.Context, userID int64, id int) (*domain.Category, error) {
+	category, err := u.ownCategory(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if category.Archived {
+		return category, nil
+	}
+
+	category.Archived = true
+	return u.repo.UpdateCategory(ctx, category)
+}
+
+// MergeCategories объединяет категорию пользователя sourceID с категорией targetID и возвращает целевую категорию.
+// Операции и подкатегории source переходят в target, сама source удаляется.
+func (u *CategoryUseCase) MergeCategories(ctx context.Context, userID int64, sourceID, targetID int) (*domain.Category, error) {
+	if sourceID == targetID {
+		return nil, fmt.Errorf("%w: cannot merge category %d into itself", ErrValidation, sourceID)
+	}
+
+	source, err := u.ownCategory(ctx, userID, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := findCategory(ctx, u.repo, userID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if err := target.CheckAssignable(source.Kind); err != nil {
+		return nil, fmt.Errorf("%w: target %w", ErrValidation, err)
+	}
+	if target.ParentID == source.ID {
+		return nil, fmt.Errorf("%w: cannot merge category %d into its own subcategory", ErrValidation, sourceID)
+	}
+
+	if target.ParentID != 0 {
+		hasChildren, err := u.hasSubcategories(ctx, userID, source.ID)
+		if err != nil {
+			return nil, err
+		}
+		if hasChildren {
+			return nil, fmt.Errorf("%w: category %d has subcategories and can only be merged into a top-level category",
+				ErrValidation, sourceID)
+		}
+	}
+
+	if err := u.repo.MergeCategories(ctx, userID, source.ID, target.ID); err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// ownCategory возвращает категорию, которую пользователь может изменять
+func (u *CategoryUseCase) ownCategory(ctx context.Context, userID int64, id int) (*domain.Category, error) {
+	if userID <= 0 || id <= 0 {
+		return nil, fmt.Errorf("%w: user ID and category ID must be valid", ErrValidation)
+	}
+
+	category, err := u.repo.GetCategory(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if category.IsSystem() {
+		return nil, fmt.Errorf("%w: system category %d cannot be modified", ErrValidation, id)
+	}
+
+	return category, nil
+}
+
+// hasSubcategories сообщает, есть ли у категории подкатегории, включая архивные
+func (u *CategoryUseCase) hasSubcategories(ctx context.Context, userID int64, id int) (bool, error) {
+	all, err := u.repo.ListCategories(ctx, domain.CategoryFilter{UserID: userID, IncludeArchived: true})
+	if err != nil {
+		return false, err
+	}
+
+	for _, c := range all {
+		if c.ParentID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// findCategory возвращает категорию, доступную пользователю.
+// Отсутствующая или чужая категория считается ошибкой валидации ссылающегося объекта.
+func findCategory(ctx context.Context, repo CategoryRepository, userID int64, id int) (*domain.Category, error) {
+	category, err := repo.GetCategory(ctx, userID, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("%w: category %d does not exist", ErrValidation, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// checkCategory проверяет, что категорию можно указать у новой операции пользователя типа kind
+func checkCategory(ctx context.Context, repo CategoryRepository, userID int64, id int, kind domain.CategoryKind) error {
+	category, err := findCategory(ctx, repo, userID, id)
+	if err != nil {
+		return err
+	}
+	if err := category.CheckAssignable(kind); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupCategoryTest(t *testing.T) (*gomock.Controller, *mocks.MockCategoryRepository, *usecases.CategoryUseCase) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockCategoryRepository(ctrl)
+	useCase := usecases.NewCategoryUseCase(mockRepo)
+	return ctrl, mockRepo, useCase
+}
+
+func userCategory(id int, kind domain.CategoryKind) *domain.Category {
+	return &domain.Category{ID: id, UserID: 1, Name: "Category", Kind: kind}
+}
+
+func Test_CategoryUseCase_CreateCategory_ReturnsCreatedCategory_WhenParentValid(t *testing.T) {
+	ctrl, mockRepo, useCase := setupCategoryTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	input := &domain.Category{UserID: 1, Name: "Bonus", Kind: domain.CategoryKindIncome, ParentID: 1}
+	mockRepo.EXPECT().GetCategory(ctx, int64(1), 1).
+		Return(&domain.Category{ID: 1, Name: "Salary", Kind: domain.CategoryKindIncome}, nil)
+	created := *input
+	created.ID = 20
+	mockRepo.EXPECT().CreateCategory(ctx, input).Return(&created, nil)
+
+	got, err := useCase.CreateCategory(ctx, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, 20, got.ID)
+}
+
+func Test_CategoryUseCase_CreateCategory_ReturnsValidationError_WhenInvalidInput(t *testing.T) {
+	_, _, useCase := setupCategoryTest(t)
+
+	tests := []struct {
+		name     string
+		category domain.Category
+		errMsg   string
+	}{
+		{"Zero UserID", domain.Category{Name: "Bonus", Kind: domain.CategoryKindIncome},
+			"validation failed: user ID must be valid"},
+		{"Blank Name", domain.Category{UserID: 1, Name: "  ", Kind: domain.CategoryKindIncome},
+			"validation failed: category name must not be empty"},
+		{"Unknown Kind", domain.Category{UserID: 1, Name: "Bonus"},
+			"validation failed: category kind must be income or expense"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.CreateCategory(context.Background(), &tt.category)
+
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
+
+func Test_CategoryUseCase_CreateCategory_ReturnsValidationError_WhenParentUnsuitable(t *testing.T) {
+	tests := []struct {
+		name   string
+		parent *domain.Category
+		errMsg string
+	}{
+		{"Other Kind", &domain.Category{ID: 5, Kind: domain.CategoryKindExpense},
+			"validation failed: parent category 5 is not an income category"},
+		{"Subcategory", &domain.Category{ID: 5, Kind: domain.CategoryKindIncome, ParentID: 1},
+			"validation failed: parent category 5 is itself a subcategory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, mockRepo, useCase := setupCategoryTest(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockRepo.EXPECT().GetCategory(ctx, int64(1), 5).Return(tt.parent, nil)
+
+			_, err := useCase.CreateCategory(ctx,
+				&domain.Category{UserID: 1, Name: "Bonus", Kind: domain.CategoryKindIncome, ParentID: 5})
+
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
+
+func Test_CategoryUseCase_CreateCategory_ReturnsValidationError_WhenParentMissing(t *testing.T) {
+	ctrl, mockRepo, useCase := setupCategoryTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetCategory(ctx, int64(1), 5).Return(nil, domain.ErrNotFound)
+
+	_, err := useCase.CreateCategory(ctx, &domain.Category{UserID: 1, Name: "Bonus", Kind: domain.CategoryKindIncome, ParentID: 5})
+
+	assert.EqualError(t, err, "validation failed: category 5 does not exist")
+}
+
+func Test_CategoryUseCase_ListCategories_ReturnsValidationError_WhenKindUnknown(t *testing.T) {
+	_, _, useCase := setupCategoryTest(t)
+
+	_, err := useCase.ListCategories(context.Background(), domain.CategoryFilter{UserID: 1, Kind: 7})
+
+	assert.EqualError(t, err, "validation failed: unknown category kind 7")
+}
+
+func Test_CategoryUseCase_RenameCategory_UpdatesName_WhenCategoryOwned(t *testing.T) {
+	ctrl, mockRepo, useCase := setupCategoryTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetCategory(ctx, int64(1), 10).Return(userCategory(10, domain.CategoryKindExpense), nil)
+	expected := userCategory(10, domain.CategoryKindExpense)
+	expected.Name = "Food"
+	mockRepo.EXPECT().UpdateCategory(ctx, expected).Return(expected, nil)
+
+	got, err := useCase.RenameCategory(ctx, 1, 10, "Food")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Food", got.Name)
+}
+
+func Test_CategoryUseCase_RenameCategory_ReturnsValidationError_WhenCategorySystem(t *testing.T) {
+	ctrl, mockRepo, useCase := setupCategoryTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetCategory(ctx, int64(1), 1).
+		Return(&domain.Category{ID: 1, Name: "Salary", Kind: domain.CategoryKindIncome}, nil)
+
+	_, err := useCase.RenameCategory(ctx, 1, 1, "Wages")
+
+	assert.EqualError(t, err, "validation failed: system category 1 cannot be modified")
+}
+
+func Test_CategoryUseCase_RenameCategory_ReturnsNotFound_WhenCategoryMissing(t *testing.T) {
+	ctrl, mockRepo, useCase := setupCategoryTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetCategory(ctx, int64(1), 10).Return(nil, domain.ErrNotFound)
+
+	_, err := useCase.RenameCategory(ctx, 1, 10, "Food")
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func Test_CategoryUseCase_ArchiveCategory_SetsArchived_WhenCategoryActive(t *testing.T) {
+	ctrl, mockRepo, useCase := setupCategoryTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetCategory(ctx, int64(1), 10).Return(userCategory(10, domain.CategoryKindExpense), nil)
+	expected := userCategory(10, domain.CategoryKindExpense)
+	expected.Archived = true
+	mockRepo.EXPECT().UpdateCategory(ctx, expected).Return(expected, nil)
+
+	got, err := useCase.ArchiveCategory(ctx, 1, 10)
+
+	require.NoError(t, err)
+	assert.True(t, got.Archived)
+}
+
+func Test_CategoryUseCase_MergeCategories_ReturnsTarget_WhenCategoriesCompatible(t *testing.T) {
+	ctrl, mockRepo, useCase := setupCategoryTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	target := userCategory(11, domain.CategoryKindExpense)
+	mockRepo.EXPECT().GetCategory(ctx, int64(1), 10).Return(userCategory(10, domain.CategoryKindExpense), nil)
+	mockRepo.EXPECT().GetCategory(ctx, int64(1), 11).Return(target, nil)
+	mockRepo.EXPECT().MergeCategories(ctx, int64(1), 10, 11).Return(nil)
+
+	got, err := useCase.MergeCategories(ctx, 1, 10, 11)
+
+	require.NoError(t, err)
+	assert.Equal(t, target, got)
+}
+
+func Test_CategoryUseCase_MergeCategories_ReturnsValidationError_WhenMergeBreaksHierarchy(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   *domain.Category
+		children []domain.Category
+		errMsg   string
+	}{
+		{"Other Kind", userCategory(11, domain.CategoryKindIncome), nil,
+			"validation failed: target category 11 is not an expense category"},
+		{"Own Subcategory", &domain.Category{ID: 11, UserID: 1, Kind: domain.CategoryKindExpense, ParentID: 10}, nil,
+			"validation failed: cannot merge category 10 into its own subcategory"},
+		{"Children Into Subcategory", &domain.Category{ID: 11, UserID: 1, Kind: domain.CategoryKindExpense, ParentID: 3},
+			[]domain.Category{{ID: 12, UserID: 1, Kind: domain.CategoryKindExpense, ParentID: 10}},
+			"validation failed: category 10 has subcategories and can only be merged into a top-level category"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, mockRepo, useCase := setupCategoryTest(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockRepo.EXPECT().GetCategory(ctx, int64(1), 10).Return(userCategory(10, domain.CategoryKindExpense), nil)
+			mockRepo.EXPECT().GetCategory(ctx, int64(1), 11).Return(tt.target, nil)
+			mockRepo.EXPECT().ListCategories(ctx, gomock.Any()).Return(tt.children, nil).AnyTimes()
+
+			_, err := useCase.MergeCategories(ctx, 1, 10, 11)
+
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
+
+func Test_CategoryUseCase_MergeCategories_ReturnsValidationError_WhenSameCategory(t *testing.T) {
+	_, _, useCase := setupCategoryTest(t)
+
+	_, err := useCase.MergeCategories(context.Background(), 1, 10, 10)
+
+	assert.EqualError(t, err, "validation failed: cannot merge category 10 into itself")
+}
@@ -0,0 +1,21 @@
+package usecases
+
+import (
+	"errors"
+
+	"fincraft-finance/internal/domain"
+)
+
+var (
+	// ErrValidation оборачивает нарушения бизнес-правил во входных данных
+	ErrValidation = errors.New("validation failed")
+	// ErrIdempotencyKeyReused ключ идемпотентности повторно использован с другим содержимым запроса
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different request")
+	// ErrIdempotencyInProgress запрос с этим ключом идемпотентности еще выполняется
+	ErrIdempotencyInProgress = errors.New("request with this idempotency key is still in progress")
+)
+
+// isRejection сообщает, что ошибка вызвана содержимым запроса, а не сбоем хранилища
+func isRejection(err error) bool {
+	return errors.Is(err, ErrValidation) || errors.Is(err, domain.ErrNotFound)
+}
@@ -0,0 +1,21 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=expense_repository.go -destination=mocks/expense_repository_mock.go -package=mocks
+
+// ExpenseRepository репозиторий для работы с расходами
+type ExpenseRepository interface {
+	AddExpense(ctx context.Context, expense *domain.Expense) (*domain.Expense, error)
+	GetExpense(ctx context.Context, userID, id int64) (*domain.Expense, error)
+	ListExpenses(ctx context.Context, filter domain.ExpenseFilter) ([]domain.Expense, error)
+	UpdateExpense(ctx context.Context, expense *domain.Expense) (*domain.Expense, error)
+	DeleteExpense(ctx context.Context, userID, id int64) error
+	// ListExpensesInRange возвращает все расходы пользователя с датой в полуинтервале [from, to)
+	ListExpensesInRange(ctx context.Context, userID int64, from, to time.Time) ([]domain.Expense, error)
+}
@@ -0,0 +1,185 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=expense_usecase.go -destination=mocks/expense_usecase_mock.go -package=mocks
+
+// ExpenseService контракт сервиса для работы с расходами
+type ExpenseService interface {
+	AddExpense(ctx context.Context, expense *domain.Expense) (*domain.Expense, error)
+	GetExpense(ctx context.Context, userID, id int64) (*domain.Expense, error)
+	ListExpenses(ctx context.Context, filter domain.ExpenseFilter) (domain.ExpensePage, error)
+	UpdateExpense(ctx context.Context, expense *domain.Expense) (*domain.Expense, error)
+	DeleteExpense(ctx context.Context, userID, id int64) error
+}
+
+// ExpenseDeps хранилища, которые использует ExpenseUseCase
+type ExpenseDeps struct {
+	Expenses   ExpenseRepository
+	Categories CategoryRepository
+	Accounts   AccountRepository
+	Ledger     LedgerRepository
+	Tx         TxManager
+}
+
+// ExpenseUseCase use-case для работы с расходами
+type ExpenseUseCase struct {
+	repo            ExpenseRepository
+	categories      CategoryRepository
+	accounts        AccountRepository
+	ledger          LedgerRepository
+	tx              TxManager
+	futureTolerance time.Duration
+}
+
+// NewExpenseUseCase создает новый экземпляр ExpenseUseCase.
+// futureTolerance задает, насколько дата расхода может опережать текущее время.
+func NewExpenseUseCase(deps ExpenseDeps, futureTolerance time.Duration) *ExpenseUseCase {
+	return &ExpenseUseCase{
+		repo:            deps.Expenses,
+		categories:      deps.Categories,
+		accounts:        deps.Accounts,
+		ledger:          deps.Ledger,
+		tx:              deps.Tx,
+		futureTolerance: futureTolerance,
+	}
+}
+
+// AddExpense добавляет новый расход и возвращает его с присвоенным ID и временем создания.
+// Если дата расхода не указана, используется текущее время.
+// Категория должна быть доступной пользователю неархивной категорией расходов, счет, если указан, -
+// открытым счетом пользователя в валюте расхода.
+// В той же транзакции расход проводится по журналу: дебет категории, кредит счета или нераспределенных денег.
+func (u *ExpenseUseCase) AddExpense(ctx context.Context, expense *domain.Expense) (*domain.Expense, error) {
+	if expense.OccurredAt.IsZero() {
+		expense.OccurredAt = time.Now()
+	}
+
+	if err := u.validate(expense); err != nil {
+		return nil, err
+	}
+	if err := checkCategory(ctx, u.categories, expense.UserID, expense.CategoryID, domain.CategoryKindExpense); err != nil {
+		return nil, err
+	}
+	if err := checkPostable(ctx, u.accounts, expense.UserID, expense.AccountID, expense.Amount); err != nil {
+		return nil, err
+	}
+
+	var created *domain.Expense
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = u.repo.AddExpense(ctx, expense); err != nil {
+			return err
+		}
+		return postEntry(ctx, u.ledger, domain.ExpenseEntry(created))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// GetExpense возвращает расход пользователя по ID
+func (u *ExpenseUseCase) GetExpense(ctx context.Context, userID, id int64) (*domain.Expense, error) {
+	if userID <= 0 || id <= 0 {
+		return nil, fmt.Errorf("%w: user ID and expense ID must be valid", ErrValidation)
+	}
+
+	return u.repo.GetExpense(ctx, userID, id)
+}
+
+// ListExpenses возвращает страницу расходов пользователя, новые первыми
+func (u *ExpenseUseCase) ListExpenses(ctx context.Context, filter domain.ExpenseFilter) (domain.ExpensePage, error) {
+	if filter.UserID <= 0 {
+		return domain.ExpensePage{}, fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+
+	size := normalizePageSize(filter.PageSize)
+	filter.PageSize = size + 1
+
+	items, err := u.repo.ListExpenses(ctx, filter)
+	if err != nil {
+		return domain.ExpensePage{}, err
+	}
+
+	items, hasMore := cutPage(items, size)
+	return domain.ExpensePage{Items: items, HasMore: hasMore}, nil
+}
+
+// UpdateExpense полностью заменяет изменяемые поля расхода.
+// Категория проверяется, только если она изменилась: расход может остаться в архивной категории.
+// Счет проверяется, если изменились счет или сумма: расход может остаться на закрытом счете.
+// Если изменились категория, счет, сумма или дата, прежняя запись журнала сторнируется и проводится новая.
+func (u *ExpenseUseCase) UpdateExpense(ctx context.Context, expense *domain.Expense) (*domain.Expense, error) {
+	if expense.ID <= 0 {
+		return nil, fmt.Errorf("%w: expense ID must be valid", ErrValidation)
+	}
+	if err := u.validate(expense); err != nil {
+		return nil, err
+	}
+
+	var updated *domain.Expense
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := u.repo.GetExpense(ctx, expense.UserID, expense.ID)
+		if err != nil {
+			return err
+		}
+		if current.CategoryID != expense.CategoryID {
+			if err := checkCategory(ctx, u.categories, expense.UserID, expense.CategoryID, domain.CategoryKindExpense); err != nil {
+				return err
+			}
+		}
+		if current.AccountID != expense.AccountID || current.Amount != expense.Amount {
+			if err := checkPostable(ctx, u.accounts, expense.UserID, expense.AccountID, expense.Amount); err != nil {
+				return err
+			}
+		}
+
+		if updated, err = u.repo.UpdateExpense(ctx, expense); err != nil {
+			return err
+		}
+		return replaceEntry(ctx, u.ledger, domain.ExpenseEntry(current), domain.ExpenseEntry(updated))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteExpense удаляет расход пользователя и сторнирует его запись в журнале
+func (u *ExpenseUseCase) DeleteExpense(ctx context.Context, userID, id int64) error {
+	if userID <= 0 || id <= 0 {
+		return fmt.Errorf("%w: user ID and expense ID must be valid", ErrValidation)
+	}
+
+	return u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		expense, err := u.repo.GetExpense(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		if err := u.repo.DeleteExpense(ctx, userID, id); err != nil {
+			return err
+		}
+
+		return reverseEntry(ctx, u.ledger, domain.ExpenseEntry(expense))
+	})
+}
+
+// validate проверяет расход по бизнес-правилам и допустимость даты
+func (u *ExpenseUseCase) validate(expense *domain.Expense) error {
+	if err := expense.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	if err := domain.ValidateOccurredAt(expense.OccurredAt, time.Now(), u.futureTolerance); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	return nil
+}
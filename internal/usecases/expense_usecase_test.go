@@ -0,0 +1,361 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupExpenseTest(t *testing.T) (*gomock.Controller, *mocks.MockExpenseRepository, *usecases.ExpenseUseCase) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockExpenseRepository(ctrl)
+	mockCategories := mocks.NewMockCategoryRepository(ctrl)
+	allowCategories(mockCategories, domain.CategoryKindExpense)
+	mockLedger := mocks.NewMockLedgerRepository(ctrl)
+	allowLedger(mockLedger)
+	return ctrl, mockRepo, newExpenseUseCase(mockRepo, mockCategories, mocks.NewMockAccountRepository(ctrl), mockLedger)
+}
+
+func newExpenseUseCase(repo usecases.ExpenseRepository, categories usecases.CategoryRepository,
+	accounts usecases.AccountRepository, ledger usecases.LedgerRepository) *usecases.ExpenseUseCase {
+	return usecases.NewExpenseUseCase(usecases.ExpenseDeps{
+		Expenses:   repo,
+		Categories: categories,
+		Accounts:   accounts,
+		Ledger:     ledger,
+		Tx:         inlineTx{},
+	}, futureTolerance)
+}
+
+func validExpense() *domain.Expense {
+	return &domain.Expense{
+		UserID:      1,
+		CategoryID:  2,
+		Amount:      domain.NewMoney(10050, kzt),
+		Description: "Test expense",
+		OccurredAt:  time.Now().Add(-time.Hour),
+	}
+}
+
+func Test_ExpenseUseCase_AddExpense_ReturnsCreatedExpense_WhenValidInput(t *testing.T) {
+	ctrl, mockRepo, useCase := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	input := validExpense()
+	created := *input
+	created.ID = 10
+	mockRepo.EXPECT().AddExpense(ctx, input).Return(&created, nil)
+
+	got, err := useCase.AddExpense(ctx, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), got.ID)
+}
+
+func Test_ExpenseUseCase_AddExpense_ReturnsValidationError_WhenInvalidInput(t *testing.T) {
+	_, _, useCase := setupExpenseTest(t)
+
+	tests := []struct {
+		name   string
+		modify func(e *domain.Expense)
+		errMsg string
+	}{
+		{"Zero Amount", func(e *domain.Expense) { e.Amount = domain.NewMoney(0, kzt) },
+			"validation failed: amount must be greater than 0"},
+		{"Missing Currency", func(e *domain.Expense) { e.Amount = domain.NewMoney(100, domain.Currency{}) },
+			"validation failed: currency must be valid"},
+		{"Zero UserID", func(e *domain.Expense) { e.UserID = 0 },
+			"validation failed: user ID must be valid"},
+		{"Zero CategoryID", func(e *domain.Expense) { e.CategoryID = 0 },
+			"validation failed: category ID must be valid"},
+		{"Long Description", func(e *domain.Expense) { e.Description = strings.Repeat("я", 256) },
+			"validation failed: description must not exceed 255 characters"},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expense := validExpense()
+			tt.modify(expense)
+
+			_, err := useCase.AddExpense(ctx, expense)
+
+			assert.ErrorIs(t, err, usecases.ErrValidation)
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
+
+func Test_ExpenseUseCase_AddExpense_ReturnsValidationError_WhenOccurredAtInFuture(t *testing.T) {
+	_, _, useCase := setupExpenseTest(t)
+
+	expense := validExpense()
+	expense.OccurredAt = time.Now().Add(2 * futureTolerance)
+
+	_, err := useCase.AddExpense(context.Background(), expense)
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+	assert.Contains(t, err.Error(), "is in the future")
+}
+
+func Test_ExpenseUseCase_AddExpense_ReturnsError_WhenRepoFails(t *testing.T) {
+	ctrl, mockRepo, useCase := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().AddExpense(ctx, gomock.Any()).Return(nil, errors.New("db error"))
+
+	_, err := useCase.AddExpense(ctx, validExpense())
+
+	assert.EqualError(t, err, "db error")
+}
+
+func Test_ExpenseUseCase_GetExpense_ReturnsValidationError_WhenIDInvalid(t *testing.T) {
+	_, _, useCase := setupExpenseTest(t)
+
+	_, err := useCase.GetExpense(context.Background(), 1, 0)
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+}
+
+func Test_ExpenseUseCase_ListExpenses_ReturnsPageWithHasMore_WhenRepoReturnsExtraItem(t *testing.T) {
+	ctrl, mockRepo, useCase := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().
+		ListExpenses(ctx, domain.ExpenseFilter{UserID: 1, PageSize: 3}).
+		Return([]domain.Expense{{ID: 3}, {ID: 2}, {ID: 1}}, nil)
+
+	page, err := useCase.ListExpenses(ctx, domain.ExpenseFilter{UserID: 1, PageSize: 2})
+
+	require.NoError(t, err)
+	assert.True(t, page.HasMore)
+	assert.Len(t, page.Items, 2)
+}
+
+func Test_ExpenseUseCase_ListExpenses_UsesDefaultPageSize_WhenNotSet(t *testing.T) {
+	ctrl, mockRepo, useCase := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().
+		ListExpenses(ctx, domain.ExpenseFilter{UserID: 1, PageSize: 51}).
+		Return([]domain.Expense{{ID: 1}}, nil)
+
+	page, err := useCase.ListExpenses(ctx, domain.ExpenseFilter{UserID: 1})
+
+	require.NoError(t, err)
+	assert.False(t, page.HasMore)
+	assert.Len(t, page.Items, 1)
+}
+
+func Test_ExpenseUseCase_UpdateExpense_ReturnsValidationError_WhenIDMissing(t *testing.T) {
+	_, _, useCase := setupExpenseTest(t)
+
+	_, err := useCase.UpdateExpense(context.Background(), validExpense())
+
+	assert.EqualError(t, err, "validation failed: expense ID must be valid")
+}
+
+func Test_ExpenseUseCase_UpdateExpense_ReturnsValidationError_WhenOccurredAtMissing(t *testing.T) {
+	_, _, useCase := setupExpenseTest(t)
+
+	expense := validExpense()
+	expense.ID = 10
+	expense.OccurredAt = time.Time{}
+
+	_, err := useCase.UpdateExpense(context.Background(), expense)
+
+	assert.EqualError(t, err, "validation failed: occurred at must be set")
+}
+
+func Test_ExpenseUseCase_UpdateExpense_ReturnsUpdatedExpense_WhenValidInput(t *testing.T) {
+	ctrl, mockRepo, useCase := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	input := validExpense()
+	input.ID = 10
+	stored := *input
+	stored.Amount = domain.NewMoney(5000, kzt)
+	mockRepo.EXPECT().GetExpense(ctx, int64(1), int64(10)).Return(&stored, nil)
+	mockRepo.EXPECT().UpdateExpense(ctx, input).Return(input, nil)
+
+	got, err := useCase.UpdateExpense(ctx, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, input, got)
+}
+
+func Test_ExpenseUseCase_UpdateExpense_KeepsArchivedCategory_WhenCategoryUnchanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockExpenseRepository(ctrl)
+	// Без ожиданий: обращение к категориям или журналу провалит тест
+	mockCategories := mocks.NewMockCategoryRepository(ctrl)
+	useCase := newExpenseUseCase(mockRepo, mockCategories, mocks.NewMockAccountRepository(ctrl),
+		mocks.NewMockLedgerRepository(ctrl))
+
+	ctx := context.Background()
+	input := validExpense()
+	input.ID = 10
+	stored := *input
+	input.Description = "Renamed"
+	mockRepo.EXPECT().GetExpense(ctx, int64(1), int64(10)).Return(&stored, nil)
+	mockRepo.EXPECT().UpdateExpense(ctx, input).Return(input, nil)
+
+	_, err := useCase.UpdateExpense(ctx, input)
+
+	assert.NoError(t, err)
+}
+
+func Test_ExpenseUseCase_UpdateExpense_ReturnsValidationError_WhenNewCategoryIsIncome(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockExpenseRepository(ctrl)
+	mockCategories := mocks.NewMockCategoryRepository(ctrl)
+	useCase := newExpenseUseCase(mockRepo, mockCategories, mocks.NewMockAccountRepository(ctrl),
+		mocks.NewMockLedgerRepository(ctrl))
+
+	ctx := context.Background()
+	input := validExpense()
+	input.ID = 10
+	input.CategoryID = 5
+	mockRepo.EXPECT().GetExpense(ctx, int64(1), int64(10)).Return(validExpense(), nil)
+	mockCategories.EXPECT().GetCategory(ctx, int64(1), 5).
+		Return(&domain.Category{ID: 5, Kind: domain.CategoryKindIncome}, nil)
+
+	_, err := useCase.UpdateExpense(ctx, input)
+
+	assert.EqualError(t, err, "validation failed: category 5 is not an expense category")
+}
+
+func Test_ExpenseUseCase_DeleteExpense_ReturnsNotFound_WhenRepoReturnsNotFound(t *testing.T) {
+	ctrl, mockRepo, useCase := setupExpenseTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetExpense(ctx, int64(1), int64(10)).Return(nil, domain.ErrNotFound)
+
+	err := useCase.DeleteExpense(ctx, 1, 10)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func Test_ExpenseUseCase_AddExpense_PostsBalancedEntry_WhenExpenseSaved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockExpenseRepository(ctrl)
+	mockCategories := mocks.NewMockCategoryRepository(ctrl)
+	allowCategories(mockCategories, domain.CategoryKindExpense)
+	mockLedger := mocks.NewMockLedgerRepository(ctrl)
+	useCase := newExpenseUseCase(mockRepo, mockCategories, mocks.NewMockAccountRepository(ctrl), mockLedger)
+
+	ctx := context.Background()
+	input := validExpense()
+	created := *input
+	created.ID = 10
+	mockRepo.EXPECT().AddExpense(ctx, input).Return(&created, nil)
+	mockLedger.EXPECT().PostEntry(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *domain.JournalEntry) (*domain.JournalEntry, error) {
+			assert.Equal(t, domain.JournalSourceExpense, entry.SourceKind)
+			assert.Equal(t, int64(10), entry.SourceID)
+			assert.Equal(t, []domain.Posting{
+				{Account: domain.LedgerAccount{Kind: domain.LedgerAccountExpense, ID: 2}, Amount: domain.NewMoney(10050, kzt)},
+				{Account: domain.LedgerAccount{Kind: domain.LedgerAccountUnassigned}, Amount: domain.NewMoney(-10050, kzt)},
+			}, entry.Postings)
+			return entry, nil
+		})
+
+	_, err := useCase.AddExpense(ctx, input)
+
+	assert.NoError(t, err)
+}
+
+func Test_ExpenseUseCase_AddExpense_CreditsAccount_WhenAccountSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockExpenseRepository(ctrl)
+	mockCategories := mocks.NewMockCategoryRepository(ctrl)
+	allowCategories(mockCategories, domain.CategoryKindExpense)
+	mockAccounts := mocks.NewMockAccountRepository(ctrl)
+	mockLedger := mocks.NewMockLedgerRepository(ctrl)
+	useCase := newExpenseUseCase(mockRepo, mockCategories, mockAccounts, mockLedger)
+
+	ctx := context.Background()
+	input := validExpense()
+	input.AccountID = 3
+	created := *input
+	created.ID = 10
+	mockAccounts.EXPECT().GetAccount(ctx, int64(1), int64(3)).
+		Return(&domain.Account{ID: 3, UserID: 1, OpeningBalance: domain.NewMoney(0, kzt)}, nil)
+	mockRepo.EXPECT().AddExpense(ctx, input).Return(&created, nil)
+	mockLedger.EXPECT().PostEntry(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *domain.JournalEntry) (*domain.JournalEntry, error) {
+			assert.Equal(t, domain.LedgerAccount{Kind: domain.LedgerAccountAsset, ID: 3}, entry.Postings[1].Account)
+			assert.Equal(t, domain.NewMoney(-10050, kzt), entry.Postings[1].Amount)
+			return entry, nil
+		})
+
+	got, err := useCase.AddExpense(ctx, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), got.AccountID)
+}
+
+func Test_ExpenseUseCase_AddExpense_ReturnsValidationError_WhenAccountClosed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCategories := mocks.NewMockCategoryRepository(ctrl)
+	allowCategories(mockCategories, domain.CategoryKindExpense)
+	mockAccounts := mocks.NewMockAccountRepository(ctrl)
+	useCase := newExpenseUseCase(mocks.NewMockExpenseRepository(ctrl), mockCategories, mockAccounts,
+		mocks.NewMockLedgerRepository(ctrl))
+
+	ctx := context.Background()
+	input := validExpense()
+	input.AccountID = 3
+	mockAccounts.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(&domain.Account{ID: 3, UserID: 1,
+		OpeningBalance: domain.NewMoney(0, kzt), ClosedAt: time.Now()}, nil)
+
+	_, err := useCase.AddExpense(ctx, input)
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+}
+
+func Test_ExpenseUseCase_DeleteExpense_ReversesEntry_WhenExpenseDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockExpenseRepository(ctrl)
+	mockLedger := mocks.NewMockLedgerRepository(ctrl)
+	useCase := newExpenseUseCase(mockRepo, mocks.NewMockCategoryRepository(ctrl), mocks.NewMockAccountRepository(ctrl),
+		mockLedger)
+
+	ctx := context.Background()
+	existing := validExpense()
+	existing.ID = 10
+	mockRepo.EXPECT().GetExpense(ctx, int64(1), int64(10)).Return(existing, nil)
+	mockRepo.EXPECT().DeleteExpense(ctx, int64(1), int64(10)).Return(nil)
+	mockLedger.EXPECT().PostEntry(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *domain.JournalEntry) (*domain.JournalEntry, error) {
+			assert.Equal(t, domain.NewMoney(-10050, kzt), entry.Postings[0].Amount)
+			assert.Equal(t, domain.NewMoney(10050, kzt), entry.Postings[1].Amount)
+			return entry, nil
+		})
+
+	err := useCase.DeleteExpense(ctx, 1, 10)
+
+	assert.NoError(t, err)
+}
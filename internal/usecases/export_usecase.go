@@ -0,0 +1,182 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/export"
+)
+
+//go:generate mockgen -source=export_usecase.go -destination=mocks/export_usecase_mock.go -package=mocks
+
+// exportPageSize число операций, читаемых из хранилища за один запрос при выгрузке
+const exportPageSize = 500
+
+// ExportService контракт сервиса выгрузки операций
+type ExportService interface {
+	ExportTransactions(ctx context.Context, query export.Query, w io.Writer) error
+}
+
+// ExportDeps зависимости ExportUseCase
+type ExportDeps struct {
+	Incomes   IncomeRepository
+	Expenses  ExpenseRepository
+	Transfers TransferRepository
+	Users     UserRepository
+}
+
+// ExportUseCase use-case для выгрузки операций в файлы
+type ExportUseCase struct {
+	incomes   IncomeRepository
+	expenses  ExpenseRepository
+	transfers TransferRepository
+	users     UserRepository
+}
+
+// NewExportUseCase создает новый экземпляр ExportUseCase
+func NewExportUseCase(deps ExportDeps) *ExportUseCase {
+	return &ExportUseCase{
+		incomes:   deps.Incomes,
+		expenses:  deps.Expenses,
+		transfers: deps.Transfers,
+		users:     deps.Users,
+	}
+}
+
+// exportRange полуинтервал [from, to) дат операций выгрузки, нулевые границы не ограничивают
+type exportRange struct {
+	from, to time.Time
+}
+
+// ExportTransactions пишет в w операции пользователя по запросу: сначала доходы, затем расходы и переводы,
+// каждый вид от новых к старым. Операции читаются страницами, поэтому выгрузка не держит в памяти весь результат.
+// Время операций выгружается в часовом поясе пользователя.
+func (u *ExportUseCase) ExportTransactions(ctx context.Context, query export.Query, w io.Writer) error {
+	query.Tags = domain.NormalizeTags(query.Tags)
+	if err := query.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	loc, err := u.users.GetTimezone(ctx, query.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user timezone: %w", err)
+	}
+	var rng exportRange
+	if !query.FromDate.IsZero() {
+		rng.from = query.FromDate.Start(loc)
+	}
+	if !query.ToDate.IsZero() {
+		rng.to = query.ToDate.AddDays(1).Start(loc)
+	}
+
+	writer, err := export.NewWriter(w, query.Options)
+	if err != nil {
+		return err
+	}
+	if query.Includes(export.KindIncome) {
+		if err := u.exportIncomes(ctx, writer, query, rng, loc); err != nil {
+			return err
+		}
+	}
+	// У расходов нет тегов, у переводов - категории и тегов: такие фильтры их исключают
+	if query.Includes(export.KindExpense) && len(query.Tags) == 0 {
+		if err := u.exportExpenses(ctx, writer, query, rng, loc); err != nil {
+			return err
+		}
+	}
+	if query.Includes(export.KindTransfer) && query.CategoryID == 0 && len(query.Tags) == 0 {
+		if err := u.exportTransfers(ctx, writer, query, rng, loc); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// exportIncomes выгружает доходы страницами по курсору даты; фильтры применяет хранилище
+func (u *ExportUseCase) exportIncomes(ctx context.Context, w export.Writer, query export.Query, rng exportRange,
+	loc *time.Location) error {
+	filter := domain.IncomeFilter{
+		UserID:     query.UserID,
+		CategoryID: query.CategoryID,
+		AccountID:  query.AccountID,
+		From:       rng.from,
+		To:         rng.to,
+		Tags:       query.Tags,
+		SortBy:     domain.IncomeSortByDate,
+		PageSize:   exportPageSize,
+	}
+	for {
+		items, err := u.incomes.ListIncomes(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for i := range items {
+			income := &items[i]
+			if err := w.Write(&export.Record{Kind: export.KindIncome, ID: income.ID,
+				OccurredAt: income.OccurredAt.In(loc), Amount: income.Amount, CategoryID: income.CategoryID,
+				AccountID: income.AccountID, Description: income.Description, Tags: income.Tags}); err != nil {
+				return err
+			}
+		}
+		if len(items) < exportPageSize {
+			return nil
+		}
+		cursor := items[len(items)-1].Cursor(domain.IncomeSortByDate)
+		filter.After = &cursor
+	}
+}
+
+// exportExpenses выгружает расходы страницами по ID; фильтры применяет хранилище
+func (u *ExportUseCase) exportExpenses(ctx context.Context, w export.Writer, query export.Query, rng exportRange,
+	loc *time.Location) error {
+	filter := domain.ExpenseFilter{UserID: query.UserID, CategoryID: query.CategoryID, AccountID: query.AccountID,
+		From: rng.from, To: rng.to, PageSize: exportPageSize}
+	for {
+		items, err := u.expenses.ListExpenses(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for i := range items {
+			expense := &items[i]
+			if err := w.Write(&export.Record{Kind: export.KindExpense, ID: expense.ID,
+				OccurredAt: expense.OccurredAt.In(loc), Amount: expense.Amount, CategoryID: expense.CategoryID,
+				AccountID: expense.AccountID, Description: expense.Description}); err != nil {
+				return err
+			}
+		}
+		if len(items) < exportPageSize {
+			return nil
+		}
+		filter.AfterID = items[len(items)-1].ID
+	}
+}
+
+// exportTransfers выгружает переводы страницами по ID; фильтры применяет хранилище
+func (u *ExportUseCase) exportTransfers(ctx context.Context, w export.Writer, query export.Query, rng exportRange,
+	loc *time.Location) error {
+	filter := domain.TransferFilter{UserID: query.UserID, AccountID: query.AccountID, From: rng.from, To: rng.to,
+		PageSize: exportPageSize}
+	for {
+		items, err := u.transfers.ListTransfers(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for i := range items {
+			transfer := &items[i]
+			if err := w.Write(&export.Record{Kind: export.KindTransfer, ID: transfer.ID,
+				OccurredAt: transfer.OccurredAt.In(loc), Amount: transfer.FromAmount, AccountID: transfer.FromAccountID,
+				ToAccountID: transfer.ToAccountID, ToAmount: transfer.ToAmount,
+				Description: transfer.Description}); err != nil {
+				return err
+			}
+		}
+		if len(items) < exportPageSize {
+			return nil
+		}
+		filter.AfterID = items[len(items)-1].ID
+	}
+}
@@ -0,0 +1,114 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/export"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+type exportMocks struct {
+	incomes   *mocks.MockIncomeRepository
+	expenses  *mocks.MockExpenseRepository
+	transfers *mocks.MockTransferRepository
+	users     *mocks.MockUserRepository
+}
+
+func setupExportTest(t *testing.T) (*gomock.Controller, exportMocks, *usecases.ExportUseCase) {
+	ctrl := gomock.NewController(t)
+	m := exportMocks{
+		incomes:   mocks.NewMockIncomeRepository(ctrl),
+		expenses:  mocks.NewMockExpenseRepository(ctrl),
+		transfers: mocks.NewMockTransferRepository(ctrl),
+		users:     mocks.NewMockUserRepository(ctrl),
+	}
+	useCase := usecases.NewExportUseCase(usecases.ExportDeps{
+		Incomes:   m.incomes,
+		Expenses:  m.expenses,
+		Transfers: m.transfers,
+		Users:     m.users,
+	})
+	return ctrl, m, useCase
+}
+
+func Test_ExportUseCase_ExportTransactions_WritesAllKindsInRange_WhenKindsNotSet(t *testing.T) {
+	ctrl, m, useCase := setupExportTest(t)
+	defer ctrl.Finish()
+
+	almaty, err := time.LoadLocation("Asia/Almaty")
+	require.NoError(t, err)
+	ctx := context.Background()
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, almaty)
+	to := time.Date(2024, time.April, 1, 0, 0, 0, 0, almaty)
+	inMarch := time.Date(2024, time.March, 10, 6, 0, 0, 0, time.UTC)
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(almaty, nil)
+	m.incomes.EXPECT().ListIncomes(ctx, domain.IncomeFilter{UserID: 1, From: from, To: to, PageSize: 500}).
+		Return([]domain.Income{{ID: 5, UserID: 1, CategoryID: 2, Amount: domain.NewMoney(100_000, kzt),
+			OccurredAt: inMarch}}, nil)
+	m.expenses.EXPECT().ListExpenses(ctx, domain.ExpenseFilter{UserID: 1, From: from, To: to, PageSize: 500}).
+		Return([]domain.Expense{{ID: 6, UserID: 1, CategoryID: 3, Amount: domain.NewMoney(1_500, kzt),
+			OccurredAt: inMarch}}, nil)
+	m.transfers.EXPECT().ListTransfers(ctx, domain.TransferFilter{UserID: 1, From: from, To: to, PageSize: 500}).
+		Return([]domain.Transfer{{ID: 4, UserID: 1, FromAccountID: 1, ToAccountID: 2,
+			FromAmount: domain.NewMoney(500, kzt), ToAmount: domain.NewMoney(500, kzt), OccurredAt: inMarch}}, nil)
+	var buf bytes.Buffer
+
+	err = useCase.ExportTransactions(ctx, export.Query{UserID: 1,
+		FromDate: domain.Date{Year: 2024, Month: time.March, Day: 1}, ToDate: domain.Date{Year: 2024, Month: time.March, Day: 31},
+		Options: export.Options{Format: export.FormatJSONL}}, &buf)
+
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], `"kind":"income","id":5,"occurred_at":"2024-03-10T11:00:00+05:00"`)
+	assert.Contains(t, lines[1], `"kind":"expense","id":6`)
+	assert.Contains(t, lines[2], `"kind":"transfer","id":4`)
+}
+
+func Test_ExportUseCase_ExportTransactions_ReadsNextPage_WhenPageFull(t *testing.T) {
+	ctrl, m, useCase := setupExportTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	page := make([]domain.Income, 500)
+	for i := range page {
+		page[i] = domain.Income{ID: int64(1000 - i), UserID: 1, CategoryID: 2, Amount: domain.NewMoney(100, kzt),
+			OccurredAt: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC).Add(-time.Duration(i) * time.Hour)}
+	}
+	cursor := page[499].Cursor(domain.IncomeSortByDate)
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	gomock.InOrder(
+		m.incomes.EXPECT().ListIncomes(ctx, domain.IncomeFilter{UserID: 1, Tags: []string{"work"}, PageSize: 500}).
+			Return(page, nil),
+		m.incomes.EXPECT().ListIncomes(ctx, domain.IncomeFilter{UserID: 1, Tags: []string{"work"}, After: &cursor,
+			PageSize: 500}).Return(nil, nil),
+	)
+	var buf bytes.Buffer
+
+	// Фильтр по тегам исключает расходы и переводы: у них нет тегов
+	err := useCase.ExportTransactions(ctx, export.Query{UserID: 1, Tags: []string{" work "},
+		Options: export.Options{Format: export.FormatCSV}}, &buf)
+
+	require.NoError(t, err)
+	assert.Equal(t, 501, strings.Count(buf.String(), "\n"))
+}
+
+func Test_ExportUseCase_ExportTransactions_ReturnsValidationError_WhenOptionsInvalid(t *testing.T) {
+	_, _, useCase := setupExportTest(t)
+
+	err := useCase.ExportTransactions(context.Background(), export.Query{UserID: 1,
+		Options: export.Options{Locale: "fr"}}, &bytes.Buffer{})
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+	assert.EqualError(t, err, `validation failed: unsupported locale "fr"`)
+}
@@ -0,0 +1,24 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=idempotency_repository.go -destination=mocks/idempotency_repository_mock.go -package=mocks
+
+// IdempotencyRepository хранилище запросов, выполненных с ключом идемпотентности
+type IdempotencyRepository interface {
+	// Reserve сохраняет незавершенную запись, если для ключа нет действующей, и возвращает true.
+	// Истекшие записи и незавершенные записи, созданные раньше staleBefore, перезаписываются.
+	// Иначе возвращает существующую запись и false.
+	Reserve(ctx context.Context, record *domain.IdempotencyRecord, staleBefore time.Time) (*domain.IdempotencyRecord, bool, error)
+	// Complete сохраняет результат запроса и отмечает запись завершенной; ошибка, если записи уже нет
+	Complete(ctx context.Context, record *domain.IdempotencyRecord) error
+	// Release удаляет незавершенную запись, чтобы запрос с тем же ключом можно было повторить
+	Release(ctx context.Context, key domain.IdempotencyKey) error
+	// DeleteExpired удаляет записи, истекшие к моменту now, и возвращает их число
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=idempotency_usecase.go -destination=mocks/idempotency_usecase_mock.go -package=mocks
+
+// IdempotencyService контракт сервиса идемпотентного выполнения запросов
+type IdempotencyService interface {
+	Do(ctx context.Context, key domain.IdempotencyKey, fingerprint []byte,
+		fn func(ctx context.Context) (domain.IdempotentResponse, error)) (domain.IdempotentResponse, bool, error)
+}
+
+// IdempotencyUseCase use-case идемпотентного выполнения запросов
+type IdempotencyUseCase struct {
+	repo           IdempotencyRepository
+	tx             TxManager
+	ttl            time.Duration
+	pendingTimeout time.Duration
+}
+
+// NewIdempotencyUseCase создает новый экземпляр IdempotencyUseCase.
+// ttl задает, сколько хранится результат запроса, выполненного с ключом, pendingTimeout - через сколько
+// незавершенная запись считается брошенной (например, после падения сервиса). pendingTimeout должен быть
+// больше дедлайна запросов: иначе повтор выполняемого запроса выполнит его второй раз.
+func NewIdempotencyUseCase(repo IdempotencyRepository, tx TxManager, ttl, pendingTimeout time.Duration) *IdempotencyUseCase {
+	return &IdempotencyUseCase{repo: repo, tx: tx, ttl: ttl, pendingTimeout: pendingTimeout}
+}
+
+// Do выполняет fn не больше одного раза для ключа и возвращает ее результат.
+// Повтор с тем же ключом и отпечатком возвращает сохраненный результат и true.
+// fn выполняется в транзакции вместе с сохранением результата, поэтому выполненный запрос всегда отмечен
+// завершенным. При ошибке fn или сохранения результата ничего не фиксируется и запрос с тем же ключом можно повторить.
+func (u *IdempotencyUseCase) Do(ctx context.Context, key domain.IdempotencyKey, fingerprint []byte,
+	fn func(ctx context.Context) (domain.IdempotentResponse, error)) (domain.IdempotentResponse, bool, error) {
+	if err := key.Validate(); err != nil {
+		return domain.IdempotentResponse{}, false, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	now := time.Now()
+	record := &domain.IdempotencyRecord{Key: key, Fingerprint: fingerprint, CreatedAt: now, ExpiresAt: now.Add(u.ttl)}
+	existing, reserved, err := u.repo.Reserve(ctx, record, now.Add(-u.pendingTimeout))
+	if err != nil {
+		return domain.IdempotentResponse{}, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if !reserved {
+		switch {
+		case !bytes.Equal(existing.Fingerprint, fingerprint):
+			return domain.IdempotentResponse{}, false, ErrIdempotencyKeyReused
+		case !existing.Completed:
+			return domain.IdempotentResponse{}, false, ErrIdempotencyInProgress
+		}
+		return existing.Response, true, nil
+	}
+
+	var resp domain.IdempotentResponse
+	err = u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if resp, err = fn(ctx); err != nil {
+			return err
+		}
+		record.Response = resp
+		record.Completed = true
+		if err := u.repo.Complete(ctx, record); err != nil {
+			return fmt.Errorf("failed to complete idempotency key: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		// Если освободить ключ не удалось, повтор станет возможен через pendingTimeout
+		_ = u.repo.Release(context.WithoutCancel(ctx), key)
+		return domain.IdempotentResponse{}, false, err
+	}
+
+	return resp, false, nil
+}
+
+// DeleteExpired удаляет истекшие записи; подходит как задача планировщика
+func (u *IdempotencyUseCase) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	return u.repo.DeleteExpired(ctx, now)
+}
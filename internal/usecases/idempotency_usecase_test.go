@@ -0,0 +1,150 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupIdempotencyTest(t *testing.T) (*gomock.Controller, *mocks.MockIdempotencyRepository, *usecases.IdempotencyUseCase) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockIdempotencyRepository(ctrl)
+	useCase := usecases.NewIdempotencyUseCase(mockRepo, inlineTx{}, 24*time.Hour, 5*time.Minute)
+	return ctrl, mockRepo, useCase
+}
+
+var (
+	addIncomeKey = domain.IdempotencyKey{UserID: 1, Method: "/finance.FinanceService/AddIncome", Key: "c0ffee"}
+	incomeReply  = domain.IdempotentResponse{Type: "finance.Income", Body: []byte{8, 7}}
+)
+
+func Test_IdempotencyUseCase_Do_SavesResponse_WhenKeyIsNew(t *testing.T) {
+	ctrl, mockRepo, useCase := setupIdempotencyTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().Reserve(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, record *domain.IdempotencyRecord, _ time.Time) (*domain.IdempotencyRecord, bool, error) {
+			assert.False(t, record.Completed)
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), record.ExpiresAt, time.Minute)
+			return nil, true, nil
+		})
+	mockRepo.EXPECT().Complete(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, record *domain.IdempotencyRecord) error {
+			assert.True(t, record.Completed)
+			assert.Equal(t, incomeReply, record.Response)
+			return nil
+		})
+
+	got, replayed, err := useCase.Do(ctx, addIncomeKey, []byte("fp"), func(context.Context) (domain.IdempotentResponse, error) {
+		return incomeReply, nil
+	})
+
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	assert.Equal(t, incomeReply, got)
+}
+
+func Test_IdempotencyUseCase_Do_ReturnsSavedResponse_WhenKeyReplayed(t *testing.T) {
+	ctrl, mockRepo, useCase := setupIdempotencyTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().Reserve(ctx, gomock.Any(), gomock.Any()).Return(&domain.IdempotencyRecord{
+		Key: addIncomeKey, Fingerprint: []byte("fp"), Response: incomeReply, Completed: true,
+	}, false, nil)
+
+	got, replayed, err := useCase.Do(ctx, addIncomeKey, []byte("fp"), func(context.Context) (domain.IdempotentResponse, error) {
+		t.Fatal("request must not be executed twice")
+		return domain.IdempotentResponse{}, nil
+	})
+
+	require.NoError(t, err)
+	assert.True(t, replayed)
+	assert.Equal(t, incomeReply, got)
+}
+
+func Test_IdempotencyUseCase_Do_ReturnsError_WhenKeyConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing domain.IdempotencyRecord
+		err      error
+	}{
+		{"Different Payload", domain.IdempotencyRecord{Fingerprint: []byte("other"), Completed: true},
+			usecases.ErrIdempotencyKeyReused},
+		{"Still Running", domain.IdempotencyRecord{Fingerprint: []byte("fp")}, usecases.ErrIdempotencyInProgress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, mockRepo, useCase := setupIdempotencyTest(t)
+			defer ctrl.Finish()
+
+			mockRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), gomock.Any()).Return(&tt.existing, false, nil)
+
+			_, _, err := useCase.Do(context.Background(), addIncomeKey, []byte("fp"),
+				func(context.Context) (domain.IdempotentResponse, error) {
+					return incomeReply, nil
+				})
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func Test_IdempotencyUseCase_Do_ReleasesKey_WhenRequestFails(t *testing.T) {
+	ctrl, mockRepo, useCase := setupIdempotencyTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	failure := errors.New("database is unavailable")
+	mockRepo.EXPECT().Reserve(ctx, gomock.Any(), gomock.Any()).Return(nil, true, nil)
+	mockRepo.EXPECT().Release(gomock.Any(), addIncomeKey).Return(nil)
+
+	_, _, err := useCase.Do(ctx, addIncomeKey, []byte("fp"), func(context.Context) (domain.IdempotentResponse, error) {
+		return domain.IdempotentResponse{}, failure
+	})
+
+	assert.ErrorIs(t, err, failure)
+}
+
+func Test_IdempotencyUseCase_Do_ReturnsErrorAndReleasesKey_WhenResponseNotSaved(t *testing.T) {
+	ctrl, mockRepo, useCase := setupIdempotencyTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	failure := errors.New("database is unavailable")
+	mockRepo.EXPECT().Reserve(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *domain.IdempotencyRecord, staleBefore time.Time) (*domain.IdempotencyRecord, bool, error) {
+			assert.WithinDuration(t, time.Now().Add(-5*time.Minute), staleBefore, time.Second)
+			return nil, true, nil
+		})
+	mockRepo.EXPECT().Complete(gomock.Any(), gomock.Any()).Return(failure)
+	mockRepo.EXPECT().Release(gomock.Any(), addIncomeKey).Return(nil)
+
+	_, replayed, err := useCase.Do(ctx, addIncomeKey, []byte("fp"), func(context.Context) (domain.IdempotentResponse, error) {
+		return incomeReply, nil
+	})
+
+	assert.ErrorIs(t, err, failure)
+	assert.False(t, replayed)
+}
+
+func Test_IdempotencyUseCase_Do_ReturnsValidationError_WhenKeyHasSpaces(t *testing.T) {
+	_, _, useCase := setupIdempotencyTest(t)
+
+	key := addIncomeKey
+	key.Key = "retry 1"
+	_, _, err := useCase.Do(context.Background(), key, nil, nil)
+
+	assert.EqualError(t, err, "validation failed: idempotency key must consist of printable characters without spaces")
+}
@@ -1,10 +1,28 @@
 package usecases
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
 
 //go:generate mockgen -source=income_repository.go -destination=mocks/income_repository_mock.go -package=mocks
 
 // IncomeRepository репозиторий для работы с доходами
 type IncomeRepository interface {
-	AddIncome(ctx context.Context, userID int64, categoryID int, amount float64, description string) error
+	AddIncome(ctx context.Context, income *domain.Income) (*domain.Income, error)
+	// AddIncomes сохраняет доходы многострочной вставкой и возвращает их в исходном порядке
+	AddIncomes(ctx context.Context, incomes []domain.Income) ([]domain.Income, error)
+	GetIncome(ctx context.Context, userID, id int64) (*domain.Income, error)
+	ListIncomes(ctx context.Context, filter domain.IncomeFilter) ([]domain.Income, error)
+	UpdateIncome(ctx context.Context, income *domain.Income) (*domain.Income, error)
+	DeleteIncome(ctx context.Context, userID, id int64) error
+	// ListIncomesInRange возвращает все доходы пользователя с датой в полуинтервале [from, to)
+	ListIncomesInRange(ctx context.Context, userID int64, from, to time.Time) ([]domain.Income, error)
+	// MergeIncome сохраняет доход merged как источник дохода keptID, переносит на keptID его источники
+	// и связи с повторяющимися правилами и удаляет merged
+	MergeIncome(ctx context.Context, keptID int64, merged *domain.Income) error
+	// ListIncomeSources возвращает источники дохода в порядке объединения
+	ListIncomeSources(ctx context.Context, userID, incomeID int64) ([]domain.IncomeSource, error)
 }
@@ -1,8 +1,11 @@
 package usecases
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"fincraft-finance/internal/domain"
 )
@@ -11,31 +14,474 @@ import (
 
 // IncomeService контракт сервиса для работы с доходами
 type IncomeService interface {
-	AddIncome(ctx context.Context, userID int64, categoryID int, amount float64, description string) error
+	AddIncome(ctx context.Context, income *domain.Income) (*domain.Income, []domain.DuplicateCandidate, error)
+	AddIncomes(ctx context.Context, incomes []*domain.Income, mode domain.BatchMode) ([]domain.IncomeBatchResult, error)
+	AddIncomesWithoutRules(ctx context.Context, incomes []*domain.Income, mode domain.BatchMode) ([]domain.IncomeBatchResult, error)
+	GetIncome(ctx context.Context, userID, id int64) (*domain.Income, error)
+	ListIncomes(ctx context.Context, filter domain.IncomeFilter) (domain.IncomePage, error)
+	UpdateIncome(ctx context.Context, userID, id int64, patch domain.IncomePatch) (*domain.Income, error)
+	DeleteIncome(ctx context.Context, userID, id int64) error
+	FindDuplicates(ctx context.Context, incomes []*domain.Income) ([][]domain.DuplicateCandidate, error)
+	MergeIncomes(ctx context.Context, userID, keepID, duplicateID int64) (*domain.Income, []domain.IncomeSource, error)
+}
+
+// IncomeDeps хранилища, которые использует IncomeUseCase
+type IncomeDeps struct {
+	Incomes    IncomeRepository
+	Users      UserRepository
+	Categories CategoryRepository
+	Accounts   AccountRepository
+	Ledger     LedgerRepository
+	Rules      RuleRepository
+	Tx         TxManager
 }
 
 // IncomeUseCase use-case для работы с доходами
 type IncomeUseCase struct {
-	repo IncomeRepository
+	repo            IncomeRepository
+	users           UserRepository
+	categories      CategoryRepository
+	accounts        AccountRepository
+	ledger          LedgerRepository
+	rules           RuleRepository
+	tx              TxManager
+	futureTolerance time.Duration
+}
+
+// NewIncomeUseCase создает новый экземпляр IncomeUseCase.
+// futureTolerance задает, насколько дата дохода может опережать текущее время (расхождение часов клиента).
+func NewIncomeUseCase(deps IncomeDeps, futureTolerance time.Duration) *IncomeUseCase {
+	return &IncomeUseCase{
+		repo:            deps.Incomes,
+		users:           deps.Users,
+		categories:      deps.Categories,
+		accounts:        deps.Accounts,
+		ledger:          deps.Ledger,
+		rules:           deps.Rules,
+		tx:              deps.Tx,
+		futureTolerance: futureTolerance,
+	}
+}
+
+// AddIncome добавляет новый доход в хранилище данных и возвращает его с присвоенным ID и временем создания
+// вместе с вероятными дубликатами среди уже сохраненных доходов (см. FindDuplicates).
+// Если дата дохода не указана, используется текущее время.
+// Перед проверкой к доходу применяются правила категоризации пользователя (см. applyRules).
+// Категория должна существовать, принадлежать пользователю или быть системной и не находиться в архиве.
+// В той же транзакции ищутся дубликаты и доход проводится по журналу: дебет счета (или нераспределенных денег),
+// кредит категории. Дубликаты не мешают сохранению: объединить их можно через MergeIncomes.
+func (u *IncomeUseCase) AddIncome(ctx context.Context, income *domain.Income) (*domain.Income, []domain.DuplicateCandidate, error) {
+	if income.OccurredAt.IsZero() {
+		income.OccurredAt = time.Now()
+	}
+	if err := u.applyRules(ctx, income); err != nil {
+		return nil, nil, err
+	}
+
+	if err := u.validate(income); err != nil {
+		return nil, nil, err
+	}
+	if err := checkCategory(ctx, u.categories, income.UserID, income.CategoryID, domain.CategoryKindIncome); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		created    *domain.Income
+		duplicates []domain.DuplicateCandidate
+	)
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := checkPostable(ctx, u.accounts, income.UserID, income.AccountID, income.Amount); err != nil {
+			return err
+		}
+		found, err := u.FindDuplicates(ctx, []*domain.Income{income})
+		if err != nil {
+			return err
+		}
+		duplicates = found[0]
+
+		if created, err = u.repo.AddIncome(ctx, income); err != nil {
+			return err
+		}
+		return postEntry(ctx, u.ledger, domain.IncomeEntry(created))
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return created, duplicates, nil
+}
+
+// AddIncomes добавляет пакет доходов одной транзакцией и возвращает результат по каждому элементу.
+// Как и в AddIncome, к каждому доходу перед проверкой применяются правила категоризации пользователя,
+// затем доход проверяется. В режиме BatchAtomic первый отклоненный элемент отменяет весь пакет
+// и возвращается как *domain.BatchItemError, в режиме BatchBestEffort отклоненные элементы получают
+// ошибку в результате, а остальные записываются.
+func (u *IncomeUseCase) AddIncomes(ctx context.Context, incomes []*domain.Income, mode domain.BatchMode) ([]domain.IncomeBatchResult, error) {
+	return u.addIncomes(ctx, incomes, mode, true)
+}
+
+// AddIncomesWithoutRules добавляет пакет доходов так же, как AddIncomes, но без правил категоризации:
+// для доходов, к которым вызывающая сторона уже применила правила (импорт выписки)
+func (u *IncomeUseCase) AddIncomesWithoutRules(ctx context.Context, incomes []*domain.Income,
+	mode domain.BatchMode) ([]domain.IncomeBatchResult, error) {
+	return u.addIncomes(ctx, incomes, mode, false)
+}
+
+// addIncomes добавляет пакет доходов, применяя правила категоризации, если withRules
+func (u *IncomeUseCase) addIncomes(ctx context.Context, incomes []*domain.Income, mode domain.BatchMode,
+	withRules bool) ([]domain.IncomeBatchResult, error) {
+	if len(incomes) == 0 || len(incomes) > domain.MaxBatchSize {
+		return nil, fmt.Errorf("%w: batch must contain from 1 to %d incomes", ErrValidation, domain.MaxBatchSize)
+	}
+
+	now := time.Now()
+	results := make([]domain.IncomeBatchResult, len(incomes))
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		checked := make(map[int64]map[int]error)
+		sets := make(map[int64]*domain.RuleSet)
+		accepted := make([]domain.Income, 0, len(incomes))
+		positions := make([]int, 0, len(incomes))
+		for i, income := range incomes {
+			if income.OccurredAt.IsZero() {
+				income.OccurredAt = now
+			}
+			if withRules {
+				set, ok := sets[income.UserID]
+				if !ok {
+					var err error
+					if set, err = u.ruleSet(ctx, income.UserID); err != nil {
+						return err
+					}
+					sets[income.UserID] = set
+				}
+				applyIncomeRules(set, income)
+			}
+
+			err := u.checkBatchItem(ctx, income, checked)
+			switch {
+			case err == nil:
+				accepted = append(accepted, *income)
+				positions = append(positions, i)
+			case !isRejection(err):
+				return err
+			case mode == domain.BatchAtomic:
+				return &domain.BatchItemError{Index: i, Err: err}
+			default:
+				results[i].Err = err
+			}
+		}
+		if len(accepted) == 0 {
+			return nil
+		}
+
+		created, err := u.repo.AddIncomes(ctx, accepted)
+		if err != nil {
+			return err
+		}
+		for j := range created {
+			results[positions[j]].Income = &created[j]
+			if err := postEntry(ctx, u.ledger, domain.IncomeEntry(&created[j])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// checkBatchItem проверяет доход из пакета; результат проверки категории запоминается в checked
+func (u *IncomeUseCase) checkBatchItem(ctx context.Context, income *domain.Income, checked map[int64]map[int]error) error {
+	if err := u.validate(income); err != nil {
+		return err
+	}
+
+	categories, ok := checked[income.UserID]
+	if !ok {
+		categories = make(map[int]error)
+		checked[income.UserID] = categories
+	}
+	err, ok := categories[income.CategoryID]
+	if !ok {
+		err = checkCategory(ctx, u.categories, income.UserID, income.CategoryID, domain.CategoryKindIncome)
+		categories[income.CategoryID] = err
+	}
+	if err != nil {
+		return err
+	}
+
+	return checkPostable(ctx, u.accounts, income.UserID, income.AccountID, income.Amount)
+}
+
+// GetIncome возвращает доход пользователя по ID
+func (u *IncomeUseCase) GetIncome(ctx context.Context, userID, id int64) (*domain.Income, error) {
+	if userID <= 0 || id <= 0 {
+		return nil, fmt.Errorf("%w: user ID and income ID must be valid", ErrValidation)
+	}
+
+	return u.repo.GetIncome(ctx, userID, id)
+}
+
+// ListIncomes возвращает страницу доходов пользователя по фильтру
+func (u *IncomeUseCase) ListIncomes(ctx context.Context, filter domain.IncomeFilter) (domain.IncomePage, error) {
+	filter.Tags = domain.NormalizeTags(filter.Tags)
+	if err := filter.Validate(); err != nil {
+		return domain.IncomePage{}, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	if err := u.resolveCalendarRange(ctx, &filter); err != nil {
+		return domain.IncomePage{}, err
+	}
+
+	size := normalizePageSize(filter.PageSize)
+	filter.PageSize = size + 1
+
+	items, err := u.repo.ListIncomes(ctx, filter)
+	if err != nil {
+		return domain.IncomePage{}, err
+	}
+
+	items, hasMore := cutPage(items, size)
+	return domain.IncomePage{Items: items, HasMore: hasMore}, nil
+}
+
+// UpdateIncome применяет частичное изменение к доходу пользователя.
+// Новая категория проверяется так же, как при добавлении дохода.
+// Если изменились счет, категория, сумма или дата, прежняя запись журнала сторнируется и проводится новая.
+func (u *IncomeUseCase) UpdateIncome(ctx context.Context, userID, id int64, patch domain.IncomePatch) (*domain.Income, error) {
+	if userID <= 0 || id <= 0 {
+		return nil, fmt.Errorf("%w: user ID and income ID must be valid", ErrValidation)
+	}
+
+	var updated *domain.Income
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		income, err := u.repo.GetIncome(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		previous := *income
+		income.Apply(patch)
+		if err := u.validate(income); err != nil {
+			return err
+		}
+		if patch.CategoryID != nil {
+			if err := checkCategory(ctx, u.categories, userID, income.CategoryID, domain.CategoryKindIncome); err != nil {
+				return err
+			}
+		}
+
+		if previous.AccountID != income.AccountID || previous.Amount != income.Amount {
+			if err := checkPostable(ctx, u.accounts, userID, income.AccountID, income.Amount); err != nil {
+				return err
+			}
+		}
+
+		if updated, err = u.repo.UpdateIncome(ctx, income); err != nil {
+			return err
+		}
+		return replaceEntry(ctx, u.ledger, domain.IncomeEntry(&previous), domain.IncomeEntry(updated))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
-// NewIncomeUseCase создает новый экземпляр IncomeUseCase
-func NewIncomeUseCase(repo IncomeRepository) *IncomeUseCase {
-	return &IncomeUseCase{repo: repo}
+// DeleteIncome удаляет доход пользователя и сторнирует его запись в журнале
+func (u *IncomeUseCase) DeleteIncome(ctx context.Context, userID, id int64) error {
+	if userID <= 0 || id <= 0 {
+		return fmt.Errorf("%w: user ID and income ID must be valid", ErrValidation)
+	}
+
+	return u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		income, err := u.repo.GetIncome(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		if err := u.repo.DeleteIncome(ctx, userID, id); err != nil {
+			return err
+		}
+
+		return reverseEntry(ctx, u.ledger, domain.IncomeEntry(income))
+	})
+}
+
+// FindDuplicates для каждого дохода возвращает сохраненные доходы, которые вероятно являются той же операцией,
+// в порядке убывания оценки. Доходы должны принадлежать одному пользователю; доход без даты сравнивается
+// как полученный сейчас, сам с собой доход не сравнивается.
+func (u *IncomeUseCase) FindDuplicates(ctx context.Context, incomes []*domain.Income) ([][]domain.DuplicateCandidate, error) {
+	duplicates := make([][]domain.DuplicateCandidate, len(incomes))
+	if len(incomes) == 0 {
+		return duplicates, nil
+	}
+
+	userID := incomes[0].UserID
+	now := time.Now()
+	checked := make([]domain.Income, len(incomes))
+	var from, to time.Time
+	for i, income := range incomes {
+		if income.UserID != userID || userID <= 0 {
+			return nil, fmt.Errorf("%w: incomes must belong to one valid user", ErrValidation)
+		}
+		checked[i] = *income
+		if checked[i].OccurredAt.IsZero() {
+			checked[i].OccurredAt = now
+		}
+		if from.IsZero() || checked[i].OccurredAt.Before(from) {
+			from = checked[i].OccurredAt
+		}
+		if checked[i].OccurredAt.After(to) {
+			to = checked[i].OccurredAt
+		}
+	}
+
+	// Окно берется с запасом в полдня: так даты сравниваются по календарным дням
+	window := time.Duration(domain.DuplicateWindowDays)*24*time.Hour + 12*time.Hour
+	existing, err := u.repo.ListIncomesInRange(ctx, userID, from.Add(-window), to.Add(window))
+	if err != nil {
+		return nil, err
+	}
+	byAmount := make(map[domain.Money][]int)
+	for j := range existing {
+		byAmount[existing[j].Amount] = append(byAmount[existing[j].Amount], j)
+	}
+
+	for i := range checked {
+		for _, j := range byAmount[checked[i].Amount] {
+			if existing[j].ID == checked[i].ID {
+				continue
+			}
+			if score := domain.IncomeDuplicateScore(&checked[i], &existing[j]); score >= domain.DuplicateThreshold {
+				duplicates[i] = append(duplicates[i], domain.DuplicateCandidate{Income: existing[j], Score: score})
+			}
+		}
+		slices.SortStableFunc(duplicates[i], func(a, b domain.DuplicateCandidate) int {
+			return cmp.Compare(b.Score, a.Score)
+		})
+	}
+
+	return duplicates, nil
 }
 
-// AddIncome добавляет новый доход в хранилище данных
-func (u *IncomeUseCase) AddIncome(ctx context.Context, userID int64, categoryID int, amount float64, description string) error {
-	income := &domain.Income{
-		UserID:      userID,
-		CategoryID:  categoryID,
-		Amount:      domain.NewMoneyFromFloat(amount),
-		Description: description,
+// MergeIncomes объединяет дубликат с доходом keepID: доход keepID остается без изменений, дубликат удаляется,
+// его запись в журнале сторнируется, а сам он сохраняется как источник оставшегося дохода.
+// Возвращает оставшийся доход и все его источники.
+func (u *IncomeUseCase) MergeIncomes(ctx context.Context, userID, keepID, duplicateID int64) (*domain.Income, []domain.IncomeSource, error) {
+	if userID <= 0 || keepID <= 0 || duplicateID <= 0 {
+		return nil, nil, fmt.Errorf("%w: user ID and income IDs must be valid", ErrValidation)
+	}
+	if keepID == duplicateID {
+		return nil, nil, fmt.Errorf("%w: income cannot be merged with itself", ErrValidation)
+	}
+
+	var (
+		kept    *domain.Income
+		sources []domain.IncomeSource
+	)
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if kept, err = u.repo.GetIncome(ctx, userID, keepID); err != nil {
+			return err
+		}
+		duplicate, err := u.repo.GetIncome(ctx, userID, duplicateID)
+		if err != nil {
+			return err
+		}
+
+		if err := u.repo.MergeIncome(ctx, keepID, duplicate); err != nil {
+			return err
+		}
+		if err := reverseEntry(ctx, u.ledger, domain.IncomeEntry(duplicate)); err != nil {
+			return err
+		}
+
+		sources, err = u.repo.ListIncomeSources(ctx, userID, keepID)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
+	return kept, sources, nil
+}
+
+// applyRules применяет к доходу правила категоризации пользователя (см. applyIncomeRules)
+func (u *IncomeUseCase) applyRules(ctx context.Context, income *domain.Income) error {
+	set, err := u.ruleSet(ctx, income.UserID)
+	if err != nil {
+		return err
+	}
+	applyIncomeRules(set, income)
+	return nil
+}
+
+// ruleSet возвращает правила категоризации пользователя; nil, если правил нет или ID пользователя некорректен
+func (u *IncomeUseCase) ruleSet(ctx context.Context, userID int64) (*domain.RuleSet, error) {
+	if userID <= 0 {
+		return nil, nil
+	}
+
+	rules, err := u.rules.ListRules(ctx, userID)
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+	return domain.NewRuleSet(rules)
+}
+
+// applyIncomeRules применяет к доходу правила set в порядке приоритета.
+// Категория из правила назначается, только если у дохода она не указана; описание из правила заменяет исходное,
+// теги правил добавляются к тегам дохода.
+// Пометка перевода к отдельному доходу не применяется.
+func applyIncomeRules(set *domain.RuleSet, income *domain.Income) {
+	if set == nil {
+		return
+	}
+
+	outcome := set.Evaluate(domain.RuleSubject{Amount: income.Amount, Description: income.Description,
+		AccountID: income.AccountID})
+	if income.CategoryID == 0 {
+		income.CategoryID = outcome.CategoryID
+	}
+	if outcome.Description != "" {
+		income.Description = outcome.Description
+	}
+	income.Tags = slices.Concat(income.Tags, outcome.Tags)
+}
+
+// validate приводит теги дохода к каноническому виду и проверяет доход по бизнес-правилам и допустимость даты
+func (u *IncomeUseCase) validate(income *domain.Income) error {
+	income.Tags = domain.NormalizeTags(income.Tags)
 	if err := income.Validate(); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	if err := domain.ValidateOccurredAt(income.OccurredAt, time.Now(), u.futureTolerance); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	return nil
+}
+
+// resolveCalendarRange переводит календарные даты фильтра в моменты времени по часовому поясу пользователя
+func (u *IncomeUseCase) resolveCalendarRange(ctx context.Context, filter *domain.IncomeFilter) error {
+	if filter.FromDate.IsZero() && filter.ToDate.IsZero() {
+		return nil
+	}
+
+	loc, err := u.users.GetTimezone(ctx, filter.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user timezone: %w", err)
+	}
+
+	if !filter.FromDate.IsZero() {
+		filter.From = filter.FromDate.Start(loc)
+	}
+	if !filter.ToDate.IsZero() {
+		filter.To = filter.ToDate.AddDays(1).Start(loc)
 	}
 
-	return u.repo.AddIncome(ctx, userID, categoryID, amount, description)
+	return nil
 }
@@ -3,32 +3,158 @@ package usecases_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"fincraft-finance/internal/domain"
 	"fincraft-finance/internal/usecases"
 	"fincraft-finance/internal/usecases/mocks"
 )
 
-func setupTest(t *testing.T) (*gomock.Controller, *mocks.MockIncomeRepository, *usecases.IncomeUseCase) {
+var kzt = domain.MustCurrency("KZT")
+
+// futureTolerance допустимое опережение даты операции в тестах
+const futureTolerance = time.Hour
+
+// inlineTx выполняет функцию без транзакции: в тестах use-case хранилища заменены моками
+type inlineTx struct{}
+
+// WithinTx вызывает fn с исходным контекстом
+func (inlineTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// WithinReadOnlyTx вызывает fn с исходным контекстом
+func (inlineTx) WithinReadOnlyTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// incomeMocks моки хранилищ IncomeUseCase
+type incomeMocks struct {
+	incomes    *mocks.MockIncomeRepository
+	users      *mocks.MockUserRepository
+	categories *mocks.MockCategoryRepository
+	accounts   *mocks.MockAccountRepository
+	ledger     *mocks.MockLedgerRepository
+	rules      *mocks.MockRuleRepository
+}
+
+func setupIncomeMocks(t *testing.T) (*gomock.Controller, incomeMocks, *usecases.IncomeUseCase) {
 	ctrl := gomock.NewController(t)
-	mockRepo := mocks.NewMockIncomeRepository(ctrl)
-	useCase := usecases.NewIncomeUseCase(mockRepo)
+	m := incomeMocks{
+		incomes:    mocks.NewMockIncomeRepository(ctrl),
+		users:      mocks.NewMockUserRepository(ctrl),
+		categories: mocks.NewMockCategoryRepository(ctrl),
+		accounts:   mocks.NewMockAccountRepository(ctrl),
+		ledger:     mocks.NewMockLedgerRepository(ctrl),
+		rules:      mocks.NewMockRuleRepository(ctrl),
+	}
+	useCase := usecases.NewIncomeUseCase(usecases.IncomeDeps{
+		Incomes:    m.incomes,
+		Users:      m.users,
+		Categories: m.categories,
+		Accounts:   m.accounts,
+		Ledger:     m.ledger,
+		Rules:      m.rules,
+		Tx:         inlineTx{},
+	}, futureTolerance)
+	return ctrl, m, useCase
+}
+
+func setupTest(t *testing.T) (*gomock.Controller, *mocks.MockIncomeRepository, *usecases.IncomeUseCase) {
+	ctrl, mockRepo, _, useCase := setupTestWithUsers(t)
 	return ctrl, mockRepo, useCase
 }
 
+func setupTestWithUsers(t *testing.T) (*gomock.Controller, *mocks.MockIncomeRepository, *mocks.MockUserRepository, *usecases.IncomeUseCase) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	allowCategories(m.categories, domain.CategoryKindIncome)
+	allowLedger(m.ledger)
+	useRules(m.rules)
+	useIncomeHistory(m.incomes)
+	return ctrl, m.incomes, m.users, useCase
+}
+
+func setupTestWithCategories(t *testing.T) (*gomock.Controller, *mocks.MockIncomeRepository, *mocks.MockCategoryRepository, *usecases.IncomeUseCase) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	allowLedger(m.ledger)
+	useRules(m.rules)
+	useIncomeHistory(m.incomes)
+	return ctrl, m.incomes, m.categories, useCase
+}
+
+func setupTestWithAccounts(t *testing.T) (*gomock.Controller, *mocks.MockIncomeRepository, *mocks.MockAccountRepository, *usecases.IncomeUseCase) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	allowCategories(m.categories, domain.CategoryKindIncome)
+	allowLedger(m.ledger)
+	useRules(m.rules)
+	useIncomeHistory(m.incomes)
+	return ctrl, m.incomes, m.accounts, useCase
+}
+
+// allowCategories разрешает любые категории указанного типа для тестов, которые их не проверяют
+func allowCategories(mockCategories *mocks.MockCategoryRepository, kind domain.CategoryKind) {
+	mockCategories.EXPECT().GetCategory(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, userID int64, id int) (*domain.Category, error) {
+			return &domain.Category{ID: id, UserID: userID, Name: "Test", Kind: kind}, nil
+		}).AnyTimes()
+}
+
+// allowLedger принимает любые записи журнала для тестов, которые их не проверяют
+func allowLedger(mockLedger *mocks.MockLedgerRepository) {
+	mockLedger.EXPECT().PostEntry(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *domain.JournalEntry) (*domain.JournalEntry, error) {
+			return entry, nil
+		}).AnyTimes()
+}
+
+// useRules возвращает указанные правила категоризации при каждом запросе правил пользователя
+func useRules(mockRules *mocks.MockRuleRepository, rules ...domain.Rule) {
+	mockRules.EXPECT().ListRules(gomock.Any(), gomock.Any()).Return(rules, nil).AnyTimes()
+}
+
+// useIncomeHistory возвращает указанные сохраненные доходы при каждом поиске дубликатов
+func useIncomeHistory(mockRepo *mocks.MockIncomeRepository, incomes ...domain.Income) {
+	mockRepo.EXPECT().ListIncomesInRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(incomes, nil).AnyTimes()
+}
+
+// storeIncome имитирует сохранение дохода в хранилище: возвращает копию с присвоенным ID
+func storeIncome(_ context.Context, income *domain.Income) (*domain.Income, error) {
+	stored := *income
+	stored.ID = 7
+	return &stored, nil
+}
+
+func newIncome(userID int64, categoryID int, amount domain.Money, description string) *domain.Income {
+	return &domain.Income{
+		UserID:      userID,
+		CategoryID:  categoryID,
+		Amount:      amount,
+		Description: description,
+		OccurredAt:  time.Now().Add(-time.Hour),
+	}
+}
+
 func Test_IncomeUseCase_AddIncome_ReturnsNoError_WhenValidInput(t *testing.T) {
 	ctrl, mockRepo, useCase := setupTest(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
-	mockRepo.EXPECT().AddIncome(ctx, int64(1), 2, 100.50, "Test income").Return(nil)
+	input := newIncome(1, 2, domain.NewMoney(10050, kzt), "Test income")
+	created := *input
+	created.ID = 7
+	mockRepo.EXPECT().AddIncome(ctx, input).Return(&created, nil)
 
-	err := useCase.AddIncome(ctx, int64(1), 2, 100.50, "Test income")
+	got, _, err := useCase.AddIncome(ctx, input)
 
 	assert.NoError(t, err)
+	assert.Equal(t, int64(7), got.ID)
 }
 
 func Test_IncomeUseCase_AddIncome_ReturnsValidationError_WhenInvalidInput(t *testing.T) {
@@ -38,35 +164,689 @@ func Test_IncomeUseCase_AddIncome_ReturnsValidationError_WhenInvalidInput(t *tes
 		name   string
 		userID int64
 		catID  int
-		amount float64
+		amount domain.Money
 		desc   string
 		errMsg string
 	}{
-		{"Negative Amount", 1, 2, -100, "Invalid income", "validation failed: amount must be greater than 0"},
-		{"Zero UserID", 0, 2, 100, "Invalid income", "validation failed: user ID must be valid"},
-		{"Zero CategoryID", 1, 0, 100, "Invalid income", "validation failed: category ID must be valid"},
+		{"Negative Amount", 1, 2, domain.NewMoney(-10000, kzt), "Invalid income", "validation failed: amount must be greater than 0"},
+		{"Missing Currency", 1, 2, domain.NewMoney(10000, domain.Currency{}), "Invalid income", "validation failed: currency must be valid"},
+		{"Zero UserID", 0, 2, domain.NewMoney(10000, kzt), "Invalid income", "validation failed: user ID must be valid"},
+		{"Zero CategoryID", 1, 0, domain.NewMoney(10000, kzt), "Invalid income", "validation failed: category ID must be valid"},
 	}
 
 	ctx := context.Background()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := useCase.AddIncome(ctx, tt.userID, tt.catID, tt.amount, tt.desc)
+			_, _, err := useCase.AddIncome(ctx, newIncome(tt.userID, tt.catID, tt.amount, tt.desc))
 
 			assert.Error(t, err)
+			assert.ErrorIs(t, err, usecases.ErrValidation)
 			assert.EqualError(t, err, tt.errMsg)
 		})
 	}
 }
 
+func Test_IncomeUseCase_AddIncome_SetsOccurredAtToNow_WhenNotProvided(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	input := newIncome(1, 2, domain.NewMoney(10050, kzt), "Test income")
+	input.OccurredAt = time.Time{}
+	mockRepo.EXPECT().AddIncome(ctx, input).DoAndReturn(storeIncome)
+
+	before := time.Now()
+	got, _, err := useCase.AddIncome(ctx, input)
+
+	require.NoError(t, err)
+	assert.False(t, got.OccurredAt.Before(before))
+}
+
+func Test_IncomeUseCase_AddIncome_ReturnsValidationError_WhenOccurredAtTooFarInFuture(t *testing.T) {
+	_, _, useCase := setupTest(t)
+
+	input := newIncome(1, 2, domain.NewMoney(10050, kzt), "Test income")
+	input.OccurredAt = time.Now().Add(2 * futureTolerance)
+
+	_, _, err := useCase.AddIncome(context.Background(), input)
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+	assert.Contains(t, err.Error(), "is in the future")
+}
+
+func Test_IncomeUseCase_AddIncome_ReturnsNoError_WhenOccurredAtWithinTolerance(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	input := newIncome(1, 2, domain.NewMoney(10050, kzt), "Test income")
+	input.OccurredAt = time.Now().Add(futureTolerance / 2)
+	mockRepo.EXPECT().AddIncome(ctx, input).DoAndReturn(storeIncome)
+
+	_, _, err := useCase.AddIncome(ctx, input)
+
+	assert.NoError(t, err)
+}
+
 func Test_IncomeUseCase_AddIncome_ReturnsError_WhenRepoFails(t *testing.T) {
 	ctrl, mockRepo, useCase := setupTest(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
-	mockRepo.EXPECT().AddIncome(ctx, int64(1), 2, 100.0, "Test income").Return(errors.New("db error"))
+	input := newIncome(1, 2, domain.NewMoney(10000, kzt), "Test income")
+	mockRepo.EXPECT().AddIncome(ctx, input).Return(nil, errors.New("db error"))
 
-	err := useCase.AddIncome(ctx, int64(1), 2, 100, "Test income")
+	_, _, err := useCase.AddIncome(ctx, input)
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, "db error")
 }
+
+func Test_IncomeUseCase_AddIncome_AppliesRules_WhenCategoryNotSet(t *testing.T) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	defer ctrl.Finish()
+	allowCategories(m.categories, domain.CategoryKindIncome)
+	allowLedger(m.ledger)
+	useRules(m.rules,
+		domain.Rule{ID: 1, Priority: 1, Direction: domain.RuleDirectionIncome,
+			Conditions: domain.RuleConditions{DescriptionContains: "acme"}, Actions: domain.RuleActions{CategoryID: 4}},
+		domain.Rule{ID: 2, Priority: 2, Conditions: domain.RuleConditions{DescriptionRegex: `(?i)salary`},
+			Actions: domain.RuleActions{Description: "Salary", Tags: []string{"payroll", "Work"}}},
+	)
+
+	useIncomeHistory(m.incomes)
+
+	ctx := context.Background()
+	m.incomes.EXPECT().AddIncome(ctx, gomock.Any()).DoAndReturn(storeIncome)
+
+	income := newIncome(1, 0, domain.NewMoney(10050, kzt), "ACME LLP salary March")
+	income.Tags = []string{" work "}
+	got, _, err := useCase.AddIncome(ctx, income)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, got.CategoryID)
+	assert.Equal(t, "Salary", got.Description)
+	assert.Equal(t, []string{"work", "payroll"}, got.Tags)
+}
+
+func Test_IncomeUseCase_AddIncome_ReturnsValidationError_WhenCategoryNotAssignable(t *testing.T) {
+	tests := []struct {
+		name     string
+		category *domain.Category
+		err      error
+		errMsg   string
+	}{
+		{"Missing", nil, domain.ErrNotFound, "validation failed: category 2 does not exist"},
+		{"Expense Category", &domain.Category{ID: 2, Kind: domain.CategoryKindExpense}, nil,
+			"validation failed: category 2 is not an income category"},
+		{"Archived", &domain.Category{ID: 2, Kind: domain.CategoryKindIncome, Archived: true}, nil,
+			"validation failed: category 2 is archived"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, _, mockCategories, useCase := setupTestWithCategories(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockCategories.EXPECT().GetCategory(ctx, int64(1), 2).Return(tt.category, tt.err)
+
+			_, _, err := useCase.AddIncome(ctx, newIncome(1, 2, domain.NewMoney(10000, kzt), "Income"))
+
+			assert.EqualError(t, err, tt.errMsg)
+			assert.ErrorIs(t, err, usecases.ErrValidation)
+		})
+	}
+}
+
+func Test_IncomeUseCase_GetIncome_ReturnsValidationError_WhenIDInvalid(t *testing.T) {
+	_, _, useCase := setupTest(t)
+
+	_, err := useCase.GetIncome(context.Background(), 0, 1)
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+}
+
+func Test_IncomeUseCase_ListIncomes_ReturnsPageWithHasMore_WhenRepoReturnsExtraItem(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().
+		ListIncomes(ctx, domain.IncomeFilter{UserID: 1, PageSize: 2}).
+		Return([]domain.Income{{ID: 2}, {ID: 1}}, nil)
+
+	page, err := useCase.ListIncomes(ctx, domain.IncomeFilter{UserID: 1, PageSize: 1})
+
+	require.NoError(t, err)
+	assert.True(t, page.HasMore)
+	assert.Equal(t, []domain.Income{{ID: 2}}, page.Items)
+}
+
+func Test_IncomeUseCase_ListIncomes_NormalizesTags_WhenTagFilterSet(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().
+		ListIncomes(ctx, domain.IncomeFilter{UserID: 1, Tags: []string{"Travel", "reimbursable"}, PageSize: 51}).
+		Return(nil, nil)
+
+	_, err := useCase.ListIncomes(ctx, domain.IncomeFilter{UserID: 1, Tags: []string{"Travel ", "travel", "reimbursable"}})
+
+	require.NoError(t, err)
+}
+
+func Test_IncomeUseCase_ListIncomes_ReturnsValidationError_WhenFilterInvalid(t *testing.T) {
+	_, _, useCase := setupTest(t)
+
+	minAmount := domain.NewMoney(500, kzt)
+	maxAmount := domain.NewMoney(100, kzt)
+	usdAmount := domain.NewMoney(100, domain.MustCurrency("USD"))
+
+	tests := []struct {
+		name   string
+		filter domain.IncomeFilter
+		errMsg string
+	}{
+		{"Zero UserID", domain.IncomeFilter{}, "validation failed: user ID must be valid"},
+		{"Min Above Max", domain.IncomeFilter{UserID: 1, MinAmount: &minAmount, MaxAmount: &maxAmount},
+			"validation failed: minimum amount must not exceed maximum amount"},
+		{"Mixed Currencies", domain.IncomeFilter{UserID: 1, MinAmount: &minAmount, MaxAmount: &usdAmount},
+			"validation failed: amount range: currency mismatch: KZT and USD"},
+		{"Cursor From Other Sort", domain.IncomeFilter{UserID: 1, SortBy: domain.IncomeSortByAmount,
+			After: &domain.IncomeCursor{ID: 9, Value: "2024-03-15T00:00:00Z"}},
+			"validation failed: page token: cursor was issued for another sort order"},
+		{"Malformed Cursor Amount", domain.IncomeFilter{UserID: 1, SortBy: domain.IncomeSortByAmount,
+			After: &domain.IncomeCursor{ID: 9, SortBy: domain.IncomeSortByAmount, Value: "1e3"}},
+			`validation failed: page token: invalid cursor amount "1e3"`},
+		{"Malformed Cursor Date", domain.IncomeFilter{UserID: 1,
+			After: &domain.IncomeCursor{ID: 9, Value: "yesterday"}},
+			`validation failed: page token: invalid cursor date "yesterday"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.ListIncomes(context.Background(), tt.filter)
+
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
+
+func Test_IncomeUseCase_ListIncomes_UsesUserTimezone_WhenCalendarDatesSet(t *testing.T) {
+	ctrl, mockRepo, mockUsers, useCase := setupTestWithUsers(t)
+	defer ctrl.Finish()
+
+	almaty, err := time.LoadLocation("Asia/Almaty")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	filter := domain.IncomeFilter{
+		UserID:   1,
+		FromDate: domain.Date{Year: 2024, Month: time.March, Day: 1},
+		ToDate:   domain.Date{Year: 2024, Month: time.March, Day: 31},
+	}
+	mockUsers.EXPECT().GetTimezone(ctx, int64(1)).Return(almaty, nil)
+	mockRepo.EXPECT().ListIncomes(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, got domain.IncomeFilter) ([]domain.Income, error) {
+			assert.True(t, got.From.Equal(time.Date(2024, time.February, 29, 19, 0, 0, 0, time.UTC)))
+			assert.True(t, got.To.Equal(time.Date(2024, time.March, 31, 19, 0, 0, 0, time.UTC)))
+			return nil, nil
+		})
+
+	_, err = useCase.ListIncomes(ctx, filter)
+
+	assert.NoError(t, err)
+}
+
+func Test_IncomeUseCase_ListIncomes_ReturnsValidationError_WhenDatesAndTimestampsCombined(t *testing.T) {
+	_, _, useCase := setupTest(t)
+
+	filter := domain.IncomeFilter{
+		UserID:   1,
+		From:     time.Now(),
+		FromDate: domain.Date{Year: 2024, Month: time.March, Day: 1},
+	}
+
+	_, err := useCase.ListIncomes(context.Background(), filter)
+
+	assert.EqualError(t, err, "validation failed: calendar dates and timestamps cannot be combined in one range")
+}
+
+func Test_IncomeUseCase_UpdateIncome_AppliesPatch_WhenIncomeExists(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	existing := newIncome(1, 2, domain.NewMoney(10000, kzt), "Tpyo")
+	existing.ID = 7
+	mockRepo.EXPECT().GetIncome(ctx, int64(1), int64(7)).Return(existing, nil)
+
+	expected := *existing
+	expected.Description = "Typo"
+	mockRepo.EXPECT().UpdateIncome(ctx, &expected).Return(&expected, nil)
+
+	description := "Typo"
+	got, err := useCase.UpdateIncome(ctx, 1, 7, domain.IncomePatch{Description: &description})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Typo", got.Description)
+}
+
+func Test_IncomeUseCase_UpdateIncome_ReturnsValidationError_WhenPatchBreaksRules(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	existing := newIncome(1, 2, domain.NewMoney(10000, kzt), "Test income")
+	mockRepo.EXPECT().GetIncome(ctx, int64(1), int64(7)).Return(existing, nil)
+
+	categoryID := 0
+	_, err := useCase.UpdateIncome(ctx, 1, 7, domain.IncomePatch{CategoryID: &categoryID})
+
+	assert.EqualError(t, err, "validation failed: category ID must be valid")
+}
+
+func Test_IncomeUseCase_UpdateIncome_ReturnsNotFound_WhenIncomeMissing(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetIncome(ctx, int64(1), int64(7)).Return(nil, domain.ErrNotFound)
+
+	_, err := useCase.UpdateIncome(ctx, 1, 7, domain.IncomePatch{})
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func Test_IncomeUseCase_DeleteIncome_ReturnsRepoError_WhenRepoFails(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetIncome(ctx, int64(1), int64(7)).Return(newIncome(1, 2, domain.NewMoney(100, kzt), ""), nil)
+	mockRepo.EXPECT().DeleteIncome(ctx, int64(1), int64(7)).Return(errors.New("db error"))
+
+	err := useCase.DeleteIncome(ctx, 1, 7)
+
+	assert.EqualError(t, err, "db error")
+}
+
+func Test_IncomeUseCase_AddIncome_PostsEntryToAccount_WhenAccountSet(t *testing.T) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	defer ctrl.Finish()
+	allowCategories(m.categories, domain.CategoryKindIncome)
+	useRules(m.rules)
+
+	useIncomeHistory(m.incomes)
+
+	ctx := context.Background()
+	input := newIncome(1, 2, domain.NewMoney(10050, kzt), "Salary")
+	input.AccountID = 3
+	created := *input
+	created.ID = 7
+	gomock.InOrder(
+		m.accounts.EXPECT().GetAccount(ctx, int64(1), int64(3)).
+			Return(&domain.Account{ID: 3, UserID: 1, OpeningBalance: domain.NewMoney(0, kzt)}, nil),
+		m.incomes.EXPECT().AddIncome(ctx, input).Return(&created, nil),
+		m.ledger.EXPECT().PostEntry(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, entry *domain.JournalEntry) (*domain.JournalEntry, error) {
+				assert.Equal(t, domain.JournalSourceIncome, entry.SourceKind)
+				assert.Equal(t, int64(7), entry.SourceID)
+				assert.Equal(t, []domain.Posting{
+					{Account: domain.LedgerAccount{Kind: domain.LedgerAccountAsset, ID: 3}, Amount: domain.NewMoney(10050, kzt)},
+					{Account: domain.LedgerAccount{Kind: domain.LedgerAccountIncome, ID: 2}, Amount: domain.NewMoney(-10050, kzt)},
+				}, entry.Postings)
+				return entry, nil
+			}),
+	)
+
+	_, _, err := useCase.AddIncome(ctx, input)
+
+	assert.NoError(t, err)
+}
+
+func Test_IncomeUseCase_AddIncome_ReturnsError_WhenLedgerFails(t *testing.T) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	defer ctrl.Finish()
+	allowCategories(m.categories, domain.CategoryKindIncome)
+	useRules(m.rules)
+
+	useIncomeHistory(m.incomes)
+
+	ctx := context.Background()
+	input := newIncome(1, 2, domain.NewMoney(10050, kzt), "Salary")
+	created := *input
+	created.ID = 7
+	m.incomes.EXPECT().AddIncome(ctx, input).Return(&created, nil)
+	m.ledger.EXPECT().PostEntry(ctx, gomock.Any()).Return(nil, errors.New("db error"))
+
+	_, _, err := useCase.AddIncome(ctx, input)
+
+	assert.EqualError(t, err, "db error")
+}
+
+func Test_IncomeUseCase_AddIncome_ReturnsValidationError_WhenAccountUnusable(t *testing.T) {
+	usd := domain.MustCurrency("USD")
+	tests := []struct {
+		name    string
+		account *domain.Account
+		err     error
+		errMsg  string
+	}{
+		{"Missing", nil, domain.ErrNotFound, "validation failed: account 3 does not exist"},
+		{"Closed", &domain.Account{ID: 3, OpeningBalance: domain.NewMoney(0, kzt), ClosedAt: time.Now()}, nil,
+			"validation failed: account 3 is closed"},
+		{"Other Currency", &domain.Account{ID: 3, OpeningBalance: domain.NewMoney(0, usd)}, nil,
+			"validation failed: currency mismatch: account 3 is in USD, operation is in KZT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, _, mockAccounts, useCase := setupTestWithAccounts(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			input := newIncome(1, 2, domain.NewMoney(10050, kzt), "Salary")
+			input.AccountID = 3
+			mockAccounts.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(tt.account, tt.err)
+
+			_, _, err := useCase.AddIncome(ctx, input)
+
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
+
+func Test_IncomeUseCase_UpdateIncome_ReversesAndRepostsEntry_WhenAccountChanged(t *testing.T) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	existing := newIncome(1, 2, domain.NewMoney(10000, kzt), "Salary")
+	existing.ID = 7
+	existing.AccountID = 3
+	m.incomes.EXPECT().GetIncome(ctx, int64(1), int64(7)).Return(existing, nil)
+	m.accounts.EXPECT().GetAccount(ctx, int64(1), int64(4)).
+		Return(&domain.Account{ID: 4, UserID: 1, OpeningBalance: domain.NewMoney(0, kzt)}, nil)
+	m.incomes.EXPECT().UpdateIncome(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, income *domain.Income) (*domain.Income, error) {
+			return income, nil
+		})
+
+	var posted []domain.Posting
+	m.ledger.EXPECT().PostEntry(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *domain.JournalEntry) (*domain.JournalEntry, error) {
+			posted = append(posted, entry.Postings...)
+			return entry, nil
+		}).Times(2)
+
+	accountID := int64(4)
+	got, err := useCase.UpdateIncome(ctx, 1, 7, domain.IncomePatch{AccountID: &accountID})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), got.AccountID)
+	require.Len(t, posted, 4)
+	assert.Equal(t, domain.Posting{Account: domain.LedgerAccount{Kind: domain.LedgerAccountAsset, ID: 3},
+		Amount: domain.NewMoney(-10000, kzt)}, posted[0])
+	assert.Equal(t, domain.Posting{Account: domain.LedgerAccount{Kind: domain.LedgerAccountAsset, ID: 4},
+		Amount: domain.NewMoney(10000, kzt)}, posted[2])
+}
+
+func Test_IncomeUseCase_UpdateIncome_KeepsEntry_WhenOnlyDescriptionChanged(t *testing.T) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	existing := newIncome(1, 2, domain.NewMoney(10000, kzt), "Salary")
+	existing.ID = 7
+	m.incomes.EXPECT().GetIncome(ctx, int64(1), int64(7)).Return(existing, nil)
+	m.incomes.EXPECT().UpdateIncome(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, income *domain.Income) (*domain.Income, error) {
+			return income, nil
+		})
+
+	description := "Bonus"
+	_, err := useCase.UpdateIncome(ctx, 1, 7, domain.IncomePatch{Description: &description})
+
+	assert.NoError(t, err)
+}
+
+func Test_IncomeUseCase_DeleteIncome_ReversesEntry_WhenIncomeOnAccount(t *testing.T) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	existing := newIncome(1, 2, domain.NewMoney(10000, kzt), "Salary")
+	existing.ID = 7
+	existing.AccountID = 3
+	m.incomes.EXPECT().GetIncome(ctx, int64(1), int64(7)).Return(existing, nil)
+	m.incomes.EXPECT().DeleteIncome(ctx, int64(1), int64(7)).Return(nil)
+	m.ledger.EXPECT().PostEntry(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *domain.JournalEntry) (*domain.JournalEntry, error) {
+			assert.Equal(t, domain.NewMoney(-10000, kzt), entry.Postings[0].Amount)
+			assert.Equal(t, domain.NewMoney(10000, kzt), entry.Postings[1].Amount)
+			return entry, nil
+		})
+
+	err := useCase.DeleteIncome(ctx, 1, 7)
+
+	assert.NoError(t, err)
+}
+
+// storeIncomes имитирует многострочную вставку: присваивает доходам последовательные ID
+func storeIncomes(_ context.Context, incomes []domain.Income) ([]domain.Income, error) {
+	stored := slices.Clone(incomes)
+	for i := range stored {
+		stored[i].ID = int64(i + 1)
+	}
+	return stored, nil
+}
+
+func Test_IncomeUseCase_AddIncomes_ReturnsPerItemResults_WhenBestEffort(t *testing.T) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	defer ctrl.Finish()
+	allowLedger(m.ledger)
+	useRules(m.rules)
+
+	ctx := context.Background()
+	m.categories.EXPECT().GetCategory(ctx, int64(1), 2).
+		Return(&domain.Category{ID: 2, UserID: 1, Name: "Salary", Kind: domain.CategoryKindIncome}, nil)
+	m.incomes.EXPECT().AddIncomes(ctx, gomock.Len(2)).DoAndReturn(storeIncomes)
+	incomes := []*domain.Income{
+		newIncome(1, 2, domain.NewMoney(100, kzt), "First"),
+		newIncome(1, 2, domain.NewMoney(-100, kzt), "Negative"),
+		newIncome(1, 2, domain.NewMoney(300, kzt), "Third"),
+	}
+
+	results, err := useCase.AddIncomes(ctx, incomes, domain.BatchBestEffort)
+
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "First", results[0].Income.Description)
+	assert.Nil(t, results[1].Income)
+	assert.ErrorIs(t, results[1].Err, usecases.ErrValidation)
+	assert.Equal(t, "Third", results[2].Income.Description)
+	assert.Equal(t, int64(2), results[2].Income.ID)
+}
+
+func Test_IncomeUseCase_AddIncomes_AppliesRulesToEachItem_WhenRulesMatch(t *testing.T) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	defer ctrl.Finish()
+	allowCategories(m.categories, domain.CategoryKindIncome)
+	allowLedger(m.ledger)
+
+	ctx := context.Background()
+	m.rules.EXPECT().ListRules(ctx, int64(1)).Return([]domain.Rule{
+		{ID: 1, Conditions: domain.RuleConditions{DescriptionContains: "acme"},
+			Actions: domain.RuleActions{CategoryID: 4, Description: "Salary", Tags: []string{"work"}}},
+	}, nil)
+	m.incomes.EXPECT().AddIncomes(ctx, gomock.Len(2)).DoAndReturn(storeIncomes)
+	incomes := []*domain.Income{
+		newIncome(1, 0, domain.NewMoney(100, kzt), "ACME LLP"),
+		newIncome(1, 2, domain.NewMoney(300, kzt), "Bonus"),
+	}
+
+	results, err := useCase.AddIncomes(ctx, incomes, domain.BatchAtomic)
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, 4, results[0].Income.CategoryID)
+	assert.Equal(t, "Salary", results[0].Income.Description)
+	assert.Equal(t, []string{"work"}, results[0].Income.Tags)
+	assert.Equal(t, 2, results[1].Income.CategoryID)
+	assert.Equal(t, "Bonus", results[1].Income.Description)
+}
+
+func Test_IncomeUseCase_AddIncomesWithoutRules_SavesIncomesAsIs_WhenCallerAppliedRules(t *testing.T) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	defer ctrl.Finish()
+	allowCategories(m.categories, domain.CategoryKindIncome)
+	allowLedger(m.ledger)
+
+	ctx := context.Background()
+	m.incomes.EXPECT().AddIncomes(ctx, gomock.Len(1)).DoAndReturn(storeIncomes)
+
+	results, err := useCase.AddIncomesWithoutRules(ctx,
+		[]*domain.Income{newIncome(1, 2, domain.NewMoney(100, kzt), "ACME LLP")}, domain.BatchAtomic)
+
+	require.NoError(t, err)
+	assert.Equal(t, "ACME LLP", results[0].Income.Description)
+}
+
+func Test_IncomeUseCase_AddIncomes_ReturnsItemError_WhenAtomicAndItemInvalid(t *testing.T) {
+	ctrl, _, useCase := setupTest(t)
+	defer ctrl.Finish()
+
+	incomes := []*domain.Income{
+		newIncome(1, 2, domain.NewMoney(100, kzt), "First"),
+		newIncome(1, 2, domain.NewMoney(-100, kzt), "Negative"),
+	}
+
+	_, err := useCase.AddIncomes(context.Background(), incomes, domain.BatchAtomic)
+
+	var itemErr *domain.BatchItemError
+	require.ErrorAs(t, err, &itemErr)
+	assert.Equal(t, 1, itemErr.Index)
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+}
+
+func Test_IncomeUseCase_AddIncomes_ReturnsValidationError_WhenBatchEmpty(t *testing.T) {
+	ctrl, _, useCase := setupTest(t)
+	defer ctrl.Finish()
+
+	_, err := useCase.AddIncomes(context.Background(), nil, domain.BatchAtomic)
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+}
+
+func Test_IncomeUseCase_AddIncomes_ReturnsError_WhenRepoFails(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTest(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().AddIncomes(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+	_, err := useCase.AddIncomes(context.Background(),
+		[]*domain.Income{newIncome(1, 2, domain.NewMoney(100, kzt), "First")}, domain.BatchBestEffort)
+
+	assert.EqualError(t, err, "db error")
+}
+
+func Test_IncomeUseCase_FindDuplicates_ReturnsCandidatesByScore_WhenSimilarIncomesStored(t *testing.T) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	at := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+	input := newIncome(1, 2, domain.NewMoney(50_000, kzt), "Salary")
+	input.OccurredAt = at
+	stored := []domain.Income{
+		{ID: 3, UserID: 1, Amount: domain.NewMoney(50_000, kzt), OccurredAt: at.AddDate(0, 0, -1)},
+		{ID: 4, UserID: 1, Amount: domain.NewMoney(50_000, kzt), Description: "ACME SALARY", OccurredAt: at},
+		{ID: 5, UserID: 1, Amount: domain.NewMoney(70_000, kzt), Description: "Salary", OccurredAt: at},
+	}
+	window := 3*24*time.Hour + 12*time.Hour
+	m.incomes.EXPECT().ListIncomesInRange(ctx, int64(1), at.Add(-window), at.Add(window)).Return(stored, nil)
+
+	duplicates, err := useCase.FindDuplicates(ctx, []*domain.Income{input})
+
+	require.NoError(t, err)
+	require.Len(t, duplicates, 1)
+	require.Len(t, duplicates[0], 1)
+	assert.Equal(t, int64(4), duplicates[0][0].Income.ID)
+	assert.InDelta(t, 1, duplicates[0][0].Score, 1e-9)
+}
+
+func Test_IncomeUseCase_AddIncome_ReturnsDuplicates_WhenRenamedIncomeMatchesStored(t *testing.T) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	defer ctrl.Finish()
+	allowCategories(m.categories, domain.CategoryKindIncome)
+	allowLedger(m.ledger)
+	useRules(m.rules, domain.Rule{ID: 1, Conditions: domain.RuleConditions{DescriptionContains: "too 4471"},
+		Actions: domain.RuleActions{Description: "Salary"}})
+
+	ctx := context.Background()
+	input := newIncome(1, 2, domain.NewMoney(50_000, kzt), "TOO 4471 PAYMENT")
+	useIncomeHistory(m.incomes, domain.Income{ID: 4, UserID: 1, Amount: domain.NewMoney(50_000, kzt),
+		Description: "Salary", OccurredAt: input.OccurredAt})
+	m.incomes.EXPECT().AddIncome(ctx, gomock.Any()).DoAndReturn(storeIncome)
+
+	got, duplicates, err := useCase.AddIncome(ctx, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), got.ID)
+	require.Len(t, duplicates, 1)
+	assert.Equal(t, int64(4), duplicates[0].Income.ID)
+}
+
+func Test_IncomeUseCase_FindDuplicates_ReturnsValidationError_WhenUsersDiffer(t *testing.T) {
+	ctrl, _, useCase := setupIncomeMocks(t)
+	defer ctrl.Finish()
+
+	_, err := useCase.FindDuplicates(context.Background(), []*domain.Income{
+		newIncome(1, 2, domain.NewMoney(100, kzt), ""),
+		newIncome(2, 2, domain.NewMoney(100, kzt), ""),
+	})
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+}
+
+func Test_IncomeUseCase_MergeIncomes_ReversesDuplicateEntry_WhenMerged(t *testing.T) {
+	ctrl, m, useCase := setupIncomeMocks(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	kept := newIncome(1, 2, domain.NewMoney(10000, kzt), "Salary")
+	kept.ID = 7
+	duplicate := newIncome(1, 2, domain.NewMoney(10000, kzt), "ACME salary")
+	duplicate.ID, duplicate.AccountID = 5, 3
+	sources := []domain.IncomeSource{{IncomeID: 7, Income: *duplicate, MergedAt: time.Now()}}
+	gomock.InOrder(
+		m.incomes.EXPECT().GetIncome(ctx, int64(1), int64(7)).Return(kept, nil),
+		m.incomes.EXPECT().GetIncome(ctx, int64(1), int64(5)).Return(duplicate, nil),
+		m.incomes.EXPECT().MergeIncome(ctx, int64(7), duplicate).Return(nil),
+		m.ledger.EXPECT().PostEntry(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, entry *domain.JournalEntry) (*domain.JournalEntry, error) {
+				assert.Equal(t, int64(5), entry.SourceID)
+				assert.Equal(t, domain.NewMoney(-10000, kzt), entry.Postings[0].Amount)
+				return entry, nil
+			}),
+		m.incomes.EXPECT().ListIncomeSources(ctx, int64(1), int64(7)).Return(sources, nil),
+	)
+
+	income, merged, err := useCase.MergeIncomes(ctx, 1, 7, 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, kept, income)
+	assert.Equal(t, sources, merged)
+}
+
+func Test_IncomeUseCase_MergeIncomes_ReturnsValidationError_WhenSameIncome(t *testing.T) {
+	ctrl, _, useCase := setupIncomeMocks(t)
+	defer ctrl.Finish()
+
+	_, _, err := useCase.MergeIncomes(context.Background(), 1, 7, 7)
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+}
@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"fincraft-finance/internal/domain"
+)
+
+// postEntry проверяет инварианты двойной записи и добавляет запись в журнал
+func postEntry(ctx context.Context, ledger LedgerRepository, entry domain.JournalEntry) error {
+	if err := entry.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	_, err := ledger.PostEntry(ctx, &entry)
+	return err
+}
+
+// reverseEntry сторнирует запись журнала при изменении или удалении операции
+func reverseEntry(ctx context.Context, ledger LedgerRepository, entry domain.JournalEntry) error {
+	return postEntry(ctx, ledger, entry.Reverse())
+}
+
+// replaceEntry сторнирует прежнюю запись операции и проводит новую, если они различаются
+func replaceEntry(ctx context.Context, ledger LedgerRepository, previous, current domain.JournalEntry) error {
+	if previous.Equivalent(&current) {
+		return nil
+	}
+	if err := reverseEntry(ctx, ledger, previous); err != nil {
+		return err
+	}
+	return postEntry(ctx, ledger, current)
+}
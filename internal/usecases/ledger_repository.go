@@ -0,0 +1,17 @@
+package usecases
+
+import (
+	"context"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=ledger_repository.go -destination=mocks/ledger_repository_mock.go -package=mocks
+
+// LedgerRepository журнал двойной записи
+type LedgerRepository interface {
+	// PostEntry добавляет запись журнала; вызывается внутри транзакции вместе с записью исходной операции
+	PostEntry(ctx context.Context, entry *domain.JournalEntry) (*domain.JournalEntry, error)
+	// RebuildDailyBalances пересчитывает дневные обороты счетов пользователя по журналу в его текущем часовом поясе
+	RebuildDailyBalances(ctx context.Context, userID int64) error
+}
@@ -0,0 +1,26 @@
+package usecases
+
+// Размеры страниц для списочных запросов
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+// normalizePageSize подставляет размер по умолчанию и ограничивает слишком большие страницы
+func normalizePageSize(size int) int {
+	if size <= 0 {
+		return defaultPageSize
+	}
+	if size > maxPageSize {
+		return maxPageSize
+	}
+	return size
+}
+
+// cutPage обрезает выборку, запрошенную с запасом в один элемент, до размера страницы
+func cutPage[T any](items []T, size int) ([]T, bool) {
+	if len(items) > size {
+		return items[:size], true
+	}
+	return items, false
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=recurring_repository.go -destination=mocks/recurring_repository_mock.go -package=mocks
+
+// RecurringRuleRepository репозиторий правил повторяющихся доходов и их повторений
+type RecurringRuleRepository interface {
+	CreateRule(ctx context.Context, rule *domain.RecurringRule) (*domain.RecurringRule, error)
+	GetRule(ctx context.Context, userID, id int64) (*domain.RecurringRule, error)
+	ListRules(ctx context.Context, userID int64) ([]domain.RecurringRule, error)
+	// UpdateRule сохраняет признак паузы и положение расписания правила
+	UpdateRule(ctx context.Context, rule *domain.RecurringRule) (*domain.RecurringRule, error)
+	// ClaimDueRule блокирует до конца транзакции и возвращает одно активное правило,
+	// следующее повторение которого на момент now уже наступило по календарю пользователя.
+	// Правила, заблокированные другими экземплярами сервиса, и правила из skip пропускаются; nil - таких правил нет.
+	ClaimDueRule(ctx context.Context, now time.Time, skip []int64) (*domain.RecurringRule, error)
+	// ReserveOccurrence отмечает повторение проведенным; false, если оно уже отмечено (например, пропущено)
+	ReserveOccurrence(ctx context.Context, ruleID int64, date domain.Date) (bool, error)
+	// LinkOccurrence сохраняет доход, созданный по повторению
+	LinkOccurrence(ctx context.Context, ruleID int64, date domain.Date, incomeID int64) error
+	// SkipOccurrence отмечает будущее повторение пропущенным
+	SkipOccurrence(ctx context.Context, ruleID int64, date domain.Date) error
+	// SkippedOccurrences возвращает пропущенные необработанные повторения правил пользователя по ID правила
+	SkippedOccurrences(ctx context.Context, userID int64) (map[int64][]domain.Date, error)
+}
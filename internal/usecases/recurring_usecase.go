@@ -0,0 +1,297 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=recurring_usecase.go -destination=mocks/recurring_usecase_mock.go -package=mocks
+
+const (
+	// maxUpcomingPerRule сколько предстоящих повторений одного правила возвращается не более
+	maxUpcomingPerRule = 100
+	// defaultUpcomingDays на сколько дней вперед показываются предстоящие повторения по умолчанию
+	defaultUpcomingDays = 30
+)
+
+// RecurringService контракт сервиса для работы с повторяющимися доходами
+type RecurringService interface {
+	CreateRule(ctx context.Context, rule *domain.RecurringRule) (*domain.RecurringRule, error)
+	ListRules(ctx context.Context, userID int64) ([]domain.RecurringRule, error)
+	PauseRule(ctx context.Context, userID, id int64) (*domain.RecurringRule, error)
+	ResumeRule(ctx context.Context, userID, id int64) (*domain.RecurringRule, error)
+	SkipOccurrence(ctx context.Context, userID, id int64, date domain.Date) error
+	ListUpcoming(ctx context.Context, userID int64, until domain.Date) ([]domain.UpcomingOccurrence, error)
+}
+
+// RecurringDeps зависимости RecurringUseCase
+type RecurringDeps struct {
+	Rules      RecurringRuleRepository
+	Incomes    IncomeService
+	Users      UserRepository
+	Categories CategoryRepository
+	Accounts   AccountRepository
+	Tx         TxManager
+}
+
+// RecurringUseCase use-case для работы с повторяющимися доходами
+type RecurringUseCase struct {
+	rules      RecurringRuleRepository
+	incomes    IncomeService
+	users      UserRepository
+	categories CategoryRepository
+	accounts   AccountRepository
+	tx         TxManager
+}
+
+// NewRecurringUseCase создает новый экземпляр RecurringUseCase
+func NewRecurringUseCase(deps RecurringDeps) *RecurringUseCase {
+	return &RecurringUseCase{
+		rules:      deps.Rules,
+		incomes:    deps.Incomes,
+		users:      deps.Users,
+		categories: deps.Categories,
+		accounts:   deps.Accounts,
+		tx:         deps.Tx,
+	}
+}
+
+// CreateRule создает правило повторяющегося дохода.
+// Без даты начала правило действует с сегодняшнего дня в часовом поясе пользователя;
+// повторения с датой в прошлом будут проведены при ближайшем запуске планировщика.
+func (u *RecurringUseCase) CreateRule(ctx context.Context, rule *domain.RecurringRule) (*domain.RecurringRule, error) {
+	if rule.StartDate.IsZero() && rule.UserID > 0 {
+		today, err := u.today(ctx, rule.UserID)
+		if err != nil {
+			return nil, err
+		}
+		rule.StartDate = today
+	}
+	if rule.Interval == 0 {
+		rule.Interval = 1
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	if err := checkCategory(ctx, u.categories, rule.UserID, rule.CategoryID, domain.CategoryKindIncome); err != nil {
+		return nil, err
+	}
+	if err := checkPostable(ctx, u.accounts, rule.UserID, rule.AccountID, rule.Amount); err != nil {
+		return nil, err
+	}
+
+	rule.Processed = 0
+	rule.Reschedule()
+	return u.rules.CreateRule(ctx, rule)
+}
+
+// ListRules возвращает правила повторяющихся доходов пользователя
+func (u *RecurringUseCase) ListRules(ctx context.Context, userID int64) ([]domain.RecurringRule, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+
+	return u.rules.ListRules(ctx, userID)
+}
+
+// PauseRule приостанавливает проведение повторений правила
+func (u *RecurringUseCase) PauseRule(ctx context.Context, userID, id int64) (*domain.RecurringRule, error) {
+	return u.setPaused(ctx, userID, id, true)
+}
+
+// ResumeRule возобновляет правило. Повторения, наступившие за время паузы, не проводятся.
+func (u *RecurringUseCase) ResumeRule(ctx context.Context, userID, id int64) (*domain.RecurringRule, error) {
+	return u.setPaused(ctx, userID, id, false)
+}
+
+// setPaused меняет признак паузы правила
+func (u *RecurringUseCase) setPaused(ctx context.Context, userID, id int64, paused bool) (*domain.RecurringRule, error) {
+	if userID <= 0 || id <= 0 {
+		return nil, fmt.Errorf("%w: user ID and rule ID must be valid", ErrValidation)
+	}
+
+	var updated *domain.RecurringRule
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rule, err := u.rules.GetRule(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		if rule.Paused == paused {
+			updated = rule
+			return nil
+		}
+
+		if !paused {
+			today, err := u.today(ctx, userID)
+			if err != nil {
+				return err
+			}
+			rule.SkipBefore(today)
+		}
+		rule.Paused = paused
+
+		updated, err = u.rules.UpdateRule(ctx, rule)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// SkipOccurrence отменяет проведение одного предстоящего повторения правила
+func (u *RecurringUseCase) SkipOccurrence(ctx context.Context, userID, id int64, date domain.Date) error {
+	if userID <= 0 || id <= 0 {
+		return fmt.Errorf("%w: user ID and rule ID must be valid", ErrValidation)
+	}
+
+	rule, err := u.rules.GetRule(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if !rule.IsUpcoming(date) {
+		return fmt.Errorf("%w: %s is not an upcoming occurrence of rule %d", ErrValidation, date, id)
+	}
+
+	return u.rules.SkipOccurrence(ctx, rule.ID, date)
+}
+
+// ListUpcoming возвращает предстоящие повторения активных правил пользователя до даты until включительно,
+// упорядоченные по дате. Нулевая дата означает defaultUpcomingDays дней от сегодняшнего.
+func (u *RecurringUseCase) ListUpcoming(ctx context.Context, userID int64, until domain.Date) ([]domain.UpcomingOccurrence, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+	if until.IsZero() {
+		today, err := u.today(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		until = today.AddDays(defaultUpcomingDays)
+	}
+
+	rules, err := u.rules.ListRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	skipped, err := u.rules.SkippedOccurrences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var upcoming []domain.UpcomingOccurrence
+	for _, rule := range rules {
+		if rule.Paused {
+			continue
+		}
+		for _, date := range rule.Upcoming(until, maxUpcomingPerRule) {
+			upcoming = append(upcoming, domain.UpcomingOccurrence{
+				Rule:    rule,
+				Date:    date,
+				Skipped: containsDate(skipped[rule.ID], date),
+			})
+		}
+	}
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].Date.Before(upcoming[j].Date)
+	})
+
+	return upcoming, nil
+}
+
+// PostDue проводит все наступившие на момент now повторения и возвращает число обработанных.
+// Каждое повторение проводится в отдельной транзакции вместе со сдвигом расписания правила,
+// поэтому после перезапуска или на нескольких экземплярах сервиса повторение проводится ровно один раз.
+// Правило, повторение которого не проходит проверки (например, счет закрыт), ставится на паузу.
+// Правило, которое не удалось провести по другой причине, пропускается до следующего запуска,
+// чтобы не задерживать остальные; ошибки таких правил возвращаются вместе после обработки.
+func (u *RecurringUseCase) PostDue(ctx context.Context, now time.Time) (int, error) {
+	var (
+		processed int
+		failed    []int64
+		errs      []error
+	)
+	for ctx.Err() == nil {
+		var rule *domain.RecurringRule
+		err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			if rule, err = u.rules.ClaimDueRule(ctx, now, failed); err != nil || rule == nil {
+				return err
+			}
+			return u.postNext(ctx, rule)
+		})
+		if err != nil && rule == nil {
+			return processed, errors.Join(append(errs, err)...)
+		}
+		if errors.Is(err, ErrValidation) {
+			_, err = u.PauseRule(ctx, rule.UserID, rule.ID)
+			if err == nil {
+				continue
+			}
+		}
+		if err != nil {
+			failed = append(failed, rule.ID)
+			errs = append(errs, fmt.Errorf("rule %d: %w", rule.ID, err))
+			continue
+		}
+		if rule == nil {
+			return processed, errors.Join(errs...)
+		}
+		processed++
+	}
+
+	return processed, errors.Join(append(errs, ctx.Err())...)
+}
+
+// postNext проводит следующее повторение заблокированного правила и сдвигает его расписание
+func (u *RecurringUseCase) postNext(ctx context.Context, rule *domain.RecurringRule) error {
+	date := rule.NextDate
+	reserved, err := u.rules.ReserveOccurrence(ctx, rule.ID, date)
+	if err != nil {
+		return err
+	}
+
+	if reserved {
+		loc, err := u.users.GetTimezone(ctx, rule.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get user timezone: %w", err)
+		}
+		income, _, err := u.incomes.AddIncome(ctx, rule.Income(date, loc))
+		if err != nil {
+			return fmt.Errorf("occurrence %s: %w", date, err)
+		}
+		if err := u.rules.LinkOccurrence(ctx, rule.ID, date, income.ID); err != nil {
+			return err
+		}
+	}
+
+	rule.Advance()
+	_, err = u.rules.UpdateRule(ctx, rule)
+	return err
+}
+
+// today возвращает сегодняшнюю дату в часовом поясе пользователя
+func (u *RecurringUseCase) today(ctx context.Context, userID int64) (domain.Date, error) {
+	loc, err := u.users.GetTimezone(ctx, userID)
+	if err != nil {
+		return domain.Date{}, fmt.Errorf("failed to get user timezone: %w", err)
+	}
+	return domain.DateOf(time.Now().In(loc)), nil
+}
+
+// containsDate сообщает, что дата есть в списке
+func containsDate(dates []domain.Date, d domain.Date) bool {
+	for _, date := range dates {
+		if date == d {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,261 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+// recurringMocks моки зависимостей RecurringUseCase
+type recurringMocks struct {
+	rules      *mocks.MockRecurringRuleRepository
+	incomes    *mocks.MockIncomeService
+	users      *mocks.MockUserRepository
+	categories *mocks.MockCategoryRepository
+	accounts   *mocks.MockAccountRepository
+}
+
+func setupRecurringTest(t *testing.T) (*gomock.Controller, recurringMocks, *usecases.RecurringUseCase) {
+	ctrl := gomock.NewController(t)
+	m := recurringMocks{
+		rules:      mocks.NewMockRecurringRuleRepository(ctrl),
+		incomes:    mocks.NewMockIncomeService(ctrl),
+		users:      mocks.NewMockUserRepository(ctrl),
+		categories: mocks.NewMockCategoryRepository(ctrl),
+		accounts:   mocks.NewMockAccountRepository(ctrl),
+	}
+	useCase := usecases.NewRecurringUseCase(usecases.RecurringDeps{
+		Rules:      m.rules,
+		Incomes:    m.incomes,
+		Users:      m.users,
+		Categories: m.categories,
+		Accounts:   m.accounts,
+		Tx:         inlineTx{},
+	})
+	return ctrl, m, useCase
+}
+
+// salaryRule ежемесячная зарплата 10-го числа, начиная с марта 2024
+func salaryRule() *domain.RecurringRule {
+	rule := &domain.RecurringRule{
+		ID:          4,
+		UserID:      1,
+		CategoryID:  1,
+		Amount:      domain.NewMoney(50_000_000, kzt),
+		Description: "Salary",
+		Frequency:   domain.RecurrenceMonthly,
+		Interval:    1,
+		StartDate:   domain.Date{Year: 2024, Month: time.March, Day: 10},
+	}
+	rule.Reschedule()
+	return rule
+}
+
+// returnRule имитирует сохранение правила: возвращает его копию
+func returnRule(_ context.Context, rule *domain.RecurringRule) (*domain.RecurringRule, error) {
+	stored := *rule
+	return &stored, nil
+}
+
+func Test_RecurringUseCase_CreateRule_SchedulesFirstOccurrence_WhenValidInput(t *testing.T) {
+	ctrl, m, useCase := setupRecurringTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	allowCategories(m.categories, domain.CategoryKindIncome)
+	input := salaryRule()
+	input.Interval, input.NextDate = 0, domain.Date{}
+	m.rules.EXPECT().CreateRule(ctx, gomock.Any()).DoAndReturn(returnRule)
+
+	got, err := useCase.CreateRule(ctx, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Interval)
+	assert.Equal(t, domain.Date{Year: 2024, Month: time.March, Day: 10}, got.NextDate)
+}
+
+func Test_RecurringUseCase_CreateRule_ReturnsValidationError_WhenCategoryIsExpense(t *testing.T) {
+	ctrl, m, useCase := setupRecurringTest(t)
+	defer ctrl.Finish()
+
+	allowCategories(m.categories, domain.CategoryKindExpense)
+
+	_, err := useCase.CreateRule(context.Background(), salaryRule())
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+}
+
+func Test_RecurringUseCase_PostDue_PostsIncomeOnce_WhenOccurrenceDue(t *testing.T) {
+	ctrl, m, useCase := setupRecurringTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	now := time.Date(2024, time.March, 10, 5, 0, 0, 0, time.UTC)
+	almaty := time.FixedZone("Asia/Almaty", 5*60*60)
+	march := domain.Date{Year: 2024, Month: time.March, Day: 10}
+	gomock.InOrder(
+		m.rules.EXPECT().ClaimDueRule(ctx, now, nil).Return(salaryRule(), nil),
+		m.rules.EXPECT().ReserveOccurrence(ctx, int64(4), march).Return(true, nil),
+		m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(almaty, nil),
+		m.incomes.EXPECT().AddIncome(ctx, &domain.Income{UserID: 1, CategoryID: 1, Amount: domain.NewMoney(50_000_000, kzt),
+			Description: "Salary", OccurredAt: march.Start(almaty)}).Return(&domain.Income{ID: 7}, nil, nil),
+		m.rules.EXPECT().LinkOccurrence(ctx, int64(4), march, int64(7)).Return(nil),
+		m.rules.EXPECT().UpdateRule(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, rule *domain.RecurringRule) (*domain.RecurringRule, error) {
+				assert.Equal(t, 1, rule.Processed)
+				assert.Equal(t, domain.Date{Year: 2024, Month: time.April, Day: 10}, rule.NextDate)
+				return returnRule(ctx, rule)
+			}),
+		m.rules.EXPECT().ClaimDueRule(ctx, now, nil).Return(nil, nil),
+	)
+
+	n, err := useCase.PostDue(ctx, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func Test_RecurringUseCase_PostDue_AdvancesWithoutIncome_WhenOccurrenceSkipped(t *testing.T) {
+	ctrl, m, useCase := setupRecurringTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	now := time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC)
+	gomock.InOrder(
+		m.rules.EXPECT().ClaimDueRule(ctx, now, nil).Return(salaryRule(), nil),
+		m.rules.EXPECT().ReserveOccurrence(ctx, int64(4), gomock.Any()).Return(false, nil),
+		m.rules.EXPECT().UpdateRule(ctx, gomock.Any()).DoAndReturn(returnRule),
+		m.rules.EXPECT().ClaimDueRule(ctx, now, nil).Return(nil, nil),
+	)
+
+	n, err := useCase.PostDue(ctx, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func Test_RecurringUseCase_PostDue_PausesRule_WhenIncomeRejected(t *testing.T) {
+	ctrl, m, useCase := setupRecurringTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	now := time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC)
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil).AnyTimes()
+	gomock.InOrder(
+		m.rules.EXPECT().ClaimDueRule(ctx, now, nil).Return(salaryRule(), nil),
+		m.rules.EXPECT().ReserveOccurrence(ctx, int64(4), gomock.Any()).Return(true, nil),
+		m.incomes.EXPECT().AddIncome(ctx, gomock.Any()).
+			Return(nil, nil, fmt.Errorf("%w: account 3 is closed", usecases.ErrValidation)),
+		m.rules.EXPECT().GetRule(ctx, int64(1), int64(4)).Return(salaryRule(), nil),
+		m.rules.EXPECT().UpdateRule(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, rule *domain.RecurringRule) (*domain.RecurringRule, error) {
+				assert.True(t, rule.Paused)
+				assert.Equal(t, 0, rule.Processed)
+				return returnRule(ctx, rule)
+			}),
+		m.rules.EXPECT().ClaimDueRule(ctx, now, nil).Return(nil, nil),
+	)
+
+	n, err := useCase.PostDue(ctx, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func Test_RecurringUseCase_PostDue_PostsOtherRules_WhenFirstRuleFails(t *testing.T) {
+	ctrl, m, useCase := setupRecurringTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	now := time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC)
+	rent := salaryRule()
+	rent.ID, rent.UserID = 5, 2
+	m.users.EXPECT().GetTimezone(ctx, gomock.Any()).Return(time.UTC, nil).AnyTimes()
+	gomock.InOrder(
+		m.rules.EXPECT().ClaimDueRule(ctx, now, nil).Return(salaryRule(), nil),
+		m.rules.EXPECT().ReserveOccurrence(ctx, int64(4), gomock.Any()).Return(true, nil),
+		m.incomes.EXPECT().AddIncome(ctx, gomock.Any()).Return(nil, nil, errors.New("exchange rate unavailable")),
+		m.rules.EXPECT().ClaimDueRule(ctx, now, []int64{4}).Return(rent, nil),
+		m.rules.EXPECT().ReserveOccurrence(ctx, int64(5), gomock.Any()).Return(true, nil),
+		m.incomes.EXPECT().AddIncome(ctx, gomock.Any()).Return(&domain.Income{ID: 8}, nil, nil),
+		m.rules.EXPECT().LinkOccurrence(ctx, int64(5), gomock.Any(), int64(8)).Return(nil),
+		m.rules.EXPECT().UpdateRule(ctx, gomock.Any()).DoAndReturn(returnRule),
+		m.rules.EXPECT().ClaimDueRule(ctx, now, []int64{4}).Return(nil, nil),
+	)
+
+	n, err := useCase.PostDue(ctx, now)
+
+	assert.EqualError(t, err, "rule 4: occurrence 2024-03-10: exchange rate unavailable")
+	assert.Equal(t, 1, n)
+}
+
+func Test_RecurringUseCase_ResumeRule_SkipsMissedOccurrences_WhenPausedInPast(t *testing.T) {
+	ctrl, m, useCase := setupRecurringTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	rule := salaryRule()
+	rule.Paused = true
+	m.rules.EXPECT().GetRule(ctx, int64(1), int64(4)).Return(rule, nil)
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	m.rules.EXPECT().UpdateRule(ctx, gomock.Any()).DoAndReturn(returnRule)
+
+	got, err := useCase.ResumeRule(ctx, 1, 4)
+
+	require.NoError(t, err)
+	assert.False(t, got.Paused)
+	assert.False(t, got.NextDate.Before(domain.DateOf(time.Now().UTC())))
+}
+
+func Test_RecurringUseCase_SkipOccurrence_ReturnsValidationError_WhenDateNotScheduled(t *testing.T) {
+	ctrl, m, useCase := setupRecurringTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.rules.EXPECT().GetRule(ctx, int64(1), int64(4)).Return(salaryRule(), nil)
+
+	err := useCase.SkipOccurrence(ctx, 1, 4, domain.Date{Year: 2024, Month: time.April, Day: 11})
+
+	assert.EqualError(t, err, "validation failed: 2024-04-11 is not an upcoming occurrence of rule 4")
+}
+
+func Test_RecurringUseCase_ListUpcoming_MarksSkipped_WhenOccurrenceSkipped(t *testing.T) {
+	ctrl, m, useCase := setupRecurringTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	paused := salaryRule()
+	paused.ID, paused.Paused = 5, true
+	weekly := &domain.RecurringRule{ID: 6, UserID: 1, Frequency: domain.RecurrenceWeekly, Interval: 1,
+		StartDate: domain.Date{Year: 2024, Month: time.March, Day: 14}}
+	weekly.Reschedule()
+	april := domain.Date{Year: 2024, Month: time.April, Day: 10}
+	m.rules.EXPECT().ListRules(ctx, int64(1)).Return([]domain.RecurringRule{*salaryRule(), *paused, *weekly}, nil)
+	m.rules.EXPECT().SkippedOccurrences(ctx, int64(1)).Return(map[int64][]domain.Date{4: {april}}, nil)
+
+	got, err := useCase.ListUpcoming(ctx, 1, april)
+
+	require.NoError(t, err)
+	var dates []string
+	for _, o := range got {
+		dates = append(dates, fmt.Sprintf("%d:%s:%t", o.Rule.ID, o.Date, o.Skipped))
+	}
+	assert.Equal(t, []string{
+		"4:2024-03-10:false",
+		"6:2024-03-14:false",
+		"6:2024-03-21:false",
+		"6:2024-03-28:false",
+		"6:2024-04-04:false",
+		"4:2024-04-10:true",
+	}, dates)
+}
@@ -0,0 +1,22 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/reports"
+)
+
+//go:generate mockgen -source=report_repository.go -destination=mocks/report_repository_mock.go -package=mocks
+
+// ReportRepository агрегирует операции для отчетов
+type ReportRepository interface {
+	// SumIncomes возвращает суммы доходов по запросу за дни [From, To] в часовом поясе loc,
+	// сгруппированные по периодам запроса, категориям, счетам и валютам
+	SumIncomes(ctx context.Context, query reports.IncomeQuery, loc *time.Location) ([]reports.Row, error)
+	// OpeningBalances возвращает остатки счетов запроса по валютам перед днем before
+	OpeningBalances(ctx context.Context, query reports.CashFlowQuery, before domain.Date) ([]reports.Balance, error)
+	// SumFlows возвращает обороты счетов запроса за дни [From, To], сгруппированные по периодам, счетам и валютам
+	SumFlows(ctx context.Context, query reports.CashFlowQuery) ([]reports.FlowRow, error)
+}
@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/reports"
+)
+
+//go:generate mockgen -source=report_usecase.go -destination=mocks/report_usecase_mock.go -package=mocks
+
+// ReportService контракт сервиса отчетов
+type ReportService interface {
+	GetIncomeSummary(ctx context.Context, query reports.IncomeQuery) (*reports.IncomeSummary, error)
+	GetCashFlow(ctx context.Context, query reports.CashFlowQuery) (*reports.CashFlow, error)
+}
+
+// ReportDeps зависимости ReportUseCase
+type ReportDeps struct {
+	Reports   ReportRepository
+	Users     UserRepository
+	Converter MoneyConverter
+}
+
+// ReportUseCase use-case для построения отчетов
+type ReportUseCase struct {
+	repo      ReportRepository
+	users     UserRepository
+	converter MoneyConverter
+}
+
+// NewReportUseCase создает новый экземпляр ReportUseCase
+func NewReportUseCase(deps ReportDeps) *ReportUseCase {
+	return &ReportUseCase{
+		repo:      deps.Reports,
+		users:     deps.Users,
+		converter: deps.Converter,
+	}
+}
+
+// GetIncomeSummary возвращает доходы пользователя по периодам, категориям и счетам в валюте отчета.
+// Периоды считаются по часовому поясу пользователя, первый период сравнивается с предшествующим ему.
+// Суммы агрегирует хранилище, use-case только пересчитывает их в валюту отчета.
+func (u *ReportUseCase) GetIncomeSummary(ctx context.Context, query reports.IncomeQuery) (*reports.IncomeSummary, error) {
+	query.Tags = domain.NormalizeTags(query.Tags)
+	if err := query.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	loc, err := u.users.GetTimezone(ctx, query.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user timezone: %w", err)
+	}
+
+	periods := query.Periods()
+	sumQuery := query
+	sumQuery.From, sumQuery.To = query.Previous().From, periods[len(periods)-1].To.AddDays(-1)
+	rows, err := u.repo.SumIncomes(ctx, sumQuery, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	return reports.BuildIncomeSummary(ctx, u.converter, query, rows, domain.DateOf(time.Now().In(loc)))
+}
+
+// GetCashFlow возвращает доходы, расходы, переводы, чистый поток и остатки на конец каждого периода
+// по счетам и в целом в валюте отчета. Из остатков строится и отчет о капитале.
+// Обороты берутся из дневных оборотов счетов, поэтому запрос за много лет не обходит журнал.
+func (u *ReportUseCase) GetCashFlow(ctx context.Context, query reports.CashFlowQuery) (*reports.CashFlow, error) {
+	if err := query.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	loc, err := u.users.GetTimezone(ctx, query.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user timezone: %w", err)
+	}
+
+	periods := query.Periods()
+	opening, err := u.repo.OpeningBalances(ctx, query, periods[0].From)
+	if err != nil {
+		return nil, err
+	}
+	flowQuery := query
+	flowQuery.From, flowQuery.To = periods[0].From, periods[len(periods)-1].To.AddDays(-1)
+	rows, err := u.repo.SumFlows(ctx, flowQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return reports.BuildCashFlow(ctx, u.converter, query, opening, rows, domain.DateOf(time.Now().In(loc)))
+}
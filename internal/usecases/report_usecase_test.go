@@ -0,0 +1,124 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/reports"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+type reportMocks struct {
+	reports   *mocks.MockReportRepository
+	users     *mocks.MockUserRepository
+	converter *mocks.MockMoneyConverter
+}
+
+func setupReportTest(t *testing.T) (*gomock.Controller, reportMocks, *usecases.ReportUseCase) {
+	ctrl := gomock.NewController(t)
+	m := reportMocks{
+		reports:   mocks.NewMockReportRepository(ctrl),
+		users:     mocks.NewMockUserRepository(ctrl),
+		converter: mocks.NewMockMoneyConverter(ctrl),
+	}
+	useCase := usecases.NewReportUseCase(usecases.ReportDeps{
+		Reports:   m.reports,
+		Users:     m.users,
+		Converter: m.converter,
+	})
+	return ctrl, m, useCase
+}
+
+func Test_ReportUseCase_GetIncomeSummary_QueriesPreviousPeriod_WhenRangeValid(t *testing.T) {
+	ctrl, m, useCase := setupReportTest(t)
+	defer ctrl.Finish()
+
+	almaty, err := time.LoadLocation("Asia/Almaty")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	query := reports.IncomeQuery{UserID: 1, Granularity: reports.GranularityMonth,
+		From: domain.Date{Year: 2024, Month: time.February, Day: 10}, To: domain.Date{Year: 2024, Month: time.March, Day: 5},
+		Currency: kzt, Tags: []string{"work", "Work"}}
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(almaty, nil)
+	expected := query
+	expected.From = domain.Date{Year: 2024, Month: time.January, Day: 1}
+	expected.To = domain.Date{Year: 2024, Month: time.March, Day: 31}
+	expected.Tags = []string{"work"}
+	m.reports.EXPECT().SumIncomes(ctx, expected, almaty).Return([]reports.Row{
+		{PeriodStart: domain.Date{Year: 2024, Month: time.January, Day: 1}, CategoryID: 2,
+			Amount: domain.NewMoney(100_000, kzt)},
+		{PeriodStart: domain.Date{Year: 2024, Month: time.March, Day: 1}, CategoryID: 2,
+			Amount: domain.NewMoney(250_000, kzt)},
+	}, nil)
+	m.converter.EXPECT().Convert(ctx, gomock.Any(), kzt, gomock.Any()).
+		DoAndReturn(func(_ context.Context, money domain.Money, _ domain.Currency, _ time.Time) (domain.Money, error) {
+			return money, nil
+		}).Times(2)
+
+	summary, err := useCase.GetIncomeSummary(ctx, query)
+
+	require.NoError(t, err)
+	require.Len(t, summary.Periods, 2)
+	assert.Equal(t, domain.NewMoney(-100_000, kzt), summary.Periods[0].Delta)
+	assert.Equal(t, domain.NewMoney(250_000, kzt), summary.Periods[1].Delta)
+	assert.Equal(t, domain.NewMoney(250_000, kzt), summary.Total)
+}
+
+func Test_ReportUseCase_GetIncomeSummary_ReturnsValidationError_WhenQueryInvalid(t *testing.T) {
+	_, _, useCase := setupReportTest(t)
+
+	_, err := useCase.GetIncomeSummary(context.Background(), reports.IncomeQuery{UserID: 1,
+		Granularity: reports.GranularityMonth, Currency: kzt})
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+	assert.EqualError(t, err, "validation failed: from and to dates must be set and from must not be after to")
+}
+
+func Test_ReportUseCase_GetCashFlow_QueriesOpeningBalancesAndWholePeriods_WhenRangeValid(t *testing.T) {
+	ctrl, m, useCase := setupReportTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	query := reports.CashFlowQuery{UserID: 1, Granularity: reports.GranularityMonth,
+		From: domain.Date{Year: 2024, Month: time.February, Day: 10}, To: domain.Date{Year: 2024, Month: time.March, Day: 5},
+		Currency: kzt}
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	feb := domain.Date{Year: 2024, Month: time.February, Day: 1}
+	m.reports.EXPECT().OpeningBalances(ctx, query, feb).
+		Return([]reports.Balance{{AccountID: 3, Amount: domain.NewMoney(100_000, kzt)}}, nil)
+	expected := query
+	expected.From, expected.To = feb, domain.Date{Year: 2024, Month: time.March, Day: 31}
+	m.reports.EXPECT().SumFlows(ctx, expected).Return([]reports.FlowRow{{PeriodStart: feb, AccountID: 3,
+		Inflow: domain.NewMoney(50_000, kzt), Outflow: domain.NewMoney(20_000, kzt), Transfers: domain.NewMoney(0, kzt)}}, nil)
+	m.converter.EXPECT().Convert(ctx, gomock.Any(), kzt, gomock.Any()).
+		DoAndReturn(func(_ context.Context, money domain.Money, _ domain.Currency, _ time.Time) (domain.Money, error) {
+			return money, nil
+		}).AnyTimes()
+
+	cf, err := useCase.GetCashFlow(ctx, query)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(100_000, kzt), cf.Opening)
+	require.Len(t, cf.Periods, 2)
+	assert.Equal(t, domain.NewMoney(30_000, kzt), cf.Periods[0].Net)
+	assert.Equal(t, domain.NewMoney(130_000, kzt), cf.Periods[0].Balance)
+	assert.Equal(t, domain.NewMoney(130_000, kzt), cf.Periods[1].Balance)
+}
+
+func Test_ReportUseCase_GetCashFlow_ReturnsValidationError_WhenAccountInvalid(t *testing.T) {
+	_, _, useCase := setupReportTest(t)
+
+	_, err := useCase.GetCashFlow(context.Background(), reports.CashFlowQuery{UserID: 1,
+		Granularity: reports.GranularityMonth, From: domain.Date{Year: 2024, Month: time.January, Day: 1},
+		To: domain.Date{Year: 2024, Month: time.January, Day: 31}, Currency: kzt, AccountID: -1})
+
+	assert.EqualError(t, err, "validation failed: account ID must be valid")
+}
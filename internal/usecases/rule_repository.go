@@ -0,0 +1,20 @@
+package usecases
+
+import (
+	"context"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=rule_repository.go -destination=mocks/rule_repository_mock.go -package=mocks
+
+// RuleRepository репозиторий правил автоматической категоризации
+type RuleRepository interface {
+	CreateRule(ctx context.Context, rule *domain.Rule) (*domain.Rule, error)
+	GetRule(ctx context.Context, userID, id int64) (*domain.Rule, error)
+	// ListRules возвращает правила пользователя в порядке применения: по приоритету, затем по ID
+	ListRules(ctx context.Context, userID int64) ([]domain.Rule, error)
+	// UpdateRule сохраняет все изменяемые поля правила
+	UpdateRule(ctx context.Context, rule *domain.Rule) (*domain.Rule, error)
+	DeleteRule(ctx context.Context, userID, id int64) error
+}
@@ -0,0 +1,308 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=rule_usecase.go -destination=mocks/rule_usecase_mock.go -package=mocks
+
+// RuleService контракт сервиса правил автоматической категоризации
+type RuleService interface {
+	CreateRule(ctx context.Context, rule *domain.Rule) (*domain.Rule, error)
+	ListRules(ctx context.Context, userID int64) ([]domain.Rule, error)
+	UpdateRule(ctx context.Context, rule *domain.Rule) (*domain.Rule, error)
+	DeleteRule(ctx context.Context, userID, id int64) error
+	DryRunRule(ctx context.Context, rule *domain.Rule, from, to domain.Date) ([]domain.RuleChange, error)
+	ApplyRule(ctx context.Context, userID, id int64, from, to domain.Date) ([]domain.RuleChange, error)
+}
+
+// RuleDeps зависимости RuleUseCase
+type RuleDeps struct {
+	Rules RuleRepository
+	// IncomeHistory и ExpenseHistory читают операции за период, к которым применяется правило
+	IncomeHistory  IncomeRepository
+	ExpenseHistory ExpenseRepository
+	// Incomes и Expenses изменяют операции вместе с их записями в журнале
+	Incomes    IncomeService
+	Expenses   ExpenseService
+	Users      UserRepository
+	Categories CategoryRepository
+	Accounts   AccountRepository
+	Tx         TxManager
+}
+
+// RuleUseCase use-case для работы с правилами автоматической категоризации
+type RuleUseCase struct {
+	rules          RuleRepository
+	incomeHistory  IncomeRepository
+	expenseHistory ExpenseRepository
+	incomes        IncomeService
+	expenses       ExpenseService
+	users          UserRepository
+	categories     CategoryRepository
+	accounts       AccountRepository
+	tx             TxManager
+}
+
+// NewRuleUseCase создает новый экземпляр RuleUseCase
+func NewRuleUseCase(deps RuleDeps) *RuleUseCase {
+	return &RuleUseCase{
+		rules:          deps.Rules,
+		incomeHistory:  deps.IncomeHistory,
+		expenseHistory: deps.ExpenseHistory,
+		incomes:        deps.Incomes,
+		expenses:       deps.Expenses,
+		users:          deps.Users,
+		categories:     deps.Categories,
+		accounts:       deps.Accounts,
+		tx:             deps.Tx,
+	}
+}
+
+// ruleHistory операции пользователя за период, к которым применяется правило
+type ruleHistory struct {
+	incomes  []domain.Income
+	expenses []domain.Expense
+}
+
+// CreateRule создает правило категоризации.
+// Категория правила должна подходить к направлению правила, счета из условия и действия - существовать.
+func (u *RuleUseCase) CreateRule(ctx context.Context, rule *domain.Rule) (*domain.Rule, error) {
+	if err := u.checkRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return u.rules.CreateRule(ctx, rule)
+}
+
+// ListRules возвращает правила пользователя в порядке применения
+func (u *RuleUseCase) ListRules(ctx context.Context, userID int64) ([]domain.Rule, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+
+	return u.rules.ListRules(ctx, userID)
+}
+
+// UpdateRule полностью заменяет изменяемые поля правила и проверяет его так же, как при создании.
+// Уже обработанные операции не меняются: для этого служит ApplyRule.
+func (u *RuleUseCase) UpdateRule(ctx context.Context, rule *domain.Rule) (*domain.Rule, error) {
+	if rule.ID <= 0 {
+		return nil, fmt.Errorf("%w: rule ID must be valid", ErrValidation)
+	}
+	if err := u.checkRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return u.rules.UpdateRule(ctx, rule)
+}
+
+// DeleteRule удаляет правило пользователя; операции, к которым оно применялось, не меняются
+func (u *RuleUseCase) DeleteRule(ctx context.Context, userID, id int64) error {
+	if userID <= 0 || id <= 0 {
+		return fmt.Errorf("%w: user ID and rule ID must be valid", ErrValidation)
+	}
+
+	return u.rules.DeleteRule(ctx, userID, id)
+}
+
+// DryRunRule показывает, как правило, в том числе еще не сохраненное, изменило бы категории, описания и теги
+// доходов и расходов пользователя с датой в календарных днях [from, to] по его часовому поясу.
+// Правило применяется отдельно от остальных правил пользователя; ничего не сохраняется.
+func (u *RuleUseCase) DryRunRule(ctx context.Context, rule *domain.Rule, from, to domain.Date) ([]domain.RuleChange, error) {
+	if err := u.checkRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	history, err := u.history(ctx, rule, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return ruleChanges(rule, history)
+}
+
+// ApplyRule применяет сохраненное правило к доходам и расходам за календарные дни [from, to] и возвращает
+// внесенные изменения. Все операции меняются одной транзакцией вместе с записями журнала: отказ по любой
+// из них (например, категория правила с тех пор архивирована) отменяет применение целиком.
+// Теги добавляются только доходам, пометка перевода к уже сохраненным операциям не применяется.
+func (u *RuleUseCase) ApplyRule(ctx context.Context, userID, id int64, from, to domain.Date) ([]domain.RuleChange, error) {
+	if userID <= 0 || id <= 0 {
+		return nil, fmt.Errorf("%w: user ID and rule ID must be valid", ErrValidation)
+	}
+
+	var changes []domain.RuleChange
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rule, err := u.rules.GetRule(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		history, err := u.history(ctx, rule, from, to)
+		if err != nil {
+			return err
+		}
+		if changes, err = ruleChanges(rule, history); err != nil {
+			return err
+		}
+
+		for _, change := range changes {
+			if err := u.applyChange(ctx, userID, change, history); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// applyChange сохраняет изменение одной операции из history
+func (u *RuleUseCase) applyChange(ctx context.Context, userID int64, change domain.RuleChange, history ruleHistory) error {
+	if change.Kind == domain.JournalSourceExpense {
+		i := slices.IndexFunc(history.expenses, func(e domain.Expense) bool { return e.ID == change.OperationID })
+		expense := history.expenses[i]
+		expense.CategoryID, expense.Description = change.NewCategoryID, change.NewDescription
+		_, err := u.expenses.UpdateExpense(ctx, &expense)
+		return err
+	}
+
+	var patch domain.IncomePatch
+	if change.NewCategoryID != change.OldCategoryID {
+		patch.CategoryID = &change.NewCategoryID
+	}
+	if change.NewDescription != change.OldDescription {
+		patch.Description = &change.NewDescription
+	}
+	if len(change.AddedTags) > 0 {
+		i := slices.IndexFunc(history.incomes, func(in domain.Income) bool { return in.ID == change.OperationID })
+		tags := slices.Concat(history.incomes[i].Tags, change.AddedTags)
+		patch.Tags = &tags
+	}
+	_, err := u.incomes.UpdateIncome(ctx, userID, change.OperationID, patch)
+	return err
+}
+
+// checkRule проверяет правило по бизнес-правилам и ссылки на категорию и счета пользователя
+func (u *RuleUseCase) checkRule(ctx context.Context, rule *domain.Rule) error {
+	if err := rule.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	if id := rule.Actions.CategoryID; id != 0 {
+		kind := domain.CategoryKindIncome
+		if rule.Direction == domain.RuleDirectionExpense {
+			kind = domain.CategoryKindExpense
+		}
+		if err := checkCategory(ctx, u.categories, rule.UserID, id, kind); err != nil {
+			return err
+		}
+	}
+	for _, id := range []int64{rule.Conditions.AccountID, rule.Actions.TransferAccountID} {
+		if id == 0 {
+			continue
+		}
+		if _, err := findAccount(ctx, u.accounts, rule.UserID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// history возвращает операции пользователя за календарные дни [from, to], к которым может относиться правило
+func (u *RuleUseCase) history(ctx context.Context, rule *domain.Rule, from, to domain.Date) (ruleHistory, error) {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return ruleHistory{}, fmt.Errorf("%w: from and to dates must be set and from must not be after to", ErrValidation)
+	}
+	if from.DaysUntil(to) >= domain.MaxRuleHistoryDays {
+		return ruleHistory{}, fmt.Errorf("%w: period must not exceed %d days", ErrValidation, domain.MaxRuleHistoryDays)
+	}
+
+	loc, err := u.users.GetTimezone(ctx, rule.UserID)
+	if err != nil {
+		return ruleHistory{}, fmt.Errorf("failed to get user timezone: %w", err)
+	}
+	start, end := from.Start(loc), to.AddDays(1).Start(loc)
+
+	var history ruleHistory
+	if rule.Direction != domain.RuleDirectionExpense {
+		if history.incomes, err = u.incomeHistory.ListIncomesInRange(ctx, rule.UserID, start, end); err != nil {
+			return ruleHistory{}, err
+		}
+	}
+	if rule.Direction != domain.RuleDirectionIncome {
+		if history.expenses, err = u.expenseHistory.ListExpensesInRange(ctx, rule.UserID, start, end); err != nil {
+			return ruleHistory{}, err
+		}
+	}
+	return history, nil
+}
+
+// ruleChanges применяет правило к операциям и возвращает изменения в порядке дат операций.
+// Операции, условия для которых не выполнены или которые правило не меняет, пропускаются.
+func ruleChanges(rule *domain.Rule, history ruleHistory) ([]domain.RuleChange, error) {
+	set, err := domain.NewRuleSet([]domain.Rule{*rule})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	var changes []domain.RuleChange
+	for _, income := range history.incomes {
+		outcome := set.Evaluate(domain.RuleSubject{Amount: income.Amount, Description: income.Description,
+			AccountID: income.AccountID})
+		change := domain.RuleChange{Kind: domain.JournalSourceIncome, OperationID: income.ID, Amount: income.Amount,
+			OccurredAt: income.OccurredAt, OldCategoryID: income.CategoryID, OldDescription: income.Description,
+			AddedTags: addedTags(income.Tags, outcome.Tags)}
+		if changed(&change, outcome) {
+			changes = append(changes, change)
+		}
+	}
+	for _, expense := range history.expenses {
+		outcome := set.Evaluate(domain.RuleSubject{Amount: expense.Amount.Neg(), Description: expense.Description})
+		change := domain.RuleChange{Kind: domain.JournalSourceExpense, OperationID: expense.ID, Amount: expense.Amount,
+			OccurredAt: expense.OccurredAt, OldCategoryID: expense.CategoryID, OldDescription: expense.Description}
+		if changed(&change, outcome) {
+			changes = append(changes, change)
+		}
+	}
+
+	slices.SortStableFunc(changes, func(a, b domain.RuleChange) int {
+		return cmp.Or(a.OccurredAt.Compare(b.OccurredAt), cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.OperationID, b.OperationID))
+	})
+	return changes, nil
+}
+
+// changed заполняет новые категорию и описание операции по итогу правил; false, если операция не меняется.
+// Добавленные теги должны быть заполнены заранее.
+func changed(change *domain.RuleChange, outcome domain.RuleOutcome) bool {
+	change.NewCategoryID, change.NewDescription = change.OldCategoryID, change.OldDescription
+	if !outcome.Matched() {
+		return false
+	}
+	if outcome.CategoryID != 0 {
+		change.NewCategoryID = outcome.CategoryID
+	}
+	if outcome.Description != "" {
+		change.NewDescription = outcome.Description
+	}
+	return change.NewCategoryID != change.OldCategoryID || change.NewDescription != change.OldDescription ||
+		len(change.AddedTags) > 0
+}
+
+// addedTags возвращает теги ruleTags, которых нет среди tags без учета регистра
+func addedTags(tags, ruleTags []string) []string {
+	var added []string
+	for _, tag := range ruleTags {
+		if !slices.ContainsFunc(tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			added = append(added, tag)
+		}
+	}
+	return added
+}
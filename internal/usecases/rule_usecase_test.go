@@ -0,0 +1,184 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+// ruleMocks моки зависимостей RuleUseCase
+type ruleMocks struct {
+	rules          *mocks.MockRuleRepository
+	incomeHistory  *mocks.MockIncomeRepository
+	expenseHistory *mocks.MockExpenseRepository
+	incomes        *mocks.MockIncomeService
+	expenses       *mocks.MockExpenseService
+	users          *mocks.MockUserRepository
+	categories     *mocks.MockCategoryRepository
+	accounts       *mocks.MockAccountRepository
+}
+
+func setupRuleTest(t *testing.T) (*gomock.Controller, ruleMocks, *usecases.RuleUseCase) {
+	ctrl := gomock.NewController(t)
+	m := ruleMocks{
+		rules:          mocks.NewMockRuleRepository(ctrl),
+		incomeHistory:  mocks.NewMockIncomeRepository(ctrl),
+		expenseHistory: mocks.NewMockExpenseRepository(ctrl),
+		incomes:        mocks.NewMockIncomeService(ctrl),
+		expenses:       mocks.NewMockExpenseService(ctrl),
+		users:          mocks.NewMockUserRepository(ctrl),
+		categories:     mocks.NewMockCategoryRepository(ctrl),
+		accounts:       mocks.NewMockAccountRepository(ctrl),
+	}
+	useCase := usecases.NewRuleUseCase(usecases.RuleDeps{
+		Rules:          m.rules,
+		IncomeHistory:  m.incomeHistory,
+		ExpenseHistory: m.expenseHistory,
+		Incomes:        m.incomes,
+		Expenses:       m.expenses,
+		Users:          m.users,
+		Categories:     m.categories,
+		Accounts:       m.accounts,
+		Tx:             inlineTx{},
+	})
+	return ctrl, m, useCase
+}
+
+// taxiRule правило, которое переносит расходы на такси в категорию 8 и переименовывает их
+func taxiRule() *domain.Rule {
+	return &domain.Rule{ID: 3, UserID: 1, Name: "Taxi", Direction: domain.RuleDirectionExpense,
+		Conditions: domain.RuleConditions{DescriptionRegex: `(?i)\b(yandex|uber)\b`},
+		Actions:    domain.RuleActions{CategoryID: 8, Description: "Taxi"}}
+}
+
+// marchFrom и marchTo период истории, к которому применяется правило в тестах
+var marchFrom, marchTo = domain.Date{Year: 2024, Month: time.March, Day: 1}, domain.Date{Year: 2024, Month: time.March, Day: 31}
+
+func Test_RuleUseCase_CreateRule_ReturnsValidationError_WhenCategoryKindMismatch(t *testing.T) {
+	ctrl, m, useCase := setupRuleTest(t)
+	defer ctrl.Finish()
+	allowCategories(m.categories, domain.CategoryKindIncome)
+
+	_, err := useCase.CreateRule(context.Background(), taxiRule())
+
+	assert.EqualError(t, err, "validation failed: category 8 is not an expense category")
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+}
+
+func Test_RuleUseCase_DryRunRule_ReturnsChanges_WhenExpensesMatch(t *testing.T) {
+	ctrl, m, useCase := setupRuleTest(t)
+	defer ctrl.Finish()
+	allowCategories(m.categories, domain.CategoryKindExpense)
+
+	ctx := context.Background()
+	first := time.Date(2024, time.March, 2, 9, 0, 0, 0, time.UTC)
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	m.expenseHistory.EXPECT().ListExpensesInRange(ctx, int64(1), marchFrom.Start(time.UTC), marchTo.AddDays(1).Start(time.UTC)).
+		Return([]domain.Expense{
+			{ID: 20, UserID: 1, CategoryID: 5, Amount: domain.NewMoney(2_000, kzt), Description: "UBER trip", OccurredAt: first.AddDate(0, 0, 3)},
+			{ID: 21, UserID: 1, CategoryID: 5, Amount: domain.NewMoney(900, kzt), Description: "Coffee", OccurredAt: first},
+			{ID: 22, UserID: 1, CategoryID: 8, Amount: domain.NewMoney(1_500, kzt), Description: "Taxi", OccurredAt: first},
+			{ID: 23, UserID: 1, CategoryID: 8, Amount: domain.NewMoney(1_200, kzt), Description: "Yandex Go", OccurredAt: first},
+		}, nil)
+
+	changes, err := useCase.DryRunRule(ctx, taxiRule(), marchFrom, marchTo)
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.RuleChange{
+		{Kind: domain.JournalSourceExpense, OperationID: 23, Amount: domain.NewMoney(1_200, kzt), OccurredAt: first,
+			OldCategoryID: 8, NewCategoryID: 8, OldDescription: "Yandex Go", NewDescription: "Taxi"},
+		{Kind: domain.JournalSourceExpense, OperationID: 20, Amount: domain.NewMoney(2_000, kzt), OccurredAt: first.AddDate(0, 0, 3),
+			OldCategoryID: 5, NewCategoryID: 8, OldDescription: "UBER trip", NewDescription: "Taxi"},
+	}, changes)
+}
+
+func Test_RuleUseCase_DryRunRule_ReturnsValidationError_WhenPeriodInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to domain.Date
+		errMsg   string
+	}{
+		{"Missing From", domain.Date{}, marchTo, "validation failed: from and to dates must be set and from must not be after to"},
+		{"Reversed", marchTo, marchFrom, "validation failed: from and to dates must be set and from must not be after to"},
+		{"Too Long", marchFrom, marchFrom.AddDays(domain.MaxRuleHistoryDays), "validation failed: period must not exceed 366 days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, m, useCase := setupRuleTest(t)
+			defer ctrl.Finish()
+			allowCategories(m.categories, domain.CategoryKindExpense)
+
+			_, err := useCase.DryRunRule(context.Background(), taxiRule(), tt.from, tt.to)
+
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
+
+func Test_RuleUseCase_ApplyRule_UpdatesOperations_WhenRuleMatches(t *testing.T) {
+	ctrl, m, useCase := setupRuleTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	at := time.Date(2024, time.March, 5, 9, 0, 0, 0, time.UTC)
+	salary := "Salary"
+	m.rules.EXPECT().GetRule(ctx, int64(1), int64(4)).Return(&domain.Rule{ID: 4, UserID: 1, Name: "Salary",
+		Conditions: domain.RuleConditions{DescriptionContains: "acme"},
+		Actions:    domain.RuleActions{Description: salary}}, nil)
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	m.incomeHistory.EXPECT().ListIncomesInRange(ctx, int64(1), gomock.Any(), gomock.Any()).
+		Return([]domain.Income{{ID: 30, UserID: 1, CategoryID: 2, Amount: domain.NewMoney(250_000, kzt),
+			Description: "ACME LLP payroll", OccurredAt: at}}, nil)
+	m.expenseHistory.EXPECT().ListExpensesInRange(ctx, int64(1), gomock.Any(), gomock.Any()).
+		Return([]domain.Expense{{ID: 31, UserID: 1, CategoryID: 5, Amount: domain.NewMoney(3_000, kzt),
+			Description: "ACME refund fee", OccurredAt: at}}, nil)
+	m.incomes.EXPECT().UpdateIncome(ctx, int64(1), int64(30), domain.IncomePatch{Description: &salary}).
+		Return(&domain.Income{ID: 30}, nil)
+	m.expenses.EXPECT().UpdateExpense(ctx, &domain.Expense{ID: 31, UserID: 1, CategoryID: 5,
+		Amount: domain.NewMoney(3_000, kzt), Description: salary, OccurredAt: at}).Return(&domain.Expense{ID: 31}, nil)
+
+	changes, err := useCase.ApplyRule(ctx, 1, 4, marchFrom, marchTo)
+
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, domain.JournalSourceIncome, changes[0].Kind)
+	assert.Equal(t, domain.JournalSourceExpense, changes[1].Kind)
+}
+
+func Test_RuleUseCase_ApplyRule_AddsMissingTags_WhenIncomeMatches(t *testing.T) {
+	ctrl, m, useCase := setupRuleTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	at := time.Date(2024, time.March, 5, 9, 0, 0, 0, time.UTC)
+	m.rules.EXPECT().GetRule(ctx, int64(1), int64(4)).Return(&domain.Rule{ID: 4, UserID: 1, Name: "Refunds",
+		Direction:  domain.RuleDirectionIncome,
+		Conditions: domain.RuleConditions{DescriptionContains: "refund"},
+		Actions:    domain.RuleActions{Tags: []string{"reimbursable", "work"}}}, nil)
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	m.incomeHistory.EXPECT().ListIncomesInRange(ctx, int64(1), gomock.Any(), gomock.Any()).
+		Return([]domain.Income{
+			{ID: 30, UserID: 1, CategoryID: 2, Amount: domain.NewMoney(5_000, kzt), Description: "Travel refund",
+				Tags: []string{"Work"}, OccurredAt: at},
+			{ID: 32, UserID: 1, CategoryID: 2, Amount: domain.NewMoney(7_000, kzt), Description: "Hotel refund",
+				Tags: []string{"reimbursable", "work"}, OccurredAt: at},
+		}, nil)
+	tags := []string{"Work", "reimbursable"}
+	m.incomes.EXPECT().UpdateIncome(ctx, int64(1), int64(30), domain.IncomePatch{Tags: &tags}).
+		Return(&domain.Income{ID: 30}, nil)
+
+	changes, err := useCase.ApplyRule(ctx, 1, 4, marchFrom, marchTo)
+
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, []string{"reimbursable"}, changes[0].AddedTags)
+}
@@ -0,0 +1,15 @@
+package usecases
+
+import (
+	"context"
+)
+
+//go:generate mockgen -source=statement_repository.go -destination=mocks/statement_repository_mock.go -package=mocks
+
+// StatementRepository хранилище идентификаторов операций, импортированных из выписок
+type StatementRepository interface {
+	// ImportedIDs возвращает те из ids, которые пользователь уже импортировал
+	ImportedIDs(ctx context.Context, userID int64, ids []string) (map[string]bool, error)
+	// SaveImportedIDs запоминает импортированные идентификаторы; повтор уже сохраненного - ошибка
+	SaveImportedIDs(ctx context.Context, userID int64, ids []string) error
+}
@@ -0,0 +1,454 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=statement_usecase.go -destination=mocks/statement_usecase_mock.go -package=mocks
+
+// StatementService контракт сервиса импорта банковских выписок
+type StatementService interface {
+	ImportStatement(ctx context.Context, imp domain.StatementImport) (domain.StatementImportResult, error)
+}
+
+// StatementDeps зависимости StatementUseCase
+type StatementDeps struct {
+	Statements StatementRepository
+	Incomes    IncomeService
+	Expenses   ExpenseService
+	Transfers  TransferService
+	Users      UserRepository
+	Categories CategoryRepository
+	Accounts   AccountRepository
+	Rules      RuleRepository
+	Tx         TxManager
+}
+
+// StatementUseCase use-case импорта разобранных банковских выписок
+type StatementUseCase struct {
+	statements StatementRepository
+	incomes    IncomeService
+	expenses   ExpenseService
+	transfers  TransferService
+	users      UserRepository
+	categories CategoryRepository
+	accounts   AccountRepository
+	rules      RuleRepository
+	tx         TxManager
+
+	futureTolerance time.Duration
+}
+
+// NewStatementUseCase создает новый экземпляр StatementUseCase.
+// futureTolerance задает, насколько дата строки выписки может опережать текущее время.
+func NewStatementUseCase(deps StatementDeps, futureTolerance time.Duration) *StatementUseCase {
+	return &StatementUseCase{
+		statements: deps.Statements,
+		incomes:    deps.Incomes,
+		expenses:   deps.Expenses,
+		transfers:  deps.Transfers,
+		users:      deps.Users,
+		categories: deps.Categories,
+		accounts:   deps.Accounts,
+		rules:      deps.Rules,
+		tx:         deps.Tx,
+
+		futureTolerance: futureTolerance,
+	}
+}
+
+// statementOperations операции, собранные из строк выписки, с номерами исходных строк
+type statementOperations struct {
+	incomes       []*domain.Income
+	incomeLines   []int
+	expenses      []*domain.Expense
+	expenseLines  []int
+	transfers     []*domain.Transfer
+	transferLines []int
+}
+
+// ImportStatement создает доходы из поступлений, расходы из списаний и переводы из строк с названием
+// второго счета. Операция датируется началом дня строки в часовом поясе пользователя.
+// К строкам применяются правила категоризации пользователя (см. applyRules); строки без категории
+// из правила попадают в категорию импорта по умолчанию.
+// Строки с ошибкой разбора, уже импортированные ранее (по ExternalID) или не прошедшие проверку
+// (дата в будущем, недоступная категория, закрытый счет, валюта, отличная от валюты счета) пропускаются
+// и возвращаются с причиной; остальные записываются одной транзакцией: отказ хранилища по любой
+// из них отменяет весь импорт. Поступления, похожие на уже сохраненные доходы, помечаются
+// вероятным дубликатом, но импортируются: объединить их можно через MergeIncomes. При DryRun ничего не сохраняется.
+func (u *StatementUseCase) ImportStatement(ctx context.Context, imp domain.StatementImport) (domain.StatementImportResult, error) {
+	if imp.UserID <= 0 {
+		return domain.StatementImportResult{}, fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+	if len(imp.Lines) == 0 || len(imp.Lines) > domain.MaxBatchSize {
+		return domain.StatementImportResult{}, fmt.Errorf("%w: statement must contain from 1 to %d lines",
+			ErrValidation, domain.MaxBatchSize)
+	}
+
+	loc, err := u.users.GetTimezone(ctx, imp.UserID)
+	if err != nil {
+		return domain.StatementImportResult{}, fmt.Errorf("failed to get user timezone: %w", err)
+	}
+
+	result := domain.StatementImportResult{Lines: slices.Clone(imp.Lines)}
+	if err := u.markImported(ctx, imp.UserID, result.Lines); err != nil {
+		return domain.StatementImportResult{}, err
+	}
+	if err := u.applyRules(ctx, imp, result.Lines); err != nil {
+		return domain.StatementImportResult{}, err
+	}
+	if err := u.checkCategories(ctx, imp, result.Lines); err != nil {
+		return domain.StatementImportResult{}, err
+	}
+	if err := u.checkImportAccount(ctx, imp, result.Lines); err != nil {
+		return domain.StatementImportResult{}, err
+	}
+	counterparts, err := u.resolveTransferAccounts(ctx, imp, result.Lines)
+	if err != nil {
+		return domain.StatementImportResult{}, err
+	}
+
+	ops := collectStatementOperations(imp, result.Lines, counterparts, loc, u.futureTolerance)
+	if err := u.flagDuplicates(ctx, ops, result.Lines); err != nil {
+		return domain.StatementImportResult{}, err
+	}
+	result.Incomes, result.Expenses, result.Transfers = len(ops.incomes), len(ops.expenses), len(ops.transfers)
+	if imp.DryRun {
+		return result, nil
+	}
+
+	err = u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.saveOperations(ctx, ops, result.Lines); err != nil {
+			return err
+		}
+
+		var imported []string
+		for i := range result.Lines {
+			if line := &result.Lines[i]; line.ExternalID != "" && line.OperationID != 0 {
+				imported = append(imported, line.ExternalID)
+			}
+		}
+		if len(imported) == 0 {
+			return nil
+		}
+		return u.statements.SaveImportedIDs(ctx, imp.UserID, imported)
+	})
+	if err != nil {
+		return domain.StatementImportResult{}, err
+	}
+
+	return result, nil
+}
+
+// saveOperations создает собранные операции и записывает их ID в строки выписки
+func (u *StatementUseCase) saveOperations(ctx context.Context, ops statementOperations, lines []domain.StatementLine) error {
+	if len(ops.incomes) > 0 {
+		created, err := u.incomes.AddIncomesWithoutRules(ctx, ops.incomes, domain.BatchAtomic)
+		var itemErr *domain.BatchItemError
+		if errors.As(err, &itemErr) {
+			return fmt.Errorf("line %d: %w", lines[ops.incomeLines[itemErr.Index]].Number, itemErr.Err)
+		}
+		if err != nil {
+			return err
+		}
+		for j, r := range created {
+			lines[ops.incomeLines[j]].OperationID = r.Income.ID
+		}
+	}
+
+	for j, expense := range ops.expenses {
+		line := &lines[ops.expenseLines[j]]
+		created, err := u.expenses.AddExpense(ctx, expense)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line.Number, err)
+		}
+		line.OperationID = created.ID
+	}
+
+	for j, transfer := range ops.transfers {
+		line := &lines[ops.transferLines[j]]
+		created, err := u.transfers.AddTransfer(ctx, transfer)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line.Number, err)
+		}
+		line.OperationID = created.ID
+	}
+	return nil
+}
+
+// markImported отмечает строки, операции которых уже импортированы ранее или повторяются в выписке
+func (u *StatementUseCase) markImported(ctx context.Context, userID int64, lines []domain.StatementLine) error {
+	var ids []string
+	for i := range lines {
+		if lines[i].Err == nil && lines[i].ExternalID != "" {
+			ids = append(ids, lines[i].ExternalID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	imported, err := u.statements.ImportedIDs(ctx, userID, ids)
+	if err != nil {
+		return fmt.Errorf("failed to check imported transactions: %w", err)
+	}
+	for i := range lines {
+		line := &lines[i]
+		if line.Err != nil || line.ExternalID == "" {
+			continue
+		}
+		if imported[line.ExternalID] {
+			line.Err = fmt.Errorf("%w: %s", domain.ErrAlreadyImported, line.ExternalID)
+			continue
+		}
+		imported[line.ExternalID] = true
+	}
+	return nil
+}
+
+// applyRules применяет к строкам выписки правила категоризации пользователя в порядке приоритета.
+// Описание из правила заменяет описание строки, категория из правила - категорию импорта по умолчанию.
+// Пометка перевода применяется только при импорте на счет и только к другому счету; к строкам, у которых
+// второй счет перевода указан в выписке, категория и пометка перевода из правил не применяются.
+// Теги правил сохраняются у созданных доходов; у расходов и переводов тегов пока нет.
+// Доходы сохраняются без повторного применения правил (см. IncomeService.AddIncomesWithoutRules).
+func (u *StatementUseCase) applyRules(ctx context.Context, imp domain.StatementImport, lines []domain.StatementLine) error {
+	rules, err := u.rules.ListRules(ctx, imp.UserID)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+	set, err := domain.NewRuleSet(rules)
+	if err != nil {
+		return err
+	}
+
+	for i := range lines {
+		line := &lines[i]
+		if line.Err != nil {
+			continue
+		}
+		outcome := set.Evaluate(domain.RuleSubject{Amount: line.Amount, Description: line.Description,
+			AccountID: imp.AccountID, CounterpartyName: line.CounterpartyName, CounterpartyIBAN: line.CounterpartyIBAN})
+		if !outcome.Matched() {
+			continue
+		}
+
+		line.RuleIDs, line.Tags = outcome.RuleIDs, outcome.Tags
+		if outcome.Description != "" {
+			line.Description = outcome.Description
+		}
+		if line.TransferAccount != "" {
+			continue
+		}
+		line.CategoryID = outcome.CategoryID
+		if imp.AccountID > 0 && outcome.TransferAccountID != imp.AccountID {
+			line.TransferAccountID = outcome.TransferAccountID
+		}
+	}
+	return nil
+}
+
+// checkCategories проверяет категории, в которые попадут поступления и списания выписки.
+// Недоступная категория из правила - причина отказа строки, категория импорта по умолчанию - всего импорта.
+func (u *StatementUseCase) checkCategories(ctx context.Context, imp domain.StatementImport, lines []domain.StatementLine) error {
+	var hasIncomes, hasExpenses bool
+	checked := make(map[int]error)
+	for i := range lines {
+		line := &lines[i]
+		if line.Err != nil || line.IsTransfer() {
+			continue
+		}
+		if line.CategoryID == 0 {
+			hasIncomes = hasIncomes || line.IsIncome()
+			hasExpenses = hasExpenses || line.IsExpense()
+			continue
+		}
+
+		err, ok := checked[line.CategoryID]
+		if !ok {
+			kind := domain.CategoryKindIncome
+			if line.IsExpense() {
+				kind = domain.CategoryKindExpense
+			}
+			err = checkCategory(ctx, u.categories, imp.UserID, line.CategoryID, kind)
+			if err != nil && !isRejection(err) {
+				return err
+			}
+			checked[line.CategoryID] = err
+		}
+		line.Err = err
+	}
+
+	if hasIncomes {
+		if imp.IncomeCategoryID <= 0 {
+			return fmt.Errorf("%w: income category must be set to import incoming lines", ErrValidation)
+		}
+		if err := checkCategory(ctx, u.categories, imp.UserID, imp.IncomeCategoryID, domain.CategoryKindIncome); err != nil {
+			return err
+		}
+	}
+	if hasExpenses {
+		if imp.ExpenseCategoryID <= 0 {
+			return fmt.Errorf("%w: expense category must be set to import outgoing lines", ErrValidation)
+		}
+		if err := checkCategory(ctx, u.categories, imp.UserID, imp.ExpenseCategoryID, domain.CategoryKindExpense); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkImportAccount проверяет, что на счет импорта можно провести строки выписки.
+// Отсутствующий счет - причина отказа всего импорта, закрытый счет или строка в другой валюте - строки.
+func (u *StatementUseCase) checkImportAccount(ctx context.Context, imp domain.StatementImport,
+	lines []domain.StatementLine) error {
+	if imp.AccountID == 0 {
+		return nil
+	}
+
+	account, err := findAccount(ctx, u.accounts, imp.UserID, imp.AccountID)
+	if err != nil {
+		return err
+	}
+	for i := range lines {
+		if line := &lines[i]; line.Err == nil {
+			line.Err = account.CheckCanPost(line.Amount)
+		}
+	}
+	return nil
+}
+
+// resolveTransferAccounts находит счета пользователя по названиям или ID из строк-переводов и возвращает
+// ID второго счета по номеру строки в lines. Ненайденный или закрытый счет, а также счет в валюте,
+// отличной от валюты строки (перевод с конвертацией выписка не описывает), - причина отказа строки.
+func (u *StatementUseCase) resolveTransferAccounts(ctx context.Context, imp domain.StatementImport,
+	lines []domain.StatementLine) (map[int]int64, error) {
+	hasTransfers := slices.ContainsFunc(lines, func(l domain.StatementLine) bool { return l.Err == nil && l.IsTransfer() })
+	if !hasTransfers {
+		return nil, nil
+	}
+	if imp.AccountID <= 0 {
+		return nil, fmt.Errorf("%w: account must be set to import transfers", ErrValidation)
+	}
+
+	accounts, err := u.accounts.ListAccounts(ctx, domain.AccountFilter{UserID: imp.UserID, IncludeClosed: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	byName := make(map[string]*domain.Account, len(accounts))
+	byID := make(map[int64]*domain.Account, len(accounts))
+	for j := range accounts {
+		account := &accounts[j]
+		byName[strings.ToLower(account.Name)] = account
+		byID[account.ID] = account
+	}
+
+	counterparts := make(map[int]int64)
+	for i := range lines {
+		line := &lines[i]
+		if line.Err != nil || !line.IsTransfer() {
+			continue
+		}
+
+		var account *domain.Account
+		if line.TransferAccountID != 0 {
+			if account = byID[line.TransferAccountID]; account == nil {
+				line.Err = fmt.Errorf("account %d does not exist", line.TransferAccountID)
+				continue
+			}
+		} else if account = byName[strings.ToLower(line.TransferAccount)]; account == nil {
+			line.Err = fmt.Errorf("account %q does not exist", line.TransferAccount)
+			continue
+		}
+		if line.Err = account.CheckCanPost(line.Amount.Neg()); line.Err == nil {
+			counterparts[i] = account.ID
+		}
+	}
+	return counterparts, nil
+}
+
+// flagDuplicates отмечает строки-поступления, для которых найден вероятный дубликат среди сохраненных доходов
+func (u *StatementUseCase) flagDuplicates(ctx context.Context, ops statementOperations, lines []domain.StatementLine) error {
+	if len(ops.incomes) == 0 {
+		return nil
+	}
+
+	duplicates, err := u.incomes.FindDuplicates(ctx, ops.incomes)
+	if err != nil {
+		return err
+	}
+	for k, candidates := range duplicates {
+		if len(candidates) > 0 {
+			line := &lines[ops.incomeLines[k]]
+			line.DuplicateOf, line.DuplicateScore = candidates[0].Income.ID, candidates[0].Score
+		}
+	}
+	return nil
+}
+
+// collectStatementOperations собирает операции из строк выписки; причина отказа записывается в строку.
+// counterparts содержит ID второго счета для строк-переводов, futureTolerance - допустимое опережение
+// даты строки относительно текущего времени.
+func collectStatementOperations(imp domain.StatementImport, lines []domain.StatementLine, counterparts map[int]int64,
+	loc *time.Location, futureTolerance time.Duration) statementOperations {
+	var ops statementOperations
+	now := time.Now()
+	for i := range lines {
+		line := &lines[i]
+		if line.Err != nil {
+			continue
+		}
+
+		occurredAt := line.Date.Start(loc)
+		if line.Err = domain.ValidateOccurredAt(occurredAt, now, futureTolerance); line.Err != nil {
+			continue
+		}
+		description := truncateDescription(line.Description)
+		switch {
+		case line.IsTransfer():
+			other := counterparts[i]
+			transfer := &domain.Transfer{UserID: imp.UserID, FromAccountID: imp.AccountID, ToAccountID: other,
+				FromAmount: line.Amount.Neg(), ToAmount: line.Amount.Neg(), Description: description, OccurredAt: occurredAt}
+			if line.Amount.IsPositive() {
+				transfer.FromAccountID, transfer.ToAccountID = other, imp.AccountID
+				transfer.FromAmount, transfer.ToAmount = line.Amount, line.Amount
+			}
+			if line.Err = transfer.Validate(); line.Err == nil {
+				ops.transfers = append(ops.transfers, transfer)
+				ops.transferLines = append(ops.transferLines, i)
+			}
+		case line.IsIncome():
+			income := &domain.Income{UserID: imp.UserID, CategoryID: cmp.Or(line.CategoryID, imp.IncomeCategoryID),
+				AccountID: imp.AccountID, Amount: line.Amount, Description: description, Tags: line.Tags, OccurredAt: occurredAt}
+			if line.Err = income.Validate(); line.Err == nil {
+				ops.incomes = append(ops.incomes, income)
+				ops.incomeLines = append(ops.incomeLines, i)
+			}
+		default:
+			expense := &domain.Expense{UserID: imp.UserID, CategoryID: cmp.Or(line.CategoryID, imp.ExpenseCategoryID),
+				AccountID: imp.AccountID, Amount: line.Amount.Neg(), Description: description, OccurredAt: occurredAt}
+			if line.Err = expense.Validate(); line.Err == nil {
+				ops.expenses = append(ops.expenses, expense)
+				ops.expenseLines = append(ops.expenseLines, i)
+			}
+		}
+	}
+	return ops
+}
+
+// truncateDescription обрезает описание операции до допустимой длины
+func truncateDescription(s string) string {
+	runes := []rune(s)
+	if len(runes) <= domain.MaxDescriptionLength {
+		return s
+	}
+	return string(runes[:domain.MaxDescriptionLength])
+}
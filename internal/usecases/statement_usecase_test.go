@@ -0,0 +1,357 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+// statementMocks моки зависимостей StatementUseCase
+type statementMocks struct {
+	statements *mocks.MockStatementRepository
+	incomes    *mocks.MockIncomeService
+	expenses   *mocks.MockExpenseService
+	transfers  *mocks.MockTransferService
+	users      *mocks.MockUserRepository
+	categories *mocks.MockCategoryRepository
+	accounts   *mocks.MockAccountRepository
+	rules      *mocks.MockRuleRepository
+}
+
+func setupStatementTest(t *testing.T) (*gomock.Controller, statementMocks, *usecases.StatementUseCase) {
+	ctrl := gomock.NewController(t)
+	m := statementMocks{
+		statements: mocks.NewMockStatementRepository(ctrl),
+		incomes:    mocks.NewMockIncomeService(ctrl),
+		expenses:   mocks.NewMockExpenseService(ctrl),
+		transfers:  mocks.NewMockTransferService(ctrl),
+		users:      mocks.NewMockUserRepository(ctrl),
+		categories: mocks.NewMockCategoryRepository(ctrl),
+		accounts:   mocks.NewMockAccountRepository(ctrl),
+		rules:      mocks.NewMockRuleRepository(ctrl),
+	}
+	useCase := usecases.NewStatementUseCase(usecases.StatementDeps{
+		Statements: m.statements,
+		Incomes:    m.incomes,
+		Expenses:   m.expenses,
+		Transfers:  m.transfers,
+		Users:      m.users,
+		Categories: m.categories,
+		Accounts:   m.accounts,
+		Rules:      m.rules,
+		Tx:         inlineTx{},
+	}, futureTolerance)
+	return ctrl, m, useCase
+}
+
+// allowNoDuplicates разрешает поиск дубликатов поступлений, который ничего не находит
+func allowNoDuplicates(mock *mocks.MockIncomeService) {
+	mock.EXPECT().FindDuplicates(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, incomes []*domain.Income) ([][]domain.DuplicateCandidate, error) {
+			return make([][]domain.DuplicateCandidate, len(incomes)), nil
+		}).AnyTimes()
+}
+
+// statementAccounts счета пользователя для строк-переводов: текущий счет импорта, сберегательный,
+// закрытый и долларовый
+func statementAccounts() []domain.Account {
+	return []domain.Account{
+		{ID: 3, Name: "Checking", OpeningBalance: domain.NewMoney(0, kzt)},
+		{ID: 4, Name: "Savings", OpeningBalance: domain.NewMoney(0, kzt)},
+		{ID: 5, Name: "Deposit", OpeningBalance: domain.NewMoney(0, kzt), ClosedAt: time.Now()},
+		{ID: 6, Name: "Dollars", OpeningBalance: domain.NewMoney(0, usd)},
+	}
+}
+
+// statementImport выписка из поступления, списания и строки с ошибкой разбора
+func statementImport() domain.StatementImport {
+	date := domain.Date{Year: 2024, Month: time.March, Day: 15}
+	return domain.StatementImport{
+		UserID:            1,
+		AccountID:         3,
+		IncomeCategoryID:  2,
+		ExpenseCategoryID: 5,
+		Lines: []domain.StatementLine{
+			{Number: 2, Date: date, Amount: domain.NewMoney(250_000, kzt), Description: "Salary"},
+			{Number: 3, Err: errors.New(`invalid date "15.03"`)},
+			{Number: 4, Date: date, Amount: domain.NewMoney(-1_500, kzt), Description: "Coffee"},
+		},
+	}
+}
+
+func Test_StatementUseCase_ImportStatement_CreatesIncomesAndExpenses_WhenLinesValid(t *testing.T) {
+	ctrl, m, useCase := setupStatementTest(t)
+	defer ctrl.Finish()
+	useRules(m.rules)
+	allowNoDuplicates(m.incomes)
+
+	ctx := context.Background()
+	almaty, err := time.LoadLocation("Asia/Almaty")
+	require.NoError(t, err)
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(almaty, nil)
+	m.accounts.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(newAccount(0), nil)
+	m.categories.EXPECT().GetCategory(ctx, int64(1), 2).
+		Return(&domain.Category{ID: 2, UserID: 1, Name: "Salary", Kind: domain.CategoryKindIncome}, nil)
+	m.categories.EXPECT().GetCategory(ctx, int64(1), 5).
+		Return(&domain.Category{ID: 5, UserID: 1, Name: "Cafe", Kind: domain.CategoryKindExpense}, nil)
+	m.incomes.EXPECT().AddIncomesWithoutRules(ctx, gomock.Len(1), domain.BatchAtomic).
+		DoAndReturn(func(_ context.Context, incomes []*domain.Income, _ domain.BatchMode) ([]domain.IncomeBatchResult, error) {
+			assert.Equal(t, int64(3), incomes[0].AccountID)
+			assert.Equal(t, time.Date(2024, time.March, 15, 0, 0, 0, 0, almaty), incomes[0].OccurredAt)
+			stored := *incomes[0]
+			stored.ID = 7
+			return []domain.IncomeBatchResult{{Income: &stored}}, nil
+		})
+	m.expenses.EXPECT().AddExpense(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, expense *domain.Expense) (*domain.Expense, error) {
+			assert.Equal(t, domain.NewMoney(1_500, kzt), expense.Amount)
+			assert.Equal(t, 5, expense.CategoryID)
+			assert.Equal(t, int64(3), expense.AccountID)
+			stored := *expense
+			stored.ID = 9
+			return &stored, nil
+		})
+
+	result, err := useCase.ImportStatement(ctx, statementImport())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Incomes)
+	assert.Equal(t, 1, result.Expenses)
+	assert.Equal(t, int64(7), result.Lines[0].OperationID)
+	assert.Error(t, result.Lines[1].Err)
+	assert.Equal(t, int64(9), result.Lines[2].OperationID)
+}
+
+func Test_StatementUseCase_ImportStatement_SavesNothing_WhenDryRun(t *testing.T) {
+	ctrl, m, useCase := setupStatementTest(t)
+	defer ctrl.Finish()
+	useRules(m.rules)
+	allowNoDuplicates(m.incomes)
+
+	m.users.EXPECT().GetTimezone(gomock.Any(), int64(1)).Return(time.UTC, nil)
+	m.accounts.EXPECT().GetAccount(gomock.Any(), int64(1), int64(3)).Return(newAccount(0), nil)
+	m.categories.EXPECT().GetCategory(gomock.Any(), int64(1), 2).
+		Return(&domain.Category{ID: 2, UserID: 1, Name: "Salary", Kind: domain.CategoryKindIncome}, nil)
+	m.categories.EXPECT().GetCategory(gomock.Any(), int64(1), 5).
+		Return(&domain.Category{ID: 5, UserID: 1, Name: "Cafe", Kind: domain.CategoryKindExpense}, nil)
+	imp := statementImport()
+	imp.DryRun = true
+	imp.Lines = append(imp.Lines, domain.StatementLine{Number: 5, Date: imp.Lines[0].Date,
+		Amount: domain.NewMoney(0, kzt), Description: "Zero"})
+
+	result, err := useCase.ImportStatement(context.Background(), imp)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Incomes)
+	assert.Equal(t, 1, result.Expenses)
+	assert.Zero(t, result.Lines[0].OperationID)
+	assert.EqualError(t, result.Lines[3].Err, "amount must be greater than 0")
+}
+
+func Test_StatementUseCase_ImportStatement_ReturnsValidationError_WhenExpenseCategoryMissing(t *testing.T) {
+	ctrl, m, useCase := setupStatementTest(t)
+	defer ctrl.Finish()
+	useRules(m.rules)
+
+	m.users.EXPECT().GetTimezone(gomock.Any(), int64(1)).Return(time.UTC, nil)
+	allowCategories(m.categories, domain.CategoryKindIncome)
+	imp := statementImport()
+	imp.ExpenseCategoryID = 0
+
+	_, err := useCase.ImportStatement(context.Background(), imp)
+
+	assert.EqualError(t, err, "validation failed: expense category must be set to import outgoing lines")
+}
+
+func Test_StatementUseCase_ImportStatement_ReturnsLineNumber_WhenIncomeRejected(t *testing.T) {
+	ctrl, m, useCase := setupStatementTest(t)
+	defer ctrl.Finish()
+	useRules(m.rules)
+	allowNoDuplicates(m.incomes)
+
+	m.users.EXPECT().GetTimezone(gomock.Any(), int64(1)).Return(time.UTC, nil)
+	m.accounts.EXPECT().GetAccount(gomock.Any(), int64(1), int64(3)).Return(newAccount(0), nil)
+	m.categories.EXPECT().GetCategory(gomock.Any(), int64(1), gomock.Any()).
+		DoAndReturn(func(_ context.Context, userID int64, id int) (*domain.Category, error) {
+			kind := domain.CategoryKindIncome
+			if id == 5 {
+				kind = domain.CategoryKindExpense
+			}
+			return &domain.Category{ID: id, UserID: userID, Name: "Test", Kind: kind}, nil
+		}).Times(2)
+	m.incomes.EXPECT().AddIncomesWithoutRules(gomock.Any(), gomock.Any(), domain.BatchAtomic).
+		Return(nil, &domain.BatchItemError{Index: 0, Err: usecases.ErrValidation})
+
+	_, err := useCase.ImportStatement(context.Background(), statementImport())
+
+	assert.EqualError(t, err, "line 2: validation failed")
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+}
+
+func Test_StatementUseCase_ImportStatement_SkipsLines_WhenAlreadyImported(t *testing.T) {
+	ctrl, m, useCase := setupStatementTest(t)
+	defer ctrl.Finish()
+	useRules(m.rules)
+	allowNoDuplicates(m.incomes)
+
+	ctx := context.Background()
+	date := domain.Date{Year: 2024, Month: time.March, Day: 15}
+	imp := domain.StatementImport{UserID: 1, IncomeCategoryID: 2, Lines: []domain.StatementLine{
+		{Number: 1, Date: date, Amount: domain.NewMoney(100, kzt), ExternalID: "4000/A-1"},
+		{Number: 2, Date: date, Amount: domain.NewMoney(200, kzt), ExternalID: "4000/A-2"},
+		{Number: 3, Date: date, Amount: domain.NewMoney(200, kzt), ExternalID: "4000/A-2"},
+	}}
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	m.statements.EXPECT().ImportedIDs(ctx, int64(1), []string{"4000/A-1", "4000/A-2", "4000/A-2"}).
+		Return(map[string]bool{"4000/A-1": true}, nil)
+	allowCategories(m.categories, domain.CategoryKindIncome)
+	m.incomes.EXPECT().AddIncomesWithoutRules(ctx, gomock.Len(1), domain.BatchAtomic).
+		Return([]domain.IncomeBatchResult{{Income: &domain.Income{ID: 7}}}, nil)
+	m.statements.EXPECT().SaveImportedIDs(ctx, int64(1), []string{"4000/A-2"}).Return(nil)
+
+	result, err := useCase.ImportStatement(ctx, imp)
+
+	require.NoError(t, err)
+	assert.ErrorIs(t, result.Lines[0].Err, domain.ErrAlreadyImported)
+	assert.Equal(t, int64(7), result.Lines[1].OperationID)
+	assert.ErrorIs(t, result.Lines[2].Err, domain.ErrAlreadyImported)
+	assert.Equal(t, 1, result.Incomes)
+}
+
+func Test_StatementUseCase_ImportStatement_CreatesTransfers_WhenAccountNamed(t *testing.T) {
+	ctrl, m, useCase := setupStatementTest(t)
+	defer ctrl.Finish()
+	useRules(m.rules)
+
+	ctx := context.Background()
+	date := domain.Date{Year: 2024, Month: time.March, Day: 16}
+	imp := domain.StatementImport{UserID: 1, AccountID: 3, Lines: []domain.StatementLine{
+		{Number: 12, Date: date, Amount: domain.NewMoney(-30_000, kzt), Description: "Move", TransferAccount: "savings"},
+		{Number: 16, Date: date, Amount: domain.NewMoney(500, kzt), TransferAccount: "Broker"},
+	}}
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	m.accounts.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(newAccount(0), nil)
+	m.accounts.EXPECT().ListAccounts(ctx, domain.AccountFilter{UserID: 1, IncludeClosed: true}).
+		Return(statementAccounts(), nil)
+	m.transfers.EXPECT().AddTransfer(ctx, &domain.Transfer{UserID: 1, FromAccountID: 3, ToAccountID: 4,
+		FromAmount: domain.NewMoney(30_000, kzt), ToAmount: domain.NewMoney(30_000, kzt), Description: "Move",
+		OccurredAt: date.Start(time.UTC)}).Return(&domain.Transfer{ID: 11}, nil)
+
+	result, err := useCase.ImportStatement(ctx, imp)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Transfers)
+	assert.Equal(t, int64(11), result.Lines[0].OperationID)
+	assert.EqualError(t, result.Lines[1].Err, `account "Broker" does not exist`)
+}
+
+func Test_StatementUseCase_ImportStatement_FlagsDuplicate_WhenIncomeAlreadyEntered(t *testing.T) {
+	ctrl, m, useCase := setupStatementTest(t)
+	defer ctrl.Finish()
+	useRules(m.rules)
+
+	ctx := context.Background()
+	imp := statementImport()
+	imp.DryRun = true
+	imp.Lines = imp.Lines[:1]
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	m.accounts.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(newAccount(0), nil)
+	allowCategories(m.categories, domain.CategoryKindIncome)
+	m.incomes.EXPECT().FindDuplicates(ctx, gomock.Len(1)).
+		Return([][]domain.DuplicateCandidate{{{Income: domain.Income{ID: 5}, Score: 0.85}}}, nil)
+
+	result, err := useCase.ImportStatement(ctx, imp)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), result.Lines[0].DuplicateOf)
+	assert.Equal(t, 0.85, result.Lines[0].DuplicateScore)
+	assert.Equal(t, 1, result.Incomes)
+}
+
+func Test_StatementUseCase_ImportStatement_AppliesRules_WhenLinesMatch(t *testing.T) {
+	ctrl, m, useCase := setupStatementTest(t)
+	defer ctrl.Finish()
+	allowNoDuplicates(m.incomes)
+	useRules(m.rules,
+		domain.Rule{ID: 1, Direction: domain.RuleDirectionExpense,
+			Conditions: domain.RuleConditions{DescriptionContains: "coffee"},
+			Actions:    domain.RuleActions{CategoryID: 8, Description: "Coffee shop", Tags: []string{"food"}}},
+		domain.Rule{ID: 2, Conditions: domain.RuleConditions{Counterparty: "KZ01SAVINGS"},
+			Actions: domain.RuleActions{TransferAccountID: 4}},
+	)
+
+	ctx := context.Background()
+	imp := statementImport()
+	imp.DryRun = true
+	imp.IncomeCategoryID, imp.ExpenseCategoryID = 0, 0
+	imp.Lines[0].CounterpartyIBAN = "KZ01SAVINGS"
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	m.categories.EXPECT().GetCategory(ctx, int64(1), 8).
+		Return(&domain.Category{ID: 8, UserID: 1, Name: "Cafe", Kind: domain.CategoryKindExpense}, nil)
+	m.accounts.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(newAccount(0), nil)
+	m.accounts.EXPECT().ListAccounts(ctx, domain.AccountFilter{UserID: 1, IncludeClosed: true}).
+		Return(statementAccounts(), nil)
+
+	result, err := useCase.ImportStatement(ctx, imp)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Transfers)
+	assert.Equal(t, 1, result.Expenses)
+	assert.Equal(t, int64(4), result.Lines[0].TransferAccountID)
+	assert.Equal(t, []int64{2}, result.Lines[0].RuleIDs)
+	assert.Equal(t, 8, result.Lines[2].CategoryID)
+	assert.Equal(t, "Coffee shop", result.Lines[2].Description)
+	assert.Equal(t, []string{"food"}, result.Lines[2].Tags)
+}
+
+func Test_StatementUseCase_ImportStatement_SkipsRejectedLines_WhenOthersValid(t *testing.T) {
+	ctrl, m, useCase := setupStatementTest(t)
+	defer ctrl.Finish()
+	useRules(m.rules)
+	allowNoDuplicates(m.incomes)
+
+	ctx := context.Background()
+	imp := statementImport()
+	future := domain.DateOf(time.Now().AddDate(0, 0, 2))
+	imp.Lines = append(imp.Lines,
+		domain.StatementLine{Number: 5, Date: future, Amount: domain.NewMoney(-700, kzt), Description: "Preorder"},
+		domain.StatementLine{Number: 6, Date: imp.Lines[0].Date, Amount: domain.NewMoney(-10, usd), Description: "App"},
+		domain.StatementLine{Number: 7, Date: imp.Lines[0].Date, Amount: domain.NewMoney(-100, kzt),
+			TransferAccount: "Deposit"},
+		domain.StatementLine{Number: 8, Date: imp.Lines[0].Date, Amount: domain.NewMoney(-100, kzt),
+			TransferAccount: "Dollars"},
+	)
+	m.users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	m.accounts.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(newAccount(0), nil)
+	m.accounts.EXPECT().ListAccounts(ctx, domain.AccountFilter{UserID: 1, IncludeClosed: true}).
+		Return(statementAccounts(), nil)
+	m.categories.EXPECT().GetCategory(ctx, int64(1), 2).
+		Return(&domain.Category{ID: 2, UserID: 1, Name: "Salary", Kind: domain.CategoryKindIncome}, nil)
+	m.categories.EXPECT().GetCategory(ctx, int64(1), 5).
+		Return(&domain.Category{ID: 5, UserID: 1, Name: "Cafe", Kind: domain.CategoryKindExpense}, nil)
+	m.incomes.EXPECT().AddIncomesWithoutRules(ctx, gomock.Len(1), domain.BatchAtomic).
+		Return([]domain.IncomeBatchResult{{Income: &domain.Income{ID: 7}}}, nil)
+	m.expenses.EXPECT().AddExpense(ctx, gomock.Any()).Return(&domain.Expense{ID: 9}, nil)
+
+	result, err := useCase.ImportStatement(ctx, imp)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Incomes)
+	assert.Equal(t, 1, result.Expenses)
+	assert.Zero(t, result.Transfers)
+	assert.Equal(t, int64(7), result.Lines[0].OperationID)
+	assert.Equal(t, int64(9), result.Lines[2].OperationID)
+	assert.ErrorContains(t, result.Lines[3].Err, "is in the future")
+	assert.ErrorIs(t, result.Lines[4].Err, domain.ErrCurrencyMismatch)
+	assert.EqualError(t, result.Lines[5].Err, "account 5 is closed")
+	assert.ErrorIs(t, result.Lines[6].Err, domain.ErrCurrencyMismatch)
+}
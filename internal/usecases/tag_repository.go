@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"context"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=tag_repository.go -destination=mocks/tag_repository_mock.go -package=mocks
+
+// TagRepository репозиторий для работы с тегами операций
+type TagRepository interface {
+	GetTag(ctx context.Context, userID, id int64) (*domain.Tag, error)
+	// FindTag возвращает тег пользователя по названию без учета регистра
+	FindTag(ctx context.Context, userID int64, name string) (*domain.Tag, error)
+	// ListTags возвращает не больше limit тегов пользователя, название которых начинается с prefix
+	// без учета регистра, по убыванию числа операций, затем по названию
+	ListTags(ctx context.Context, userID int64, prefix string, limit int) ([]domain.Tag, error)
+	// RenameTag меняет название тега пользователя
+	RenameTag(ctx context.Context, userID, id int64, name string) (*domain.Tag, error)
+	// MergeTags переносит операции тега sourceID на targetID и удаляет sourceID
+	MergeTags(ctx context.Context, userID, sourceID, targetID int64) error
+}
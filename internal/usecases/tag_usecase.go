@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=tag_usecase.go -destination=mocks/tag_usecase_mock.go -package=mocks
+
+const (
+	// defaultTagSuggestions число подсказок тегов, если лимит не указан
+	defaultTagSuggestions = 10
+	// maxTagSuggestions наибольшее число подсказок тегов за один запрос
+	maxTagSuggestions = 100
+)
+
+// TagService контракт сервиса для работы с тегами операций
+type TagService interface {
+	ListTags(ctx context.Context, userID int64, prefix string, limit int) ([]domain.Tag, error)
+	RenameTag(ctx context.Context, userID, id int64, name string) (*domain.Tag, error)
+	MergeTags(ctx context.Context, userID, sourceID, targetID int64) (*domain.Tag, error)
+}
+
+// TagUseCase use-case для работы с тегами операций
+type TagUseCase struct {
+	repo TagRepository
+	tx   TxManager
+}
+
+// NewTagUseCase создает новый экземпляр TagUseCase
+func NewTagUseCase(repo TagRepository, tx TxManager) *TagUseCase {
+	return &TagUseCase{repo: repo, tx: tx}
+}
+
+// ListTags возвращает теги пользователя для автодополнения: название начинается с prefix без учета регистра,
+// сначала самые используемые. Пустой prefix возвращает все теги, limit 0 - лимит по умолчанию.
+func (u *TagUseCase) ListTags(ctx context.Context, userID int64, prefix string, limit int) ([]domain.Tag, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", ErrValidation)
+	}
+	if limit == 0 {
+		limit = defaultTagSuggestions
+	}
+
+	return u.repo.ListTags(ctx, userID, strings.TrimSpace(prefix), min(limit, maxTagSuggestions))
+}
+
+// RenameTag меняет название тега пользователя во всех операциях.
+// Название не должно совпадать без учета регистра с другим тегом пользователя: для этого служит MergeTags.
+func (u *TagUseCase) RenameTag(ctx context.Context, userID, id int64, name string) (*domain.Tag, error) {
+	if userID <= 0 || id <= 0 {
+		return nil, fmt.Errorf("%w: user ID and tag ID must be valid", ErrValidation)
+	}
+	name = strings.TrimSpace(name)
+	if err := domain.ValidateTagName(name); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	var renamed *domain.Tag
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := u.repo.FindTag(ctx, userID, name)
+		switch {
+		case err == nil && existing.ID != id:
+			return fmt.Errorf("%w: tag %q already exists, merge the tags instead", ErrValidation, existing.Name)
+		case err != nil && !errors.Is(err, domain.ErrNotFound):
+			return err
+		}
+
+		renamed, err = u.repo.RenameTag(ctx, userID, id, name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return renamed, nil
+}
+
+// MergeTags объединяет тег пользователя sourceID с тегом targetID и возвращает целевой тег.
+// Операции source получают тег target, сам source удаляется.
+func (u *TagUseCase) MergeTags(ctx context.Context, userID, sourceID, targetID int64) (*domain.Tag, error) {
+	if userID <= 0 || sourceID <= 0 || targetID <= 0 {
+		return nil, fmt.Errorf("%w: user ID and tag IDs must be valid", ErrValidation)
+	}
+	if sourceID == targetID {
+		return nil, fmt.Errorf("%w: cannot merge tag %d into itself", ErrValidation, sourceID)
+	}
+
+	var target *domain.Tag
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := u.repo.GetTag(ctx, userID, sourceID); err != nil {
+			return err
+		}
+		if _, err := u.repo.GetTag(ctx, userID, targetID); err != nil {
+			return err
+		}
+		if err := u.repo.MergeTags(ctx, userID, sourceID, targetID); err != nil {
+			return err
+		}
+
+		var err error
+		target, err = u.repo.GetTag(ctx, userID, targetID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
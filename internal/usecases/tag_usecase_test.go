@@ -0,0 +1,116 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupTagTest(t *testing.T) (*gomock.Controller, *mocks.MockTagRepository, *usecases.TagUseCase) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockTagRepository(ctrl)
+	useCase := usecases.NewTagUseCase(mockRepo, inlineTx{})
+	return ctrl, mockRepo, useCase
+}
+
+func Test_TagUseCase_ListTags_UsesDefaultLimit_WhenLimitNotSet(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTagTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tags := []domain.Tag{{ID: 3, UserID: 1, Name: "vacation-2026", UsageCount: 12}}
+	mockRepo.EXPECT().ListTags(ctx, int64(1), "vac", 10).Return(tags, nil)
+
+	got, err := useCase.ListTags(ctx, 1, " vac ", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, tags, got)
+}
+
+func Test_TagUseCase_ListTags_CapsLimit_WhenLimitTooLarge(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTagTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().ListTags(ctx, int64(1), "", 100).Return(nil, nil)
+
+	_, err := useCase.ListTags(ctx, 1, "", 1000)
+
+	require.NoError(t, err)
+}
+
+func Test_TagUseCase_RenameTag_ReturnsRenamedTag_WhenOnlyCaseChanges(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTagTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().FindTag(ctx, int64(1), "Reimbursable").
+		Return(&domain.Tag{ID: 4, UserID: 1, Name: "reimbursable"}, nil)
+	renamed := &domain.Tag{ID: 4, UserID: 1, Name: "Reimbursable", UsageCount: 2}
+	mockRepo.EXPECT().RenameTag(ctx, int64(1), int64(4), "Reimbursable").Return(renamed, nil)
+
+	got, err := useCase.RenameTag(ctx, 1, 4, " Reimbursable ")
+
+	require.NoError(t, err)
+	assert.Equal(t, renamed, got)
+}
+
+func Test_TagUseCase_RenameTag_ReturnsValidationError_WhenNameTaken(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTagTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().FindTag(ctx, int64(1), "travel").Return(&domain.Tag{ID: 7, UserID: 1, Name: "Travel"}, nil)
+
+	_, err := useCase.RenameTag(ctx, 1, 4, "travel")
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+	assert.EqualError(t, err, `validation failed: tag "Travel" already exists, merge the tags instead`)
+}
+
+func Test_TagUseCase_RenameTag_ReturnsNotFound_WhenTagMissing(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTagTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().FindTag(ctx, int64(1), "travel").Return(nil, fmt.Errorf("tag: %w", domain.ErrNotFound))
+	mockRepo.EXPECT().RenameTag(ctx, int64(1), int64(4), "travel").
+		Return(nil, fmt.Errorf("tag 4: %w", domain.ErrNotFound))
+
+	_, err := useCase.RenameTag(ctx, 1, 4, "travel")
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func Test_TagUseCase_MergeTags_ReturnsTarget_WhenBothExist(t *testing.T) {
+	ctrl, mockRepo, useCase := setupTagTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetTag(ctx, int64(1), int64(4)).Return(&domain.Tag{ID: 4, UserID: 1, Name: "trip"}, nil)
+	mockRepo.EXPECT().GetTag(ctx, int64(1), int64(7)).Return(&domain.Tag{ID: 7, UserID: 1, Name: "travel"}, nil)
+	mockRepo.EXPECT().MergeTags(ctx, int64(1), int64(4), int64(7)).Return(nil)
+	merged := &domain.Tag{ID: 7, UserID: 1, Name: "travel", UsageCount: 5}
+	mockRepo.EXPECT().GetTag(ctx, int64(1), int64(7)).Return(merged, nil)
+
+	got, err := useCase.MergeTags(ctx, 1, 4, 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, merged, got)
+}
+
+func Test_TagUseCase_MergeTags_ReturnsValidationError_WhenSameTag(t *testing.T) {
+	_, _, useCase := setupTagTest(t)
+
+	_, err := useCase.MergeTags(context.Background(), 1, 4, 4)
+
+	assert.EqualError(t, err, "validation failed: cannot merge tag 4 into itself")
+}
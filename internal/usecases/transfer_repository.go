@@ -0,0 +1,15 @@
+package usecases
+
+import (
+	"context"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=transfer_repository.go -destination=mocks/transfer_repository_mock.go -package=mocks
+
+// TransferRepository репозиторий для работы с переводами между счетами
+type TransferRepository interface {
+	AddTransfer(ctx context.Context, transfer *domain.Transfer) (*domain.Transfer, error)
+	ListTransfers(ctx context.Context, filter domain.TransferFilter) ([]domain.Transfer, error)
+}
@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=transfer_usecase.go -destination=mocks/transfer_usecase_mock.go -package=mocks
+
+// TransferService контракт сервиса для работы с переводами между счетами
+type TransferService interface {
+	AddTransfer(ctx context.Context, transfer *domain.Transfer) (*domain.Transfer, error)
+	ListTransfers(ctx context.Context, filter domain.TransferFilter) (domain.TransferPage, error)
+}
+
+// TransferUseCase use-case для работы с переводами между счетами
+type TransferUseCase struct {
+	repo            TransferRepository
+	accounts        AccountRepository
+	ledger          LedgerRepository
+	tx              TxManager
+	futureTolerance time.Duration
+}
+
+// NewTransferUseCase создает новый экземпляр TransferUseCase.
+// futureTolerance задает, насколько дата перевода может опережать текущее время.
+func NewTransferUseCase(repo TransferRepository, accounts AccountRepository, ledger LedgerRepository, tx TxManager,
+	futureTolerance time.Duration) *TransferUseCase {
+	return &TransferUseCase{repo: repo, accounts: accounts, ledger: ledger, tx: tx, futureTolerance: futureTolerance}
+}
+
+// AddTransfer списывает FromAmount со счета-источника и зачисляет ToAmount на счет-получатель:
+// перевод и его запись в журнале сохраняются в одной транзакции.
+// Если ToAmount не указана, зачисляется FromAmount: так задаются переводы в одной валюте.
+func (u *TransferUseCase) AddTransfer(ctx context.Context, transfer *domain.Transfer) (*domain.Transfer, error) {
+	if transfer.OccurredAt.IsZero() {
+		transfer.OccurredAt = time.Now()
+	}
+	if transfer.ToAmount.Currency().IsZero() {
+		transfer.ToAmount = transfer.FromAmount
+	}
+
+	if err := transfer.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	if err := domain.ValidateOccurredAt(transfer.OccurredAt, time.Now(), u.futureTolerance); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	var created *domain.Transfer
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		err := checkPostable(ctx, u.accounts, transfer.UserID, transfer.FromAccountID, transfer.FromAmount.Neg())
+		if err != nil {
+			return err
+		}
+		if err := checkPostable(ctx, u.accounts, transfer.UserID, transfer.ToAccountID, transfer.ToAmount); err != nil {
+			return err
+		}
+
+		if created, err = u.repo.AddTransfer(ctx, transfer); err != nil {
+			return err
+		}
+		return postEntry(ctx, u.ledger, domain.TransferEntry(created))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ListTransfers возвращает страницу переводов пользователя, новые первыми
+func (u *TransferUseCase) ListTransfers(ctx context.Context, filter domain.TransferFilter) (domain.TransferPage, error) {
+	if filter.UserID <= 0 {
+		return domain.TransferPage{}, fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+
+	size := normalizePageSize(filter.PageSize)
+	filter.PageSize = size + 1
+
+	items, err := u.repo.ListTransfers(ctx, filter)
+	if err != nil {
+		return domain.TransferPage{}, err
+	}
+
+	items, hasMore := cutPage(items, size)
+	return domain.TransferPage{Items: items, HasMore: hasMore}, nil
+}
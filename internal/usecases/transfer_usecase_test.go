@@ -0,0 +1,172 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+var usd = domain.MustCurrency("USD")
+
+// transferMocks моки хранилищ TransferUseCase
+type transferMocks struct {
+	transfers *mocks.MockTransferRepository
+	accounts  *mocks.MockAccountRepository
+	ledger    *mocks.MockLedgerRepository
+}
+
+func setupTransferTest(t *testing.T) (*gomock.Controller, transferMocks, *usecases.TransferUseCase) {
+	ctrl := gomock.NewController(t)
+	m := transferMocks{
+		transfers: mocks.NewMockTransferRepository(ctrl),
+		accounts:  mocks.NewMockAccountRepository(ctrl),
+		ledger:    mocks.NewMockLedgerRepository(ctrl),
+	}
+	useCase := usecases.NewTransferUseCase(m.transfers, m.accounts, m.ledger, inlineTx{}, 5*time.Minute)
+	return ctrl, m, useCase
+}
+
+func accountIn(id int64, currency domain.Currency) *domain.Account {
+	return &domain.Account{ID: id, UserID: 1, Name: "Account", Type: domain.AccountTypeChecking,
+		OpeningBalance: domain.NewMoney(0, currency)}
+}
+
+func Test_TransferUseCase_AddTransfer_MovesSameAmount_WhenToAmountOmitted(t *testing.T) {
+	ctrl, m, useCase := setupTransferTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	amount := domain.NewMoney(10000, kzt)
+	m.accounts.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(accountIn(3, kzt), nil)
+	m.accounts.EXPECT().GetAccount(ctx, int64(1), int64(4)).Return(accountIn(4, kzt), nil)
+	m.transfers.EXPECT().AddTransfer(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, transfer *domain.Transfer) (*domain.Transfer, error) {
+			transfer.ID = 7
+			return transfer, nil
+		})
+	m.ledger.EXPECT().PostEntry(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *domain.JournalEntry) (*domain.JournalEntry, error) {
+			assert.Equal(t, []domain.Posting{
+				{Account: domain.LedgerAccount{Kind: domain.LedgerAccountAsset, ID: 4}, Amount: amount},
+				{Account: domain.LedgerAccount{Kind: domain.LedgerAccountAsset, ID: 3}, Amount: amount.Neg()},
+			}, entry.Postings)
+			return entry, nil
+		})
+
+	got, err := useCase.AddTransfer(ctx, &domain.Transfer{UserID: 1, FromAccountID: 3, ToAccountID: 4, FromAmount: amount})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), got.ID)
+	assert.Equal(t, amount, got.ToAmount)
+	assert.False(t, got.OccurredAt.IsZero())
+}
+
+func Test_TransferUseCase_AddTransfer_PostsThroughExchange_WhenCrossCurrency(t *testing.T) {
+	ctrl, m, useCase := setupTransferTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	from := domain.NewMoney(4_700_000, kzt)
+	to := domain.NewMoney(10000, usd)
+	m.accounts.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(accountIn(3, kzt), nil)
+	m.accounts.EXPECT().GetAccount(ctx, int64(1), int64(4)).Return(accountIn(4, usd), nil)
+	m.transfers.EXPECT().AddTransfer(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, transfer *domain.Transfer) (*domain.Transfer, error) {
+			transfer.ID = 7
+			return transfer, nil
+		})
+	m.ledger.EXPECT().PostEntry(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *domain.JournalEntry) (*domain.JournalEntry, error) {
+			assert.Len(t, entry.Postings, 4)
+			assert.NoError(t, entry.Validate())
+			return entry, nil
+		})
+
+	got, err := useCase.AddTransfer(ctx, &domain.Transfer{UserID: 1, FromAccountID: 3, ToAccountID: 4,
+		FromAmount: from, ToAmount: to, OccurredAt: time.Now()})
+
+	require.NoError(t, err)
+	assert.Equal(t, "1/470", got.Rate().String())
+}
+
+func Test_TransferUseCase_AddTransfer_ReturnsValidationError_WhenInvalidInput(t *testing.T) {
+	_, _, useCase := setupTransferTest(t)
+
+	tests := []struct {
+		name     string
+		transfer domain.Transfer
+		errMsg   string
+	}{
+		{"Same Account", domain.Transfer{UserID: 1, FromAccountID: 3, ToAccountID: 3, FromAmount: domain.NewMoney(100, kzt)},
+			"validation failed: source and destination accounts must differ"},
+		{"Zero Amount", domain.Transfer{UserID: 1, FromAccountID: 3, ToAccountID: 4, FromAmount: domain.NewMoney(0, kzt)},
+			"validation failed: amounts must be greater than 0"},
+		{"Unequal Same Currency", domain.Transfer{UserID: 1, FromAccountID: 3, ToAccountID: 4,
+			FromAmount: domain.NewMoney(100, kzt), ToAmount: domain.NewMoney(90, kzt)},
+			"validation failed: amounts of a same-currency transfer must be equal: 1.00 KZT and 0.90 KZT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.AddTransfer(context.Background(), &tt.transfer)
+
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
+
+func Test_TransferUseCase_AddTransfer_ReturnsValidationError_WhenAccountCurrencyDiffers(t *testing.T) {
+	ctrl, m, useCase := setupTransferTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	amount := domain.NewMoney(10000, kzt)
+	m.accounts.EXPECT().GetAccount(ctx, int64(1), int64(3)).Return(accountIn(3, kzt), nil)
+	m.accounts.EXPECT().GetAccount(ctx, int64(1), int64(4)).Return(accountIn(4, usd), nil)
+
+	_, err := useCase.AddTransfer(ctx, &domain.Transfer{UserID: 1, FromAccountID: 3, ToAccountID: 4, FromAmount: amount})
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+	assert.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+}
+
+func Test_TransferUseCase_AddTransfer_ReturnsError_WhenRepoFails(t *testing.T) {
+	ctrl, m, useCase := setupTransferTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.accounts.EXPECT().GetAccount(ctx, int64(1), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, id int64) (*domain.Account, error) {
+			return accountIn(id, kzt), nil
+		}).Times(2)
+	m.transfers.EXPECT().AddTransfer(ctx, gomock.Any()).Return(nil, errors.New("db error"))
+
+	_, err := useCase.AddTransfer(ctx, &domain.Transfer{UserID: 1, FromAccountID: 3, ToAccountID: 4,
+		FromAmount: domain.NewMoney(100, kzt)})
+
+	assert.EqualError(t, err, "db error")
+}
+
+func Test_TransferUseCase_ListTransfers_ReturnsPageWithHasMore_WhenRepoReturnsExtraItem(t *testing.T) {
+	ctrl, m, useCase := setupTransferTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.transfers.EXPECT().ListTransfers(ctx, domain.TransferFilter{UserID: 1, AccountID: 3, PageSize: 3}).
+		Return([]domain.Transfer{{ID: 9}, {ID: 8}, {ID: 7}}, nil)
+
+	page, err := useCase.ListTransfers(ctx, domain.TransferFilter{UserID: 1, AccountID: 3, PageSize: 2})
+
+	require.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	assert.True(t, page.HasMore)
+}
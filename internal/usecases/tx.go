@@ -0,0 +1,13 @@
+package usecases
+
+import "context"
+
+//go:generate mockgen -source=tx.go -destination=mocks/tx_mock.go -package=mocks
+
+// TxManager выполняет несколько операций с хранилищем атомарно.
+// Репозитории, вызванные с контекстом, переданным в fn, работают внутри одной транзакции.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// WithinReadOnlyTx выполняет fn в транзакции только для чтения, все чтения которой видят один снимок данных
+	WithinReadOnlyTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
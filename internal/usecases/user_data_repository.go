@@ -0,0 +1,21 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=user_data_repository.go -destination=mocks/user_data_repository_mock.go -package=mocks
+
+// UserDataRepository репозиторий всех данных пользователя для выгрузки и стирания
+type UserDataRepository interface {
+	// ExportSection передает fn строки раздела пользователя по одной, каждую - JSON-объектом схемы архива
+	ExportSection(ctx context.Context, userID int64, section domain.UserDataSection,
+		fn func(row json.RawMessage) error) error
+	// CountSection возвращает число строк раздела пользователя
+	CountSection(ctx context.Context, userID int64, section domain.UserDataSection) (int64, error)
+	// EraseUser удаляет все данные пользователя вместе с ним самим и возвращает число удаленных строк по разделам
+	EraseUser(ctx context.Context, userID int64) (map[domain.UserDataSection]int64, error)
+}
@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"fincraft-finance/internal/domain"
+)
+
+//go:generate mockgen -source=user_data_usecase.go -destination=mocks/user_data_usecase_mock.go -package=mocks
+
+// UserDataService контракт сервиса запросов субъекта данных: выгрузки и стирания всех данных пользователя
+type UserDataService interface {
+	ExportUserData(ctx context.Context, userID int64, w io.Writer) error
+	EraseUser(ctx context.Context, userID int64) (*domain.ErasureReport, error)
+}
+
+// UserDataUseCase use-case для выгрузки и стирания всех данных пользователя
+type UserDataUseCase struct {
+	repo  UserDataRepository
+	users UserRepository
+	tx    TxManager
+}
+
+// NewUserDataUseCase создает новый экземпляр UserDataUseCase
+func NewUserDataUseCase(repo UserDataRepository, users UserRepository, tx TxManager) *UserDataUseCase {
+	return &UserDataUseCase{repo: repo, users: users, tx: tx}
+}
+
+// ExportUserData пишет в w архив всех данных пользователя - JSON-документ схемы версии domain.UserDataSchemaVersion:
+//
+//	{"schema_version":1,"user_id":42,"generated_at":"...","sections":{"user":[...],"accounts":[...],...}}
+//
+// Разделы идут в порядке domain.UserDataSections и присутствуют всегда, даже пустые.
+// Строки читаются из хранилища по одной, поэтому архив не собирается в памяти. Все разделы читаются
+// в одной транзакции только для чтения, поэтому архив согласован: операции, записанные во время выгрузки,
+// не попадают в одни разделы без других (доход без проводки по журналу).
+func (u *UserDataUseCase) ExportUserData(ctx context.Context, userID int64, w io.Writer) error {
+	if userID <= 0 {
+		return fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+
+	return u.tx.WithinReadOnlyTx(ctx, func(ctx context.Context) error {
+		if _, err := u.users.GetTimezone(ctx, userID); err != nil {
+			return err
+		}
+		return u.writeArchive(ctx, userID, w)
+	})
+}
+
+// writeArchive пишет в w архив данных пользователя, читая разделы из хранилища
+func (u *UserDataUseCase) writeArchive(ctx context.Context, userID int64, w io.Writer) error {
+	aw := &archiveWriter{w: w}
+	aw.write(`{"schema_version":` + strconv.Itoa(domain.UserDataSchemaVersion) +
+		`,"user_id":` + strconv.FormatInt(userID, 10) +
+		`,"generated_at":"` + time.Now().UTC().Format(time.RFC3339) + `","sections":{`)
+	for i, section := range domain.UserDataSections {
+		if i > 0 {
+			aw.write(",")
+		}
+		aw.write(`"` + string(section) + `":[`)
+		first := true
+		err := u.repo.ExportSection(ctx, userID, section, func(row json.RawMessage) error {
+			if !first {
+				aw.write(",")
+			}
+			first = false
+			aw.write(string(row))
+			return aw.err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to export section %s: %w", section, err)
+		}
+		aw.write("]")
+	}
+	aw.write("}}\n")
+	return aw.err
+}
+
+// EraseUser удаляет все данные пользователя одной транзакцией и возвращает отчет об удаленных строках.
+// Перед фиксацией каждый раздел пересчитывается: если где-то остались строки, транзакция откатывается.
+func (u *UserDataUseCase) EraseUser(ctx context.Context, userID int64) (*domain.ErasureReport, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+
+	report := &domain.ErasureReport{UserID: userID}
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := u.users.GetTimezone(ctx, userID); err != nil {
+			return err
+		}
+		removed, err := u.repo.EraseUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		report.Sections = make([]domain.SectionErasure, 0, len(domain.UserDataSections))
+		for _, section := range domain.UserDataSections {
+			left, err := u.repo.CountSection(ctx, userID, section)
+			if err != nil {
+				return err
+			}
+			if left != 0 {
+				return fmt.Errorf("erasure left %d rows in section %s", left, section)
+			}
+			report.Sections = append(report.Sections, domain.SectionErasure{Section: section, Removed: removed[section]})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report.ErasedAt = time.Now()
+	return report, nil
+}
+
+// archiveWriter пишет части архива, запоминая первую ошибку записи
+type archiveWriter struct {
+	w   io.Writer
+	err error
+}
+
+// write пишет s, если предыдущие записи прошли успешно
+func (a *archiveWriter) write(s string) {
+	if a.err != nil {
+		return
+	}
+	_, a.err = io.WriteString(a.w, s)
+}
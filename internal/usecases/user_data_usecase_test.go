@@ -0,0 +1,186 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/domain"
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupUserDataTest(t *testing.T) (*gomock.Controller, *mocks.MockUserDataRepository, *mocks.MockUserRepository,
+	*usecases.UserDataUseCase) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockUserDataRepository(ctrl)
+	users := mocks.NewMockUserRepository(ctrl)
+	useCase := usecases.NewUserDataUseCase(repo, users, inlineTx{})
+	return ctrl, repo, users, useCase
+}
+
+func Test_UserDataUseCase_ExportUserData_WritesAllSections_WhenUserExists(t *testing.T) {
+	ctrl, repo, users, useCase := setupUserDataTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	repo.EXPECT().ExportSection(ctx, int64(1), gomock.Any(), gomock.Any()).Times(len(domain.UserDataSections)).
+		DoAndReturn(func(_ context.Context, _ int64, section domain.UserDataSection,
+			fn func(json.RawMessage) error) error {
+			switch section {
+			case domain.UserDataUser:
+				return fn(json.RawMessage(`{"id":1,"email":"user@example.com"}`))
+			case domain.UserDataIncomes:
+				if err := fn(json.RawMessage(`{"id":5,"amount":"1000.50"}`)); err != nil {
+					return err
+				}
+				return fn(json.RawMessage(`{"id":6,"amount":"20"}`))
+			}
+			return nil
+		})
+
+	var buf bytes.Buffer
+	err := useCase.ExportUserData(ctx, 1, &buf)
+
+	require.NoError(t, err)
+	var archive struct {
+		SchemaVersion int                         `json:"schema_version"`
+		UserID        int64                       `json:"user_id"`
+		GeneratedAt   time.Time                   `json:"generated_at"`
+		Sections      map[string][]map[string]any `json:"sections"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &archive))
+	assert.Equal(t, domain.UserDataSchemaVersion, archive.SchemaVersion)
+	assert.Equal(t, int64(1), archive.UserID)
+	assert.False(t, archive.GeneratedAt.IsZero())
+	assert.Len(t, archive.Sections, len(domain.UserDataSections))
+	assert.Equal(t, "user@example.com", archive.Sections["user"][0]["email"])
+	assert.Equal(t, []map[string]any{{"id": 5.0, "amount": "1000.50"}, {"id": 6.0, "amount": "20"}},
+		archive.Sections["incomes"])
+	assert.NotNil(t, archive.Sections["budgets"])
+	assert.Empty(t, archive.Sections["budgets"])
+}
+
+// snapshotKey ключ контекста, которым тест отмечает транзакцию выгрузки
+type snapshotKey struct{}
+
+func Test_UserDataUseCase_ExportUserData_ReadsAllSectionsInReadOnlyTx_WhenUserExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mocks.NewMockUserDataRepository(ctrl)
+	users := mocks.NewMockUserRepository(ctrl)
+	tx := mocks.NewMockTxManager(ctrl)
+	useCase := usecases.NewUserDataUseCase(repo, users, tx)
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, snapshotKey{}, true)
+	tx.EXPECT().WithinReadOnlyTx(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(ctx context.Context) error) error {
+			return fn(txCtx)
+		})
+	users.EXPECT().GetTimezone(txCtx, int64(1)).Return(time.UTC, nil)
+	repo.EXPECT().ExportSection(txCtx, int64(1), gomock.Any(), gomock.Any()).
+		Times(len(domain.UserDataSections)).Return(nil)
+
+	err := useCase.ExportUserData(ctx, 1, &bytes.Buffer{})
+
+	assert.NoError(t, err)
+}
+
+func Test_UserDataUseCase_ExportUserData_ReturnsNotFound_WhenUserMissing(t *testing.T) {
+	ctrl, _, users, useCase := setupUserDataTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	users.EXPECT().GetTimezone(ctx, int64(1)).Return(nil, domain.ErrNotFound)
+
+	var buf bytes.Buffer
+	err := useCase.ExportUserData(ctx, 1, &buf)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Zero(t, buf.Len())
+}
+
+func Test_UserDataUseCase_ExportUserData_ReturnsValidationError_WhenUserIDInvalid(t *testing.T) {
+	ctrl, _, _, useCase := setupUserDataTest(t)
+	defer ctrl.Finish()
+
+	err := useCase.ExportUserData(context.Background(), 0, &bytes.Buffer{})
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+}
+
+func Test_UserDataUseCase_EraseUser_ReturnsReport_WhenNothingLeft(t *testing.T) {
+	ctrl, repo, users, useCase := setupUserDataTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	repo.EXPECT().EraseUser(ctx, int64(1)).Return(map[domain.UserDataSection]int64{
+		domain.UserDataUser:    1,
+		domain.UserDataIncomes: 3,
+		domain.UserDataTags:    2,
+	}, nil)
+	repo.EXPECT().CountSection(ctx, int64(1), gomock.Any()).Return(int64(0), nil).
+		Times(len(domain.UserDataSections))
+
+	report, err := useCase.EraseUser(ctx, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), report.UserID)
+	assert.False(t, report.ErasedAt.IsZero())
+	require.Len(t, report.Sections, len(domain.UserDataSections))
+	assert.Equal(t, domain.SectionErasure{Section: domain.UserDataUser, Removed: 1}, report.Sections[0])
+	assert.Equal(t, int64(6), report.TotalRemoved())
+}
+
+func Test_UserDataUseCase_EraseUser_ReturnsError_WhenRowsLeft(t *testing.T) {
+	ctrl, repo, users, useCase := setupUserDataTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	repo.EXPECT().EraseUser(ctx, int64(1)).Return(map[domain.UserDataSection]int64{}, nil)
+	repo.EXPECT().CountSection(ctx, int64(1), domain.UserDataUser).Return(int64(0), nil)
+	repo.EXPECT().CountSection(ctx, int64(1), domain.UserDataAccounts).Return(int64(2), nil)
+
+	report, err := useCase.EraseUser(ctx, 1)
+
+	assert.ErrorContains(t, err, "erasure left 2 rows in section accounts")
+	assert.Nil(t, report)
+}
+
+func Test_UserDataUseCase_EraseUser_ReturnsError_WhenRepositoryFails(t *testing.T) {
+	ctrl, repo, users, useCase := setupUserDataTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	users.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	repo.EXPECT().EraseUser(ctx, int64(1)).Return(nil, errors.New("db down"))
+
+	report, err := useCase.EraseUser(ctx, 1)
+
+	assert.ErrorContains(t, err, "db down")
+	assert.Nil(t, report)
+}
+
+func Test_UserDataUseCase_EraseUser_ReturnsNotFound_WhenUserMissing(t *testing.T) {
+	ctrl, _, users, useCase := setupUserDataTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	users.EXPECT().GetTimezone(ctx, int64(1)).Return(nil, domain.ErrNotFound)
+
+	report, err := useCase.EraseUser(ctx, 1)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, report)
+}
@@ -0,0 +1,14 @@
+package usecases
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=user_repository.go -destination=mocks/user_repository_mock.go -package=mocks
+
+// UserRepository репозиторий настроек пользователя
+type UserRepository interface {
+	GetTimezone(ctx context.Context, userID int64) (*time.Location, error)
+	SetTimezone(ctx context.Context, userID int64, loc *time.Location) error
+}
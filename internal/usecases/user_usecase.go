@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+//go:generate mockgen -source=user_usecase.go -destination=mocks/user_usecase_mock.go -package=mocks
+
+// UserService контракт сервиса настроек пользователя
+type UserService interface {
+	GetTimezone(ctx context.Context, userID int64) (*time.Location, error)
+	SetTimezone(ctx context.Context, userID int64, name string) error
+}
+
+// UserUseCase use-case для работы с настройками пользователя
+type UserUseCase struct {
+	repo   UserRepository
+	ledger LedgerRepository
+	tx     TxManager
+}
+
+// NewUserUseCase создает новый экземпляр UserUseCase
+func NewUserUseCase(repo UserRepository, ledger LedgerRepository, tx TxManager) *UserUseCase {
+	return &UserUseCase{repo: repo, ledger: ledger, tx: tx}
+}
+
+// GetTimezone возвращает часовой пояс пользователя
+func (u *UserUseCase) GetTimezone(ctx context.Context, userID int64) (*time.Location, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+
+	return u.repo.GetTimezone(ctx, userID)
+}
+
+// SetTimezone задает часовой пояс пользователя по имени из базы IANA, например "Asia/Almaty".
+// Дневные обороты счетов группируются по дням пользователя, поэтому при смене пояса пересчитываются.
+func (u *UserUseCase) SetTimezone(ctx context.Context, userID int64, name string) error {
+	if userID <= 0 {
+		return fmt.Errorf("%w: user ID must be valid", ErrValidation)
+	}
+	if name == "" || name == "Local" {
+		return fmt.Errorf("%w: timezone must be an IANA name", ErrValidation)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrValidation, name)
+	}
+
+	return u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := u.repo.GetTimezone(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if current.String() == loc.String() {
+			return nil
+		}
+		if err := u.repo.SetTimezone(ctx, userID, loc); err != nil {
+			return err
+		}
+		return u.ledger.RebuildDailyBalances(ctx, userID)
+	})
+}
@@ -0,0 +1,83 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"fincraft-finance/internal/usecases"
+	"fincraft-finance/internal/usecases/mocks"
+)
+
+func setupUserTest(t *testing.T) (*gomock.Controller, *mocks.MockUserRepository, *mocks.MockLedgerRepository,
+	*usecases.UserUseCase) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockLedger := mocks.NewMockLedgerRepository(ctrl)
+	return ctrl, mockRepo, mockLedger, usecases.NewUserUseCase(mockRepo, mockLedger, inlineTx{})
+}
+
+func Test_UserUseCase_SetTimezone_StoresLocationAndRebuildsBalances_WhenNameValid(t *testing.T) {
+	ctrl, mockRepo, mockLedger, useCase := setupUserTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().GetTimezone(ctx, int64(1)).Return(time.UTC, nil)
+	mockRepo.EXPECT().SetTimezone(ctx, int64(1), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int64, loc *time.Location) error {
+			assert.Equal(t, "Asia/Almaty", loc.String())
+			return nil
+		})
+	mockLedger.EXPECT().RebuildDailyBalances(ctx, int64(1)).Return(nil)
+
+	err := useCase.SetTimezone(ctx, 1, "Asia/Almaty")
+
+	assert.NoError(t, err)
+}
+
+func Test_UserUseCase_SetTimezone_DoesNothing_WhenTimezoneUnchanged(t *testing.T) {
+	ctrl, mockRepo, _, useCase := setupUserTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	almaty, err := time.LoadLocation("Asia/Almaty")
+	require.NoError(t, err)
+	mockRepo.EXPECT().GetTimezone(ctx, int64(1)).Return(almaty, nil)
+
+	err = useCase.SetTimezone(ctx, 1, "Asia/Almaty")
+
+	assert.NoError(t, err)
+}
+
+func Test_UserUseCase_SetTimezone_ReturnsValidationError_WhenNameInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone string
+	}{
+		{"Empty", ""},
+		{"Local", "Local"},
+		{"Unknown", "Mars/Olympus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, useCase := setupUserTest(t)
+
+			err := useCase.SetTimezone(context.Background(), 1, tt.timezone)
+
+			assert.ErrorIs(t, err, usecases.ErrValidation)
+		})
+	}
+}
+
+func Test_UserUseCase_GetTimezone_ReturnsValidationError_WhenUserInvalid(t *testing.T) {
+	_, _, _, useCase := setupUserTest(t)
+
+	_, err := useCase.GetTimezone(context.Background(), 0)
+
+	assert.ErrorIs(t, err, usecases.ErrValidation)
+}